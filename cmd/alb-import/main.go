@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/pflag"
+	ctrlCfg "k8s.io/alibaba-load-balancer-controller/pkg/config"
+	"k8s.io/alibaba-load-balancer-controller/pkg/migration/albimport"
+	"k8s.io/alibaba-load-balancer-controller/pkg/provider/alibaba"
+)
+
+type options struct {
+	cloudConfig string
+	output      string
+	report      string
+	importOpts  albimport.Options
+}
+
+func main() {
+	opts := options{}
+	fs := pflag.NewFlagSet(os.Args[0], pflag.ExitOnError)
+	fs.StringVar(&opts.cloudConfig, "cloud-config", "", "The path to the cloud provider configuration file with the credentials and region.")
+	fs.StringVar(&opts.importOpts.LoadBalancerID, "loadbalancer-id", "", "ID of the ALB instance to import.")
+	fs.StringVar(&opts.importOpts.AlbConfigName, "albconfig-name", "", "Name of the generated AlbConfig, derived from the name of the ALB instance if empty.")
+	fs.StringVar(&opts.importOpts.IngressClassName, "ingress-class", albimport.DefaultIngressClassName, "Name of the generated IngressClass of the Ingresses.")
+	fs.StringVarP(&opts.importOpts.Namespace, "namespace", "n", albimport.DefaultNamespace, "Namespace of the generated Ingresses and Services.")
+	fs.StringVarP(&opts.output, "output", "o", "", "File to write the generated manifests to, stdout if empty.")
+	fs.StringVar(&opts.report, "report", "", "File to write the import report to, stderr if empty.")
+	_ = fs.Parse(os.Args[1:])
+
+	if err := run(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(opts options) error {
+	if opts.importOpts.LoadBalancerID == "" {
+		return fmt.Errorf("--loadbalancer-id is required")
+	}
+	ctrlCfg.ControllerCFG.CloudConfigPath = opts.cloudConfig
+	cloud := alibaba.NewAlibabaCloud()
+	result, err := albimport.Import(context.TODO(), cloud, opts.importOpts)
+	if err != nil {
+		return err
+	}
+
+	out, closeOut, err := openOutput(opts.output, os.Stdout)
+	if err != nil {
+		return err
+	}
+	defer closeOut()
+	if err := albimport.WriteManifests(out, result); err != nil {
+		return err
+	}
+	report, closeReport, err := openOutput(opts.report, os.Stderr)
+	if err != nil {
+		return err
+	}
+	defer closeReport()
+	return albimport.WriteReport(report, result.Findings)
+}
+
+func openOutput(file string, std *os.File) (io.Writer, func(), error) {
+	if file == "" {
+		return std, func() {}, nil
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, func() { f.Close() }, nil
+}
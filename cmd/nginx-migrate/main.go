@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/pflag"
+	"k8s.io/alibaba-load-balancer-controller/pkg/migration/nginx"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+)
+
+type options struct {
+	files       []string
+	fromCluster bool
+	namespace   string
+	nginxClass  string
+	output      string
+	report      string
+	convert     nginx.Options
+}
+
+func main() {
+	opts := options{}
+	fs := pflag.NewFlagSet(os.Args[0], pflag.ExitOnError)
+	fs.StringSliceVarP(&opts.files, "filename", "f", nil, "Manifests of the Ingresses to convert, - for stdin.")
+	fs.BoolVar(&opts.fromCluster, "from-cluster", false, "Convert the Ingresses of the cluster of the kubeconfig.")
+	fs.StringVarP(&opts.namespace, "namespace", "n", "", "Namespace of the Ingresses read from the cluster, all namespaces if empty.")
+	fs.StringVar(&opts.nginxClass, "nginx-class", "nginx", "IngressClass of the ingress-nginx Ingresses to convert.")
+	fs.StringVarP(&opts.output, "output", "o", "", "File to write the converted manifests to, stdout if empty.")
+	fs.StringVar(&opts.report, "report", "", "File to write the conversion report to, stderr if empty.")
+	fs.StringVar(&opts.convert.AlbConfigName, "albconfig-name", nginx.DefaultAlbConfigName, "Name of the generated AlbConfig.")
+	fs.StringVar(&opts.convert.IngressClassName, "ingress-class", nginx.DefaultIngressClassName, "Name of the generated IngressClass of the converted Ingresses.")
+	fs.StringVar(&opts.convert.AddressType, "address-type", nginx.DefaultAddressType, "Address type of the ALB instance, Internet or Intranet.")
+	fs.StringSliceVar(&opts.convert.VSwitchIDs, "vswitch-ids", nil, "VSwitches of the ALB instance, in two zones at least.")
+	fs.StringVar(&opts.convert.NameSuffix, "name-suffix", "-alb", "Suffix of the names of the converted Ingresses.")
+	_ = fs.Parse(os.Args[1:])
+
+	if err := run(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(opts options) error {
+	if len(opts.files) == 0 && !opts.fromCluster {
+		return fmt.Errorf("either --filename or --from-cluster is required")
+	}
+	ings, err := loadIngresses(opts)
+	if err != nil {
+		return err
+	}
+	var selected []*networking.Ingress
+	for _, ing := range ings {
+		if nginx.IsNginxIngress(ing, opts.nginxClass) {
+			selected = append(selected, ing)
+		}
+	}
+	if len(selected) == 0 {
+		return fmt.Errorf("no Ingresses of class %s found", opts.nginxClass)
+	}
+	result := nginx.Convert(selected, opts.convert)
+
+	out, closeOut, err := openOutput(opts.output, os.Stdout)
+	if err != nil {
+		return err
+	}
+	defer closeOut()
+	if err := nginx.WriteManifests(out, result); err != nil {
+		return err
+	}
+	report, closeReport, err := openOutput(opts.report, os.Stderr)
+	if err != nil {
+		return err
+	}
+	defer closeReport()
+	return nginx.WriteReport(report, result.Findings)
+}
+
+func loadIngresses(opts options) ([]*networking.Ingress, error) {
+	var ings []*networking.Ingress
+	for _, file := range opts.files {
+		var r io.Reader = os.Stdin
+		if file != "-" {
+			f, err := os.Open(file)
+			if err != nil {
+				return nil, err
+			}
+			defer f.Close()
+			r = f
+		}
+		found, err := nginx.ReadIngresses(r)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %s", file, err.Error())
+		}
+		ings = append(ings, found...)
+	}
+	if opts.fromCluster {
+		found, err := listIngresses(opts.namespace)
+		if err != nil {
+			return nil, err
+		}
+		ings = append(ings, found...)
+	}
+	return ings, nil
+}
+
+func listIngresses(namespace string) ([]*networking.Ingress, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	scheme := runtime.NewScheme()
+	if err := networking.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	kubeClient, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+	list := &networking.IngressList{}
+	if err := kubeClient.List(context.TODO(), list, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("list ingresses: %s", err.Error())
+	}
+	ings := make([]*networking.Ingress, 0, len(list.Items))
+	for i := range list.Items {
+		ings = append(ings, &list.Items[i])
+	}
+	return ings, nil
+}
+
+func openOutput(file string, std *os.File) (io.Writer, func(), error) {
+	if file == "" {
+		return std, func() {}, nil
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, func() { f.Close() }, nil
+}
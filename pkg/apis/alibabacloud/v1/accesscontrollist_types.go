@@ -0,0 +1,121 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	SchemeBuilder.Register(&AccessControlList{}, &AccessControlListList{})
+}
+
+const (
+	AccessControlListPhaseSynced = "Synced"
+	AccessControlListPhaseFailed = "Failed"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AccessControlList is an alb acl whose entries are kept in sync with static CIDRs and Kubernetes sources.
+// The acl is shared by the listeners of AlbConfigs referencing it by name. It can not be attached to nlb listeners,
+// since the nlb api has no acls.
+type AccessControlList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// Spec is the entries of the acl.
+	// +optional
+	Spec AccessControlListSpec `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+
+	// Status is the result of the last sync.
+	// +optional
+	Status AccessControlListStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AccessControlListList is a collection of AccessControlList.
+type AccessControlListList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// Items is the list of AccessControlList.
+	Items []AccessControlList `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
+
+// AccessControlListSpec describes the acl and the sources of its entries.
+type AccessControlListSpec struct {
+	// AclName is the name of the acl. Defaults to k8s.<cluster id>.<name>.
+	// An existing acl with the name is only adopted if it is tagged with the cluster id and the name of the object.
+	// +optional
+	AclName string `json:"aclName,omitempty" protobuf:"bytes,1,opt,name=aclName"`
+	// Entries are the sources of the entries, the entries of all the sources are merged.
+	// +optional
+	Entries []AccessControlListEntry `json:"entries,omitempty" protobuf:"bytes,2,rep,name=entries"`
+}
+
+// AccessControlListEntry is one source of the entries of the acl, exactly one of the fields must be set.
+type AccessControlListEntry struct {
+	// CIDR is a static entry, such as 10.0.0.0/8. An ip address is added as a /32 or /128 entry.
+	// +optional
+	CIDR string `json:"cidr,omitempty" protobuf:"bytes,1,opt,name=cidr"`
+	// Nodes adds the external ip addresses of the nodes.
+	// +optional
+	Nodes *AccessControlListNodeSource `json:"nodes,omitempty" protobuf:"bytes,2,opt,name=nodes"`
+	// ConfigMap adds the CIDRs in a key of a ConfigMap.
+	// +optional
+	ConfigMap *AccessControlListConfigMapSource `json:"configMap,omitempty" protobuf:"bytes,3,opt,name=configMap"`
+	// LoadBalancer adds the front-end ip addresses of another load balancer from the status of its LoadBalancer Service.
+	// +optional
+	LoadBalancer *AccessControlListLoadBalancerSource `json:"loadBalancer,omitempty" protobuf:"bytes,4,opt,name=loadBalancer"`
+}
+
+// AccessControlListNodeSource selects the nodes whose external ip addresses are added.
+type AccessControlListNodeSource struct {
+	// Selector selects the nodes. All the nodes are selected if it is not set.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty" protobuf:"bytes,1,opt,name=selector"`
+}
+
+// AccessControlListConfigMapSource is a key of a ConfigMap holding CIDRs separated by commas, spaces or new lines.
+type AccessControlListConfigMapSource struct {
+	Namespace string `json:"namespace" protobuf:"bytes,1,opt,name=namespace"`
+	Name      string `json:"name" protobuf:"bytes,2,opt,name=name"`
+	Key       string `json:"key" protobuf:"bytes,3,opt,name=key"`
+}
+
+// AccessControlListLoadBalancerSource is a LoadBalancer Service whose ingress ip addresses are added.
+// They are the addresses clients connect to, not the addresses the load balancer connects to backends from.
+type AccessControlListLoadBalancerSource struct {
+	Namespace string `json:"namespace" protobuf:"bytes,1,opt,name=namespace"`
+	Name      string `json:"name" protobuf:"bytes,2,opt,name=name"`
+}
+
+// AccessControlListStatus describes the last sync of the acl.
+type AccessControlListStatus struct {
+	// ObservedGeneration is the generation of the spec of the last sync.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty" protobuf:"varint,1,opt,name=observedGeneration"`
+	// AclID is the id of the acl, which is associated with the listeners referencing the AccessControlList.
+	// +optional
+	AclID string `json:"aclID,omitempty" protobuf:"bytes,2,opt,name=aclID"`
+	// Phase is Synced or Failed.
+	// +optional
+	Phase string `json:"phase,omitempty" protobuf:"bytes,3,opt,name=phase"`
+	// Message is the error of the last failed sync.
+	// +optional
+	Message string `json:"message,omitempty" protobuf:"bytes,4,opt,name=message"`
+	// Entries is the number of the entries of the acl.
+	// +optional
+	Entries int `json:"entries,omitempty" protobuf:"varint,5,opt,name=entries"`
+	// LastSyncTime is when the entries were synced successfully last time.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty" protobuf:"bytes,6,opt,name=lastSyncTime"`
+}
@@ -0,0 +1,113 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	SchemeBuilder.Register(&AlbCanary{}, &AlbCanaryList{})
+}
+
+const (
+	AlbCanaryPhaseProgressing = "Progressing"
+	AlbCanaryPhasePromoted    = "Promoted"
+	AlbCanaryPhaseRolledBack  = "RolledBack"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AlbCanary steps the canary weight of a canary Ingress on a schedule, and promotes or rolls back
+// the canary by the error rate and latency of its server groups in the access logs of the ALB instance.
+type AlbCanary struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// Spec is the desired progress of the canary.
+	// +optional
+	Spec AlbCanarySpec `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+
+	// Status is the current progress and the last analysis of the canary.
+	// +optional
+	Status AlbCanaryStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AlbCanaryList is a collection of AlbCanary.
+type AlbCanaryList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// Items is the list of AlbCanary.
+	Items []AlbCanary `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
+
+// AlbCanarySpec describes how the canary weight is stepped.
+type AlbCanarySpec struct {
+	// IngressName is the canary Ingress in the namespace of AlbCanary, whose canary-weight annotation is stepped.
+	IngressName string `json:"ingressName" protobuf:"bytes,1,opt,name=ingressName"`
+	// StepWeight is added to the canary weight on each step. Defaults to 10.
+	// +optional
+	StepWeight int `json:"stepWeight,omitempty" protobuf:"varint,2,opt,name=stepWeight"`
+	// MaxWeight is the canary weight the canary is promoted at. Defaults to 100.
+	// +optional
+	MaxWeight int `json:"maxWeight,omitempty" protobuf:"varint,3,opt,name=maxWeight"`
+	// Interval is the time between two steps, the access logs of the interval are analyzed before stepping.
+	// Defaults to 5m.
+	// +optional
+	Interval metav1.Duration `json:"interval,omitempty" protobuf:"bytes,4,opt,name=interval"`
+	// Analysis is the thresholds the canary is rolled back beyond.
+	// +optional
+	Analysis AlbCanaryAnalysis `json:"analysis,omitempty" protobuf:"bytes,5,opt,name=analysis"`
+}
+
+// AlbCanaryAnalysis describes the thresholds of the canary server groups in an interval.
+type AlbCanaryAnalysis struct {
+	// MaxErrorRate is the max percentage of responses with status 5xx. Defaults to 5.
+	// +optional
+	MaxErrorRate *int `json:"maxErrorRate,omitempty" protobuf:"varint,1,opt,name=maxErrorRate"`
+	// MaxLatencyMillis is the max average request time in milliseconds, not checked if 0.
+	// +optional
+	MaxLatencyMillis int64 `json:"maxLatencyMillis,omitempty" protobuf:"varint,2,opt,name=maxLatencyMillis"`
+	// MinRequests is the requests needed to analyze the interval, the step waits for more requests until then.
+	// +optional
+	MinRequests int64 `json:"minRequests,omitempty" protobuf:"varint,3,opt,name=minRequests"`
+}
+
+// AlbCanaryStatus describes the progress of the canary.
+type AlbCanaryStatus struct {
+	// ObservedGeneration is the generation of the spec the canary is progressing with,
+	// the canary restarts from the current canary weight once the spec changes.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty" protobuf:"varint,1,opt,name=observedGeneration"`
+	// Phase is one of Progressing, Promoted and RolledBack.
+	// +optional
+	Phase string `json:"phase,omitempty" protobuf:"bytes,2,opt,name=phase"`
+	// Weight is the current canary weight.
+	// +optional
+	Weight int `json:"weight,omitempty" protobuf:"varint,3,opt,name=weight"`
+	// LastStepTime is when the canary weight was stepped last time, the next analysis starts from it.
+	// +optional
+	LastStepTime *metav1.Time `json:"lastStepTime,omitempty" protobuf:"bytes,4,opt,name=lastStepTime"`
+	// Message is the result of the last analysis.
+	// +optional
+	Message string `json:"message,omitempty" protobuf:"bytes,5,opt,name=message"`
+	// ServerGroups are the metrics of the canary server groups in the last analysis.
+	// +optional
+	ServerGroups []AlbCanaryServerGroupStatus `json:"serverGroups,omitempty" protobuf:"bytes,6,rep,name=serverGroups"`
+}
+
+// AlbCanaryServerGroupStatus is the metrics of a canary server group in the access logs.
+type AlbCanaryServerGroupStatus struct {
+	ServerGroupID    string `json:"serverGroupID" protobuf:"bytes,1,opt,name=serverGroupID"`
+	Requests         int64  `json:"requests" protobuf:"varint,2,opt,name=requests"`
+	Errors           int64  `json:"errors" protobuf:"varint,3,opt,name=errors"`
+	AvgLatencyMillis int64  `json:"avgLatencyMillis" protobuf:"varint,4,opt,name=avgLatencyMillis"`
+}
@@ -69,6 +69,89 @@ type AlbConfigList struct {
 type AlbConfigSpec struct {
 	LoadBalancer *LoadBalancerSpec `json:"config" protobuf:"bytes,1,rep,name=config"`
 	Listeners    []*ListenerSpec   `json:"listeners" protobuf:"bytes,2,rep,name=listeners"`
+	// Sharding spreads the Ingresses onto multiple ALB instances by host once the quotas of one instance are hit.
+	// +optional
+	Sharding *ShardingConfig `json:"sharding,omitempty" protobuf:"bytes,3,opt,name=sharding"`
+	// Admission restricts the namespaces and hosts of the Ingresses and AlbRoutes joining the AlbConfig.
+	// +optional
+	Admission *AdmissionPolicy `json:"admission,omitempty" protobuf:"bytes,4,opt,name=admission"`
+	// DeletionPolicy is Delete or Retain. Once the AlbConfig is deleted, Delete deletes the ALB instances,
+	// while Retain keeps the instances with their listeners, rules and server groups, and only removes the
+	// tags the controller tracks them by. Defaults to Delete.
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty" protobuf:"bytes,5,opt,name=deletionPolicy"`
+	// MultiCluster shares the ALB instance with the controllers of other clusters, e.g. to shift the traffic
+	// from an old cluster to a new one gradually.
+	// +optional
+	MultiCluster *MultiClusterConfig `json:"multiCluster,omitempty" protobuf:"bytes,6,opt,name=multiCluster"`
+}
+
+const (
+	// MultiClusterRolePrimary manages the ALB instance, its listeners and rules, and its own server groups.
+	MultiClusterRolePrimary = "Primary"
+	// MultiClusterRoleMember manages its own server groups only.
+	MultiClusterRoleMember = "Member"
+)
+
+// MultiClusterConfig describes the clusters sharing an ALB instance. The AlbConfigs of the clusters have
+// the same name, and the same Ingresses and Services to build the same server groups.
+type MultiClusterConfig struct {
+	// Role is Primary or Member. Exactly one of the clusters is Primary.
+	Role string `json:"role" protobuf:"bytes,1,opt,name=role"`
+	// Clusters are the weights of the clusters, the Primary one included, used by the Primary cluster.
+	// The rules forward to the server groups built by each cluster by its weight, a cluster without the
+	// server group of a rule gets no traffic of the rule.
+	// +optional
+	Clusters []ClusterWeight `json:"clusters,omitempty" protobuf:"bytes,2,rep,name=clusters"`
+}
+
+// ClusterWeight is the share of traffic forwarded to a cluster.
+type ClusterWeight struct {
+	// ClusterID is the cluster ID the controller of the cluster runs with.
+	ClusterID string `json:"clusterID" protobuf:"bytes,1,opt,name=clusterID"`
+	// Weight is from 0 to 100.
+	Weight int `json:"weight" protobuf:"varint,2,opt,name=weight"`
+}
+
+// AdmissionPolicy delegates a shared AlbConfig to namespaces. Ingresses and AlbRoutes violating the policy
+// are rejected with events, and their rules are not applied.
+type AdmissionPolicy struct {
+	// AllowedNamespaces are the namespaces allowed to join the AlbConfig.
+	// All namespaces are allowed if neither AllowedNamespaces nor NamespaceSelector is set.
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty" protobuf:"bytes,1,rep,name=allowedNamespaces"`
+	// NamespaceSelector selects the namespaces allowed to join the AlbConfig, in addition to AllowedNamespaces.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty" protobuf:"bytes,2,opt,name=namespaceSelector"`
+	// HostOwnerships reserve hosts for namespaces. Hosts not covered by any ownership can be used by all
+	// allowed namespaces.
+	// +optional
+	HostOwnerships []HostOwnership `json:"hostOwnerships,omitempty" protobuf:"bytes,3,rep,name=hostOwnerships"`
+}
+
+// HostOwnership reserves a host for namespaces.
+type HostOwnership struct {
+	// Host is an exact host like www.example.com, or a wildcard host like *.example.com which covers
+	// all the subdomains of example.com. The most specific ownership of a host applies. * is the host of
+	// the rules without host, required by them once any ownership is set.
+	Host string `json:"host" protobuf:"bytes,1,opt,name=host"`
+	// Namespaces are the namespaces owning the host.
+	Namespaces []string `json:"namespaces" protobuf:"bytes,2,rep,name=namespaces"`
+}
+
+// ShardingConfig describes when to place Ingresses onto additional ALB instances.
+// Ingresses sharing a host are placed onto the same instance.
+type ShardingConfig struct {
+	Enabled bool `json:"enabled" protobuf:"varint,1,opt,name=enabled"`
+	// MaxShards is the max number of ALB instances, including the one of AlbConfig. Defaults to 5.
+	// +optional
+	MaxShards int `json:"maxShards,omitempty" protobuf:"varint,2,opt,name=maxShards"`
+	// MaxRulesPerListener is the quota of forwarding rules of a listener. Defaults to 100.
+	// +optional
+	MaxRulesPerListener int `json:"maxRulesPerListener,omitempty" protobuf:"varint,3,opt,name=maxRulesPerListener"`
+	// MaxServerGroupsPerLoadBalancer is the quota of server groups of an ALB instance. Defaults to 100.
+	// +optional
+	MaxServerGroupsPerLoadBalancer int `json:"maxServerGroupsPerLoadBalancer,omitempty" protobuf:"varint,4,opt,name=maxServerGroupsPerLoadBalancer"`
 }
 
 // IngressStatus describe the current state of the AckIngress.
@@ -76,6 +159,43 @@ type IngressStatus struct {
 	// LoadBalancer contains the current status of the load-balancer.
 	// +optional
 	LoadBalancer LoadBalancerStatus `json:"loadBalancer,omitempty" protobuf:"bytes,1,opt,name=loadBalancer"`
+	// Shards are the ALB instances the Ingresses are placed onto when sharding is enabled,
+	// the first one is LoadBalancer.
+	// +optional
+	Shards []ShardStatus `json:"shards,omitempty" protobuf:"bytes,2,rep,name=shards"`
+	// Conflicts are the forwarding rules shadowed by the rules of other Ingresses or AlbRoutes.
+	// +optional
+	Conflicts []RuleConflict `json:"conflicts,omitempty" protobuf:"bytes,3,rep,name=conflicts"`
+}
+
+const (
+	// RuleConflictTypeExact is the conflict of rules with the same conditions, the shadowed rule is not applied.
+	RuleConflictTypeExact = "Exact"
+	// RuleConflictTypeOverlap is the conflict of rules matching part of the same requests, the shadowed rule
+	// is applied after the winner, and doesn't receive the requests matched by the winner.
+	RuleConflictTypeOverlap = "Overlap"
+)
+
+// RuleConflict is a forwarding rule shadowed by the rule of another Ingress or AlbRoute on the same listener.
+type RuleConflict struct {
+	// Listener is the port and protocol of the listener, e.g. 443/HTTPS.
+	Listener string `json:"listener" protobuf:"bytes,1,opt,name=listener"`
+	// Type is Exact or Overlap.
+	Type string `json:"type" protobuf:"bytes,2,opt,name=type"`
+	// Winner is the Ingress or AlbRoute whose rule takes precedence, e.g. Ingress default/foo.
+	Winner string `json:"winner" protobuf:"bytes,3,opt,name=winner"`
+	// Shadowed is the Ingress or AlbRoute whose rule is shadowed.
+	Shadowed string `json:"shadowed" protobuf:"bytes,4,opt,name=shadowed"`
+	// Conditions describes the conditions of the shadowed rule.
+	Conditions string `json:"conditions" protobuf:"bytes,5,opt,name=conditions"`
+}
+
+// ShardStatus is the ALB instance of a shard and the hosts it serves.
+type ShardStatus struct {
+	Index   int      `json:"index" protobuf:"varint,1,opt,name=index"`
+	Id      string   `json:"id,omitempty" protobuf:"bytes,2,opt,name=id"`
+	DNSName string   `json:"dnsname,omitempty" protobuf:"bytes,3,opt,name=dnsname"`
+	Hosts   []string `json:"hosts,omitempty" protobuf:"bytes,4,rep,name=hosts"`
 }
 
 // LoadBalancer is a nested struct in alb response
@@ -185,6 +305,17 @@ type ListenerSpec struct {
 	LogConfig           LogConfig           `json:"logConfig" protobuf:"bytes,15,opt,name=logConfig"`
 	RequestTimeout      int                 `json:"requestTimeout" protobuf:"bytes,16,opt,name=requestTimeout"`
 	AclConfig           AclConfig           `json:"aclConfig" protobuf:"bytes,17,opt,name=aclConfig"`
+	CaBundle            *CaBundle           `json:"caBundle,omitempty" protobuf:"bytes,18,opt,name=caBundle"`
+}
+
+// CaBundle references the Secret or ConfigMap holding the CA certificates to verify client certificates,
+// it is uploaded to cas and enables mutual tls on the listener.
+type CaBundle struct {
+	Namespace     string `json:"namespace" protobuf:"bytes,1,opt,name=namespace"`
+	SecretName    string `json:"secretName,omitempty" protobuf:"bytes,2,opt,name=secretName"`
+	ConfigMapName string `json:"configMapName,omitempty" protobuf:"bytes,3,opt,name=configMapName"`
+	// Key is the data key of the CA bundle, defaults to ca.crt
+	Key string `json:"key,omitempty" protobuf:"bytes,4,opt,name=key"`
 }
 type Action struct {
 	Type string `json:"actionType" protobuf:"bytes,1,opt,name=actionType"`
@@ -236,4 +367,8 @@ type AclConfig struct {
 	AclType    string   `json:"aclType" protobuf:"bytes,2,opt,name=aclType"`
 	AclEntries []string `json:"aclEntries" protobuf:"bytes,3,opt,name=aclEntries"`
 	AclIds     []string `json:"aclIds" protobuf:"bytes,4,opt,name=aclIds"`
+	// AccessControlLists are the names of the AccessControlList objects whose acls are associated with the listener,
+	// together with AclIds.
+	// +optional
+	AccessControlLists []string `json:"accessControlLists,omitempty" protobuf:"bytes,5,opt,name=accessControlLists"`
 }
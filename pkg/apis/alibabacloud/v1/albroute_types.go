@@ -0,0 +1,195 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	SchemeBuilder.Register(&AlbRoute{}, &AlbRouteList{})
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AlbRoute declares forwarding rules of a listener of an AlbConfig, as a typed
+// alternative to the conditions and actions annotations of Ingress.
+type AlbRoute struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// Spec is the desired rules of the listener.
+	// +optional
+	Spec AlbRouteSpec `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+
+	// Status is whether the rules are applied to the listener.
+	// +optional
+	Status AlbRouteStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AlbRouteList is a collection of AlbRoute.
+type AlbRouteList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// Items is the list of AlbRoute.
+	Items []AlbRoute `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
+
+// AlbRouteSpec describes the listener rules the user wishes to exist.
+type AlbRouteSpec struct {
+	// ParentRef is the listener of AlbConfig the rules are attached to.
+	ParentRef AlbRouteParentRef `json:"parentRef" protobuf:"bytes,1,opt,name=parentRef"`
+	// Rules are applied in order. Among the Ingresses and AlbRoutes on the same listener, the rules
+	// are ordered by the alb.ingress.kubernetes.io/order annotation, AlbRoutes first on the same order.
+	Rules []AlbRouteRule `json:"rules" protobuf:"bytes,2,rep,name=rules"`
+}
+
+// AlbRouteStatus is the observed state of AlbRoute.
+type AlbRouteStatus struct {
+	// Accepted is true if the rules are applied to the listener.
+	Accepted bool `json:"accepted" protobuf:"varint,1,opt,name=accepted"`
+	// Reason is why the AlbRoute is not accepted.
+	// +optional
+	Reason string `json:"reason,omitempty" protobuf:"bytes,2,opt,name=reason"`
+}
+
+// AlbRouteParentRef references a listener of AlbConfig.
+type AlbRouteParentRef struct {
+	AlbConfigName string `json:"albConfigName" protobuf:"bytes,1,opt,name=albConfigName"`
+	Port          int32  `json:"port" protobuf:"varint,2,opt,name=port"`
+	// Protocol of the listener, HTTP, HTTPS or QUIC. Defaults to HTTP.
+	// +optional
+	Protocol string `json:"protocol,omitempty" protobuf:"bytes,3,opt,name=protocol"`
+}
+
+// AlbRouteRule is a forwarding rule of listener.
+type AlbRouteRule struct {
+	// Direction of the rule, Request or Response. Defaults to Request.
+	// +optional
+	Direction string `json:"direction,omitempty" protobuf:"bytes,1,opt,name=direction"`
+	// Conditions are ANDed, at least one condition is required.
+	Conditions []AlbRouteCondition `json:"conditions,omitempty" protobuf:"bytes,2,rep,name=conditions"`
+	Actions    []AlbRouteAction    `json:"actions" protobuf:"bytes,3,rep,name=actions"`
+}
+
+// AlbRouteCondition is a condition of rule.
+// Type is one of Host, Path, Header, Cookie, QueryString, Method, SourceIp, ResponseHeader and ResponseStatusCode.
+type AlbRouteCondition struct {
+	Type string `json:"type" protobuf:"bytes,1,opt,name=type"`
+	// Key is the header name of Header and ResponseHeader conditions.
+	// +optional
+	Key string `json:"key,omitempty" protobuf:"bytes,2,opt,name=key"`
+	// Values of Host, Path, Header, Method, SourceIp, ResponseHeader and ResponseStatusCode conditions.
+	// +optional
+	Values []string `json:"values,omitempty" protobuf:"bytes,3,rep,name=values"`
+	// KeyValues of Cookie and QueryString conditions.
+	// +optional
+	KeyValues []AlbRouteKeyValue `json:"keyValues,omitempty" protobuf:"bytes,4,rep,name=keyValues"`
+}
+
+type AlbRouteKeyValue struct {
+	Key   string `json:"key" protobuf:"bytes,1,opt,name=key"`
+	Value string `json:"value" protobuf:"bytes,2,opt,name=value"`
+}
+
+// AlbRouteAction is an action of rule, the config matching Type must be set.
+// Type is one of Forward, Redirect, FixedResponse, Rewrite, InsertHeader, RemoveHeader, TrafficMirror, TrafficLimit and Cors.
+type AlbRouteAction struct {
+	Type string `json:"type" protobuf:"bytes,1,opt,name=type"`
+	// +optional
+	Forward *AlbRouteForwardConfig `json:"forward,omitempty" protobuf:"bytes,2,opt,name=forward"`
+	// +optional
+	Redirect *AlbRouteRedirectConfig `json:"redirect,omitempty" protobuf:"bytes,3,opt,name=redirect"`
+	// +optional
+	FixedResponse *AlbRouteFixedResponseConfig `json:"fixedResponse,omitempty" protobuf:"bytes,4,opt,name=fixedResponse"`
+	// +optional
+	Rewrite *AlbRouteRewriteConfig `json:"rewrite,omitempty" protobuf:"bytes,5,opt,name=rewrite"`
+	// +optional
+	InsertHeader *AlbRouteInsertHeaderConfig `json:"insertHeader,omitempty" protobuf:"bytes,6,opt,name=insertHeader"`
+	// +optional
+	RemoveHeader *AlbRouteRemoveHeaderConfig `json:"removeHeader,omitempty" protobuf:"bytes,7,opt,name=removeHeader"`
+	// +optional
+	TrafficMirror *AlbRouteTrafficMirrorConfig `json:"trafficMirror,omitempty" protobuf:"bytes,8,opt,name=trafficMirror"`
+	// +optional
+	TrafficLimit *AlbRouteTrafficLimitConfig `json:"trafficLimit,omitempty" protobuf:"bytes,9,opt,name=trafficLimit"`
+	// +optional
+	Cors *AlbRouteCorsConfig `json:"cors,omitempty" protobuf:"bytes,10,opt,name=cors"`
+}
+
+// AlbRouteBackend is a Service in the namespace of AlbRoute, or an existing server group.
+type AlbRouteBackend struct {
+	// +optional
+	ServiceName string `json:"serviceName,omitempty" protobuf:"bytes,1,opt,name=serviceName"`
+	// +optional
+	ServicePort int32 `json:"servicePort,omitempty" protobuf:"varint,2,opt,name=servicePort"`
+	// +optional
+	ServerGroupId string `json:"serverGroupId,omitempty" protobuf:"bytes,3,opt,name=serverGroupId"`
+	// +optional
+	Weight int32 `json:"weight,omitempty" protobuf:"varint,4,opt,name=weight"`
+}
+
+type AlbRouteForwardConfig struct {
+	Backends []AlbRouteBackend `json:"backends" protobuf:"bytes,1,rep,name=backends"`
+}
+
+type AlbRouteRedirectConfig struct {
+	Host     string `json:"host,omitempty" protobuf:"bytes,1,opt,name=host"`
+	HttpCode string `json:"httpCode,omitempty" protobuf:"bytes,2,opt,name=httpCode"`
+	Path     string `json:"path,omitempty" protobuf:"bytes,3,opt,name=path"`
+	Port     string `json:"port,omitempty" protobuf:"bytes,4,opt,name=port"`
+	Protocol string `json:"protocol,omitempty" protobuf:"bytes,5,opt,name=protocol"`
+	Query    string `json:"query,omitempty" protobuf:"bytes,6,opt,name=query"`
+}
+
+type AlbRouteFixedResponseConfig struct {
+	Content     string `json:"content,omitempty" protobuf:"bytes,1,opt,name=content"`
+	ContentType string `json:"contentType" protobuf:"bytes,2,opt,name=contentType"`
+	HttpCode    string `json:"httpCode" protobuf:"bytes,3,opt,name=httpCode"`
+}
+
+type AlbRouteRewriteConfig struct {
+	Host  string `json:"host,omitempty" protobuf:"bytes,1,opt,name=host"`
+	Path  string `json:"path,omitempty" protobuf:"bytes,2,opt,name=path"`
+	Query string `json:"query,omitempty" protobuf:"bytes,3,opt,name=query"`
+}
+
+type AlbRouteInsertHeaderConfig struct {
+	Key   string `json:"key" protobuf:"bytes,1,opt,name=key"`
+	Value string `json:"value" protobuf:"bytes,2,opt,name=value"`
+	// ValueType is one of UserDefined, ReferenceHeader and SystemDefined. Defaults to UserDefined.
+	ValueType    string `json:"valueType,omitempty" protobuf:"bytes,3,opt,name=valueType"`
+	CoverEnabled bool   `json:"coverEnabled,omitempty" protobuf:"varint,4,opt,name=coverEnabled"`
+}
+
+type AlbRouteRemoveHeaderConfig struct {
+	Key string `json:"key" protobuf:"bytes,1,opt,name=key"`
+}
+
+// AlbRouteTrafficMirrorConfig mirrors the traffic to existing server groups, referenced by serverGroupId.
+type AlbRouteTrafficMirrorConfig struct {
+	Backends []AlbRouteBackend `json:"backends" protobuf:"bytes,1,rep,name=backends"`
+}
+
+type AlbRouteTrafficLimitConfig struct {
+	// +optional
+	QPS int32 `json:"qps,omitempty" protobuf:"varint,1,opt,name=qps"`
+	// +optional
+	QPSPerIp int32 `json:"qpsPerIp,omitempty" protobuf:"varint,2,opt,name=qpsPerIp"`
+}
+
+type AlbRouteCorsConfig struct {
+	AllowOrigin      []string `json:"allowOrigin,omitempty" protobuf:"bytes,1,rep,name=allowOrigin"`
+	AllowMethods     []string `json:"allowMethods,omitempty" protobuf:"bytes,2,rep,name=allowMethods"`
+	AllowHeaders     []string `json:"allowHeaders,omitempty" protobuf:"bytes,3,rep,name=allowHeaders"`
+	ExposeHeaders    []string `json:"exposeHeaders,omitempty" protobuf:"bytes,4,rep,name=exposeHeaders"`
+	AllowCredentials bool     `json:"allowCredentials,omitempty" protobuf:"varint,5,opt,name=allowCredentials"`
+	MaxAge           int32    `json:"maxAge,omitempty" protobuf:"varint,6,opt,name=maxAge"`
+}
@@ -0,0 +1,118 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func init() {
+	SchemeBuilder.Register(&ServerGroupBinding{}, &ServerGroupBindingList{})
+}
+
+// load balancer types of the bound server group
+const (
+	ServerGroupBindingLoadBalancerALB = "alb"
+	ServerGroupBindingLoadBalancerNLB = "nlb"
+	ServerGroupBindingLoadBalancerCLB = "clb"
+)
+
+// target types of the servers added to the bound server group
+const (
+	ServerGroupBindingTargetECS = "ecs"
+	ServerGroupBindingTargetENI = "eni"
+	ServerGroupBindingTargetIP  = "ip"
+)
+
+const (
+	ServerGroupBindingPhaseSynced = "Synced"
+	ServerGroupBindingPhaseFailed = "Failed"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServerGroupBinding keeps the servers of an existing server group in sync with the endpoints of a Service port.
+// The server group and the load balancer are not managed by the controller, only the servers added by the binding are.
+type ServerGroupBinding struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// Spec is the server group and the Service port bound to it.
+	// +optional
+	Spec ServerGroupBindingSpec `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+
+	// Status is the result of the last sync.
+	// +optional
+	Status ServerGroupBindingStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServerGroupBindingList is a collection of ServerGroupBinding.
+type ServerGroupBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// Items is the list of ServerGroupBinding.
+	Items []ServerGroupBinding `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
+
+// ServerGroupBindingSpec describes the server group and the servers added to it.
+type ServerGroupBindingSpec struct {
+	// LoadBalancerType is the type of the load balancer of the server group, one of alb, nlb and clb.
+	LoadBalancerType string `json:"loadBalancerType" protobuf:"bytes,1,opt,name=loadBalancerType"`
+	// ServerGroupID is the id of the server group, or the id of the vserver group for clb.
+	ServerGroupID string `json:"serverGroupID" protobuf:"bytes,2,opt,name=serverGroupID"`
+	// ServiceRef is the Service port in the namespace of ServerGroupBinding whose endpoints are added.
+	ServiceRef ServerGroupBindingServiceRef `json:"serviceRef" protobuf:"bytes,3,opt,name=serviceRef"`
+	// TargetType is the type of the servers, one of ecs, eni and ip.
+	// ecs adds the nodes with the NodePort, eni adds the ENIs of the pods, and ip adds the ip addresses of the endpoints.
+	// Defaults to the backend type of the Service.
+	// +optional
+	TargetType string `json:"targetType,omitempty" protobuf:"bytes,4,opt,name=targetType"`
+	// Weight is the weight of each server. Defaults to 100.
+	// +optional
+	Weight *int `json:"weight,omitempty" protobuf:"varint,5,opt,name=weight"`
+	// NodeSelector selects the nodes added for ecs targets. All the nodes are added if it is not set.
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty" protobuf:"bytes,6,opt,name=nodeSelector"`
+}
+
+// ServerGroupBindingServiceRef is a port of a Service.
+type ServerGroupBindingServiceRef struct {
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Port is the port number or the name of the Service port.
+	Port intstr.IntOrString `json:"port" protobuf:"bytes,2,opt,name=port"`
+}
+
+// ServerGroupBindingStatus describes the last sync of the servers.
+type ServerGroupBindingStatus struct {
+	// ObservedGeneration is the generation of the spec of the last sync.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty" protobuf:"varint,1,opt,name=observedGeneration"`
+	// Phase is Synced or Failed.
+	// +optional
+	Phase string `json:"phase,omitempty" protobuf:"bytes,2,opt,name=phase"`
+	// Message is the error of the last failed sync.
+	// +optional
+	Message string `json:"message,omitempty" protobuf:"bytes,3,opt,name=message"`
+	// Servers is the number of the servers added by the binding.
+	// +optional
+	Servers int `json:"servers,omitempty" protobuf:"varint,4,opt,name=servers"`
+	// LastSyncTime is when the servers were synced successfully last time.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty" protobuf:"bytes,5,opt,name=lastSyncTime"`
+	// ServerGroupID is the server group the servers are added to. The servers are removed from it
+	// once the spec is bound to another server group.
+	// +optional
+	ServerGroupID string `json:"serverGroupID,omitempty" protobuf:"bytes,6,opt,name=serverGroupID"`
+	// LoadBalancerType is the type of the load balancer of ServerGroupID.
+	// +optional
+	LoadBalancerType string `json:"loadBalancerType,omitempty" protobuf:"bytes,7,opt,name=loadBalancerType"`
+}
@@ -0,0 +1,133 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	SchemeBuilder.Register(&ServerGroupPolicy{}, &ServerGroupPolicyList{})
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServerGroupPolicy is the server group attributes shared by the Services and Ingresses referencing it.
+// The annotations of the Services and Ingresses override the attributes of the policy.
+type ServerGroupPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// Spec is the attributes of the server groups.
+	// +optional
+	Spec ServerGroupPolicySpec `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServerGroupPolicyList is a collection of ServerGroupPolicy.
+type ServerGroupPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// Items is the list of ServerGroupPolicy.
+	Items []ServerGroupPolicy `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
+
+// ServerGroupPolicySpec describes the attributes of the server groups, the unset attributes keep the defaults.
+type ServerGroupPolicySpec struct {
+	// HealthCheck is the health check of the server groups.
+	// +optional
+	HealthCheck *ServerGroupPolicyHealthCheck `json:"healthCheck,omitempty" protobuf:"bytes,1,opt,name=healthCheck"`
+	// StickySession is the session persistence of alb server groups.
+	// +optional
+	StickySession *ServerGroupPolicyStickySession `json:"stickySession,omitempty" protobuf:"bytes,2,opt,name=stickySession"`
+	// Scheduler is the scheduling algorithm, one of wrr, wlc, sch and uch for alb, and one of wrr, rr, sch and tch for nlb.
+	// +optional
+	Scheduler string `json:"scheduler,omitempty" protobuf:"bytes,3,opt,name=scheduler"`
+	// UchKey is the query parameter hashed by the uch scheduler of alb.
+	// +optional
+	UchKey string `json:"uchKey,omitempty" protobuf:"bytes,4,opt,name=uchKey"`
+	// UpstreamKeepaliveEnabled enables persistent connections to the backends of alb server groups.
+	// +optional
+	UpstreamKeepaliveEnabled *bool `json:"upstreamKeepaliveEnabled,omitempty" protobuf:"varint,5,opt,name=upstreamKeepaliveEnabled"`
+	// ConnectionDrain is the connection draining of nlb server groups.
+	// +optional
+	ConnectionDrain *ServerGroupPolicyConnectionDrain `json:"connectionDrain,omitempty" protobuf:"bytes,6,opt,name=connectionDrain"`
+	// SlowStart is the slow start of the backends added to alb server groups.
+	// +optional
+	SlowStart *ServerGroupPolicySlowStart `json:"slowStart,omitempty" protobuf:"bytes,7,opt,name=slowStart"`
+}
+
+// ServerGroupPolicyHealthCheck describes the health check of the server groups.
+type ServerGroupPolicyHealthCheck struct {
+	// Enabled is true if it is not set.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty" protobuf:"varint,1,opt,name=enabled"`
+	// Protocol is one of HTTP, HTTPS, TCP and GRPC for alb, and one of HTTP and TCP for nlb.
+	// +optional
+	Protocol string `json:"protocol,omitempty" protobuf:"bytes,2,opt,name=protocol"`
+	// +optional
+	Path string `json:"path,omitempty" protobuf:"bytes,3,opt,name=path"`
+	// Method is one of HEAD, GET and POST.
+	// +optional
+	Method string `json:"method,omitempty" protobuf:"bytes,4,opt,name=method"`
+	// Host is the domain name of http health checks.
+	// +optional
+	Host string `json:"host,omitempty" protobuf:"bytes,5,opt,name=host"`
+	// HTTPCodes are the status codes of healthy backends, such as http_2xx.
+	// +optional
+	HTTPCodes []string `json:"httpCodes,omitempty" protobuf:"bytes,6,rep,name=httpCodes"`
+	// ConnectPort is the port of the health checks, 0 for the port of the backends.
+	// +optional
+	ConnectPort *int `json:"connectPort,omitempty" protobuf:"varint,7,opt,name=connectPort"`
+	// +optional
+	TimeoutSeconds *int `json:"timeoutSeconds,omitempty" protobuf:"varint,8,opt,name=timeoutSeconds"`
+	// +optional
+	IntervalSeconds *int `json:"intervalSeconds,omitempty" protobuf:"varint,9,opt,name=intervalSeconds"`
+	// +optional
+	HealthyThreshold *int `json:"healthyThreshold,omitempty" protobuf:"varint,10,opt,name=healthyThreshold"`
+	// +optional
+	UnhealthyThreshold *int `json:"unhealthyThreshold,omitempty" protobuf:"varint,11,opt,name=unhealthyThreshold"`
+}
+
+// ServerGroupPolicyStickySession describes the session persistence of alb server groups.
+type ServerGroupPolicyStickySession struct {
+	// Enabled is true if it is not set.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty" protobuf:"varint,1,opt,name=enabled"`
+	// Type is Insert or Server.
+	// +optional
+	Type string `json:"type,omitempty" protobuf:"bytes,2,opt,name=type"`
+	// Cookie is the cookie of the backends for the Server type.
+	// +optional
+	Cookie string `json:"cookie,omitempty" protobuf:"bytes,3,opt,name=cookie"`
+	// CookieTimeoutSeconds is the timeout of the cookie inserted for the Insert type.
+	// +optional
+	CookieTimeoutSeconds *int `json:"cookieTimeoutSeconds,omitempty" protobuf:"varint,4,opt,name=cookieTimeoutSeconds"`
+}
+
+// ServerGroupPolicyConnectionDrain describes the connection draining of nlb server groups.
+type ServerGroupPolicyConnectionDrain struct {
+	// +optional
+	Enabled *bool `json:"enabled,omitempty" protobuf:"varint,1,opt,name=enabled"`
+	// TimeoutSeconds is from 10 to 900.
+	// +optional
+	TimeoutSeconds *int `json:"timeoutSeconds,omitempty" protobuf:"varint,2,opt,name=timeoutSeconds"`
+}
+
+// ServerGroupPolicySlowStart describes the slow start of alb server groups, which ramps up the traffic of new backends.
+// It is supported by the wrr and wlc schedulers.
+type ServerGroupPolicySlowStart struct {
+	// Enabled is true if it is not set.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty" protobuf:"varint,1,opt,name=enabled"`
+	// DurationSeconds is from 30 to 900, 30 if it is not set.
+	// +optional
+	DurationSeconds *int `json:"durationSeconds,omitempty" protobuf:"varint,2,opt,name=durationSeconds"`
+}
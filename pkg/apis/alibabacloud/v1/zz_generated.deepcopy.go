@@ -20,94 +20,1355 @@ limitations under the License.
 package v1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessControlList) DeepCopyInto(out *AccessControlList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessControlList.
+func (in *AccessControlList) DeepCopy() *AccessControlList {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessControlList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AccessControlList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessControlListConfigMapSource) DeepCopyInto(out *AccessControlListConfigMapSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessControlListConfigMapSource.
+func (in *AccessControlListConfigMapSource) DeepCopy() *AccessControlListConfigMapSource {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessControlListConfigMapSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessControlListEntry) DeepCopyInto(out *AccessControlListEntry) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = new(AccessControlListNodeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(AccessControlListConfigMapSource)
+		**out = **in
+	}
+	if in.LoadBalancer != nil {
+		in, out := &in.LoadBalancer, &out.LoadBalancer
+		*out = new(AccessControlListLoadBalancerSource)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessControlListEntry.
+func (in *AccessControlListEntry) DeepCopy() *AccessControlListEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessControlListEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessControlListList) DeepCopyInto(out *AccessControlListList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AccessControlList, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessControlListList.
+func (in *AccessControlListList) DeepCopy() *AccessControlListList {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessControlListList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AccessControlListList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessControlListLoadBalancerSource) DeepCopyInto(out *AccessControlListLoadBalancerSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessControlListLoadBalancerSource.
+func (in *AccessControlListLoadBalancerSource) DeepCopy() *AccessControlListLoadBalancerSource {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessControlListLoadBalancerSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessControlListNodeSource) DeepCopyInto(out *AccessControlListNodeSource) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessControlListNodeSource.
+func (in *AccessControlListNodeSource) DeepCopy() *AccessControlListNodeSource {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessControlListNodeSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessControlListSpec) DeepCopyInto(out *AccessControlListSpec) {
+	*out = *in
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = make([]AccessControlListEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessControlListSpec.
+func (in *AccessControlListSpec) DeepCopy() *AccessControlListSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessControlListSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessControlListStatus) DeepCopyInto(out *AccessControlListStatus) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessControlListStatus.
+func (in *AccessControlListStatus) DeepCopy() *AccessControlListStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessControlListStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccessLogConfig) DeepCopyInto(out *AccessLogConfig) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogConfig.
-func (in *AccessLogConfig) DeepCopy() *AccessLogConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogConfig.
+func (in *AccessLogConfig) DeepCopy() *AccessLogConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessLogConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessLogTracingConfig) DeepCopyInto(out *AccessLogTracingConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogTracingConfig.
+func (in *AccessLogTracingConfig) DeepCopy() *AccessLogTracingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessLogTracingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Action) DeepCopyInto(out *Action) {
+	*out = *in
+	if in.FixedResponseConfig != nil {
+		in, out := &in.FixedResponseConfig, &out.FixedResponseConfig
+		*out = new(FixedResponseActionConfig)
+		**out = **in
+	}
+	if in.RedirectConfig != nil {
+		in, out := &in.RedirectConfig, &out.RedirectConfig
+		*out = new(RedirectActionConfig)
+		**out = **in
+	}
+	if in.ForwardConfig != nil {
+		in, out := &in.ForwardConfig, &out.ForwardConfig
+		*out = new(ForwardActionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Action.
+func (in *Action) DeepCopy() *Action {
+	if in == nil {
+		return nil
+	}
+	out := new(Action)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionPolicy) DeepCopyInto(out *AdmissionPolicy) {
+	*out = *in
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostOwnerships != nil {
+		in, out := &in.HostOwnerships, &out.HostOwnerships
+		*out = make([]HostOwnership, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionPolicy.
+func (in *AdmissionPolicy) DeepCopy() *AdmissionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbCanary) DeepCopyInto(out *AlbCanary) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbCanary.
+func (in *AlbCanary) DeepCopy() *AlbCanary {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbCanary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AlbCanary) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbCanaryAnalysis) DeepCopyInto(out *AlbCanaryAnalysis) {
+	*out = *in
+	if in.MaxErrorRate != nil {
+		in, out := &in.MaxErrorRate, &out.MaxErrorRate
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbCanaryAnalysis.
+func (in *AlbCanaryAnalysis) DeepCopy() *AlbCanaryAnalysis {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbCanaryAnalysis)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbCanaryList) DeepCopyInto(out *AlbCanaryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AlbCanary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbCanaryList.
+func (in *AlbCanaryList) DeepCopy() *AlbCanaryList {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbCanaryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AlbCanaryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbCanaryServerGroupStatus) DeepCopyInto(out *AlbCanaryServerGroupStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbCanaryServerGroupStatus.
+func (in *AlbCanaryServerGroupStatus) DeepCopy() *AlbCanaryServerGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbCanaryServerGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbCanarySpec) DeepCopyInto(out *AlbCanarySpec) {
+	*out = *in
+	out.Interval = in.Interval
+	in.Analysis.DeepCopyInto(&out.Analysis)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbCanarySpec.
+func (in *AlbCanarySpec) DeepCopy() *AlbCanarySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbCanarySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbCanaryStatus) DeepCopyInto(out *AlbCanaryStatus) {
+	*out = *in
+	if in.LastStepTime != nil {
+		in, out := &in.LastStepTime, &out.LastStepTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ServerGroups != nil {
+		in, out := &in.ServerGroups, &out.ServerGroups
+		*out = make([]AlbCanaryServerGroupStatus, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbCanaryStatus.
+func (in *AlbCanaryStatus) DeepCopy() *AlbCanaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbCanaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbConfig) DeepCopyInto(out *AlbConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbConfig.
+func (in *AlbConfig) DeepCopy() *AlbConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AlbConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbConfigList) DeepCopyInto(out *AlbConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AlbConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbConfigList.
+func (in *AlbConfigList) DeepCopy() *AlbConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AlbConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbConfigSpec) DeepCopyInto(out *AlbConfigSpec) {
+	*out = *in
+	if in.LoadBalancer != nil {
+		in, out := &in.LoadBalancer, &out.LoadBalancer
+		*out = new(LoadBalancerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Listeners != nil {
+		in, out := &in.Listeners, &out.Listeners
+		*out = make([]*ListenerSpec, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ListenerSpec)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Sharding != nil {
+		in, out := &in.Sharding, &out.Sharding
+		*out = new(ShardingConfig)
+		**out = **in
+	}
+	if in.Admission != nil {
+		in, out := &in.Admission, &out.Admission
+		*out = new(AdmissionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MultiCluster != nil {
+		in, out := &in.MultiCluster, &out.MultiCluster
+		*out = new(MultiClusterConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbConfigSpec.
+func (in *AlbConfigSpec) DeepCopy() *AlbConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbRoute) DeepCopyInto(out *AlbRoute) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbRoute.
+func (in *AlbRoute) DeepCopy() *AlbRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AlbRoute) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbRouteAction) DeepCopyInto(out *AlbRouteAction) {
+	*out = *in
+	if in.Forward != nil {
+		in, out := &in.Forward, &out.Forward
+		*out = new(AlbRouteForwardConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Redirect != nil {
+		in, out := &in.Redirect, &out.Redirect
+		*out = new(AlbRouteRedirectConfig)
+		**out = **in
+	}
+	if in.FixedResponse != nil {
+		in, out := &in.FixedResponse, &out.FixedResponse
+		*out = new(AlbRouteFixedResponseConfig)
+		**out = **in
+	}
+	if in.Rewrite != nil {
+		in, out := &in.Rewrite, &out.Rewrite
+		*out = new(AlbRouteRewriteConfig)
+		**out = **in
+	}
+	if in.InsertHeader != nil {
+		in, out := &in.InsertHeader, &out.InsertHeader
+		*out = new(AlbRouteInsertHeaderConfig)
+		**out = **in
+	}
+	if in.RemoveHeader != nil {
+		in, out := &in.RemoveHeader, &out.RemoveHeader
+		*out = new(AlbRouteRemoveHeaderConfig)
+		**out = **in
+	}
+	if in.TrafficMirror != nil {
+		in, out := &in.TrafficMirror, &out.TrafficMirror
+		*out = new(AlbRouteTrafficMirrorConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TrafficLimit != nil {
+		in, out := &in.TrafficLimit, &out.TrafficLimit
+		*out = new(AlbRouteTrafficLimitConfig)
+		**out = **in
+	}
+	if in.Cors != nil {
+		in, out := &in.Cors, &out.Cors
+		*out = new(AlbRouteCorsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbRouteAction.
+func (in *AlbRouteAction) DeepCopy() *AlbRouteAction {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbRouteAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbRouteBackend) DeepCopyInto(out *AlbRouteBackend) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbRouteBackend.
+func (in *AlbRouteBackend) DeepCopy() *AlbRouteBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbRouteBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbRouteCondition) DeepCopyInto(out *AlbRouteCondition) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.KeyValues != nil {
+		in, out := &in.KeyValues, &out.KeyValues
+		*out = make([]AlbRouteKeyValue, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbRouteCondition.
+func (in *AlbRouteCondition) DeepCopy() *AlbRouteCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbRouteCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbRouteCorsConfig) DeepCopyInto(out *AlbRouteCorsConfig) {
+	*out = *in
+	if in.AllowOrigin != nil {
+		in, out := &in.AllowOrigin, &out.AllowOrigin
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowMethods != nil {
+		in, out := &in.AllowMethods, &out.AllowMethods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowHeaders != nil {
+		in, out := &in.AllowHeaders, &out.AllowHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExposeHeaders != nil {
+		in, out := &in.ExposeHeaders, &out.ExposeHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbRouteCorsConfig.
+func (in *AlbRouteCorsConfig) DeepCopy() *AlbRouteCorsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbRouteCorsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbRouteFixedResponseConfig) DeepCopyInto(out *AlbRouteFixedResponseConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbRouteFixedResponseConfig.
+func (in *AlbRouteFixedResponseConfig) DeepCopy() *AlbRouteFixedResponseConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbRouteFixedResponseConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbRouteForwardConfig) DeepCopyInto(out *AlbRouteForwardConfig) {
+	*out = *in
+	if in.Backends != nil {
+		in, out := &in.Backends, &out.Backends
+		*out = make([]AlbRouteBackend, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbRouteForwardConfig.
+func (in *AlbRouteForwardConfig) DeepCopy() *AlbRouteForwardConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbRouteForwardConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbRouteInsertHeaderConfig) DeepCopyInto(out *AlbRouteInsertHeaderConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbRouteInsertHeaderConfig.
+func (in *AlbRouteInsertHeaderConfig) DeepCopy() *AlbRouteInsertHeaderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbRouteInsertHeaderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbRouteKeyValue) DeepCopyInto(out *AlbRouteKeyValue) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbRouteKeyValue.
+func (in *AlbRouteKeyValue) DeepCopy() *AlbRouteKeyValue {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbRouteKeyValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbRouteList) DeepCopyInto(out *AlbRouteList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AlbRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbRouteList.
+func (in *AlbRouteList) DeepCopy() *AlbRouteList {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbRouteList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AlbRouteList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbRouteParentRef) DeepCopyInto(out *AlbRouteParentRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbRouteParentRef.
+func (in *AlbRouteParentRef) DeepCopy() *AlbRouteParentRef {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbRouteParentRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbRouteRedirectConfig) DeepCopyInto(out *AlbRouteRedirectConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbRouteRedirectConfig.
+func (in *AlbRouteRedirectConfig) DeepCopy() *AlbRouteRedirectConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbRouteRedirectConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbRouteRemoveHeaderConfig) DeepCopyInto(out *AlbRouteRemoveHeaderConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbRouteRemoveHeaderConfig.
+func (in *AlbRouteRemoveHeaderConfig) DeepCopy() *AlbRouteRemoveHeaderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbRouteRemoveHeaderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbRouteRewriteConfig) DeepCopyInto(out *AlbRouteRewriteConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbRouteRewriteConfig.
+func (in *AlbRouteRewriteConfig) DeepCopy() *AlbRouteRewriteConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbRouteRewriteConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbRouteRule) DeepCopyInto(out *AlbRouteRule) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]AlbRouteCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]AlbRouteAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbRouteRule.
+func (in *AlbRouteRule) DeepCopy() *AlbRouteRule {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbRouteRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbRouteSpec) DeepCopyInto(out *AlbRouteSpec) {
+	*out = *in
+	out.ParentRef = in.ParentRef
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]AlbRouteRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbRouteSpec.
+func (in *AlbRouteSpec) DeepCopy() *AlbRouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbRouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbRouteStatus) DeepCopyInto(out *AlbRouteStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbRouteStatus.
+func (in *AlbRouteStatus) DeepCopy() *AlbRouteStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbRouteStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbRouteTrafficLimitConfig) DeepCopyInto(out *AlbRouteTrafficLimitConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbRouteTrafficLimitConfig.
+func (in *AlbRouteTrafficLimitConfig) DeepCopy() *AlbRouteTrafficLimitConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbRouteTrafficLimitConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlbRouteTrafficMirrorConfig) DeepCopyInto(out *AlbRouteTrafficMirrorConfig) {
+	*out = *in
+	if in.Backends != nil {
+		in, out := &in.Backends, &out.Backends
+		*out = make([]AlbRouteBackend, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbRouteTrafficMirrorConfig.
+func (in *AlbRouteTrafficMirrorConfig) DeepCopy() *AlbRouteTrafficMirrorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AlbRouteTrafficMirrorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BillingConfig) DeepCopyInto(out *BillingConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BillingConfig.
+func (in *BillingConfig) DeepCopy() *BillingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BillingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CaBundle) DeepCopyInto(out *CaBundle) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CaBundle.
+func (in *CaBundle) DeepCopy() *CaBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(CaBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Certificate) DeepCopyInto(out *Certificate) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Certificate.
+func (in *Certificate) DeepCopy() *Certificate {
+	if in == nil {
+		return nil
+	}
+	out := new(Certificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterWeight) DeepCopyInto(out *ClusterWeight) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterWeight.
+func (in *ClusterWeight) DeepCopy() *ClusterWeight {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterWeight)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeletionProtectionConfig) DeepCopyInto(out *DeletionProtectionConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeletionProtectionConfig.
+func (in *DeletionProtectionConfig) DeepCopy() *DeletionProtectionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DeletionProtectionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FixedResponseActionConfig) DeepCopyInto(out *FixedResponseActionConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FixedResponseActionConfig.
+func (in *FixedResponseActionConfig) DeepCopy() *FixedResponseActionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FixedResponseActionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardActionConfig) DeepCopyInto(out *ForwardActionConfig) {
+	*out = *in
+	if in.TargetGroups != nil {
+		in, out := &in.TargetGroups, &out.TargetGroups
+		*out = make([]TargetGroupTuple, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardActionConfig.
+func (in *ForwardActionConfig) DeepCopy() *ForwardActionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardActionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostOwnership) DeepCopyInto(out *HostOwnership) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOwnership.
+func (in *HostOwnership) DeepCopy() *HostOwnership {
+	if in == nil {
+		return nil
+	}
+	out := new(HostOwnership)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressStatus) DeepCopyInto(out *IngressStatus) {
+	*out = *in
+	out.LoadBalancer = in.LoadBalancer
+	if in.Shards != nil {
+		in, out := &in.Shards, &out.Shards
+		*out = make([]ShardStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conflicts != nil {
+		in, out := &in.Conflicts, &out.Conflicts
+		*out = make([]RuleConflict, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressStatus.
+func (in *IngressStatus) DeepCopy() *IngressStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListenerSpec) DeepCopyInto(out *ListenerSpec) {
+	*out = *in
+	if in.GzipEnabled != nil {
+		in, out := &in.GzipEnabled, &out.GzipEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	out.QuicConfig = in.QuicConfig
+	if in.Http2Enabled != nil {
+		in, out := &in.Http2Enabled, &out.Http2Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DefaultActions != nil {
+		in, out := &in.DefaultActions, &out.DefaultActions
+		*out = make([]Action, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.Port = in.Port
+	if in.CaCertificates != nil {
+		in, out := &in.CaCertificates, &out.CaCertificates
+		*out = make([]Certificate, len(*in))
+		copy(*out, *in)
+	}
+	out.XForwardedForConfig = in.XForwardedForConfig
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]Certificate, len(*in))
+		copy(*out, *in)
+	}
+	out.LogConfig = in.LogConfig
+	if in.CaBundle != nil {
+		in, out := &in.CaBundle, &out.CaBundle
+		*out = new(CaBundle)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerSpec.
+func (in *ListenerSpec) DeepCopy() *ListenerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ListenerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerSpec) DeepCopyInto(out *LoadBalancerSpec) {
+	*out = *in
+	if in.ZoneMappings != nil {
+		in, out := &in.ZoneMappings, &out.ZoneMappings
+		*out = make([]ZoneMapping, len(*in))
+		copy(*out, *in)
+	}
+	out.AccessLogConfig = in.AccessLogConfig
+	if in.DeletionProtectionEnabled != nil {
+		in, out := &in.DeletionProtectionEnabled, &out.DeletionProtectionEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	out.BillingConfig = in.BillingConfig
+	if in.ForceOverride != nil {
+		in, out := &in.ForceOverride, &out.ForceOverride
+		*out = new(bool)
+		**out = **in
+	}
+	out.ModificationProtectionConfig = in.ModificationProtectionConfig
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerSpec.
+func (in *LoadBalancerSpec) DeepCopy() *LoadBalancerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerStatus) DeepCopyInto(out *LoadBalancerStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerStatus.
+func (in *LoadBalancerStatus) DeepCopy() *LoadBalancerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogConfig) DeepCopyInto(out *LogConfig) {
+	*out = *in
+	out.AccessLogTracingConfig = in.AccessLogTracingConfig
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogConfig.
+func (in *LogConfig) DeepCopy() *LogConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LogConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModificationProtectionConfig) DeepCopyInto(out *ModificationProtectionConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModificationProtectionConfig.
+func (in *ModificationProtectionConfig) DeepCopy() *ModificationProtectionConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(AccessLogConfig)
+	out := new(ModificationProtectionConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AccessLogTracingConfig) DeepCopyInto(out *AccessLogTracingConfig) {
+func (in *QuicConfig) DeepCopyInto(out *QuicConfig) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogTracingConfig.
-func (in *AccessLogTracingConfig) DeepCopy() *AccessLogTracingConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuicConfig.
+func (in *QuicConfig) DeepCopy() *QuicConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(AccessLogTracingConfig)
+	out := new(QuicConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Action) DeepCopyInto(out *Action) {
+func (in *RedirectActionConfig) DeepCopyInto(out *RedirectActionConfig) {
 	*out = *in
-	if in.FixedResponseConfig != nil {
-		in, out := &in.FixedResponseConfig, &out.FixedResponseConfig
-		*out = new(FixedResponseActionConfig)
-		**out = **in
-	}
-	if in.RedirectConfig != nil {
-		in, out := &in.RedirectConfig, &out.RedirectConfig
-		*out = new(RedirectActionConfig)
-		**out = **in
-	}
-	if in.ForwardConfig != nil {
-		in, out := &in.ForwardConfig, &out.ForwardConfig
-		*out = new(ForwardActionConfig)
-		(*in).DeepCopyInto(*out)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedirectActionConfig.
+func (in *RedirectActionConfig) DeepCopy() *RedirectActionConfig {
+	if in == nil {
+		return nil
 	}
+	out := new(RedirectActionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleConflict) DeepCopyInto(out *RuleConflict) {
+	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Action.
-func (in *Action) DeepCopy() *Action {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleConflict.
+func (in *RuleConflict) DeepCopy() *RuleConflict {
 	if in == nil {
 		return nil
 	}
-	out := new(Action)
+	out := new(RuleConflict)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AlbConfig) DeepCopyInto(out *AlbConfig) {
+func (in *ServerGroupBinding) DeepCopyInto(out *ServerGroupBinding) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbConfig.
-func (in *AlbConfig) DeepCopy() *AlbConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerGroupBinding.
+func (in *ServerGroupBinding) DeepCopy() *ServerGroupBinding {
 	if in == nil {
 		return nil
 	}
-	out := new(AlbConfig)
+	out := new(ServerGroupBinding)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *AlbConfig) DeepCopyObject() runtime.Object {
+func (in *ServerGroupBinding) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -115,13 +1376,13 @@ func (in *AlbConfig) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AlbConfigList) DeepCopyInto(out *AlbConfigList) {
+func (in *ServerGroupBindingList) DeepCopyInto(out *ServerGroupBindingList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]AlbConfig, len(*in))
+		*out = make([]ServerGroupBinding, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -129,18 +1390,18 @@ func (in *AlbConfigList) DeepCopyInto(out *AlbConfigList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbConfigList.
-func (in *AlbConfigList) DeepCopy() *AlbConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerGroupBindingList.
+func (in *ServerGroupBindingList) DeepCopy() *ServerGroupBindingList {
 	if in == nil {
 		return nil
 	}
-	out := new(AlbConfigList)
+	out := new(ServerGroupBindingList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *AlbConfigList) DeepCopyObject() runtime.Object {
+func (in *ServerGroupBindingList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -148,297 +1409,353 @@ func (in *AlbConfigList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AlbConfigSpec) DeepCopyInto(out *AlbConfigSpec) {
+func (in *ServerGroupBindingServiceRef) DeepCopyInto(out *ServerGroupBindingServiceRef) {
 	*out = *in
-	if in.LoadBalancer != nil {
-		in, out := &in.LoadBalancer, &out.LoadBalancer
-		*out = new(LoadBalancerSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Listeners != nil {
-		in, out := &in.Listeners, &out.Listeners
-		*out = make([]*ListenerSpec, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(ListenerSpec)
-				(*in).DeepCopyInto(*out)
-			}
-		}
-	}
+	out.Port = in.Port
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlbConfigSpec.
-func (in *AlbConfigSpec) DeepCopy() *AlbConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerGroupBindingServiceRef.
+func (in *ServerGroupBindingServiceRef) DeepCopy() *ServerGroupBindingServiceRef {
 	if in == nil {
 		return nil
 	}
-	out := new(AlbConfigSpec)
+	out := new(ServerGroupBindingServiceRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BillingConfig) DeepCopyInto(out *BillingConfig) {
+func (in *ServerGroupBindingSpec) DeepCopyInto(out *ServerGroupBindingSpec) {
 	*out = *in
+	out.ServiceRef = in.ServiceRef
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int)
+		**out = **in
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BillingConfig.
-func (in *BillingConfig) DeepCopy() *BillingConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerGroupBindingSpec.
+func (in *ServerGroupBindingSpec) DeepCopy() *ServerGroupBindingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(BillingConfig)
+	out := new(ServerGroupBindingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Certificate) DeepCopyInto(out *Certificate) {
+func (in *ServerGroupBindingStatus) DeepCopyInto(out *ServerGroupBindingStatus) {
 	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Certificate.
-func (in *Certificate) DeepCopy() *Certificate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerGroupBindingStatus.
+func (in *ServerGroupBindingStatus) DeepCopy() *ServerGroupBindingStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(Certificate)
+	out := new(ServerGroupBindingStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeletionProtectionConfig) DeepCopyInto(out *DeletionProtectionConfig) {
+func (in *ServerGroupPolicy) DeepCopyInto(out *ServerGroupPolicy) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeletionProtectionConfig.
-func (in *DeletionProtectionConfig) DeepCopy() *DeletionProtectionConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerGroupPolicy.
+func (in *ServerGroupPolicy) DeepCopy() *ServerGroupPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(DeletionProtectionConfig)
+	out := new(ServerGroupPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FixedResponseActionConfig) DeepCopyInto(out *FixedResponseActionConfig) {
-	*out = *in
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FixedResponseActionConfig.
-func (in *FixedResponseActionConfig) DeepCopy() *FixedResponseActionConfig {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServerGroupPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(FixedResponseActionConfig)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ForwardActionConfig) DeepCopyInto(out *ForwardActionConfig) {
+func (in *ServerGroupPolicyConnectionDrain) DeepCopyInto(out *ServerGroupPolicyConnectionDrain) {
 	*out = *in
-	if in.TargetGroups != nil {
-		in, out := &in.TargetGroups, &out.TargetGroups
-		*out = make([]TargetGroupTuple, len(*in))
-		copy(*out, *in)
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardActionConfig.
-func (in *ForwardActionConfig) DeepCopy() *ForwardActionConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerGroupPolicyConnectionDrain.
+func (in *ServerGroupPolicyConnectionDrain) DeepCopy() *ServerGroupPolicyConnectionDrain {
 	if in == nil {
 		return nil
 	}
-	out := new(ForwardActionConfig)
+	out := new(ServerGroupPolicyConnectionDrain)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IngressStatus) DeepCopyInto(out *IngressStatus) {
+func (in *ServerGroupPolicyHealthCheck) DeepCopyInto(out *ServerGroupPolicyHealthCheck) {
 	*out = *in
-	out.LoadBalancer = in.LoadBalancer
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HTTPCodes != nil {
+		in, out := &in.HTTPCodes, &out.HTTPCodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConnectPort != nil {
+		in, out := &in.ConnectPort, &out.ConnectPort
+		*out = new(int)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.HealthyThreshold != nil {
+		in, out := &in.HealthyThreshold, &out.HealthyThreshold
+		*out = new(int)
+		**out = **in
+	}
+	if in.UnhealthyThreshold != nil {
+		in, out := &in.UnhealthyThreshold, &out.UnhealthyThreshold
+		*out = new(int)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressStatus.
-func (in *IngressStatus) DeepCopy() *IngressStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerGroupPolicyHealthCheck.
+func (in *ServerGroupPolicyHealthCheck) DeepCopy() *ServerGroupPolicyHealthCheck {
 	if in == nil {
 		return nil
 	}
-	out := new(IngressStatus)
+	out := new(ServerGroupPolicyHealthCheck)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ListenerSpec) DeepCopyInto(out *ListenerSpec) {
+func (in *ServerGroupPolicyList) DeepCopyInto(out *ServerGroupPolicyList) {
 	*out = *in
-	if in.GzipEnabled != nil {
-		in, out := &in.GzipEnabled, &out.GzipEnabled
-		*out = new(bool)
-		**out = **in
-	}
-	out.QuicConfig = in.QuicConfig
-	if in.Http2Enabled != nil {
-		in, out := &in.Http2Enabled, &out.Http2Enabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.DefaultActions != nil {
-		in, out := &in.DefaultActions, &out.DefaultActions
-		*out = make([]Action, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ServerGroupPolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.Port = in.Port
-	if in.CaCertificates != nil {
-		in, out := &in.CaCertificates, &out.CaCertificates
-		*out = make([]Certificate, len(*in))
-		copy(*out, *in)
-	}
-	out.XForwardedForConfig = in.XForwardedForConfig
-	if in.Certificates != nil {
-		in, out := &in.Certificates, &out.Certificates
-		*out = make([]Certificate, len(*in))
-		copy(*out, *in)
-	}
-	out.LogConfig = in.LogConfig
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerSpec.
-func (in *ListenerSpec) DeepCopy() *ListenerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerGroupPolicyList.
+func (in *ServerGroupPolicyList) DeepCopy() *ServerGroupPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(ListenerSpec)
+	out := new(ServerGroupPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServerGroupPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LoadBalancerSpec) DeepCopyInto(out *LoadBalancerSpec) {
+func (in *ServerGroupPolicySlowStart) DeepCopyInto(out *ServerGroupPolicySlowStart) {
 	*out = *in
-	if in.ZoneMappings != nil {
-		in, out := &in.ZoneMappings, &out.ZoneMappings
-		*out = make([]ZoneMapping, len(*in))
-		copy(*out, *in)
-	}
-	out.AccessLogConfig = in.AccessLogConfig
-	if in.DeletionProtectionEnabled != nil {
-		in, out := &in.DeletionProtectionEnabled, &out.DeletionProtectionEnabled
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
 		**out = **in
 	}
-	out.BillingConfig = in.BillingConfig
-	if in.ForceOverride != nil {
-		in, out := &in.ForceOverride, &out.ForceOverride
-		*out = new(bool)
+	if in.DurationSeconds != nil {
+		in, out := &in.DurationSeconds, &out.DurationSeconds
+		*out = new(int)
 		**out = **in
 	}
-	out.ModificationProtectionConfig = in.ModificationProtectionConfig
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerSpec.
-func (in *LoadBalancerSpec) DeepCopy() *LoadBalancerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerGroupPolicySlowStart.
+func (in *ServerGroupPolicySlowStart) DeepCopy() *ServerGroupPolicySlowStart {
 	if in == nil {
 		return nil
 	}
-	out := new(LoadBalancerSpec)
+	out := new(ServerGroupPolicySlowStart)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LoadBalancerStatus) DeepCopyInto(out *LoadBalancerStatus) {
+func (in *ServerGroupPolicySpec) DeepCopyInto(out *ServerGroupPolicySpec) {
 	*out = *in
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(ServerGroupPolicyHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StickySession != nil {
+		in, out := &in.StickySession, &out.StickySession
+		*out = new(ServerGroupPolicyStickySession)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpstreamKeepaliveEnabled != nil {
+		in, out := &in.UpstreamKeepaliveEnabled, &out.UpstreamKeepaliveEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ConnectionDrain != nil {
+		in, out := &in.ConnectionDrain, &out.ConnectionDrain
+		*out = new(ServerGroupPolicyConnectionDrain)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SlowStart != nil {
+		in, out := &in.SlowStart, &out.SlowStart
+		*out = new(ServerGroupPolicySlowStart)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerStatus.
-func (in *LoadBalancerStatus) DeepCopy() *LoadBalancerStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerGroupPolicySpec.
+func (in *ServerGroupPolicySpec) DeepCopy() *ServerGroupPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(LoadBalancerStatus)
+	out := new(ServerGroupPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogConfig) DeepCopyInto(out *LogConfig) {
+func (in *ServerGroupPolicyStickySession) DeepCopyInto(out *ServerGroupPolicyStickySession) {
 	*out = *in
-	out.AccessLogTracingConfig = in.AccessLogTracingConfig
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CookieTimeoutSeconds != nil {
+		in, out := &in.CookieTimeoutSeconds, &out.CookieTimeoutSeconds
+		*out = new(int)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogConfig.
-func (in *LogConfig) DeepCopy() *LogConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerGroupPolicyStickySession.
+func (in *ServerGroupPolicyStickySession) DeepCopy() *ServerGroupPolicyStickySession {
 	if in == nil {
 		return nil
 	}
-	out := new(LogConfig)
+	out := new(ServerGroupPolicyStickySession)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModificationProtectionConfig) DeepCopyInto(out *ModificationProtectionConfig) {
+func (in *ShardStatus) DeepCopyInto(out *ShardStatus) {
 	*out = *in
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModificationProtectionConfig.
-func (in *ModificationProtectionConfig) DeepCopy() *ModificationProtectionConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardStatus.
+func (in *ShardStatus) DeepCopy() *ShardStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ModificationProtectionConfig)
+	out := new(ShardStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QuicConfig) DeepCopyInto(out *QuicConfig) {
+func (in *MultiClusterConfig) DeepCopyInto(out *MultiClusterConfig) {
 	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterWeight, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuicConfig.
-func (in *QuicConfig) DeepCopy() *QuicConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterConfig.
+func (in *MultiClusterConfig) DeepCopy() *MultiClusterConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(QuicConfig)
+	out := new(MultiClusterConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RedirectActionConfig) DeepCopyInto(out *RedirectActionConfig) {
+func (in *ShardingConfig) DeepCopyInto(out *ShardingConfig) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedirectActionConfig.
-func (in *RedirectActionConfig) DeepCopy() *RedirectActionConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingConfig.
+func (in *ShardingConfig) DeepCopy() *ShardingConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(RedirectActionConfig)
+	out := new(ShardingConfig)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -30,6 +30,15 @@ type CloudConfig struct {
 		ServiceBackendType string `json:"serviceBackendType"`
 		DisablePublicSLB   bool   `json:"disablePublicSLB"`
 
+		// controller concurrency and retries, override the flags if set, the delays are in seconds
+		ConcurrentServiceSyncs  int     `json:"concurrentServiceSyncs"`
+		ConcurrentIngressSyncs  int     `json:"concurrentIngressSyncs"`
+		ConcurrentEndpointSyncs int     `json:"concurrentEndpointSyncs"`
+		ReconcileRetryBaseDelay int64   `json:"reconcileRetryBaseDelay"`
+		ReconcileRetryMaxDelay  int64   `json:"reconcileRetryMaxDelay"`
+		ReconcileRetryQPS       float64 `json:"reconcileRetryQPS"`
+		ReconcileRetryBurst     int     `json:"reconcileRetryBurst"`
+
 		// node controller
 		NodeMonitorPeriod  int64 `json:"nodeMonitorPeriod"`
 		NodeAddrSyncPeriod int64 `json:"nodeAddrSyncPeriod"`
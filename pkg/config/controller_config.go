@@ -29,6 +29,10 @@ const (
 	flagRouteReconciliationPeriod      = "route-reconciliation-period"
 	flagNodeMonitorPeriod              = "node-monitor-period"
 	flagNetwork                        = "network"
+	flagCertificateExpiryThreshold     = "certificate-expiry-threshold"
+	flagCertificateAuditPeriod         = "certificate-audit-period"
+	flagCertificateInventoryTTL        = "certificate-inventory-ttl"
+	flagCanaryAnalysisPeriod           = "canary-analysis-period"
 
 	defaultCloudProvider             = "alibabacloud"
 	defaultClusterName               = "kubernetes"
@@ -38,6 +42,10 @@ const (
 	defaultRouteReconciliationPeriod = 5 * time.Minute
 	defaultNodeMonitorPeriod         = 5 * time.Minute
 	defaultNetwork                   = "vpc"
+	defaultCertExpiryThreshold       = 30 * 24 * time.Hour
+	defaultCertAuditPeriod           = 1 * time.Hour
+	defaultCertInventoryTTL          = 10 * time.Minute
+	defaultCanaryAnalysisPeriod      = 30 * time.Second
 )
 
 var ControllerCFG = &ControllerConfig{
@@ -54,9 +62,15 @@ type ControllerConfig struct {
 	LogLevel                       int
 	DryRun                         bool
 	NetWork                        string
-
-	RuntimeConfig RuntimeConfig
-	CloudConfig   *CloudConfig
+	CertificateExpiryThreshold     time.Duration
+	CertificateAuditPeriod         time.Duration
+	CertificateInventoryTTL        time.Duration
+	CanaryAnalysisPeriod           time.Duration
+
+	RuntimeConfig   RuntimeConfig
+	QuotaConfig     QuotaConfig
+	ReconcileConfig ReconcileConfig
+	CloudConfig     *CloudConfig
 }
 
 func (cfg *ControllerConfig) BindFlags(fs *pflag.FlagSet) {
@@ -64,7 +78,7 @@ func (cfg *ControllerConfig) BindFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&cfg.ClusterName, flagClusterName, defaultClusterName, "The instance prefix for the cluster.")
 	fs.StringVar(&cfg.CloudConfigPath, flagCloudConfig, defaultCloudConfig,
 		"The path to the cloud provider configuration file. Empty string for no configuration file.")
-	fs.StringSliceVar(&cfg.Controllers, flagControllers, []string{"ingress", "service"}, "A list of controllers to enable.")
+	fs.StringSliceVar(&cfg.Controllers, flagControllers, []string{"ingress", "service", "servergroupbinding", "accesscontrollist"}, "A list of controllers to enable.")
 	fs.BoolVar(&cfg.UseServiceAccountCredentials, flagUseServiceAccountCredentials, false, "If true, use individual service account credentials for each controller.")
 	fs.BoolVar(&cfg.ConfigureCloudRoutes, flagConfigureCloudRoutes, defaultConfigureCloudRoutes, "Should CIDRs allocated by allocate-node-cidrs be configured on the cloud provider.")
 	fs.StringVar(&cfg.ClusterCIDR, flagClusterCidr, "", "CIDR Range for Pods in cluster. Requires --allocate-node-cidrs to be true.")
@@ -73,6 +87,14 @@ func (cfg *ControllerConfig) BindFlags(fs *pflag.FlagSet) {
 		"Maximum number of concurrently running reconcile loops for service")
 	fs.BoolVar(&cfg.DryRun, flagDryRun, false, "whether to perform a dry run")
 	fs.StringVar(&cfg.NetWork, flagNetwork, defaultNetwork, "Set network type for controller.")
+	fs.DurationVar(&cfg.CertificateExpiryThreshold, flagCertificateExpiryThreshold, defaultCertExpiryThreshold,
+		"Warning events are recorded for certificates that expire within this duration.")
+	fs.DurationVar(&cfg.CertificateAuditPeriod, flagCertificateAuditPeriod, defaultCertAuditPeriod,
+		"The period for auditing the expiry of certificates attached to listeners. The minimum value is 1 minute")
+	fs.DurationVar(&cfg.CertificateInventoryTTL, flagCertificateInventoryTTL, defaultCertInventoryTTL,
+		"The max age of the cached cas certificates used to discover certificates for ingress hosts and to upload certificates from secrets for nlb. The minimum value is 1 minute")
+	fs.DurationVar(&cfg.CanaryAnalysisPeriod, flagCanaryAnalysisPeriod, defaultCanaryAnalysisPeriod,
+		"The period for checking AlbCanaries due for analysis. The minimum value is 10 seconds")
 	fs.DurationVar(&cfg.RouteReconciliationPeriod.Duration, flagRouteReconciliationPeriod, defaultRouteReconciliationPeriod,
 		"The period for reconciling routes created for nodes by cloud provider. The minimum value is 1 minute")
 	fs.DurationVar(&cfg.NodeMonitorPeriod.Duration, flagNodeMonitorPeriod, defaultNodeMonitorPeriod, "The period for syncing NodeStatus in NodeController.")
@@ -81,6 +103,8 @@ func (cfg *ControllerConfig) BindFlags(fs *pflag.FlagSet) {
 	_ = fs.MarkDeprecated("allow-untagged-cloud", "This flag is deprecated and will be removed in a future release. A cluster-id will be required on cloud instances.")
 
 	cfg.RuntimeConfig.BindFlags(fs)
+	cfg.QuotaConfig.BindFlags(fs)
+	cfg.ReconcileConfig.BindFlags(fs)
 }
 
 // Validate the controller configuration
@@ -96,6 +120,25 @@ func (cfg *ControllerConfig) Validate() error {
 	if cfg.RouteReconciliationPeriod.Duration < 1*time.Minute {
 		cfg.RouteReconciliationPeriod.Duration = 1 * time.Minute
 	}
+
+	if cfg.CertificateAuditPeriod < 1*time.Minute {
+		cfg.CertificateAuditPeriod = 1 * time.Minute
+	}
+
+	if cfg.CanaryAnalysisPeriod < 10*time.Second {
+		cfg.CanaryAnalysisPeriod = 10 * time.Second
+	}
+
+	if cfg.CertificateInventoryTTL < 1*time.Minute {
+		cfg.CertificateInventoryTTL = 1 * time.Minute
+	}
+
+	if cfg.ServiceMaxConcurrentReconciles < 1 {
+		cfg.ServiceMaxConcurrentReconciles = defaultMaxConcurrentReconciles
+	}
+
+	cfg.QuotaConfig.Validate()
+	cfg.ReconcileConfig.Validate()
 	return nil
 }
 
@@ -118,6 +161,7 @@ func (cfg *ControllerConfig) LoadControllerConfig() error {
 		return fmt.Errorf("load cloud config error: %s", err.Error())
 	}
 	cfg.CloudConfig.PrintInfo()
+	cfg.overrideByCloudConfig()
 
 	if cfg.CloudConfig.Global.FeatureGates != "" {
 		apiClient := apiext.NewForConfigOrDie(sigConfig.GetConfigOrDie())
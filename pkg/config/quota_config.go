@@ -0,0 +1,75 @@
+package config
+
+import (
+	"github.com/spf13/pflag"
+)
+
+const (
+	flagAlbListenersPerLoadBalancerQuota    = "alb-listeners-per-loadbalancer-quota"
+	flagAlbRulesPerListenerQuota            = "alb-rules-per-listener-quota"
+	flagAlbConditionsPerRuleQuota           = "alb-conditions-per-rule-quota"
+	flagAlbActionsPerRuleQuota              = "alb-actions-per-rule-quota"
+	flagAlbServerGroupsPerLoadBalancerQuota = "alb-server-groups-per-loadbalancer-quota"
+	flagAlbServerGroupsPerActionQuota       = "alb-server-groups-per-action-quota"
+	flagAlbBackendsPerServerGroupQuota      = "alb-backends-per-server-group-quota"
+	flagNlbListenersPerLoadBalancerQuota    = "nlb-listeners-per-loadbalancer-quota"
+	flagNlbBackendsPerServerGroupQuota      = "nlb-backends-per-server-group-quota"
+
+	// the default quotas of an account, the quotas without a well-known default are not checked by default
+	defaultAlbListenersPerLoadBalancerQuota    = 50
+	defaultAlbRulesPerListenerQuota            = 100
+	defaultAlbConditionsPerRuleQuota           = 10
+	defaultAlbServerGroupsPerLoadBalancerQuota = 100
+	defaultAlbServerGroupsPerActionQuota       = 5
+	defaultAlbBackendsPerServerGroupQuota      = 200
+	defaultNlbListenersPerLoadBalancerQuota    = 50
+)
+
+// QuotaConfig stores the quotas of the account checked before applying load balancers.
+// A quota of 0 disables the check. The quotas default to the default quotas of an account,
+// and are raised by the flags once the quotas of the account are increased.
+type QuotaConfig struct {
+	AlbListenersPerLoadBalancer    int
+	AlbRulesPerListener            int
+	AlbConditionsPerRule           int
+	AlbActionsPerRule              int
+	AlbServerGroupsPerLoadBalancer int
+	AlbServerGroupsPerAction       int
+	AlbBackendsPerServerGroup      int
+	NlbListenersPerLoadBalancer    int
+	NlbBackendsPerServerGroup      int
+}
+
+func (c *QuotaConfig) BindFlags(fs *pflag.FlagSet) {
+	fs.IntVar(&c.AlbListenersPerLoadBalancer, flagAlbListenersPerLoadBalancerQuota, defaultAlbListenersPerLoadBalancerQuota,
+		"The max number of listeners on an alb instance. 0 disables the check.")
+	fs.IntVar(&c.AlbRulesPerListener, flagAlbRulesPerListenerQuota, defaultAlbRulesPerListenerQuota,
+		"The max number of forwarding rules on an alb listener. 0 disables the check.")
+	fs.IntVar(&c.AlbConditionsPerRule, flagAlbConditionsPerRuleQuota, defaultAlbConditionsPerRuleQuota,
+		"The max number of conditions of an alb forwarding rule. 0 disables the check.")
+	fs.IntVar(&c.AlbActionsPerRule, flagAlbActionsPerRuleQuota, 0,
+		"The max number of actions of an alb forwarding rule. 0 disables the check.")
+	fs.IntVar(&c.AlbServerGroupsPerLoadBalancer, flagAlbServerGroupsPerLoadBalancerQuota, defaultAlbServerGroupsPerLoadBalancerQuota,
+		"The max number of server groups used by an alb instance. 0 disables the check.")
+	fs.IntVar(&c.AlbServerGroupsPerAction, flagAlbServerGroupsPerActionQuota, defaultAlbServerGroupsPerActionQuota,
+		"The max number of server groups forwarded to by an alb rule action. 0 disables the check.")
+	fs.IntVar(&c.AlbBackendsPerServerGroup, flagAlbBackendsPerServerGroupQuota, defaultAlbBackendsPerServerGroupQuota,
+		"The max number of backends in an alb server group. 0 disables the check.")
+	fs.IntVar(&c.NlbListenersPerLoadBalancer, flagNlbListenersPerLoadBalancerQuota, defaultNlbListenersPerLoadBalancerQuota,
+		"The max number of listeners on an nlb instance. 0 disables the check.")
+	fs.IntVar(&c.NlbBackendsPerServerGroup, flagNlbBackendsPerServerGroupQuota, 0,
+		"The max number of backends in an nlb server group. 0 disables the check.")
+}
+
+// Validate turns negative quotas into 0
+func (c *QuotaConfig) Validate() {
+	for _, q := range []*int{
+		&c.AlbListenersPerLoadBalancer, &c.AlbRulesPerListener, &c.AlbConditionsPerRule, &c.AlbActionsPerRule,
+		&c.AlbServerGroupsPerLoadBalancer, &c.AlbServerGroupsPerAction, &c.AlbBackendsPerServerGroup,
+		&c.NlbListenersPerLoadBalancer, &c.NlbBackendsPerServerGroup,
+	} {
+		if *q < 0 {
+			*q = 0
+		}
+	}
+}
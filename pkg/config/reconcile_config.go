@@ -0,0 +1,100 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	flagIngressMaxConcurrentReconciles  = "concurrent-ingress-syncs"
+	flagEndpointMaxConcurrentReconciles = "concurrent-endpoint-syncs"
+	flagRetryBaseDelay                  = "reconcile-retry-base-delay"
+	flagRetryMaxDelay                   = "reconcile-retry-max-delay"
+	flagRetryQPS                        = "reconcile-retry-qps"
+	flagRetryBurst                      = "reconcile-retry-burst"
+
+	defaultEndpointMaxConcurrentReconciles = 2
+	defaultRetryBaseDelay                  = 5 * time.Second
+	defaultRetryMaxDelay                   = 300 * time.Second
+	defaultRetryQPS                        = 10
+	defaultRetryBurst                      = 100
+)
+
+// ReconcileConfig stores the concurrency and the retry rate limits of the nlb and alb controllers.
+// The service concurrency is ControllerConfig.ServiceMaxConcurrentReconciles.
+type ReconcileConfig struct {
+	IngressMaxConcurrentReconciles int
+	// EndpointMaxConcurrentReconciles is the concurrency of the queue of the services whose endpoints changed
+	EndpointMaxConcurrentReconciles int
+	// RetryBaseDelay and RetryMaxDelay are the per item exponential backoff of failed reconciles
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+	// RetryQPS and RetryBurst are the overall bucket limits of retries of each queue
+	RetryQPS   float64
+	RetryBurst int
+}
+
+func (c *ReconcileConfig) BindFlags(fs *pflag.FlagSet) {
+	fs.IntVar(&c.IngressMaxConcurrentReconciles, flagIngressMaxConcurrentReconciles, defaultMaxConcurrentReconciles,
+		"Maximum number of concurrently running reconcile loops for albconfig")
+	fs.IntVar(&c.EndpointMaxConcurrentReconciles, flagEndpointMaxConcurrentReconciles, defaultEndpointMaxConcurrentReconciles,
+		"Maximum number of concurrently running reconcile loops for nlb services whose endpoints changed")
+	fs.DurationVar(&c.RetryBaseDelay, flagRetryBaseDelay, defaultRetryBaseDelay,
+		"The delay of the first retry of a failed reconcile, doubled on each failure")
+	fs.DurationVar(&c.RetryMaxDelay, flagRetryMaxDelay, defaultRetryMaxDelay,
+		"The max delay of the retries of a failed reconcile")
+	fs.Float64Var(&c.RetryQPS, flagRetryQPS, defaultRetryQPS,
+		"The overall qps of the retries of each controller")
+	fs.IntVar(&c.RetryBurst, flagRetryBurst, defaultRetryBurst,
+		"The overall burst of the retries of each controller")
+}
+
+// Validate turns the invalid values into the defaults
+func (c *ReconcileConfig) Validate() {
+	if c.IngressMaxConcurrentReconciles < 1 {
+		c.IngressMaxConcurrentReconciles = defaultMaxConcurrentReconciles
+	}
+	if c.EndpointMaxConcurrentReconciles < 1 {
+		c.EndpointMaxConcurrentReconciles = defaultEndpointMaxConcurrentReconciles
+	}
+	if c.RetryBaseDelay <= 0 {
+		c.RetryBaseDelay = defaultRetryBaseDelay
+	}
+	if c.RetryMaxDelay < c.RetryBaseDelay {
+		c.RetryMaxDelay = c.RetryBaseDelay
+	}
+	if c.RetryQPS <= 0 {
+		c.RetryQPS = defaultRetryQPS
+	}
+	if c.RetryBurst < 1 {
+		c.RetryBurst = defaultRetryBurst
+	}
+}
+
+// overrideByCloudConfig overrides the flags by the values set in the cloud config
+func (cfg *ControllerConfig) overrideByCloudConfig() {
+	global := cfg.CloudConfig.Global
+	if global.ConcurrentServiceSyncs > 0 {
+		cfg.ServiceMaxConcurrentReconciles = global.ConcurrentServiceSyncs
+	}
+	if global.ConcurrentIngressSyncs > 0 {
+		cfg.ReconcileConfig.IngressMaxConcurrentReconciles = global.ConcurrentIngressSyncs
+	}
+	if global.ConcurrentEndpointSyncs > 0 {
+		cfg.ReconcileConfig.EndpointMaxConcurrentReconciles = global.ConcurrentEndpointSyncs
+	}
+	if global.ReconcileRetryBaseDelay > 0 {
+		cfg.ReconcileConfig.RetryBaseDelay = time.Duration(global.ReconcileRetryBaseDelay) * time.Second
+	}
+	if global.ReconcileRetryMaxDelay > 0 {
+		cfg.ReconcileConfig.RetryMaxDelay = time.Duration(global.ReconcileRetryMaxDelay) * time.Second
+	}
+	if global.ReconcileRetryQPS > 0 {
+		cfg.ReconcileConfig.RetryQPS = global.ReconcileRetryQPS
+	}
+	if global.ReconcileRetryBurst > 0 {
+		cfg.ReconcileConfig.RetryBurst = global.ReconcileRetryBurst
+	}
+	cfg.ReconcileConfig.Validate()
+}
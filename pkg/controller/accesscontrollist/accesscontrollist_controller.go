@@ -0,0 +1,261 @@
+package accesscontrollist
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sdkutils "github.com/aliyun/alibaba-cloud-sdk-go/sdk/utils"
+	"github.com/go-logr/logr"
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	ctrlCfg "k8s.io/alibaba-load-balancer-controller/pkg/config"
+	"k8s.io/alibaba-load-balancer-controller/pkg/context/shared"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/helper"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model/alb"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model/alb/core"
+	prvd "k8s.io/alibaba-load-balancer-controller/pkg/provider"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	accessControlListControllerName = "accesscontrollist-controller"
+	// AccessControlListFinalizer deletes the acl before the AccessControlList is deleted
+	AccessControlListFinalizer = "accesscontrollist.k8s.alibaba/resources"
+)
+
+func Add(mgr manager.Manager, ctx *shared.SharedContext) error {
+	if err := RegisterCRD(mgr.GetConfig()); err != nil {
+		return fmt.Errorf("register accesscontrollist crd error: %s", err.Error())
+	}
+	return add(mgr, newReconciler(mgr, ctx))
+}
+
+func newReconciler(mgr manager.Manager, ctx *shared.SharedContext) *accessControlListReconciler {
+	return &accessControlListReconciler{
+		cloud:            ctx.Provider(),
+		kubeClient:       mgr.GetClient(),
+		logger:           ctrl.Log.WithName("controller").WithName(accessControlListControllerName),
+		record:           mgr.GetEventRecorderFor(accessControlListControllerName),
+		finalizerManager: helper.NewDefaultFinalizerManager(mgr.GetClient()),
+	}
+}
+
+func add(mgr manager.Manager, r *accessControlListReconciler) error {
+	recoverPanic := true
+	c, err := controller.New(accessControlListControllerName, mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: ctrlCfg.ControllerCFG.ReconcileConfig.EndpointMaxConcurrentReconciles,
+		RateLimiter:             helper.NewControllerRateLimiter(ctrlCfg.ControllerCFG.ReconcileConfig),
+		RecoverPanic:            &recoverPanic,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := c.Watch(&source.Kind{Type: &v1.AccessControlList{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("watch resource accesscontrollist error: %s", err.Error())
+	}
+	if err := c.Watch(&source.Kind{Type: &corev1.Node{}},
+		handler.EnqueueRequestsFromMapFunc(r.mapNodeToAccessControlLists), nodeChangedPredicate()); err != nil {
+		return fmt.Errorf("watch resource node error: %s", err.Error())
+	}
+	if err := c.Watch(&source.Kind{Type: &corev1.ConfigMap{}},
+		handler.EnqueueRequestsFromMapFunc(r.mapConfigMapToAccessControlLists)); err != nil {
+		return fmt.Errorf("watch resource configmap error: %s", err.Error())
+	}
+	if err := c.Watch(&source.Kind{Type: &corev1.Service{}},
+		handler.EnqueueRequestsFromMapFunc(r.mapServiceToAccessControlLists), serviceChangedPredicate()); err != nil {
+		return fmt.Errorf("watch resource svc error: %s", err.Error())
+	}
+	return nil
+}
+
+type accessControlListReconciler struct {
+	cloud            prvd.Provider
+	kubeClient       client.Client
+	logger           logr.Logger
+	record           record.EventRecorder
+	finalizerManager helper.FinalizerManager
+}
+
+func (r *accessControlListReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	acl := &v1.AccessControlList{}
+	if err := r.kubeClient.Get(ctx, request.NamespacedName, acl); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	log := r.logger.WithValues("accesscontrollist", request.Name)
+	ctx = context.WithValue(ctx, util.TraceID, sdkutils.GetUUID())
+
+	if acl.DeletionTimestamp != nil {
+		return reconcile.Result{}, r.cleanup(ctx, acl)
+	}
+
+	if err := r.finalizerManager.AddFinalizers(ctx, acl, AccessControlListFinalizer); err != nil {
+		r.record.Event(acl, corev1.EventTypeWarning, helper.FailedAddFinalizer,
+			fmt.Sprintf("Error adding finalizer: %s", err.Error()))
+		return reconcile.Result{}, err
+	}
+
+	aclID, entries, err := r.sync(ctx, acl)
+	if err != nil {
+		log.Error(err, "sync acl failed")
+		r.record.Event(acl, corev1.EventTypeWarning, helper.FailedSyncAccessControlList, helper.GetLogMessage(err))
+		if updateErr := r.updateStatus(ctx, acl, func(status *v1.AccessControlListStatus) {
+			status.AclID = aclID
+			status.Phase = v1.AccessControlListPhaseFailed
+			status.Message = helper.GetLogMessage(err)
+		}); updateErr != nil {
+			log.Error(updateErr, "update status failed")
+		}
+		return reconcile.Result{}, err
+	}
+
+	if acl.Status.Phase != v1.AccessControlListPhaseSynced || acl.Status.AclID != aclID || acl.Status.Entries != entries {
+		r.record.Event(acl, corev1.EventTypeNormal, helper.SucceedSyncAccessControlList,
+			fmt.Sprintf("Synced %d entries to acl %s", entries, aclID))
+	}
+	now := metav1.Now()
+	return reconcile.Result{}, r.updateStatus(ctx, acl, func(status *v1.AccessControlListStatus) {
+		status.AclID = aclID
+		status.Phase = v1.AccessControlListPhaseSynced
+		status.Message = ""
+		status.Entries = entries
+		status.LastSyncTime = &now
+	})
+}
+
+// sync creates the acl if it does not exist, and updates its entries to the entries of all the sources.
+// It returns the id of the acl, which is kept in the status even if the sync failed.
+// An existing acl with the name is only adopted if it is tagged by this AccessControlList of the cluster,
+// so that the entries of an acl managed by others are not overwritten.
+func (r *accessControlListReconciler) sync(ctx context.Context, acl *v1.AccessControlList) (string, int, error) {
+	aclID := acl.Status.AclID
+	cidrs, err := resolveEntries(ctx, r.kubeClient, acl)
+	if err != nil {
+		return aclID, 0, err
+	}
+	resAcl := buildResAcl(acl, getAclName(acl, r.cloud.ClusterID()), cidrs)
+	tags := buildAclTags(acl, r.cloud.ClusterID())
+
+	if aclID == "" {
+		sdkAcl, err := r.cloud.FindAclByName(ctx, resAcl.Spec.AclName)
+		if err != nil {
+			return aclID, 0, fmt.Errorf("find acl %s error: %s", resAcl.Spec.AclName, err.Error())
+		}
+		if sdkAcl != nil {
+			sdkTags, err := r.cloud.ListAclTags(ctx, sdkAcl.AclId)
+			if err != nil {
+				return aclID, 0, fmt.Errorf("list tags of acl %s error: %s", sdkAcl.AclId, err.Error())
+			}
+			if !isAclOwned(sdkTags, tags) {
+				return aclID, 0, fmt.Errorf("acl %s named %s is not managed by this AccessControlList, "+
+					"set spec.aclName to another name or tag the acl with %v to adopt it",
+					sdkAcl.AclId, resAcl.Spec.AclName, tags)
+			}
+			aclID = sdkAcl.AclId
+		}
+	}
+	if aclID != "" {
+		err = r.cloud.UpdateAclEntries(ctx, resAcl, aclID)
+		if err == nil {
+			return aclID, len(cidrs), nil
+		}
+		if !isAclNotFound(err) {
+			return aclID, 0, fmt.Errorf("update entries of acl %s error: %s", aclID, err.Error())
+		}
+		r.logger.Info("acl not found, recreate it", "accesscontrollist", acl.Name, "aclID", aclID)
+	}
+
+	status, err := r.cloud.CreateAclWithEntries(ctx, resAcl, tags)
+	if err != nil {
+		return "", 0, fmt.Errorf("create acl %s error: %s", resAcl.Spec.AclName, err.Error())
+	}
+	return status.AclID, len(cidrs), nil
+}
+
+// cleanup deletes the acl before removing the finalizer, the acl can not be deleted
+// until it is disassociated from all the listeners
+func (r *accessControlListReconciler) cleanup(ctx context.Context, acl *v1.AccessControlList) error {
+	if !helper.HasFinalizer(acl, AccessControlListFinalizer) {
+		return nil
+	}
+	if acl.Status.AclID != "" {
+		err := r.cloud.DeleteAclByID(ctx, acl.Status.AclID)
+		if err != nil && isAclNotFound(err) {
+			r.logger.Info("acl not found, skip deleting it",
+				"accesscontrollist", acl.Name, "aclID", acl.Status.AclID)
+			err = nil
+		}
+		if err != nil {
+			r.record.Event(acl, corev1.EventTypeWarning, helper.FailedCleanAccessControlList, helper.GetLogMessage(err))
+			return err
+		}
+	}
+	if err := r.finalizerManager.RemoveFinalizers(ctx, acl, AccessControlListFinalizer); err != nil {
+		r.record.Event(acl, corev1.EventTypeWarning, helper.FailedRemoveFinalizer,
+			fmt.Sprintf("Error removing finalizer: %s", err.Error()))
+		return err
+	}
+	return nil
+}
+
+func (r *accessControlListReconciler) updateStatus(ctx context.Context, acl *v1.AccessControlList,
+	update func(status *v1.AccessControlListStatus)) error {
+	updated := acl.DeepCopy()
+	updated.Status.ObservedGeneration = acl.Generation
+	update(&updated.Status)
+	return r.kubeClient.Status().Patch(ctx, updated, client.MergeFrom(acl))
+}
+
+// buildResAcl builds the acl model, which is not associated with any listener
+func buildResAcl(acl *v1.AccessControlList, aclName string, cidrs []string) *alb.Acl {
+	stack := core.NewDefaultManager(core.StackID(types.NamespacedName{Name: acl.Name}))
+	entries := make([]alb.AclEntry, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		entries = append(entries, alb.AclEntry{Entry: cidr})
+	}
+	return alb.NewAcl(stack, acl.Name, alb.AclSpec{
+		ListenerID: core.LiteralStringToken(""),
+		AclName:    aclName,
+		AclEntries: entries,
+	})
+}
+
+// buildAclTags returns the tags identifying the acl of the AccessControlList in the cluster
+func buildAclTags(acl *v1.AccessControlList, clusterID string) map[string]string {
+	return map[string]string{
+		util.ClusterTagKey:           clusterID,
+		util.AccessControlListTagKey: acl.Name,
+	}
+}
+
+// isAclOwned returns true if the acl carries all the tags of the AccessControlList
+func isAclOwned(sdkTags, tags map[string]string) bool {
+	for k, v := range tags {
+		if sdkTags[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// isAclNotFound returns true if the acl was deleted outside the cluster
+func isAclNotFound(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "NotExist") || strings.Contains(msg, "NotFound")
+}
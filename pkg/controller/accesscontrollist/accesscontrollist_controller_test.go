@@ -0,0 +1,98 @@
+package accesscontrollist
+
+import (
+	"context"
+	"testing"
+
+	albsdk "github.com/aliyun/alibaba-cloud-sdk-go/services/alb"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model/alb"
+	prvd "k8s.io/alibaba-load-balancer-controller/pkg/provider"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeAclProvider keeps the acls and their tags in memory
+type fakeAclProvider struct {
+	prvd.Provider
+	acls    map[string]string
+	tags    map[string]map[string]string
+	updated []string
+}
+
+func (p *fakeAclProvider) ClusterID() string {
+	return "c123"
+}
+
+func (p *fakeAclProvider) FindAclByName(_ context.Context, aclName string) (*albsdk.Acl, error) {
+	for id, name := range p.acls {
+		if name == aclName {
+			return &albsdk.Acl{AclId: id, AclName: name}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (p *fakeAclProvider) ListAclTags(_ context.Context, sdkAclID string) (map[string]string, error) {
+	return p.tags[sdkAclID], nil
+}
+
+func (p *fakeAclProvider) CreateAclWithEntries(_ context.Context, resAcl *alb.Acl, tags map[string]string) (alb.AclStatus, error) {
+	id := "acl-new"
+	p.acls[id] = resAcl.Spec.AclName
+	p.tags[id] = tags
+	return alb.AclStatus{AclID: id}, nil
+}
+
+func (p *fakeAclProvider) UpdateAclEntries(_ context.Context, _ *alb.Acl, sdkAclID string) error {
+	p.updated = append(p.updated, sdkAclID)
+	return nil
+}
+
+func TestSyncAdoptsOnlyOwnedAcls(t *testing.T) {
+	cloud := &fakeAclProvider{
+		acls: map[string]string{"acl-other": "office-acl"},
+		tags: map[string]map[string]string{"acl-other": {"team": "security"}},
+	}
+	r := &accessControlListReconciler{
+		cloud:      cloud,
+		kubeClient: fake.NewClientBuilder().Build(),
+		logger:     logr.Discard(),
+	}
+	acl := &v1.AccessControlList{
+		ObjectMeta: metav1.ObjectMeta{Name: "office"},
+		Spec: v1.AccessControlListSpec{
+			AclName: "office-acl",
+			Entries: []v1.AccessControlListEntry{{CIDR: "10.0.0.0/8"}},
+		},
+	}
+
+	// the acl with the same name is managed by others
+	aclID, _, err := r.sync(context.TODO(), acl)
+	assert.Error(t, err)
+	assert.Empty(t, aclID)
+	assert.Empty(t, cloud.updated)
+
+	// and adopted once it is tagged by the AccessControlList of the cluster
+	cloud.tags["acl-other"][util.ClusterTagKey] = "c123"
+	cloud.tags["acl-other"][util.AccessControlListTagKey] = "office"
+	aclID, entries, err := r.sync(context.TODO(), acl)
+	assert.NoError(t, err)
+	assert.Equal(t, "acl-other", aclID)
+	assert.Equal(t, 1, entries)
+	assert.Equal(t, []string{"acl-other"}, cloud.updated)
+
+	// a new acl is created with the tags
+	acl.Spec.AclName = ""
+	aclID, _, err = r.sync(context.TODO(), acl)
+	assert.NoError(t, err)
+	assert.Equal(t, "acl-new", aclID)
+	assert.Equal(t, "k8s.c123.office", cloud.acls["acl-new"])
+	assert.Equal(t, map[string]string{
+		util.ClusterTagKey:           "c123",
+		util.AccessControlListTagKey: "office",
+	}, cloud.tags["acl-new"])
+}
@@ -0,0 +1,184 @@
+package accesscontrollist
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getAclName returns the name of the acl, which defaults to k8s.<cluster id>.<name>
+func getAclName(acl *v1.AccessControlList, clusterID string) string {
+	if acl.Spec.AclName != "" {
+		return acl.Spec.AclName
+	}
+	return fmt.Sprintf("k8s.%s.%s", clusterID, acl.Name)
+}
+
+// validateAccessControlList checks that exactly one source is set in each entry
+func validateAccessControlList(acl *v1.AccessControlList) error {
+	for i, entry := range acl.Spec.Entries {
+		sources := 0
+		if entry.CIDR != "" {
+			sources++
+		}
+		if entry.Nodes != nil {
+			sources++
+		}
+		if entry.ConfigMap != nil {
+			sources++
+			if entry.ConfigMap.Namespace == "" || entry.ConfigMap.Name == "" || entry.ConfigMap.Key == "" {
+				return fmt.Errorf("entries[%d]: namespace, name and key of configMap are required", i)
+			}
+		}
+		if entry.LoadBalancer != nil {
+			sources++
+			if entry.LoadBalancer.Namespace == "" || entry.LoadBalancer.Name == "" {
+				return fmt.Errorf("entries[%d]: namespace and name of loadBalancer are required", i)
+			}
+		}
+		if sources != 1 {
+			return fmt.Errorf("entries[%d]: exactly one of cidr, nodes, configMap and loadBalancer must be set", i)
+		}
+	}
+	return nil
+}
+
+// resolveEntries returns the sorted and deduplicated CIDRs of all the sources of the acl
+func resolveEntries(ctx context.Context, kubeClient client.Client, acl *v1.AccessControlList) ([]string, error) {
+	if err := validateAccessControlList(acl); err != nil {
+		return nil, err
+	}
+	cidrs := make(map[string]struct{})
+	add := func(source string, values []string) error {
+		for _, value := range values {
+			cidr, err := normalizeCIDR(value)
+			if err != nil {
+				return fmt.Errorf("%s: %s", source, err.Error())
+			}
+			cidrs[cidr] = struct{}{}
+		}
+		return nil
+	}
+
+	for i, entry := range acl.Spec.Entries {
+		var (
+			values []string
+			err    error
+		)
+		switch {
+		case entry.CIDR != "":
+			values = []string{entry.CIDR}
+		case entry.Nodes != nil:
+			values, err = getNodeExternalIPs(ctx, kubeClient, entry.Nodes)
+		case entry.ConfigMap != nil:
+			values, err = getConfigMapCIDRs(ctx, kubeClient, entry.ConfigMap)
+		case entry.LoadBalancer != nil:
+			values, err = getLoadBalancerIngressIPs(ctx, kubeClient, entry.LoadBalancer)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("entries[%d]: %s", i, err.Error())
+		}
+		if err := add(fmt.Sprintf("entries[%d]", i), values); err != nil {
+			return nil, err
+		}
+	}
+
+	entries := make([]string, 0, len(cidrs))
+	for cidr := range cidrs {
+		entries = append(entries, cidr)
+	}
+	sort.Strings(entries)
+	return entries, nil
+}
+
+// normalizeCIDR converts an ip address to a /32 or /128 CIDR, and masks the host bits of a CIDR
+func normalizeCIDR(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if strings.Contains(value, "/") {
+		_, ipNet, err := net.ParseCIDR(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid cidr %s", value)
+		}
+		return ipNet.String(), nil
+	}
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return "", fmt.Errorf("invalid ip address %s", value)
+	}
+	if ip.To4() != nil {
+		return ip.String() + "/32", nil
+	}
+	return ip.String() + "/128", nil
+}
+
+// splitCIDRs splits the CIDRs separated by commas, spaces or new lines
+func splitCIDRs(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n' || r == '\r' || r == '\t'
+	})
+}
+
+func getNodeExternalIPs(ctx context.Context, kubeClient client.Client, source *v1.AccessControlListNodeSource) ([]string, error) {
+	selector := labels.Everything()
+	if source.Selector != nil {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(source.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node selector: %s", err.Error())
+		}
+	}
+	nodes := &corev1.NodeList{}
+	if err := kubeClient.List(ctx, nodes, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("list nodes error: %s", err.Error())
+	}
+	var ips []string
+	for _, node := range nodes.Items {
+		for _, addr := range node.Status.Addresses {
+			if addr.Type == corev1.NodeExternalIP && addr.Address != "" {
+				ips = append(ips, addr.Address)
+			}
+		}
+	}
+	return ips, nil
+}
+
+func getConfigMapCIDRs(ctx context.Context, kubeClient client.Client, source *v1.AccessControlListConfigMapSource) ([]string, error) {
+	cm := &corev1.ConfigMap{}
+	if err := kubeClient.Get(ctx, client.ObjectKey{Namespace: source.Namespace, Name: source.Name}, cm); err != nil {
+		return nil, fmt.Errorf("get configmap %s/%s error: %s", source.Namespace, source.Name, err.Error())
+	}
+	value, ok := cm.Data[source.Key]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found in configmap %s/%s", source.Key, source.Namespace, source.Name)
+	}
+	return splitCIDRs(value), nil
+}
+
+// getLoadBalancerIngressIPs returns the front-end ip addresses of the load balancer in the status of the Service,
+// which are the addresses clients connect to. They are not the egress addresses the load balancer connects to
+// backends from, which are allocated from its vSwitches and are not exposed by the Service.
+// Hostnames are not resolved, so a Service without ip addresses fails instead of leaving the acl empty.
+func getLoadBalancerIngressIPs(ctx context.Context, kubeClient client.Client, source *v1.AccessControlListLoadBalancerSource) ([]string, error) {
+	svc := &corev1.Service{}
+	if err := kubeClient.Get(ctx, client.ObjectKey{Namespace: source.Namespace, Name: source.Name}, svc); err != nil {
+		return nil, fmt.Errorf("get service %s/%s error: %s", source.Namespace, source.Name, err.Error())
+	}
+	var ips []string
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			ips = append(ips, ingress.IP)
+		}
+	}
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("service %s/%s has no ingress ip addresses", source.Namespace, source.Name)
+	}
+	return ips, nil
+}
@@ -0,0 +1,113 @@
+package accesscontrollist
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNormalizeCIDR(t *testing.T) {
+	cidr, err := normalizeCIDR(" 192.168.0.1 ")
+	assert.NoError(t, err)
+	assert.Equal(t, "192.168.0.1/32", cidr)
+	cidr, err = normalizeCIDR("2001:db8::1")
+	assert.NoError(t, err)
+	assert.Equal(t, "2001:db8::1/128", cidr)
+	cidr, err = normalizeCIDR("10.1.2.3/8")
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.0/8", cidr)
+	_, err = normalizeCIDR("10.0.0.0/33")
+	assert.Error(t, err)
+	_, err = normalizeCIDR("example.com")
+	assert.Error(t, err)
+
+	assert.Equal(t, []string{"10.0.0.0/8", "192.168.0.1", "172.16.0.0/12", "1.1.1.1"},
+		splitCIDRs("10.0.0.0/8, 192.168.0.1\n172.16.0.0/12,,1.1.1.1\n"))
+}
+
+func TestValidateAccessControlList(t *testing.T) {
+	acl := &v1.AccessControlList{Spec: v1.AccessControlListSpec{Entries: []v1.AccessControlListEntry{
+		{CIDR: "10.0.0.0/8"},
+		{Nodes: &v1.AccessControlListNodeSource{}},
+		{ConfigMap: &v1.AccessControlListConfigMapSource{Namespace: "default", Name: "cidrs", Key: "office"}},
+		{LoadBalancer: &v1.AccessControlListLoadBalancerSource{Namespace: "default", Name: "nlb"}},
+	}}}
+	assert.NoError(t, validateAccessControlList(acl))
+
+	acl.Spec.Entries[0].Nodes = &v1.AccessControlListNodeSource{}
+	assert.Error(t, validateAccessControlList(acl))
+	acl.Spec.Entries[0] = v1.AccessControlListEntry{}
+	assert.Error(t, validateAccessControlList(acl))
+	acl.Spec.Entries[0] = v1.AccessControlListEntry{ConfigMap: &v1.AccessControlListConfigMapSource{Name: "cidrs"}}
+	assert.Error(t, validateAccessControlList(acl))
+}
+
+func TestResolveEntries(t *testing.T) {
+	kubeClient := fake.NewClientBuilder().WithObjects(
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "edge-1", Labels: map[string]string{"role": "edge"}},
+			Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeInternalIP, Address: "192.168.0.1"},
+				{Type: corev1.NodeExternalIP, Address: "47.0.0.1"},
+			}},
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+			Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeExternalIP, Address: "47.0.0.2"},
+			}},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cidrs"},
+			Data:       map[string]string{"office": "10.0.0.0/8, 47.0.0.1\n1.1.1.1"},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nlb"},
+			Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{
+				Ingress: []corev1.LoadBalancerIngress{{IP: "39.0.0.1"}, {Hostname: "nlb.example.com"}},
+			}},
+		},
+	).Build()
+	acl := &v1.AccessControlList{Spec: v1.AccessControlListSpec{Entries: []v1.AccessControlListEntry{
+		{CIDR: "172.16.0.0/12"},
+		{Nodes: &v1.AccessControlListNodeSource{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "edge"}},
+		}},
+		{ConfigMap: &v1.AccessControlListConfigMapSource{Namespace: "default", Name: "cidrs", Key: "office"}},
+		{LoadBalancer: &v1.AccessControlListLoadBalancerSource{Namespace: "default", Name: "nlb"}},
+	}}}
+
+	entries, err := resolveEntries(context.TODO(), kubeClient, acl)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1.1.1.1/32", "10.0.0.0/8", "172.16.0.0/12", "39.0.0.1/32", "47.0.0.1/32"}, entries)
+
+	acl.Spec.Entries[1].Nodes.Selector = nil
+	entries, err = resolveEntries(context.TODO(), kubeClient, acl)
+	assert.NoError(t, err)
+	assert.Contains(t, entries, "47.0.0.2/32")
+
+	acl.Spec.Entries[2].ConfigMap.Key = "missing"
+	_, err = resolveEntries(context.TODO(), kubeClient, acl)
+	assert.Error(t, err)
+
+	// a load balancer with only a hostname has no ip addresses to allow
+	nlb := &corev1.Service{}
+	assert.NoError(t, kubeClient.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "nlb"}, nlb))
+	nlb.Status.LoadBalancer.Ingress = nlb.Status.LoadBalancer.Ingress[1:]
+	assert.NoError(t, kubeClient.Status().Update(context.TODO(), nlb))
+	_, err = getLoadBalancerIngressIPs(context.TODO(), kubeClient, acl.Spec.Entries[3].LoadBalancer)
+	assert.Error(t, err)
+}
+
+func TestGetAclName(t *testing.T) {
+	acl := &v1.AccessControlList{ObjectMeta: metav1.ObjectMeta{Name: "office"}}
+	assert.Equal(t, "k8s.c123.office", getAclName(acl, "c123"))
+	acl.Spec.AclName = "office-acl"
+	assert.Equal(t, "office-acl", getAclName(acl, "c123"))
+}
@@ -0,0 +1,85 @@
+package accesscontrollist
+
+import (
+	"context"
+	"reflect"
+
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// mapToAccessControlLists enqueues the AccessControlLists having an entry matched by match
+func (r *accessControlListReconciler) mapToAccessControlLists(match func(entry v1.AccessControlListEntry) bool) []reconcile.Request {
+	acls := &v1.AccessControlListList{}
+	if err := r.kubeClient.List(context.TODO(), acls); err != nil {
+		r.logger.Error(err, "list accesscontrollists failed")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, acl := range acls.Items {
+		for _, entry := range acl.Spec.Entries {
+			if match(entry) {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: acl.Name},
+				})
+				break
+			}
+		}
+	}
+	return requests
+}
+
+// mapNodeToAccessControlLists enqueues all the AccessControlLists adding the addresses of nodes
+func (r *accessControlListReconciler) mapNodeToAccessControlLists(obj client.Object) []reconcile.Request {
+	return r.mapToAccessControlLists(func(entry v1.AccessControlListEntry) bool {
+		return entry.Nodes != nil
+	})
+}
+
+func (r *accessControlListReconciler) mapConfigMapToAccessControlLists(obj client.Object) []reconcile.Request {
+	return r.mapToAccessControlLists(func(entry v1.AccessControlListEntry) bool {
+		return entry.ConfigMap != nil &&
+			entry.ConfigMap.Namespace == obj.GetNamespace() && entry.ConfigMap.Name == obj.GetName()
+	})
+}
+
+func (r *accessControlListReconciler) mapServiceToAccessControlLists(obj client.Object) []reconcile.Request {
+	return r.mapToAccessControlLists(func(entry v1.AccessControlListEntry) bool {
+		return entry.LoadBalancer != nil &&
+			entry.LoadBalancer.Namespace == obj.GetNamespace() && entry.LoadBalancer.Name == obj.GetName()
+	})
+}
+
+// nodeChangedPredicate skips the node updates which do not change the labels or the addresses
+func nodeChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, ok1 := e.ObjectOld.(*corev1.Node)
+			newNode, ok2 := e.ObjectNew.(*corev1.Node)
+			if !ok1 || !ok2 {
+				return true
+			}
+			return !reflect.DeepEqual(oldNode.Labels, newNode.Labels) ||
+				!reflect.DeepEqual(oldNode.Status.Addresses, newNode.Status.Addresses)
+		},
+	}
+}
+
+// serviceChangedPredicate skips the service updates which do not change the load balancer ingress
+func serviceChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldSvc, ok1 := e.ObjectOld.(*corev1.Service)
+			newSvc, ok2 := e.ObjectNew.(*corev1.Service)
+			if !ok1 || !ok2 {
+				return true
+			}
+			return !reflect.DeepEqual(oldSvc.Status.LoadBalancer.Ingress, newSvc.Status.LoadBalancer.Ingress)
+		},
+	}
+}
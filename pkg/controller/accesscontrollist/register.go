@@ -0,0 +1,79 @@
+package accesscontrollist
+
+import (
+	"fmt"
+
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util/crd"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiext "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+func RegisterCRD(cfg *rest.Config) error {
+	extc, err := apiext.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error create incluster client: %s", err.Error())
+	}
+	if err := NewAccessControlListCRD(crd.NewClient(extc)).Initialize(); err != nil {
+		return fmt.Errorf("initialize crd: AccessControlListCRD, %s", err.Error())
+	}
+	return nil
+}
+
+// AccessControlListCRD is the cluster scoped crd of the acls shared by alb listeners.
+type AccessControlListCRD struct {
+	crdc crd.Interface
+}
+
+func NewAccessControlListCRD(crdClient crd.Interface) *AccessControlListCRD {
+	return &AccessControlListCRD{
+		crdc: crdClient,
+	}
+}
+
+// Initialize satisfies resource.crd interface.
+func (p *AccessControlListCRD) Initialize() error {
+	crd := crd.Conf{
+		Kind:                    "AccessControlList",
+		NamePlural:              "accesscontrollists",
+		Group:                   "alibabacloud.com",
+		Version:                 "v1",
+		Scope:                   apiextv1.ClusterScoped,
+		EnableStatusSubresource: true,
+		PrinterColumns: []apiextv1.CustomResourceColumnDefinition{
+			{
+				Name:     "ACLID",
+				Type:     "string",
+				JSONPath: ".status.aclID",
+			},
+			{
+				Name:     "PHASE",
+				Type:     "string",
+				JSONPath: ".status.phase",
+			},
+			{
+				Name:     "ENTRIES",
+				Type:     "integer",
+				JSONPath: ".status.entries",
+			},
+			{
+				Name:     "AGE",
+				Type:     "date",
+				JSONPath: ".metadata.creationTimestamp",
+			},
+		},
+	}
+
+	return p.crdc.EnsurePresent(crd)
+}
+
+// GetListerWatcher satisfies resource.crd interface (and retrieve.Retriever).
+func (p *AccessControlListCRD) GetListerWatcher() cache.ListerWatcher {
+	return nil
+}
+
+// GetObject satisfies resource.crd interface (and retrieve.Retriever).
+func (p *AccessControlListCRD) GetObject() runtime.Object { return &v1.AccessControlList{} }
@@ -4,15 +4,19 @@ import (
 	"fmt"
 
 	"k8s.io/alibaba-load-balancer-controller/pkg/context/shared"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/accesscontrollist"
 	"k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/servergroupbinding"
 	"k8s.io/alibaba-load-balancer-controller/pkg/controller/service"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
 func init() {
 	controllerMap = map[string]func(manager.Manager, *shared.SharedContext) error{
-		"ingress": ingress.Add,
-		"service": service.Add,
+		"ingress":            ingress.Add,
+		"service":            service.Add,
+		"servergroupbinding": servergroupbinding.Add,
+		"accesscontrollist":  accesscontrollist.Add,
 	}
 }
 
@@ -0,0 +1,178 @@
+package helper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+)
+
+// CanaryEventReason
+const (
+	CanaryStarted        = "CanaryStarted"
+	CanaryStepped        = "CanaryStepped"
+	CanaryPromoted       = "CanaryPromoted"
+	CanaryRolledBack     = "CanaryRolledBack"
+	CanaryAnalysisFailed = "CanaryAnalysisFailed"
+)
+
+const (
+	DefaultCanaryStepWeight   = 10
+	DefaultCanaryMaxWeight    = 100
+	DefaultCanaryInterval     = 5 * time.Minute
+	DefaultCanaryMaxErrorRate = 5
+)
+
+// the fields of the ALB access logs the canary server groups are analyzed by,
+// request_time is in seconds
+const (
+	accessLogServerGroupField = "server_group_id"
+	accessLogStatusField      = "status"
+	accessLogRequestTimeField = "request_time"
+)
+
+// CanaryStep is the result of analyzing an interval of the canary
+type CanaryStep struct {
+	Phase  string
+	Weight int
+	// Reason is empty if the canary waits for more requests
+	Reason  string
+	Message string
+}
+
+// CanarySpecWithDefaults fills the unset fields of spec with the defaults
+func CanarySpecWithDefaults(spec v1.AlbCanarySpec) v1.AlbCanarySpec {
+	spec = *spec.DeepCopy()
+	if spec.StepWeight == 0 {
+		spec.StepWeight = DefaultCanaryStepWeight
+	}
+	if spec.MaxWeight == 0 {
+		spec.MaxWeight = DefaultCanaryMaxWeight
+	}
+	if spec.Interval.Duration == 0 {
+		spec.Interval.Duration = DefaultCanaryInterval
+	}
+	if spec.Analysis.MaxErrorRate == nil {
+		maxErrorRate := DefaultCanaryMaxErrorRate
+		spec.Analysis.MaxErrorRate = &maxErrorRate
+	}
+	return spec
+}
+
+// ValidateCanarySpec checks spec filled with the defaults
+func ValidateCanarySpec(spec v1.AlbCanarySpec) error {
+	if spec.IngressName == "" {
+		return fmt.Errorf("ingressName is empty")
+	}
+	if spec.MaxWeight < 0 || spec.MaxWeight > 100 {
+		return fmt.Errorf("maxWeight %d out of range [0, 100]", spec.MaxWeight)
+	}
+	if spec.StepWeight < 0 || spec.StepWeight > spec.MaxWeight {
+		return fmt.Errorf("stepWeight %d out of range [0, %d]", spec.StepWeight, spec.MaxWeight)
+	}
+	if spec.Interval.Duration < 0 {
+		return fmt.Errorf("interval %s is negative", spec.Interval.Duration)
+	}
+	if *spec.Analysis.MaxErrorRate < 0 || *spec.Analysis.MaxErrorRate > 100 {
+		return fmt.Errorf("maxErrorRate %d out of range [0, 100]", *spec.Analysis.MaxErrorRate)
+	}
+	return nil
+}
+
+// StepCanary decides the next step of the canary at weight by the metrics of its server groups in the interval,
+// spec must be filled with the defaults
+func StepCanary(spec v1.AlbCanarySpec, weight int, metrics []v1.AlbCanaryServerGroupStatus) CanaryStep {
+	var requests, errors, latency int64
+	for _, m := range metrics {
+		requests += m.Requests
+		errors += m.Errors
+		latency += m.AvgLatencyMillis * m.Requests
+	}
+	if requests == 0 || requests < spec.Analysis.MinRequests {
+		return CanaryStep{
+			Phase:   v1.AlbCanaryPhaseProgressing,
+			Weight:  weight,
+			Message: fmt.Sprintf("waiting for %d requests, got %d", spec.Analysis.MinRequests, requests),
+		}
+	}
+	latency /= requests
+
+	if errors*100 > int64(*spec.Analysis.MaxErrorRate)*requests {
+		return CanaryStep{
+			Phase:  v1.AlbCanaryPhaseRolledBack,
+			Reason: CanaryRolledBack,
+			Message: fmt.Sprintf("error rate %.2f%% of %d requests exceeds %d%%",
+				float64(errors)*100/float64(requests), requests, *spec.Analysis.MaxErrorRate),
+		}
+	}
+	if spec.Analysis.MaxLatencyMillis > 0 && latency > spec.Analysis.MaxLatencyMillis {
+		return CanaryStep{
+			Phase:  v1.AlbCanaryPhaseRolledBack,
+			Reason: CanaryRolledBack,
+			Message: fmt.Sprintf("average latency %dms of %d requests exceeds %dms",
+				latency, requests, spec.Analysis.MaxLatencyMillis),
+		}
+	}
+
+	summary := fmt.Sprintf("error rate %.2f%%, average latency %dms of %d requests",
+		float64(errors)*100/float64(requests), latency, requests)
+	if weight+spec.StepWeight >= spec.MaxWeight {
+		return CanaryStep{
+			Phase:   v1.AlbCanaryPhasePromoted,
+			Weight:  spec.MaxWeight,
+			Reason:  CanaryPromoted,
+			Message: fmt.Sprintf("promoted to weight %d, %s", spec.MaxWeight, summary),
+		}
+	}
+	return CanaryStep{
+		Phase:   v1.AlbCanaryPhaseProgressing,
+		Weight:  weight + spec.StepWeight,
+		Reason:  CanaryStepped,
+		Message: fmt.Sprintf("stepped to weight %d, %s", weight+spec.StepWeight, summary),
+	}
+}
+
+// CanaryAccessLogQuery returns the query of the metrics of the server groups in the ALB access logs
+func CanaryAccessLogQuery(serverGroupIDs []string) string {
+	var conditions []string
+	for _, id := range serverGroupIDs {
+		conditions = append(conditions, fmt.Sprintf("%s: %s", accessLogServerGroupField, id))
+	}
+	return fmt.Sprintf("%s | select %s, count(1) as requests, count_if(%s >= 500) as errors, "+
+		"cast(avg(%s) * 1000 as bigint) as latency group by %s",
+		strings.Join(conditions, " or "), accessLogServerGroupField, accessLogStatusField,
+		accessLogRequestTimeField, accessLogServerGroupField)
+}
+
+// ParseCanaryAccessLogs parses the results of CanaryAccessLogQuery, server groups without requests are included
+func ParseCanaryAccessLogs(serverGroupIDs []string, logs []map[string]string) ([]v1.AlbCanaryServerGroupStatus, error) {
+	metrics := make(map[string]v1.AlbCanaryServerGroupStatus, len(logs))
+	for _, log := range logs {
+		var m v1.AlbCanaryServerGroupStatus
+		var err error
+		m.ServerGroupID = log[accessLogServerGroupField]
+		if m.Requests, err = strconv.ParseInt(log["requests"], 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid requests of server group %s: %s", m.ServerGroupID, err.Error())
+		}
+		if m.Errors, err = strconv.ParseInt(log["errors"], 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid errors of server group %s: %s", m.ServerGroupID, err.Error())
+		}
+		// latency is null without requests
+		if log["latency"] != "" && log["latency"] != "null" {
+			if m.AvgLatencyMillis, err = strconv.ParseInt(log["latency"], 10, 64); err != nil {
+				return nil, fmt.Errorf("invalid latency of server group %s: %s", m.ServerGroupID, err.Error())
+			}
+		}
+		metrics[m.ServerGroupID] = m
+	}
+
+	var result []v1.AlbCanaryServerGroupStatus
+	for _, id := range serverGroupIDs {
+		m := metrics[id]
+		m.ServerGroupID = id
+		result = append(result, m)
+	}
+	return result, nil
+}
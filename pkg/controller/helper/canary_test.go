@@ -0,0 +1,68 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+)
+
+func TestStepCanary(t *testing.T) {
+	spec := CanarySpecWithDefaults(v1.AlbCanarySpec{IngressName: "canary", MaxWeight: 50})
+	spec.Analysis.MinRequests = 100
+	spec.Analysis.MaxLatencyMillis = 500
+	assert.NoError(t, ValidateCanarySpec(spec))
+
+	metrics := func(requests, errors, latency int64) []v1.AlbCanaryServerGroupStatus {
+		return []v1.AlbCanaryServerGroupStatus{
+			{ServerGroupID: "sgp-a", Requests: requests / 2, Errors: errors, AvgLatencyMillis: latency},
+			{ServerGroupID: "sgp-b", Requests: requests / 2, AvgLatencyMillis: latency},
+		}
+	}
+
+	step := StepCanary(spec, 10, metrics(50, 0, 100))
+	assert.Equal(t, v1.AlbCanaryPhaseProgressing, step.Phase)
+	assert.Equal(t, 10, step.Weight)
+	assert.Empty(t, step.Reason)
+
+	step = StepCanary(spec, 10, metrics(200, 4, 100))
+	assert.Equal(t, CanaryStepped, step.Reason)
+	assert.Equal(t, 20, step.Weight)
+
+	step = StepCanary(spec, 40, metrics(200, 0, 100))
+	assert.Equal(t, v1.AlbCanaryPhasePromoted, step.Phase)
+	assert.Equal(t, 50, step.Weight)
+
+	step = StepCanary(spec, 40, metrics(200, 11, 100))
+	assert.Equal(t, v1.AlbCanaryPhaseRolledBack, step.Phase)
+	assert.Equal(t, 0, step.Weight)
+
+	step = StepCanary(spec, 40, metrics(200, 0, 600))
+	assert.Equal(t, CanaryRolledBack, step.Reason)
+}
+
+func TestValidateCanarySpec(t *testing.T) {
+	assert.Error(t, ValidateCanarySpec(CanarySpecWithDefaults(v1.AlbCanarySpec{})))
+	assert.Error(t, ValidateCanarySpec(CanarySpecWithDefaults(v1.AlbCanarySpec{IngressName: "canary", StepWeight: 60, MaxWeight: 50})))
+	maxErrorRate := 101
+	assert.Error(t, ValidateCanarySpec(CanarySpecWithDefaults(v1.AlbCanarySpec{IngressName: "canary",
+		Analysis: v1.AlbCanaryAnalysis{MaxErrorRate: &maxErrorRate}})))
+}
+
+func TestParseCanaryAccessLogs(t *testing.T) {
+	assert.Equal(t, "server_group_id: sgp-a or server_group_id: sgp-b | select server_group_id, count(1) as requests, "+
+		"count_if(status >= 500) as errors, cast(avg(request_time) * 1000 as bigint) as latency group by server_group_id",
+		CanaryAccessLogQuery([]string{"sgp-a", "sgp-b"}))
+
+	metrics, err := ParseCanaryAccessLogs([]string{"sgp-a", "sgp-b"}, []map[string]string{
+		{"server_group_id": "sgp-a", "requests": "120", "errors": "3", "latency": "45"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []v1.AlbCanaryServerGroupStatus{
+		{ServerGroupID: "sgp-a", Requests: 120, Errors: 3, AvgLatencyMillis: 45},
+		{ServerGroupID: "sgp-b"},
+	}, metrics)
+
+	_, err = ParseCanaryAccessLogs([]string{"sgp-a"}, []map[string]string{{"server_group_id": "sgp-a", "requests": "many"}})
+	assert.Error(t, err)
+}
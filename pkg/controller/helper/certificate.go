@@ -0,0 +1,72 @@
+package helper
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"k8s.io/alibaba-load-balancer-controller/pkg/model"
+)
+
+// CertificateEventReason
+const (
+	CertificateExpiring     = "CertificateExpiring"
+	CertificateExpired      = "CertificateExpired"
+	CertificateNotFound     = "CertificateNotFound"
+	CertificateHostMismatch = "CertificateHostMismatch"
+)
+
+// CertificateNotAfter returns the expiry time of the certificate, AfterDate is in milliseconds
+func CertificateNotAfter(cert model.CertificateInfo) time.Time {
+	return time.UnixMilli(cert.AfterDate)
+}
+
+// CertificateDaysToExpiry returns the days left before the certificate expires, negative if already expired
+func CertificateDaysToExpiry(cert model.CertificateInfo, now time.Time) float64 {
+	days := CertificateNotAfter(cert).Sub(now).Hours() / 24
+	return math.Floor(days*100) / 100
+}
+
+// CertificateDomains returns all the domains covered by the certificate, including CommonName and Sans
+func CertificateDomains(cert model.CertificateInfo) []string {
+	var domains []string
+	for _, d := range append([]string{cert.CommonName}, strings.Split(cert.Sans, ",")...) {
+		d = strings.TrimSpace(d)
+		if d != "" {
+			domains = append(domains, d)
+		}
+	}
+	return domains
+}
+
+// CertificateCoversHost check if the host is the CommonName or one of the Sans of the certificate
+func CertificateCoversHost(cert model.CertificateInfo, host string) bool {
+	for _, d := range CertificateDomains(cert) {
+		if DomainMatchesHost(d, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// DomainMatchesHost check if the certificate domain matches the tls host.
+// A wildcard domain only matches a single label, e.g. *.example.com matches a.example.com but not a.b.example.com
+func DomainMatchesHost(domain string, host string) bool {
+	lowerDomain := strings.ToLower(strings.TrimSpace(domain))
+	lowerHost := strings.ToLower(strings.TrimSpace(host))
+	if lowerDomain == "" || lowerHost == "" {
+		return false
+	}
+	if lowerDomain == lowerHost {
+		return true
+	}
+	if strings.HasPrefix(lowerDomain, "*.") {
+		ds := strings.Split(lowerDomain, ".")
+		hs := strings.Split(lowerHost, ".")
+		if len(ds) != len(hs) || hs[0] == "" {
+			return false
+		}
+		return strings.Join(ds[1:], ".") == strings.Join(hs[1:], ".")
+	}
+	return false
+}
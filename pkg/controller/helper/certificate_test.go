@@ -0,0 +1,32 @@
+package helper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model"
+)
+
+func TestDomainMatchesHost(t *testing.T) {
+	assert.True(t, DomainMatchesHost("www.example.com", "WWW.example.com"))
+	assert.True(t, DomainMatchesHost("*.example.com", "a.example.com"))
+	assert.False(t, DomainMatchesHost("*.example.com", "a.b.example.com"))
+	assert.False(t, DomainMatchesHost("*.example.com", "example.com"))
+	assert.False(t, DomainMatchesHost("", "example.com"))
+}
+
+func TestCertificateCoversHost(t *testing.T) {
+	cert := model.CertificateInfo{CommonName: "example.com", Sans: "example.com,*.example.com"}
+	assert.True(t, CertificateCoversHost(cert, "example.com"))
+	assert.True(t, CertificateCoversHost(cert, "foo.example.com"))
+	assert.False(t, CertificateCoversHost(cert, "foo.example.org"))
+}
+
+func TestCertificateDaysToExpiry(t *testing.T) {
+	now := time.Now().Truncate(time.Millisecond)
+	cert := model.CertificateInfo{AfterDate: now.Add(36 * time.Hour).UnixMilli()}
+	assert.Equal(t, 1.5, CertificateDaysToExpiry(cert, now))
+	cert.AfterDate = now.Add(-48 * time.Hour).UnixMilli()
+	assert.Equal(t, -2.0, CertificateDaysToExpiry(cert, now))
+}
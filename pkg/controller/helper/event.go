@@ -18,6 +18,8 @@ const (
 	TypeChanged            = "TypeChanged"
 	SpecChanged            = "ServiceSpecChanged"
 	DeleteTimestampChanged = "DeleteTimestampChanged"
+	WaitingForAsyncJob     = "WaitingForAsyncJob"
+	FailedAsyncJob         = "AsyncJobFailed"
 )
 
 // NodeEventReason
@@ -36,6 +38,20 @@ const (
 	SucceedCreateRoute = "CreatedRoute"
 )
 
+// ServerGroupBindingEventReason
+const (
+	SucceedSyncServerGroupBinding = "SyncedServers"
+	FailedSyncServerGroupBinding  = "SyncServersFailed"
+	FailedCleanServerGroupBinding = "CleanServersFailed"
+)
+
+// AccessControlListEventReason
+const (
+	SucceedSyncAccessControlList = "SyncedAcl"
+	FailedSyncAccessControlList  = "SyncAclFailed"
+	FailedCleanAccessControlList = "CleanAclFailed"
+)
+
 var re = regexp.MustCompile(".*(Message:.*)")
 
 func GetLogMessage(err error) string {
@@ -59,8 +75,14 @@ const (
 	IngressEventReasonFailedUpdateStatus     = "FailedUpdateStatus"
 	IngressEventReasonFailedBuildModel       = "FailedBuildModel"
 	IngressEventReasonFailedApplyModel       = "FailedApplyModel"
+	IngressEventReasonAdmissionDenied        = "AdmissionDenied"
+	IngressEventReasonRuleConflict           = "RuleConflict"
+	IngressEventReasonCertificateExpired     = "CertificateExpired"
 	IngressEventReasonSuccessfullyReconciled = "SuccessfullyReconciled"
 
+	// EventReasonQuotaExceeded is recorded when the load balancer to apply exceeds quotas
+	EventReasonQuotaExceeded = "QuotaExceeded"
+
 	// Service events
 	ServiceEventReasonFailedUpdateEndpoints  = "FailedUpdateEndpoints"
 	ServiceEventReasonSuccessfullyReconciled = "SuccessfullyReconciled"
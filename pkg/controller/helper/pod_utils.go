@@ -1,7 +1,14 @@
 package helper
 
 import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // Prefix for TargetHealth pod condition type.
@@ -48,3 +55,160 @@ func UpdatePodCondition(pod *corev1.Pod, condition corev1.PodCondition) {
 	}
 	pod.Status.Conditions = append(pod.Status.Conditions, condition)
 }
+
+// ProbeHealthCheck is the health check derived from the readinessProbe of the pods of a service port.
+// The values are clamped to the ranges supported by both alb and nlb server groups, except Timeout,
+// which is clamped to the range of alb, use NLBConnectTimeout for nlb server groups.
+type ProbeHealthCheck struct {
+	// Protocol is HTTP, HTTPS or TCP
+	Protocol string
+	Path     string
+	// Host is the Host header of the probe
+	Host string
+	// Port is the container port the probe connects to, TargetPort is the container port of the service port
+	Port               int32
+	TargetPort         int32
+	Interval           int32
+	Timeout            int32
+	HealthyThreshold   int32
+	UnhealthyThreshold int32
+}
+
+// NLBConnectTimeout returns the timeout clamped to the range of the connect timeout of nlb server groups
+func (hc *ProbeHealthCheck) NLBConnectTimeout() int32 {
+	return clampProbeValue(hc.Timeout, 1, 1, 50)
+}
+
+// ConnectPort returns the port the load balancer checks, 0 for the port of the backend servers.
+// Backends of node ports can only be checked on the node port, so the probe can not be used
+// if it connects to another container port than the target port.
+func (hc *ProbeHealthCheck) ConnectPort(podBackends bool) (int32, bool) {
+	if hc.Port == hc.TargetPort {
+		return 0, true
+	}
+	return hc.Port, podBackends
+}
+
+// ListServicePods returns the pods selected by the service, the pods of services without selectors are not listed.
+func ListServicePods(ctx context.Context, kubeClient client.Client, svc *corev1.Service) ([]corev1.Pod, error) {
+	if len(svc.Spec.Selector) == 0 {
+		return nil, nil
+	}
+	pods := &corev1.PodList{}
+	if err := kubeClient.List(ctx, pods, client.InNamespace(svc.Namespace),
+		client.MatchingLabels(svc.Spec.Selector)); err != nil {
+		return nil, fmt.Errorf("list pods of service %s/%s error: %s", svc.Namespace, svc.Name, err.Error())
+	}
+	return pods.Items, nil
+}
+
+// GetReadinessProbeHealthCheck returns the health check derived from the readinessProbe of the container serving the
+// service port. The newest pod with a probe is used, so that a changed probe takes effect during a rolling update.
+// It returns nil if no pod has an http or tcp readinessProbe for the port.
+func GetReadinessProbeHealthCheck(pods []corev1.Pod, svcPort corev1.ServicePort) *ProbeHealthCheck {
+	sorted := make([]corev1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		if pod.DeletionTimestamp == nil {
+			sorted = append(sorted, pod)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[j].CreationTimestamp.Before(&sorted[i].CreationTimestamp)
+	})
+
+	targetPort := svcPort.TargetPort
+	if targetPort.Type == intstr.Int && targetPort.IntVal == 0 {
+		targetPort = intstr.FromInt(int(svcPort.Port))
+	}
+	for _, pod := range sorted {
+		for _, c := range pod.Spec.Containers {
+			port, ok := resolveContainerPort(c, targetPort)
+			if !ok || c.ReadinessProbe == nil {
+				continue
+			}
+			// numbered ports do not need to be declared by the only container of the pod
+			if !isContainerPortDeclared(c, port) && len(pod.Spec.Containers) > 1 {
+				continue
+			}
+			if hc := buildProbeHealthCheck(c, c.ReadinessProbe); hc != nil {
+				hc.TargetPort = port
+				return hc
+			}
+		}
+	}
+	return nil
+}
+
+func buildProbeHealthCheck(c corev1.Container, probe *corev1.Probe) *ProbeHealthCheck {
+	hc := &ProbeHealthCheck{}
+	var port intstr.IntOrString
+	switch {
+	case probe.HTTPGet != nil:
+		hc.Protocol = string(corev1.URISchemeHTTP)
+		if probe.HTTPGet.Scheme == corev1.URISchemeHTTPS {
+			hc.Protocol = string(corev1.URISchemeHTTPS)
+		}
+		hc.Path = probe.HTTPGet.Path
+		if hc.Path == "" {
+			hc.Path = "/"
+		}
+		for _, h := range probe.HTTPGet.HTTPHeaders {
+			if strings.EqualFold(h.Name, "Host") {
+				hc.Host = h.Value
+			}
+		}
+		port = probe.HTTPGet.Port
+	case probe.TCPSocket != nil:
+		hc.Protocol = "TCP"
+		port = probe.TCPSocket.Port
+	default:
+		// exec and grpc probes can not be performed by load balancers
+		return nil
+	}
+
+	p, ok := resolveContainerPort(c, port)
+	if !ok {
+		return nil
+	}
+	hc.Port = p
+	hc.Interval = clampProbeValue(probe.PeriodSeconds, 10, 1, 50)
+	hc.Timeout = clampProbeValue(probe.TimeoutSeconds, 1, 1, 300)
+	hc.HealthyThreshold = clampProbeValue(probe.SuccessThreshold, 1, 2, 10)
+	hc.UnhealthyThreshold = clampProbeValue(probe.FailureThreshold, 3, 2, 10)
+	return hc
+}
+
+// resolveContainerPort resolves a named or numbered port of the container
+func resolveContainerPort(c corev1.Container, port intstr.IntOrString) (int32, bool) {
+	if port.Type == intstr.String {
+		for _, p := range c.Ports {
+			if p.Name == port.StrVal {
+				return p.ContainerPort, true
+			}
+		}
+		return 0, false
+	}
+	return port.IntVal, port.IntVal != 0
+}
+
+func isContainerPortDeclared(c corev1.Container, port int32) bool {
+	for _, p := range c.Ports {
+		if p.ContainerPort == port {
+			return true
+		}
+	}
+	return false
+}
+
+func clampProbeValue(v, defaultValue, min, max int32) int32 {
+	if v == 0 {
+		v = defaultValue
+	}
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
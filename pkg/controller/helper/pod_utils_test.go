@@ -0,0 +1,86 @@
+package helper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func probePod(name string, created time.Time, probe *corev1.Probe) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(created)},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{
+				Name:           "app",
+				Ports:          []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}, {Name: "admin", ContainerPort: 9090}},
+				ReadinessProbe: probe,
+			},
+			{
+				Name:  "sidecar",
+				Ports: []corev1.ContainerPort{{Name: "proxy", ContainerPort: 15001}},
+			},
+		}},
+	}
+}
+
+func TestGetReadinessProbeHealthCheck(t *testing.T) {
+	now := time.Now()
+	oldProbe := &corev1.Probe{ProbeHandler: corev1.ProbeHandler{
+		TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(8080)},
+	}}
+	newProbe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{
+			Path:        "/healthz",
+			Port:        intstr.FromString("admin"),
+			Scheme:      corev1.URISchemeHTTP,
+			HTTPHeaders: []corev1.HTTPHeader{{Name: "host", Value: "app.example.com"}},
+		}},
+		PeriodSeconds:    60,
+		TimeoutSeconds:   3,
+		SuccessThreshold: 1,
+		FailureThreshold: 5,
+	}
+	pods := []corev1.Pod{
+		probePod("old", now.Add(-time.Hour), oldProbe),
+		probePod("new", now, newProbe),
+	}
+	svcPort := corev1.ServicePort{Port: 80, TargetPort: intstr.FromString("http")}
+
+	hc := GetReadinessProbeHealthCheck(pods, svcPort)
+	assert.Equal(t, &ProbeHealthCheck{
+		Protocol: "HTTP", Path: "/healthz", Host: "app.example.com",
+		Port: 9090, TargetPort: 8080,
+		Interval: 50, Timeout: 3, HealthyThreshold: 2, UnhealthyThreshold: 5,
+	}, hc)
+	assert.Equal(t, int32(3), hc.NLBConnectTimeout())
+	// the timeout of alb is up to 300 seconds, the connect timeout of nlb up to 50 seconds
+	hc.Timeout = 120
+	assert.Equal(t, int32(50), hc.NLBConnectTimeout())
+	port, ok := hc.ConnectPort(true)
+	assert.True(t, ok)
+	assert.Equal(t, int32(9090), port)
+	_, ok = hc.ConnectPort(false)
+	assert.False(t, ok)
+
+	// the newest pod is deleting
+	pods[1].DeletionTimestamp = &metav1.Time{Time: now}
+	hc = GetReadinessProbeHealthCheck(pods, corev1.ServicePort{Port: 8080})
+	assert.Equal(t, &ProbeHealthCheck{
+		Protocol: "TCP", Port: 8080, TargetPort: 8080,
+		Interval: 10, Timeout: 1, HealthyThreshold: 2, UnhealthyThreshold: 3,
+	}, hc)
+	port, ok = hc.ConnectPort(false)
+	assert.True(t, ok)
+	assert.Equal(t, int32(0), port)
+
+	// undeclared ports of pods with multiple containers are not matched
+	assert.Nil(t, GetReadinessProbeHealthCheck(pods, corev1.ServicePort{Port: 80, TargetPort: intstr.FromInt(8000)}))
+	pods[0].Spec.Containers[0].ReadinessProbe = &corev1.Probe{ProbeHandler: corev1.ProbeHandler{
+		Exec: &corev1.ExecAction{Command: []string{"true"}},
+	}}
+	assert.Nil(t, GetReadinessProbeHealthCheck(pods, svcPort))
+}
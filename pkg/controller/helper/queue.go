@@ -7,6 +7,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"golang.org/x/time/rate"
+	ctrlCfg "k8s.io/alibaba-load-balancer-controller/pkg/config"
 
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
@@ -17,6 +18,15 @@ var (
 	keyFunc = cache.DeletionHandlingMetaNamespaceKeyFunc
 )
 
+// NewControllerRateLimiter returns the rate limiter of the controller queues by the reconcile config
+func NewControllerRateLimiter(cfg ctrlCfg.ReconcileConfig) workqueue.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(cfg.RetryBaseDelay, cfg.RetryMaxDelay),
+		// This is only for retry speed and its only the overall factor (not per item)
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(cfg.RetryQPS), cfg.RetryBurst)},
+	)
+}
+
 // Queue manages a time work queue through an independent worker that invokes the
 // given sync function for every work item inserted.
 // The queue uses an internal timestamp that allows the removal of certain elements
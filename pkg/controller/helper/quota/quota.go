@@ -0,0 +1,67 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/alibaba-load-balancer-controller/pkg/config"
+)
+
+// Provider provides the quotas of the account. Providers querying the quota service of the account
+// can replace the static one.
+type Provider interface {
+	Quotas(ctx context.Context) (config.QuotaConfig, error)
+}
+
+// NewStaticProvider returns the provider of the quotas set by flags
+func NewStaticProvider(cfg config.QuotaConfig) Provider {
+	return &staticProvider{cfg: cfg}
+}
+
+type staticProvider struct {
+	cfg config.QuotaConfig
+}
+
+func (p *staticProvider) Quotas(_ context.Context) (config.QuotaConfig, error) {
+	return p.cfg, nil
+}
+
+// Violation is a quota exceeded by the object
+type Violation struct {
+	// Kind, Namespace and Name are the object pushing the resource over the quota
+	Kind      string
+	Namespace string
+	Name      string
+	// Resource is the resource exceeding the quota, e.g. listener 443/HTTPS
+	Resource string
+	// Quota is the flag name of the quota
+	Quota string
+	Limit int
+	Used  int
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s %s/%s: %s needs %d, exceeds quota %s=%d",
+		v.Kind, v.Namespace, v.Name, v.Resource, v.Used, v.Quota, v.Limit)
+}
+
+// ExceededError is returned when the load balancer to apply exceeds quotas
+type ExceededError struct {
+	Violations []Violation
+}
+
+func (e *ExceededError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		msgs = append(msgs, v.String())
+	}
+	return fmt.Sprintf("quota check failed: %s", strings.Join(msgs, "; "))
+}
+
+func newExceededError(violations []Violation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	return &ExceededError{Violations: violations}
+}
@@ -0,0 +1,199 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	albmodel "k8s.io/alibaba-load-balancer-controller/pkg/model/alb"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model/alb/core"
+	nlbmodel "k8s.io/alibaba-load-balancer-controller/pkg/model/nlb"
+)
+
+const (
+	quotaAlbListenersPerLoadBalancer    = "alb-listeners-per-loadbalancer-quota"
+	quotaAlbRulesPerListener            = "alb-rules-per-listener-quota"
+	quotaAlbConditionsPerRule           = "alb-conditions-per-rule-quota"
+	quotaAlbActionsPerRule              = "alb-actions-per-rule-quota"
+	quotaAlbServerGroupsPerLoadBalancer = "alb-server-groups-per-loadbalancer-quota"
+	quotaAlbServerGroupsPerAction       = "alb-server-groups-per-action-quota"
+	quotaAlbBackendsPerServerGroup      = "alb-backends-per-server-group-quota"
+	quotaNlbListenersPerLoadBalancer    = "nlb-listeners-per-loadbalancer-quota"
+	quotaNlbBackendsPerServerGroup      = "nlb-backends-per-server-group-quota"
+
+	kindAlbConfig = "AlbConfig"
+	kindService   = "Service"
+)
+
+// Validator checks the load balancers built against the quotas before they are applied,
+// so that a load balancer exceeding quotas is not partially applied.
+type Validator struct {
+	provider Provider
+}
+
+func NewValidator(provider Provider) *Validator {
+	return &Validator{provider: provider}
+}
+
+// ValidateAlbStack checks the alb stack built from albconfig, the rules and server groups
+// exceeding quotas are reported with the Ingresses or AlbRoutes they are built from.
+func (v *Validator) ValidateAlbStack(ctx context.Context, albconfigNamespace, albconfigName string, stack core.Manager) error {
+	quotas, err := v.provider.Quotas(ctx)
+	if err != nil {
+		return fmt.Errorf("get quotas error: %v", err)
+	}
+	var violations []Violation
+
+	var listeners []*albmodel.Listener
+	_ = stack.ListResources(&listeners)
+	if exceeds(quotas.AlbListenersPerLoadBalancer, len(listeners)) {
+		violations = append(violations, Violation{
+			Kind: kindAlbConfig, Namespace: albconfigNamespace, Name: albconfigName,
+			Resource: "listeners", Quota: quotaAlbListenersPerLoadBalancer,
+			Limit: quotas.AlbListenersPerLoadBalancer, Used: len(listeners),
+		})
+	}
+
+	var rules []*albmodel.ListenerRule
+	_ = stack.ListResources(&rules)
+	rulesByListener := make(map[*albmodel.Listener][]*albmodel.ListenerRule)
+	for _, rule := range rules {
+		for _, dep := range rule.Spec.ListenerID.Dependencies() {
+			if ls, ok := dep.(*albmodel.Listener); ok {
+				rulesByListener[ls] = append(rulesByListener[ls], rule)
+			}
+		}
+	}
+	for _, ls := range listeners {
+		lsRules := rulesByListener[ls]
+		sort.SliceStable(lsRules, func(i, j int) bool {
+			return lsRules[i].Spec.Priority < lsRules[j].Spec.Priority
+		})
+		// the rules after the quota push the listener over it
+		if exceeds(quotas.AlbRulesPerListener, len(lsRules)) {
+			reported := make(map[albmodel.RuleOrigin]bool)
+			for _, rule := range lsRules[quotas.AlbRulesPerListener:] {
+				if reported[rule.Spec.Origin] {
+					continue
+				}
+				reported[rule.Spec.Origin] = true
+				violations = append(violations, ruleViolation(rule, fmt.Sprintf("rules on listener %s", listenerName(ls)),
+					quotaAlbRulesPerListener, quotas.AlbRulesPerListener, len(lsRules)))
+			}
+		}
+		for _, rule := range lsRules {
+			violations = append(violations, validateAlbRule(quotas.AlbConditionsPerRule, quotas.AlbActionsPerRule,
+				quotas.AlbServerGroupsPerAction, ls, rule)...)
+		}
+	}
+
+	var sgps []*albmodel.ServerGroup
+	_ = stack.ListResources(&sgps)
+	if exceeds(quotas.AlbServerGroupsPerLoadBalancer, len(sgps)) {
+		sort.SliceStable(sgps, func(i, j int) bool {
+			return sgps[i].Spec.ServerGroupNamedKey.Key() < sgps[j].Spec.ServerGroupNamedKey.Key()
+		})
+		reported := make(map[string]bool)
+		for _, sgp := range sgps[quotas.AlbServerGroupsPerLoadBalancer:] {
+			key := sgp.Spec.Namespace + "/" + sgp.Spec.IngressName
+			if reported[key] {
+				continue
+			}
+			reported[key] = true
+			violations = append(violations, Violation{
+				Kind: albmodel.RuleOriginKindIngress, Namespace: sgp.Spec.Namespace, Name: sgp.Spec.IngressName,
+				Resource: "server groups", Quota: quotaAlbServerGroupsPerLoadBalancer,
+				Limit: quotas.AlbServerGroupsPerLoadBalancer, Used: len(sgps),
+			})
+		}
+	}
+	return newExceededError(violations)
+}
+
+func validateAlbRule(maxConditions, maxActions, maxServerGroups int, ls *albmodel.Listener, rule *albmodel.ListenerRule) []Violation {
+	var violations []Violation
+	resource := fmt.Sprintf("rule %s on listener %s", rule.Spec.RuleName, listenerName(ls))
+	if exceeds(maxConditions, len(rule.Spec.RuleConditions)) {
+		violations = append(violations, ruleViolation(rule, resource+" conditions",
+			quotaAlbConditionsPerRule, maxConditions, len(rule.Spec.RuleConditions)))
+	}
+	if exceeds(maxActions, len(rule.Spec.RuleActions)) {
+		violations = append(violations, ruleViolation(rule, resource+" actions",
+			quotaAlbActionsPerRule, maxActions, len(rule.Spec.RuleActions)))
+	}
+	for _, action := range rule.Spec.RuleActions {
+		if action.ForwardConfig != nil && exceeds(maxServerGroups, len(action.ForwardConfig.ServerGroups)) {
+			violations = append(violations, ruleViolation(rule, resource+" forward server groups",
+				quotaAlbServerGroupsPerAction, maxServerGroups, len(action.ForwardConfig.ServerGroups)))
+		}
+	}
+	return violations
+}
+
+// ValidateAlbServiceStack checks the backends of the alb server groups of Service
+func (v *Validator) ValidateAlbServiceStack(ctx context.Context, svcStack *albmodel.ServiceManager) error {
+	quotas, err := v.provider.Quotas(ctx)
+	if err != nil {
+		return fmt.Errorf("get quotas error: %v", err)
+	}
+	ports := make([]int, 0, len(svcStack.PortToServerGroup))
+	for port := range svcStack.PortToServerGroup {
+		ports = append(ports, int(port))
+	}
+	sort.Ints(ports)
+	var violations []Violation
+	for _, port := range ports {
+		sgp := svcStack.PortToServerGroup[int32(port)]
+		if sgp != nil && exceeds(quotas.AlbBackendsPerServerGroup, len(sgp.Backends)) {
+			violations = append(violations, Violation{
+				Kind: kindService, Namespace: svcStack.Namespace, Name: svcStack.Name,
+				Resource: fmt.Sprintf("backends of server group for port %d", port), Quota: quotaAlbBackendsPerServerGroup,
+				Limit: quotas.AlbBackendsPerServerGroup, Used: len(sgp.Backends),
+			})
+		}
+	}
+	return newExceededError(violations)
+}
+
+// ValidateNLB checks the nlb built from Service
+func (v *Validator) ValidateNLB(ctx context.Context, mdl *nlbmodel.NetworkLoadBalancer) error {
+	quotas, err := v.provider.Quotas(ctx)
+	if err != nil {
+		return fmt.Errorf("get quotas error: %v", err)
+	}
+	var violations []Violation
+	if exceeds(quotas.NlbListenersPerLoadBalancer, len(mdl.Listeners)) {
+		violations = append(violations, Violation{
+			Kind: kindService, Namespace: mdl.NamespacedName.Namespace, Name: mdl.NamespacedName.Name,
+			Resource: "listeners", Quota: quotaNlbListenersPerLoadBalancer,
+			Limit: quotas.NlbListenersPerLoadBalancer, Used: len(mdl.Listeners),
+		})
+	}
+	for _, sg := range mdl.ServerGroups {
+		if sg.IsUserManaged || !exceeds(quotas.NlbBackendsPerServerGroup, len(sg.Servers)) {
+			continue
+		}
+		violations = append(violations, Violation{
+			Kind: kindService, Namespace: mdl.NamespacedName.Namespace, Name: mdl.NamespacedName.Name,
+			Resource: fmt.Sprintf("backends of server group %s", sg.ServerGroupName), Quota: quotaNlbBackendsPerServerGroup,
+			Limit: quotas.NlbBackendsPerServerGroup, Used: len(sg.Servers),
+		})
+	}
+	return newExceededError(violations)
+}
+
+func ruleViolation(rule *albmodel.ListenerRule, resource, quota string, limit, used int) Violation {
+	return Violation{
+		Kind: rule.Spec.Origin.Kind, Namespace: rule.Spec.Origin.Namespace, Name: rule.Spec.Origin.Name,
+		Resource: resource, Quota: quota, Limit: limit, Used: used,
+	}
+}
+
+func listenerName(ls *albmodel.Listener) string {
+	return fmt.Sprintf("%d/%s", ls.Spec.ListenerPort, ls.Spec.ListenerProtocol)
+}
+
+// exceeds returns whether used exceeds limit, limit 0 means no limit
+func exceeds(limit, used int) bool {
+	return limit > 0 && used > limit
+}
@@ -0,0 +1,74 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/alibaba-load-balancer-controller/pkg/config"
+	albmodel "k8s.io/alibaba-load-balancer-controller/pkg/model/alb"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model/alb/core"
+	nlbmodel "k8s.io/alibaba-load-balancer-controller/pkg/model/nlb"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestValidateAlbStack(t *testing.T) {
+	validator := NewValidator(NewStaticProvider(config.QuotaConfig{
+		AlbRulesPerListener:  2,
+		AlbConditionsPerRule: 1,
+	}))
+	stack := core.NewDefaultManager(core.StackID{Namespace: "", Name: "default"})
+	ls := albmodel.NewListener(stack, "80", albmodel.ListenerSpec{
+		LoadBalancerID:  core.LiteralStringToken("alb-id"),
+		ALBListenerSpec: albmodel.ALBListenerSpec{ListenerPort: 80, ListenerProtocol: "HTTP"},
+	})
+	owners := []string{"a", "a", "b", "c"}
+	for i, owner := range owners {
+		spec := albmodel.ListenerRuleSpec{
+			ListenerID: ls.ListenerID(),
+			Origin:     albmodel.RuleOrigin{Kind: albmodel.RuleOriginKindIngress, Namespace: "default", Name: owner},
+		}
+		spec.Priority = i + 1
+		spec.RuleName = fmt.Sprintf("rule-%d", i)
+		spec.RuleConditions = []albmodel.Condition{{Type: "Host"}}
+		_ = albmodel.NewListenerRule(stack, fmt.Sprintf("80:%d", i+1), spec)
+	}
+
+	err := validator.ValidateAlbStack(context.TODO(), "", "default", stack)
+	exceeded, ok := err.(*ExceededError)
+	if assert.True(t, ok) && assert.Len(t, exceeded.Violations, 2) {
+		// the rules after the quota are reported with their Ingresses
+		assert.Equal(t, "b", exceeded.Violations[0].Name)
+		assert.Equal(t, "c", exceeded.Violations[1].Name)
+		assert.Equal(t, quotaAlbRulesPerListener, exceeded.Violations[0].Quota)
+		assert.Equal(t, 4, exceeded.Violations[0].Used)
+	}
+
+	// quota 0 disables the check
+	validator = NewValidator(NewStaticProvider(config.QuotaConfig{AlbConditionsPerRule: 1}))
+	assert.NoError(t, validator.ValidateAlbStack(context.TODO(), "", "default", stack))
+}
+
+func TestValidateNLB(t *testing.T) {
+	validator := NewValidator(NewStaticProvider(config.QuotaConfig{NlbListenersPerLoadBalancer: 1, NlbBackendsPerServerGroup: 1}))
+	mdl := &nlbmodel.NetworkLoadBalancer{
+		NamespacedName: types.NamespacedName{Namespace: "default", Name: "nlb"},
+		Listeners:      []*nlbmodel.ListenerAttribute{{ListenerPort: 80}},
+		ServerGroups: []*nlbmodel.ServerGroup{
+			{ServerGroupName: "sg", Servers: []nlbmodel.ServerGroupServer{{ServerId: "i-1"}, {ServerId: "i-2"}}},
+			{ServerGroupName: "reused", IsUserManaged: true, Servers: []nlbmodel.ServerGroupServer{{ServerId: "i-1"}, {ServerId: "i-2"}}},
+		},
+	}
+	err := validator.ValidateNLB(context.TODO(), mdl)
+	exceeded, ok := err.(*ExceededError)
+	if assert.True(t, ok) && assert.Len(t, exceeded.Violations, 1) {
+		assert.Equal(t, quotaNlbBackendsPerServerGroup, exceeded.Violations[0].Quota)
+		assert.Contains(t, err.Error(), "Service default/nlb: backends of server group sg")
+	}
+
+	mdl.Listeners = append(mdl.Listeners, &nlbmodel.ListenerAttribute{ListenerPort: 443})
+	mdl.ServerGroups = mdl.ServerGroups[1:]
+	err = validator.ValidateNLB(context.TODO(), mdl)
+	assert.Contains(t, err.Error(), quotaNlbListenersPerLoadBalancer)
+}
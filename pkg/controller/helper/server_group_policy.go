@@ -0,0 +1,31 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ServerGroupPolicyAnnotation references the ServerGroupPolicy of the server groups of the service
+const ServerGroupPolicyAnnotation = "service.beta.kubernetes.io/server-group-policy"
+
+// GetServerGroupPolicy returns the ServerGroupPolicy in the namespace, or nil if the name is empty.
+// A missing policy is an error, so that the server groups are not changed to the defaults by mistake.
+func GetServerGroupPolicy(ctx context.Context, kubeClient client.Client, namespace, name string) (*v1.ServerGroupPolicy, error) {
+	if name == "" {
+		return nil, nil
+	}
+	policy := &v1.ServerGroupPolicy{}
+	if err := kubeClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, policy); err != nil {
+		return nil, fmt.Errorf("get servergrouppolicy %s/%s error: %s", namespace, name, err.Error())
+	}
+	return policy, nil
+}
+
+// IsServiceReferencingServerGroupPolicy returns true if the service references the ServerGroupPolicy
+func IsServiceReferencingServerGroupPolicy(svc *corev1.Service, policy *v1.ServerGroupPolicy) bool {
+	return svc.Namespace == policy.Namespace && svc.Annotations[ServerGroupPolicyAnnotation] == policy.Name
+}
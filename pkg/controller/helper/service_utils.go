@@ -2,6 +2,8 @@ package helper
 
 import (
 	"fmt"
+	"net"
+	"strconv"
 
 	ctrlCfg "k8s.io/alibaba-load-balancer-controller/pkg/config"
 	"k8s.io/alibaba-load-balancer-controller/pkg/model"
@@ -25,6 +27,7 @@ const (
 // annotation
 const (
 	BackendType       = "service.beta.kubernetes.io/backend-type"
+	BackendIPs        = "service.beta.kubernetes.io/backend-ips"
 	LoadBalancerClass = "service.beta.kubernetes.io/class"
 )
 
@@ -51,9 +54,15 @@ const (
 	ClusterTrafficPolicy = TrafficPolicy("Cluster")
 	// ENITrafficPolicy is forwarded to pod directly
 	ENITrafficPolicy = TrafficPolicy("ENI")
+	// IPTrafficPolicy is forwarded to the ip addresses of the endpoints and the backend-ips annotation,
+	// which may be outside the cluster
+	IPTrafficPolicy = TrafficPolicy("IP")
 )
 
 func GetServiceTrafficPolicy(svc *v1.Service) (TrafficPolicy, error) {
+	if IsIPBackendType(svc) {
+		return IPTrafficPolicy, nil
+	}
 	if IsENIBackendType(svc) {
 		return ENITrafficPolicy, nil
 	}
@@ -82,6 +91,55 @@ func IsENIBackendType(svc *v1.Service) bool {
 	return ctrlCfg.CloudCFG.Global.ServiceBackendType == model.ENIBackendType
 }
 
+// IsIPBackendType returns true if the backends of the service are added to ip type server groups by their ip addresses.
+// It is used for the services without selectors, whose endpoints are managed manually or set by the backend-ips annotation.
+func IsIPBackendType(svc *v1.Service) bool {
+	if svc.Annotations[BackendType] != "" {
+		return svc.Annotations[BackendType] == model.IPBackendType
+	}
+	return svc.Annotations[BackendIPs] != ""
+}
+
+// BackendIP is an ip address set by the backend-ips annotation
+type BackendIP struct {
+	IP string
+	// Port is 0 if it is not set, the target port of the service port is used instead
+	Port int32
+}
+
+// GetBackendIPs parses the backend-ips annotation, in the format of "ip[:port],[ipv6]:port"
+func GetBackendIPs(svc *v1.Service) ([]BackendIP, error) {
+	value := svc.Annotations[BackendIPs]
+	if value == "" {
+		return nil, nil
+	}
+	var ips []BackendIP
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if ip := net.ParseIP(item); ip != nil {
+			ips = append(ips, BackendIP{IP: ip.String()})
+			continue
+		}
+		host, port, err := net.SplitHostPort(item)
+		if err != nil {
+			return nil, fmt.Errorf("parse backend ip %s error: %s", item, err.Error())
+		}
+		ip := net.ParseIP(host)
+		if ip == nil {
+			return nil, fmt.Errorf("parse backend ip %s error: %s is not a valid ip", item, host)
+		}
+		p, err := strconv.Atoi(port)
+		if err != nil || p < 1 || p > 65535 {
+			return nil, fmt.Errorf("parse backend ip %s error: port %s is not in range [1, 65535]", item, port)
+		}
+		ips = append(ips, BackendIP{IP: ip.String(), Port: int32(p)})
+	}
+	return ips, nil
+}
+
 func IsClusterIPService(svc *v1.Service) bool {
 	return svc.Spec.Type == v1.ServiceTypeClusterIP
 }
@@ -0,0 +1,43 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetBackendIPs(t *testing.T) {
+	svc := &v1.Service{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+		BackendIPs: "192.168.0.1, 10.0.0.2:8080,[2408:4000::1]:443,,2408:4000::2",
+	}}}
+	assert.True(t, IsIPBackendType(svc))
+	ips, err := GetBackendIPs(svc)
+	assert.NoError(t, err)
+	assert.Equal(t, []BackendIP{
+		{IP: "192.168.0.1"},
+		{IP: "10.0.0.2", Port: 8080},
+		{IP: "2408:4000::1", Port: 443},
+		{IP: "2408:4000::2"},
+	}, ips)
+
+	policy, err := GetServiceTrafficPolicy(svc)
+	assert.NoError(t, err)
+	assert.Equal(t, IPTrafficPolicy, policy)
+
+	for _, invalid := range []string{"192.168.0", "192.168.0.1:0", "192.168.0.1:http", "host:80"} {
+		svc.Annotations[BackendIPs] = invalid
+		_, err = GetBackendIPs(svc)
+		assert.Error(t, err, invalid)
+	}
+
+	svc.Annotations[BackendType] = "eni"
+	assert.False(t, IsIPBackendType(svc))
+	svc.Annotations[BackendType] = "ip"
+	delete(svc.Annotations, BackendIPs)
+	assert.True(t, IsIPBackendType(svc))
+	ips, err = GetBackendIPs(svc)
+	assert.NoError(t, err)
+	assert.Empty(t, ips)
+}
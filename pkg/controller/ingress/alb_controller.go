@@ -27,6 +27,7 @@ import (
 	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
 	"k8s.io/alibaba-load-balancer-controller/pkg/context/shared"
 	"k8s.io/alibaba-load-balancer-controller/pkg/controller/helper"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/helper/quota"
 	"k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/applier"
 	"k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/backend"
 	albconfigmanager "k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/builder/albconfig_manager"
@@ -52,8 +53,9 @@ import (
 )
 
 const (
-	defaultMaxConcurrentReconciles = 3
-	albIngressControllerName       = "alb-ingress-controller"
+	albIngressControllerName = "alb-ingress-controller"
+	// multiClusterResyncPeriod is the period the Primary cluster picks up the server groups of the Member clusters
+	multiClusterResyncPeriod = 1 * time.Minute
 )
 
 func NewAlbConfigReconciler(mgr manager.Manager, ctx *shared.SharedContext) (*albconfigReconciler, error) {
@@ -73,6 +75,7 @@ func NewAlbConfigReconciler(mgr manager.Manager, ctx *shared.SharedContext) (*al
 	if err != nil {
 		logger.Error(err, "error create incluster client")
 	}
+	certInventory := albconfigmanager.NewCertInventory(ctx.Provider(), ctrlCfg.ControllerCFG.CertificateInventoryTTL, logger)
 	n := &albconfigReconciler{
 		cloud:            ctx.Provider(),
 		k8sClient:        mgr.GetClient(),
@@ -83,7 +86,9 @@ func NewAlbConfigReconciler(mgr manager.Manager, ctx *shared.SharedContext) (*al
 		logger:           logger,
 		updateCh:         channels.NewRingChannel(1024),
 		updateServerCh:   channels.NewRingChannel(1024),
-		albconfigBuilder: albconfigmanager.NewDefaultAlbConfigManagerBuilder(mgr.GetClient(), ctx.Provider(), logger),
+		certInventory:    certInventory,
+		quotaValidator:   quota.NewValidator(quota.NewStaticProvider(ctrlCfg.ControllerCFG.QuotaConfig)),
+		albconfigBuilder: albconfigmanager.NewDefaultAlbConfigManagerBuilder(mgr.GetClient(), ctx.Provider(), certInventory, logger),
 
 		serverApplier: applier.NewServiceManagerApplier(
 			mgr.GetClient(),
@@ -94,10 +99,11 @@ func NewAlbConfigReconciler(mgr manager.Manager, ctx *shared.SharedContext) (*al
 			ctx.Provider(),
 			logger),
 		stopLock:              &sync.Mutex{},
+		rejectedReasons:       make(map[types.UID]string),
 		groupFinalizerManager: albconfigmanager.NewDefaultFinalizerManager(helper.NewDefaultFinalizerManager(mgr.GetClient())),
 		k8sFinalizerManager:   helper.NewDefaultFinalizerManager(mgr.GetClient()),
 
-		maxConcurrentReconciles: defaultMaxConcurrentReconciles,
+		maxConcurrentReconciles: ctrlCfg.ControllerCFG.ReconcileConfig.IngressMaxConcurrentReconciles,
 	}
 	n.store = store.New(
 		config.Namespace,
@@ -106,6 +112,7 @@ func NewAlbConfigReconciler(mgr manager.Manager, ctx *shared.SharedContext) (*al
 		n.updateCh,
 		n.updateServerCh,
 		config.DisableCatchAll)
+	n.store.SetServiceReferrer(n.isServiceReferencedByAlbRoute)
 	n.serverBuilder = servicemanager.NewDefaultServiceStackBuilder(backend.NewBackendManager(n.store, mgr.GetClient(), ctx.Provider(), logger))
 	n.consoleServerBuilder = consoleservicemanager.NewDefaultServiceStackBuilder(backend.NewBackendManager(n.store, mgr.GetClient(), ctx.Provider(), logger),
 		mgr.GetClient())
@@ -135,6 +142,8 @@ type albconfigReconciler struct {
 	stackMarshaller      StackMarshaller
 	logger               logr.Logger
 	store                store.Storer
+	certInventory        *albconfigmanager.CertInventory
+	quotaValidator       *quota.Validator
 	albconfigBuilder     albconfigmanager.Builder
 	albconfigApplier     applier.AlbConfigManagerApplier
 	serverBuilder        servicemanager.Builder
@@ -154,6 +163,10 @@ type albconfigReconciler struct {
 	syncQueue               *helper.Queue
 	syncServersQueue        *helper.Queue
 	maxConcurrentReconciles int
+
+	// rejectedReasons are the reasons of the rejected Ingresses and AlbRoutes already recorded
+	rejectedReasons map[types.UID]string
+	rejectedLock    sync.Mutex
 }
 
 func (g *albconfigReconciler) syncIngress(obj interface{}) error {
@@ -213,6 +226,7 @@ func (g *albconfigReconciler) syncIngress(obj interface{}) error {
 			}
 			return err
 		}
+		g.recordRejectedMembers(ingGroup)
 		ingListByPPs := []albconfigmanager.PortProtocol{}
 		if ingGroup.Members != nil && len(ingGroup.Members) > 0 {
 			for _, ingm := range ingGroup.Members {
@@ -345,7 +359,7 @@ func (g *albconfigReconciler) reconcileServers(ctx context.Context, svc *corev1.
 	}
 
 	ings := g.store.ListIngresses()
-	if len(ings) == 0 {
+	if len(ings) == 0 && !g.isServiceReferencedByAlbRoute(svc) {
 		g.logger.Info("service not used by ingress, skip", "key", svc.Name)
 		return nil
 	}
@@ -450,7 +464,11 @@ func (g *albconfigReconciler) getServicePortToIngressNames(ctx context.Context,
 				return map[int32][]string{}, ingressAlbConfigMap, err
 			}
 		}
-		ingressAlbConfigMap[ing.Namespace+"/"+ing.Name] = ingGroup.String()
+		shardGroupID, err := g.ingressShardGroupID(ctx, *ingGroup, &ing.Ingress)
+		if err != nil {
+			return map[int32][]string{}, ingressAlbConfigMap, err
+		}
+		ingressAlbConfigMap[ing.Namespace+"/"+ing.Name] = shardGroupID.String()
 
 		if ing.Spec.DefaultBackend != nil {
 			if ing.Spec.DefaultBackend.Service.Name == request.Name {
@@ -550,6 +568,31 @@ func (g *albconfigReconciler) getServicePortToIngressNames(ctx context.Context,
 		}
 	}
 
+	routes, err := g.listAlbRoutes(ctx, request.Namespace)
+	if err != nil {
+		return map[int32][]string{}, ingressAlbConfigMap, err
+	}
+	for _, route := range routes {
+		ownerName := albconfigmanager.AlbRouteBackendOwnerName(route)
+		for _, backend := range albconfigmanager.AlbRouteServiceBackends(route) {
+			if backend.ServiceName != request.Name {
+				continue
+			}
+			processIngressBackend(networking.IngressBackend{
+				Service: &networking.IngressServiceBackend{
+					Name: backend.ServiceName,
+					Port: networking.ServiceBackendPort{
+						Number: backend.ServicePort,
+					},
+				},
+			}, ownerName)
+			ingressAlbConfigMap[route.Namespace+"/"+ownerName] = albconfigmanager.GroupID(types.NamespacedName{
+				Namespace: albconfigmanager.ALBConfigNamespace,
+				Name:      route.Spec.ParentRef.AlbConfigName,
+			}).String()
+		}
+	}
+
 	var servicePortToIngressNameList = make(map[int32][]string)
 	for servicePort, ingressNames := range servicePortToIngressNames {
 		for ingressName := range ingressNames {
@@ -560,6 +603,15 @@ func (g *albconfigReconciler) getServicePortToIngressNames(ctx context.Context,
 	return servicePortToIngressNameList, ingressAlbConfigMap, nil
 }
 
+// ingressShardGroupID returns the stack of the shard the Ingress is placed onto
+func (g *albconfigReconciler) ingressShardGroupID(ctx context.Context, groupID albconfigmanager.GroupID, ing *networking.Ingress) (albconfigmanager.GroupID, error) {
+	albconfig := &v1.AlbConfig{}
+	if err := g.k8sClient.Get(ctx, types.NamespacedName(groupID), albconfig); err != nil {
+		return groupID, fmt.Errorf("get albconfig %s error: %s", groupID.String(), err.Error())
+	}
+	return albconfigmanager.ShardGroupID(groupID, albconfigmanager.ShardIndexOfIngress(albconfig, ing)), nil
+}
+
 func (g *albconfigReconciler) buildServiceStackContext(ctx context.Context, request reconcile.Request, serverPortToIngressNames map[int32][]string, ingressAlbConfigMap map[string]string) (*albmodel.ServiceStackContext, error) {
 	var svcStackContext = &albmodel.ServiceStackContext{
 		ClusterID:                 g.cloud.ClusterID(),
@@ -610,6 +662,13 @@ func (s *albconfigReconciler) buildAndApplyServers(ctx context.Context, svcStack
 		"stack", string(serviceStackJson),
 		"buildElapsedTime", time.Since(buildStartTime).Milliseconds())
 
+	if err := s.quotaValidator.ValidateAlbServiceStack(ctx, serverStack); err != nil {
+		if svcStackCtx.Service != nil {
+			s.eventRecorder.Event(svcStackCtx.Service, corev1.EventTypeWarning, helper.EventReasonQuotaExceeded, err.Error())
+		}
+		return err
+	}
+
 	applyStartTime := time.Now()
 	err = s.serverApplier.Apply(ctx, s.cloud, serverStack)
 	if err != nil {
@@ -738,7 +797,24 @@ func (g *albconfigReconciler) Reconcile(_ context.Context, req reconcile.Request
 	}()
 
 	err = g.reconcile(ctx, req)
-	return reconcile.Result{}, err
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	return g.multiClusterResult(ctx, req), nil
+}
+
+// multiClusterResult requeues the AlbConfig of the Primary cluster periodically, since the server groups
+// created by the Member clusters on the shared ALB instance trigger no event in the Primary cluster
+func (g *albconfigReconciler) multiClusterResult(ctx context.Context, req reconcile.Request) reconcile.Result {
+	albconfig := &v1.AlbConfig{}
+	if err := g.k8sClient.Get(ctx, req.NamespacedName, albconfig); err != nil {
+		return reconcile.Result{}
+	}
+	if albconfig.DeletionTimestamp.IsZero() && albconfig.Spec.MultiCluster != nil &&
+		albconfig.Spec.MultiCluster.Role == v1.MultiClusterRolePrimary {
+		return reconcile.Result{RequeueAfter: multiClusterResyncPeriod}
+	}
+	return reconcile.Result{}
 }
 
 func (g *albconfigReconciler) reconcile(ctx context.Context, request reconcile.Request) error {
@@ -757,10 +833,25 @@ func (g *albconfigReconciler) reconcile(ctx context.Context, request reconcile.R
 		}
 		return err
 	}
+	g.recordRejectedMembers(ingGroup)
+	g.updateAlbRouteStatus(ctx, ingGroup)
 
 	if albconfig.Spec.LoadBalancer == nil {
 		return fmt.Errorf("does not exist albconfig.spec.config")
 	}
+	switch albconfig.Spec.DeletionPolicy {
+	case "", util.DeletionPolicyDelete, util.DeletionPolicyRetain:
+	default:
+		return fmt.Errorf("invalid albconfig.spec.deletionPolicy %s, either %s or %s",
+			albconfig.Spec.DeletionPolicy, util.DeletionPolicyDelete, util.DeletionPolicyRetain)
+	}
+	if err := albconfigmanager.ValidateMultiCluster(albconfig, g.cloud.ClusterID()); err != nil {
+		g.eventRecorder.Event(albconfig, corev1.EventTypeWarning, helper.IngressEventReasonFailedBuildModel, err.Error())
+		return err
+	}
+	if albconfig.Spec.MultiCluster != nil {
+		ctx = context.WithValue(ctx, util.MultiClusterConfig, albconfig.Spec.MultiCluster)
+	}
 
 	// reuse loadBalancer
 	if len(albconfig.Spec.LoadBalancer.Id) != 0 {
@@ -796,11 +887,17 @@ func (g *albconfigReconciler) reconcile(ctx context.Context, request reconcile.R
 
 func (g *albconfigReconciler) cleanupAlbLoadBalancerResources(ctx context.Context, albconfig *v1.AlbConfig, ingGroup *albconfigmanager.Group) error {
 	gwFinalizer := albconfigmanager.GetIngressFinalizer()
+	if albconfig.Spec.DeletionPolicy == util.DeletionPolicyRetain {
+		ctx = context.WithValue(ctx, util.IsRetainLb, true)
+	}
 	if helper.HasFinalizer(albconfig, gwFinalizer) {
 		_, _, err := g.buildAndApply(ctx, albconfig, ingGroup)
 		if err != nil {
 			return err
 		}
+		if err := g.cleanupStaleShards(ctx, albconfig, ingGroup.ID, 1); err != nil {
+			return err
+		}
 		if err := g.removeAlbConfigLabel(albconfig); err != nil {
 			g.eventRecorder.Event(albconfig, corev1.EventTypeWarning, helper.IngressEventReasonFailedUpdateStatus, fmt.Sprintf("Failed remove labels due to %s", err))
 			return err
@@ -825,10 +922,46 @@ func (g *albconfigReconciler) reconcileAlbLoadBalancerResources(ctx context.Cont
 		g.eventRecorder.Event(albconfig, corev1.EventTypeWarning, helper.IngressEventReasonFailedRemoveFinalizer, helper.GetLogMessage(err))
 		return err
 	}
-	stack, lb, err := g.buildAndApply(ctx, albconfig, ingGroup)
+	shards, err := albconfigmanager.ShardGroup(albconfig, ingGroup)
 	if err != nil {
+		g.recordIngressGroupEvent(ctx, albconfig, ingGroup, corev1.EventTypeWarning, helper.IngressEventReasonFailedBuildModel, helper.GetLogMessage(err))
+		return err
+	}
+	var stack core.Manager
+	var lb *albmodel.AlbLoadBalancer
+	shardStatus := make([]v1.ShardStatus, 0, len(shards))
+	dnsNameByIngress := make(map[string]string)
+	var conflicts []v1.RuleConflict
+	var expiredCerts []albconfigmanager.CertMatch
+	for _, shard := range shards {
+		shardStack, shardLB, err := g.buildAndApply(shardContext(ctx, shard.Index), albconfigmanager.ShardAlbConfig(albconfig, shard.Index), shard.Group)
+		if err != nil {
+			return err
+		}
+		conflicts = append(conflicts, shard.Group.Conflicts...)
+		expiredCerts = append(expiredCerts, shard.Group.ExpiredCertificates...)
+		if shard.Index == 0 {
+			stack, lb = shardStack, shardLB
+		}
+		status := v1.ShardStatus{Index: shard.Index, Hosts: shard.Hosts}
+		if shardLB.Status != nil {
+			status.Id = shardLB.Status.LoadBalancerID
+			status.DNSName = shardLB.Status.DNSName
+		}
+		shardStatus = append(shardStatus, status)
+		// the ingresses are not mapped until the alb of shard has dns name
+		if status.DNSName == "" {
+			continue
+		}
+		for _, ing := range shard.Group.Members {
+			dnsNameByIngress[util.Key(ing)] = status.DNSName
+		}
+	}
+	if err := g.cleanupStaleShards(ctx, albconfig, ingGroup.ID, len(shards)); err != nil {
 		return err
 	}
+	g.recordRuleConflicts(ingGroup, albconfig.Status.Conflicts, conflicts)
+	g.recordExpiredCertificates(albconfig, expiredCerts)
 	//if err := g.groupFinalizerManager.AddGroupFinalizer(ctx, ingGroup.Members); err != nil {
 	//	g.recordIngressGroupEvent(ctx, albconfig, ingGroup, corev1.EventTypeWarning, helper.IngressEventReasonFailedAddFinalizer, fmt.Sprintf("Failed add finalizer due to %v", err))
 	//	return err
@@ -837,11 +970,15 @@ func (g *albconfigReconciler) reconcileAlbLoadBalancerResources(ctx context.Cont
 		return nil
 	}
 	for _, ing := range ingGroup.Members {
-		if ing.Status.LoadBalancer.Ingress != nil && len(ing.Status.LoadBalancer.Ingress) > 0 && ing.Status.LoadBalancer.Ingress[0].Hostname == lb.Status.DNSName {
+		dnsName, ok := dnsNameByIngress[util.Key(ing)]
+		if !ok {
+			continue
+		}
+		if ing.Status.LoadBalancer.Ingress != nil && len(ing.Status.LoadBalancer.Ingress) > 0 && ing.Status.LoadBalancer.Ingress[0].Hostname == dnsName {
 			continue
 		}
 		lbi := networking.IngressLoadBalancerIngress{
-			Hostname: lb.Status.DNSName,
+			Hostname: dnsName,
 		}
 		ing.Status.LoadBalancer.Ingress = []networking.IngressLoadBalancerIngress{lbi}
 		err = g.k8sClient.Status().Update(ctx, ing)
@@ -877,6 +1014,11 @@ func (g *albconfigReconciler) reconcileAlbLoadBalancerResources(ctx context.Cont
 		Listeners: listenerStatus,
 	}
 	albconfig.Status.LoadBalancer = status
+	albconfig.Status.Conflicts = conflicts
+	albconfig.Status.Shards = nil
+	if albconfigmanager.ShardingEnabled(albconfig) {
+		albconfig.Status.Shards = shardStatus
+	}
 	err = g.k8sClient.Status().Update(ctx, albconfig)
 	if err != nil {
 		g.logger.Error(err, "LB Status Update %s, error: %s", albconfig.Name)
@@ -945,6 +1087,11 @@ func (g *albconfigReconciler) buildAndApply(ctx context.Context, albconfig *v1.A
 		"stack", stackJSON,
 		"buildElapsedTime", time.Since(buildStartTime).Milliseconds())
 
+	if err := g.quotaValidator.ValidateAlbStack(ctx, albconfig.Namespace, albconfig.Name, stack); err != nil {
+		g.recordQuotaExceededEvent(ctx, albconfig, ingGroup, err)
+		return nil, nil, err
+	}
+
 	applyStartTime := time.Now()
 	if err := g.albconfigApplier.Apply(ctx, stack); err != nil {
 		g.recordIngressGroupEvent(ctx, albconfig, ingGroup, corev1.EventTypeWarning, helper.IngressEventReasonFailedApplyModel, helper.GetLogMessage(err))
@@ -954,15 +1101,212 @@ func (g *albconfigReconciler) buildAndApply(ctx context.Context, albconfig *v1.A
 		"albconfig", util.NamespacedName(albconfig).String(),
 		"traceID", traceID,
 		"applyElapsedTime", time.Since(applyStartTime).Milliseconds())
+	g.invalidateCertInventory(stack)
 
 	return stack, lb, nil
 }
 
+// shardContext returns the context to build and apply the shard, only the first shard may reuse
+// the ALB instance of AlbConfig
+func shardContext(ctx context.Context, index int) context.Context {
+	if index == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, util.IsReuseLb, false)
+}
+
+// cleanupStaleShards deletes the ALB instances of the shards recorded in status but no longer needed,
+// by applying empty stacks of them
+func (g *albconfigReconciler) cleanupStaleShards(ctx context.Context, albconfig *v1.AlbConfig, groupID albconfigmanager.GroupID, shardCount int) error {
+	for _, shard := range albconfig.Status.Shards {
+		if shard.Index < shardCount {
+			continue
+		}
+		stackID := core.StackID(albconfigmanager.ShardGroupID(groupID, shard.Index))
+		g.logger.Info("cleanup stale shard", "albconfig", util.Key(albconfig), "shard", shard.Index, "loadBalancerId", shard.Id)
+		if err := g.albconfigApplier.Apply(shardContext(ctx, shard.Index), core.NewDefaultManager(stackID)); err != nil {
+			g.eventRecorder.Event(albconfig, corev1.EventTypeWarning, helper.IngressEventReasonFailedApplyModel,
+				fmt.Sprintf("Failed cleanup shard %d due to %s", shard.Index, helper.GetLogMessage(err)))
+			return err
+		}
+	}
+	return nil
+}
+
+// invalidateCertInventory drops the certificate inventory once certificates are uploaded from secrets,
+// so they can be discovered by the hosts without secret
+func (g *albconfigReconciler) invalidateCertInventory(stack core.Manager) {
+	var secretCerts []*albmodel.SecretCertificate
+	_ = stack.ListResources(&secretCerts)
+	for _, cert := range secretCerts {
+		if cert.Status != nil && !g.certInventory.Contains(cert.Status.CertIdentifier) {
+			g.certInventory.Invalidate()
+			return
+		}
+	}
+}
+
 func (g *albconfigReconciler) recordIngressGroupEvent(_ context.Context, albConfig *v1.AlbConfig, ingGroup *albconfigmanager.Group, eventType string, reason string, message string) {
 	g.eventRecorder.Event(albConfig, eventType, reason, message)
 	for _, member := range ingGroup.Members {
 		g.eventRecorder.Event(member, eventType, reason, message)
 	}
+	for _, route := range ingGroup.Routes {
+		g.eventRecorder.Event(route, eventType, reason, message)
+	}
+}
+
+// recordQuotaExceededEvent records the whole report on AlbConfig, and the violations of each Ingress
+// or AlbRoute on itself. The report is recorded on all members if no member is found in it.
+func (g *albconfigReconciler) recordQuotaExceededEvent(ctx context.Context, albConfig *v1.AlbConfig, ingGroup *albconfigmanager.Group, err error) {
+	exceeded, ok := err.(*quota.ExceededError)
+	if !ok {
+		g.recordIngressGroupEvent(ctx, albConfig, ingGroup, corev1.EventTypeWarning, helper.EventReasonQuotaExceeded, err.Error())
+		return
+	}
+	g.eventRecorder.Event(albConfig, corev1.EventTypeWarning, helper.EventReasonQuotaExceeded, err.Error())
+	objects := make(map[string]client.Object)
+	for _, member := range ingGroup.Members {
+		objects[albmodel.RuleOriginKindIngress+"/"+util.Key(member)] = member
+	}
+	for _, route := range ingGroup.Routes {
+		objects[albmodel.RuleOriginKindAlbRoute+"/"+util.Key(route)] = route
+	}
+	messages := make(map[client.Object][]string)
+	var offenders []client.Object
+	for _, violation := range exceeded.Violations {
+		obj, ok := objects[violation.Kind+"/"+violation.Namespace+"/"+violation.Name]
+		if !ok {
+			continue
+		}
+		if _, ok := messages[obj]; !ok {
+			offenders = append(offenders, obj)
+		}
+		messages[obj] = append(messages[obj], violation.String())
+	}
+	if len(offenders) == 0 {
+		for _, member := range ingGroup.Members {
+			g.eventRecorder.Event(member, corev1.EventTypeWarning, helper.EventReasonQuotaExceeded, err.Error())
+		}
+		return
+	}
+	for _, obj := range offenders {
+		g.eventRecorder.Event(obj, corev1.EventTypeWarning, helper.EventReasonQuotaExceeded,
+			fmt.Sprintf("quota check failed: %s", strings.Join(messages[obj], "; ")))
+	}
+}
+
+// recordRuleConflicts records the new conflicts on both the winner and the shadowed Ingresses or AlbRoutes,
+// the conflicts recorded in the status of AlbConfig are already recorded.
+func (g *albconfigReconciler) recordRuleConflicts(ingGroup *albconfigmanager.Group, recorded, conflicts []v1.RuleConflict) {
+	known := make(map[v1.RuleConflict]bool, len(recorded))
+	for _, conflict := range recorded {
+		known[conflict] = true
+	}
+	objects := make(map[string]client.Object)
+	for _, member := range ingGroup.Members {
+		objects[albmodel.RuleOriginKindIngress+" "+util.Key(member)] = member
+	}
+	for _, route := range ingGroup.Routes {
+		objects[albmodel.RuleOriginKindAlbRoute+" "+util.Key(route)] = route
+	}
+	for _, conflict := range conflicts {
+		if known[conflict] {
+			continue
+		}
+		if obj, ok := objects[conflict.Shadowed]; ok {
+			g.eventRecorder.Event(obj, corev1.EventTypeWarning, helper.IngressEventReasonRuleConflict,
+				fmt.Sprintf("%s rule of %s on listener %s is shadowed by %s", conflict.Type, conflict.Conditions, conflict.Listener, conflict.Winner))
+		}
+		if obj, ok := objects[conflict.Winner]; ok {
+			g.eventRecorder.Event(obj, corev1.EventTypeWarning, helper.IngressEventReasonRuleConflict,
+				fmt.Sprintf("%s rule of %s on listener %s shadows %s", conflict.Type, conflict.Conditions, conflict.Listener, conflict.Shadowed))
+		}
+	}
+}
+
+// recordExpiredCertificates warns the expired certificates used by the hosts without valid certificate
+func (g *albconfigReconciler) recordExpiredCertificates(albconfig *v1.AlbConfig, matches []albconfigmanager.CertMatch) {
+	for _, m := range matches {
+		g.eventRecorder.Event(albconfig, corev1.EventTypeWarning, helper.IngressEventReasonCertificateExpired,
+			fmt.Sprintf("No valid certificate found for host %s, the certificate %s expired at %s is used",
+				m.Host, m.CertificateId, m.NotAfter.Format(time.RFC3339)))
+	}
+}
+
+// recordRejectedMembers records the rejection of an Ingress or AlbRoute once, until it is admitted
+// or rejected for another reason, instead of on every reconcile
+func (g *albconfigReconciler) recordRejectedMembers(ingGroup *albconfigmanager.Group) {
+	g.rejectedLock.Lock()
+	defer g.rejectedLock.Unlock()
+	for _, member := range ingGroup.Members {
+		delete(g.rejectedReasons, member.UID)
+	}
+	for _, route := range ingGroup.Routes {
+		delete(g.rejectedReasons, route.UID)
+	}
+	for _, rejected := range ingGroup.Rejected {
+		if reason, ok := g.rejectedReasons[rejected.Object.GetUID()]; ok && reason == rejected.Reason {
+			continue
+		}
+		g.rejectedReasons[rejected.Object.GetUID()] = rejected.Reason
+		g.eventRecorder.Event(rejected.Object, corev1.EventTypeWarning, helper.IngressEventReasonAdmissionDenied,
+			fmt.Sprintf("rejected by albconfig %s: %s", ingGroup.ID.Name, rejected.Reason))
+	}
+}
+
+// updateAlbRouteStatus records whether the AlbRoutes of group are accepted, or why they are rejected
+func (g *albconfigReconciler) updateAlbRouteStatus(ctx context.Context, ingGroup *albconfigmanager.Group) {
+	statuses := make(map[*v1.AlbRoute]v1.AlbRouteStatus)
+	for _, route := range ingGroup.Routes {
+		statuses[route] = v1.AlbRouteStatus{Accepted: true}
+	}
+	for _, rejected := range ingGroup.Rejected {
+		if route, ok := rejected.Object.(*v1.AlbRoute); ok {
+			statuses[route] = v1.AlbRouteStatus{Reason: rejected.Reason}
+		}
+	}
+	for route, status := range statuses {
+		if route.Status == status {
+			continue
+		}
+		updated := route.DeepCopy()
+		updated.Status = status
+		if err := g.k8sClient.Status().Patch(ctx, updated, client.MergeFrom(route)); err != nil {
+			g.logger.Error(err, "failed to update albroute status", "albroute", util.Key(route))
+		}
+	}
+}
+
+func (g *albconfigReconciler) listAlbRoutes(ctx context.Context, namespace string) ([]*v1.AlbRoute, error) {
+	routeList := &v1.AlbRouteList{}
+	if err := g.k8sClient.List(ctx, routeList, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	routes := make([]*v1.AlbRoute, 0, len(routeList.Items))
+	for i := range routeList.Items {
+		if routeList.Items[i].DeletionTimestamp.IsZero() {
+			routes = append(routes, &routeList.Items[i])
+		}
+	}
+	return routes, nil
+}
+
+// isServiceReferencedByAlbRoute check if the service is forwarded by AlbRoutes, whose servers need to sync
+func (g *albconfigReconciler) isServiceReferencedByAlbRoute(svc *corev1.Service) bool {
+	routes, err := g.listAlbRoutes(context.TODO(), svc.Namespace)
+	if err != nil {
+		g.logger.Error(err, "failed to list albroutes", "service", util.Key(svc))
+		return false
+	}
+	for _, route := range routes {
+		for _, backend := range albconfigmanager.AlbRouteServiceBackends(route) {
+			if backend.ServiceName == svc.Name {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 func (g *albconfigReconciler) recordIngressSingleEvent(_ context.Context, albConfig *v1.AlbConfig, ing *networking.Ingress, eventType string, reason string, message string) {
@@ -0,0 +1,126 @@
+package ingress
+
+import (
+	"encoding/json"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// albRouteSchema returns the OpenAPI v3 schema of AlbRoute
+func albRouteSchema() *apiextv1.JSONSchemaProps {
+	str := func() apiextv1.JSONSchemaProps { return apiextv1.JSONSchemaProps{Type: "string"} }
+	enum := func(values ...string) apiextv1.JSONSchemaProps {
+		s := str()
+		for _, v := range values {
+			raw, _ := json.Marshal(v)
+			s.Enum = append(s.Enum, apiextv1.JSON{Raw: raw})
+		}
+		return s
+	}
+	integer := func(min, max float64) apiextv1.JSONSchemaProps {
+		return apiextv1.JSONSchemaProps{Type: "integer", Minimum: &min, Maximum: &max}
+	}
+	boolean := apiextv1.JSONSchemaProps{Type: "boolean"}
+	array := func(items apiextv1.JSONSchemaProps, minItems int64) apiextv1.JSONSchemaProps {
+		a := apiextv1.JSONSchemaProps{Type: "array", Items: &apiextv1.JSONSchemaPropsOrArray{Schema: &items}}
+		if minItems > 0 {
+			a.MinItems = &minItems
+		}
+		return a
+	}
+	object := func(props map[string]apiextv1.JSONSchemaProps, required ...string) apiextv1.JSONSchemaProps {
+		return apiextv1.JSONSchemaProps{Type: "object", Properties: props, Required: required}
+	}
+
+	keyValue := object(map[string]apiextv1.JSONSchemaProps{
+		"key":   str(),
+		"value": str(),
+	}, "value")
+	condition := object(map[string]apiextv1.JSONSchemaProps{
+		"type": enum("Host", "Path", "Header", "Cookie", "QueryString", "Method",
+			"SourceIp", "ResponseHeader", "ResponseStatusCode"),
+		"key":       str(),
+		"values":    array(str(), 1),
+		"keyValues": array(keyValue, 1),
+	}, "type")
+	backend := object(map[string]apiextv1.JSONSchemaProps{
+		"serviceName":   str(),
+		"servicePort":   integer(1, 65535),
+		"serverGroupId": str(),
+		"weight":        integer(0, 100),
+	})
+	action := object(map[string]apiextv1.JSONSchemaProps{
+		"type": enum("Forward", "Redirect", "FixedResponse", "Rewrite", "InsertHeader",
+			"RemoveHeader", "TrafficMirror", "TrafficLimit", "Cors"),
+		"forward": object(map[string]apiextv1.JSONSchemaProps{
+			"backends": array(backend, 1),
+		}, "backends"),
+		"redirect": object(map[string]apiextv1.JSONSchemaProps{
+			"host":     str(),
+			"httpCode": enum("301", "302", "303", "307", "308"),
+			"path":     str(),
+			"port":     str(),
+			"protocol": str(),
+			"query":    str(),
+		}),
+		"fixedResponse": object(map[string]apiextv1.JSONSchemaProps{
+			"content":     str(),
+			"contentType": enum("text/plain", "text/css", "text/html", "application/javascript", "application/json"),
+			"httpCode":    str(),
+		}, "contentType", "httpCode"),
+		"rewrite": object(map[string]apiextv1.JSONSchemaProps{
+			"host":  str(),
+			"path":  str(),
+			"query": str(),
+		}),
+		"insertHeader": object(map[string]apiextv1.JSONSchemaProps{
+			"key":          str(),
+			"value":        str(),
+			"valueType":    enum("UserDefined", "ReferenceHeader", "SystemDefined"),
+			"coverEnabled": boolean,
+		}, "key", "value"),
+		"removeHeader": object(map[string]apiextv1.JSONSchemaProps{
+			"key": str(),
+		}, "key"),
+		"trafficMirror": object(map[string]apiextv1.JSONSchemaProps{
+			"backends": array(backend, 1),
+		}, "backends"),
+		"trafficLimit": object(map[string]apiextv1.JSONSchemaProps{
+			"qps":      integer(1, 1000000),
+			"qpsPerIp": integer(1, 1000000),
+		}),
+		"cors": object(map[string]apiextv1.JSONSchemaProps{
+			"allowOrigin":      array(str(), 0),
+			"allowMethods":     array(enum("GET", "POST", "PUT", "DELETE", "HEAD", "OPTIONS", "PATCH"), 0),
+			"allowHeaders":     array(str(), 0),
+			"exposeHeaders":    array(str(), 0),
+			"allowCredentials": boolean,
+			"maxAge":           integer(-1, 172800),
+		}),
+	}, "type")
+	rule := object(map[string]apiextv1.JSONSchemaProps{
+		"direction":  enum("Request", "Response"),
+		"conditions": array(condition, 1),
+		"actions":    array(action, 1),
+	}, "conditions", "actions")
+	spec := object(map[string]apiextv1.JSONSchemaProps{
+		"parentRef": object(map[string]apiextv1.JSONSchemaProps{
+			"albConfigName": str(),
+			"port":          integer(1, 65535),
+			"protocol":      enum("HTTP", "HTTPS", "QUIC"),
+		}, "albConfigName", "port"),
+		"rules": array(rule, 1),
+	}, "parentRef", "rules")
+
+	schema := object(map[string]apiextv1.JSONSchemaProps{
+		"apiVersion": str(),
+		"kind":       str(),
+		"metadata":   {Type: "object"},
+		"spec":       spec,
+		"status": object(map[string]apiextv1.JSONSchemaProps{
+			"accepted": boolean,
+			"reason":   str(),
+		}),
+	}, "spec")
+	return &schema
+}
@@ -0,0 +1,243 @@
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	ctrlCfg "k8s.io/alibaba-load-balancer-controller/pkg/config"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/helper"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/annotations"
+	albconfigmanager "k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/builder/albconfig_manager"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/tracking"
+	prvd "k8s.io/alibaba-load-balancer-controller/pkg/provider"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// canaryAnalyzer periodically analyzes the access logs of the canary server groups of AlbCanaries,
+// steps the canary weight of the canary Ingresses, and promotes or rolls back the canaries by the thresholds
+type canaryAnalyzer struct {
+	cloud            prvd.Provider
+	k8sClient        client.Client
+	recon            *albconfigReconciler
+	trackingProvider tracking.TrackingProvider
+	eventRecorder    record.EventRecorder
+	logger           logr.Logger
+	period           time.Duration
+}
+
+func newCanaryAnalyzer(r *albconfigReconciler) *canaryAnalyzer {
+	return &canaryAnalyzer{
+		cloud:            r.cloud,
+		k8sClient:        r.k8sClient,
+		recon:            r,
+		trackingProvider: tracking.NewDefaultProvider(util.IngressTagKeyPrefix, r.cloud.ClusterID()),
+		eventRecorder:    r.eventRecorder,
+		logger:           r.logger.WithName("canary-analyzer"),
+		period:           ctrlCfg.ControllerCFG.CanaryAnalysisPeriod,
+	}
+}
+
+// Start implements manager.Runnable
+func (a *canaryAnalyzer) Start(ctx context.Context) error {
+	if _, err := a.recon.store.WaitCache(a.recon.stopCh); err != nil {
+		return err
+	}
+	wait.UntilWithContext(ctx, a.analyze, a.period)
+	return nil
+}
+
+func (a *canaryAnalyzer) analyze(ctx context.Context) {
+	canaries := &v1.AlbCanaryList{}
+	if err := a.k8sClient.List(ctx, canaries); err != nil {
+		a.logger.Error(err, "failed to list albcanaries")
+		return
+	}
+	now := time.Now()
+	for i := range canaries.Items {
+		canary := &canaries.Items[i]
+		if !canary.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := a.analyzeCanary(ctx, canary, now); err != nil {
+			a.logger.Error(err, "failed to analyze albcanary", "albcanary", util.NamespacedName(canary))
+		}
+	}
+}
+
+func (a *canaryAnalyzer) analyzeCanary(ctx context.Context, canary *v1.AlbCanary, now time.Time) error {
+	spec := helper.CanarySpecWithDefaults(canary.Spec)
+	if err := helper.ValidateCanarySpec(spec); err != nil {
+		return a.failAnalysis(ctx, canary, nil, fmt.Sprintf("invalid spec: %s", err.Error()))
+	}
+	ing := &networking.Ingress{}
+	if err := a.k8sClient.Get(ctx, types.NamespacedName{Namespace: canary.Namespace, Name: spec.IngressName}, ing); err != nil {
+		return a.failAnalysis(ctx, canary, nil, fmt.Sprintf("failed to get ingress %s: %s", spec.IngressName, err.Error()))
+	}
+	if ing.Annotations[annotations.AlbCanary] != "true" {
+		return a.failAnalysis(ctx, canary, ing, fmt.Sprintf("ingress %s is not annotated by %s", ing.Name, annotations.AlbCanary))
+	}
+
+	// the canary restarts from the current canary weight once the spec changes, a canary without weight
+	// gets no server group to analyze, so it starts from the step weight instead
+	if canary.Status.ObservedGeneration != canary.Generation {
+		weight, _ := strconv.Atoi(ing.Annotations[annotations.AlbCanaryWeight])
+		if weight <= 0 {
+			weight = spec.StepWeight
+			if err := a.updateCanaryWeight(ctx, ing, weight); err != nil {
+				return a.failAnalysis(ctx, canary, ing, err.Error())
+			}
+		}
+		message := fmt.Sprintf("started at weight %d", weight)
+		a.recordEvent(canary, ing, corev1.EventTypeNormal, helper.CanaryStarted, message)
+		return a.updateStatus(ctx, canary, func(status *v1.AlbCanaryStatus) {
+			status.ObservedGeneration = canary.Generation
+			status.Phase = v1.AlbCanaryPhaseProgressing
+			status.Weight = weight
+			status.LastStepTime = &metav1.Time{Time: now}
+			status.Message = message
+			status.ServerGroups = nil
+		})
+	}
+	if canary.Status.Phase != v1.AlbCanaryPhaseProgressing {
+		return nil
+	}
+	from := now
+	if canary.Status.LastStepTime != nil {
+		from = canary.Status.LastStepTime.Time
+	}
+	if now.Sub(from) < spec.Interval.Duration {
+		return nil
+	}
+
+	metrics, err := a.queryCanaryServerGroups(ctx, ing, from, now)
+	if err != nil {
+		return a.failAnalysis(ctx, canary, ing, err.Error())
+	}
+	step := helper.StepCanary(spec, canary.Status.Weight, metrics)
+	if step.Reason != "" {
+		if err := a.updateCanaryWeight(ctx, ing, step.Weight); err != nil {
+			return a.failAnalysis(ctx, canary, ing, err.Error())
+		}
+		eventType := corev1.EventTypeNormal
+		if step.Phase == v1.AlbCanaryPhaseRolledBack {
+			eventType = corev1.EventTypeWarning
+		}
+		a.recordEvent(canary, ing, eventType, step.Reason, step.Message)
+	}
+	return a.updateStatus(ctx, canary, func(status *v1.AlbCanaryStatus) {
+		status.Phase = step.Phase
+		status.Weight = step.Weight
+		status.Message = step.Message
+		status.ServerGroups = metrics
+		// the interval is extended until there are enough requests to analyze
+		if step.Reason != "" {
+			status.LastStepTime = &metav1.Time{Time: now}
+		}
+	})
+}
+
+// queryCanaryServerGroups returns the metrics of the server groups of the canary ingress in [from, to)
+func (a *canaryAnalyzer) queryCanaryServerGroups(ctx context.Context, ing *networking.Ingress, from, to time.Time) ([]v1.AlbCanaryServerGroupStatus, error) {
+	groupID, err := a.recon.groupLoader.LoadGroupID(ctx, ing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load albconfig of ingress %s: %s", ing.Name, err.Error())
+	}
+	albconfig := &v1.AlbConfig{}
+	if err := a.k8sClient.Get(ctx, types.NamespacedName(*groupID), albconfig); err != nil {
+		return nil, fmt.Errorf("failed to get albconfig %s: %s", groupID.Name, err.Error())
+	}
+	logConfig := albconfig.Spec.LoadBalancer.AccessLogConfig
+	if logConfig.LogProject == "" || logConfig.LogStore == "" {
+		return nil, fmt.Errorf("access log of albconfig %s is not enabled", albconfig.Name)
+	}
+
+	sgpIDs, err := a.canaryServerGroupIDs(ctx, ing)
+	if err != nil {
+		return nil, err
+	}
+	if len(sgpIDs) == 0 {
+		return nil, fmt.Errorf("server groups of ingress %s are not found", ing.Name)
+	}
+	logs, err := a.cloud.GetLogs(ctx, logConfig.LogProject, logConfig.LogStore, helper.CanaryAccessLogQuery(sgpIDs), from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query access logs: %s", helper.GetLogMessage(err))
+	}
+	return helper.ParseCanaryAccessLogs(sgpIDs, logs)
+}
+
+// canaryServerGroupIDs returns the server groups of the backends of the canary ingress
+func (a *canaryAnalyzer) canaryServerGroupIDs(ctx context.Context, ing *networking.Ingress) ([]string, error) {
+	resIDs := sets.NewString()
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service == nil {
+				continue
+			}
+			resIDs.Insert(albconfigmanager.ServerGroupResourceID(util.NamespacedName(ing),
+				types.NamespacedName{Namespace: ing.Namespace, Name: path.Backend.Service.Name}, int(path.Backend.Service.Port.Number)))
+		}
+	}
+
+	var sgpIDs []string
+	for _, resID := range resIDs.List() {
+		sgps, err := a.cloud.ListALBServerGroupsWithTags(ctx, map[string]string{
+			a.trackingProvider.ClusterNameTagKey(): a.cloud.ClusterID(),
+			a.trackingProvider.ResourceIDTagKey():  resID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list server groups of ingress %s: %s", ing.Name, helper.GetLogMessage(err))
+		}
+		for _, sgp := range sgps {
+			sgpIDs = append(sgpIDs, sgp.ServerGroupId)
+		}
+	}
+	return sgpIDs, nil
+}
+
+func (a *canaryAnalyzer) updateCanaryWeight(ctx context.Context, ing *networking.Ingress, weight int) error {
+	if ing.Annotations[annotations.AlbCanaryWeight] == strconv.Itoa(weight) {
+		return nil
+	}
+	updated := ing.DeepCopy()
+	updated.Annotations[annotations.AlbCanaryWeight] = strconv.Itoa(weight)
+	if err := a.k8sClient.Patch(ctx, updated, client.MergeFrom(ing)); err != nil {
+		return fmt.Errorf("failed to update canary weight of ingress %s: %s", ing.Name, err.Error())
+	}
+	return nil
+}
+
+// failAnalysis records the failure, the analysis is retried in the next period
+func (a *canaryAnalyzer) failAnalysis(ctx context.Context, canary *v1.AlbCanary, ing *networking.Ingress, message string) error {
+	a.recordEvent(canary, ing, corev1.EventTypeWarning, helper.CanaryAnalysisFailed, message)
+	return a.updateStatus(ctx, canary, func(status *v1.AlbCanaryStatus) {
+		status.Message = message
+	})
+}
+
+func (a *canaryAnalyzer) updateStatus(ctx context.Context, canary *v1.AlbCanary, update func(status *v1.AlbCanaryStatus)) error {
+	updated := canary.DeepCopy()
+	update(&updated.Status)
+	return a.k8sClient.Status().Patch(ctx, updated, client.MergeFrom(canary))
+}
+
+func (a *canaryAnalyzer) recordEvent(canary *v1.AlbCanary, ing *networking.Ingress, eventType, reason, message string) {
+	a.eventRecorder.Event(canary, eventType, reason, message)
+	if ing != nil {
+		a.eventRecorder.Event(ing, eventType, reason, message)
+	}
+}
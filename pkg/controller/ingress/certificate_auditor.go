@@ -0,0 +1,210 @@
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	ctrlCfg "k8s.io/alibaba-load-balancer-controller/pkg/config"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/helper"
+	albconfigmanager "k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/builder/albconfig_manager"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model"
+	prvd "k8s.io/alibaba-load-balancer-controller/pkg/provider"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util/metric"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// certificateAuditor periodically checks the certificates applied to the https listeners of AlbConfigs,
+// exports the days left before they expire and records events for the certificates need attention
+type certificateAuditor struct {
+	cloud         prvd.Provider
+	k8sClient     client.Client
+	recon         *albconfigReconciler
+	eventRecorder record.EventRecorder
+	logger        logr.Logger
+	period        time.Duration
+	threshold     time.Duration
+}
+
+func newCertificateAuditor(r *albconfigReconciler) *certificateAuditor {
+	return &certificateAuditor{
+		cloud:         r.cloud,
+		k8sClient:     r.k8sClient,
+		recon:         r,
+		eventRecorder: r.eventRecorder,
+		logger:        r.logger.WithName("certificate-auditor"),
+		period:        ctrlCfg.ControllerCFG.CertificateAuditPeriod,
+		threshold:     ctrlCfg.ControllerCFG.CertificateExpiryThreshold,
+	}
+}
+
+// Start implements manager.Runnable
+func (a *certificateAuditor) Start(ctx context.Context) error {
+	if _, err := a.recon.store.WaitCache(a.recon.stopCh); err != nil {
+		return err
+	}
+	wait.UntilWithContext(ctx, a.audit, a.period)
+	return nil
+}
+
+func (a *certificateAuditor) audit(ctx context.Context) {
+	albconfigs := &v1.AlbConfigList{}
+	if err := a.k8sClient.List(ctx, albconfigs); err != nil {
+		a.logger.Error(err, "failed to list albconfigs")
+		return
+	}
+	certs, err := a.cloud.DescribeSSLCertificateList(ctx)
+	if err != nil {
+		a.logger.Error(err, "failed to describe ssl certificates")
+		return
+	}
+	// share the fresh certificate list with the certificate discovery
+	a.recon.certInventory.Update(certs)
+	certMap := make(map[string]model.CertificateInfo, len(certs))
+	for _, cert := range certs {
+		certMap[cert.CertIdentifier] = cert
+	}
+
+	metric.ALBCertificateExpireDays.Reset()
+	now := time.Now()
+	for i := range albconfigs.Items {
+		albconfig := &albconfigs.Items[i]
+		if !albconfig.DeletionTimestamp.IsZero() {
+			continue
+		}
+		a.auditAlbConfig(ctx, albconfig, certMap, now)
+	}
+}
+
+func (a *certificateAuditor) auditAlbConfig(ctx context.Context, albconfig *v1.AlbConfig, certMap map[string]model.CertificateInfo, now time.Time) {
+	if len(albconfig.Status.LoadBalancer.Listeners) == 0 {
+		return
+	}
+	groupID := albconfigmanager.GroupID(types.NamespacedName{Namespace: albconfig.Namespace, Name: albconfig.Name})
+	ingGroup, err, _ := a.recon.groupLoader.Load(ctx, groupID, a.recon.store.ListIngresses())
+	if err != nil {
+		a.logger.Error(err, "failed to load ingress group", "albconfig", albconfig.Name)
+		return
+	}
+
+	for _, ls := range albconfig.Status.LoadBalancer.Listeners {
+		hostIngresses := listenerHostIngresses(ls.PortAndProtocol, ingGroup.Members)
+		var applied []model.CertificateInfo
+		missing := false
+		for _, appliedCert := range ls.Certificates {
+			cert, ok := certMap[appliedCert.CertificateId]
+			if !ok {
+				missing = true
+				a.logger.Info("applied certificate not found", "albconfig", albconfig.Name,
+					"listener", ls.PortAndProtocol, "certificateId", appliedCert.CertificateId)
+				a.recordEvent(albconfig, ingGroup.Members, helper.CertificateNotFound,
+					fmt.Sprintf("Certificate %s of listener %s is not found", appliedCert.CertificateId, ls.PortAndProtocol))
+				continue
+			}
+			applied = append(applied, cert)
+			days := helper.CertificateDaysToExpiry(cert, now)
+			covered := false
+			for host, ings := range hostIngresses {
+				if !helper.CertificateCoversHost(cert, host) {
+					continue
+				}
+				covered = true
+				metric.ALBCertificateExpireDays.WithLabelValues(albconfig.Name, ls.PortAndProtocol, host, appliedCert.CertificateId).Set(days)
+				a.recordExpiry(albconfig, ings, ls.PortAndProtocol, host, appliedCert.CertificateId, cert, now)
+			}
+			if !covered {
+				metric.ALBCertificateExpireDays.WithLabelValues(albconfig.Name, ls.PortAndProtocol, "", appliedCert.CertificateId).Set(days)
+				a.recordExpiry(albconfig, nil, ls.PortAndProtocol, "", appliedCert.CertificateId, cert, now)
+			}
+		}
+
+		// the hosts may be covered by the missing certificate, which is reported already
+		if missing {
+			continue
+		}
+		for host, ings := range hostIngresses {
+			if certsCoverHost(applied, host) {
+				continue
+			}
+			a.recordEvent(albconfig, ings, helper.CertificateHostMismatch,
+				fmt.Sprintf("No certificate of listener %s covers host %s", ls.PortAndProtocol, host))
+		}
+	}
+}
+
+func (a *certificateAuditor) recordExpiry(albconfig *v1.AlbConfig, ings []*networking.Ingress, listener, host, certId string, cert model.CertificateInfo, now time.Time) {
+	notAfter := helper.CertificateNotAfter(cert)
+	target := listener
+	if host != "" {
+		target = fmt.Sprintf("%s host %s", listener, host)
+	}
+	if notAfter.Before(now) {
+		a.recordEvent(albconfig, ings, helper.CertificateExpired,
+			fmt.Sprintf("Certificate %s of listener %s expired at %s", certId, target, notAfter.Format(time.RFC3339)))
+		return
+	}
+	if notAfter.Before(now.Add(a.threshold)) {
+		a.recordEvent(albconfig, ings, helper.CertificateExpiring,
+			fmt.Sprintf("Certificate %s of listener %s expires at %s", certId, target, notAfter.Format(time.RFC3339)))
+	}
+}
+
+func (a *certificateAuditor) recordEvent(albconfig *v1.AlbConfig, ings []*networking.Ingress, reason, message string) {
+	a.eventRecorder.Event(albconfig, corev1.EventTypeWarning, reason, message)
+	for _, ing := range ings {
+		a.eventRecorder.Event(ing, corev1.EventTypeWarning, reason, message)
+	}
+}
+
+// listenerHostIngresses returns the hosts served by the listener and the ingresses they come from
+func listenerHostIngresses(portAndProtocol string, ings []*networking.Ingress) map[string][]*networking.Ingress {
+	hostIngresses := make(map[string][]*networking.Ingress)
+	for _, ing := range ings {
+		pps, err := albconfigmanager.ComputeIngressListenPorts(ing)
+		if err != nil {
+			continue
+		}
+		listen := false
+		for _, pp := range pps {
+			if fmt.Sprintf("%d/%s", pp.Port, pp.Protocol) == portAndProtocol {
+				listen = true
+				break
+			}
+		}
+		if !listen {
+			continue
+		}
+		var hosts []string
+		for _, tls := range ing.Spec.TLS {
+			hosts = append(hosts, tls.Hosts...)
+		}
+		for _, rule := range ing.Spec.Rules {
+			hosts = append(hosts, rule.Host)
+		}
+		sort.Strings(hosts)
+		for i, host := range hosts {
+			if host == "" || (i > 0 && hosts[i-1] == host) {
+				continue
+			}
+			hostIngresses[host] = append(hostIngresses[host], ing)
+		}
+	}
+	return hostIngresses
+}
+
+func certsCoverHost(certs []model.CertificateInfo, host string) bool {
+	for _, cert := range certs {
+		if helper.CertificateCoversHost(cert, host) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,11 +1,20 @@
 package ingress
 
 import (
+	"context"
+
 	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
 	ctrlCfg "k8s.io/alibaba-load-balancer-controller/pkg/config"
 	"k8s.io/alibaba-load-balancer-controller/pkg/controller/helper"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/annotations"
+	albconfigmanager "k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/builder/albconfig_manager"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/store"
 	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -81,3 +90,293 @@ func (h *enqueueRequestsForAlbconfigEvent) enqueueAlbconfig(queue workqueue.Rate
 		NamespacedName: util.NamespacedName(albconfig),
 	})
 }
+
+// NewEnqueueRequestsForCaBundleEvent enqueue the AlbConfigs whose listeners reference the Secret or ConfigMap as caBundle
+func NewEnqueueRequestsForCaBundleEvent(k8sClient client.Client, logger logr.Logger) *enqueueRequestsForCaBundleEvent {
+	return &enqueueRequestsForCaBundleEvent{
+		k8sClient: k8sClient,
+		logger:    logger,
+	}
+}
+
+var _ handler.EventHandler = (*enqueueRequestsForCaBundleEvent)(nil)
+
+type enqueueRequestsForCaBundleEvent struct {
+	k8sClient client.Client
+	logger    logr.Logger
+}
+
+func (h *enqueueRequestsForCaBundleEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueReferencingAlbconfigs(queue, e.Object)
+}
+
+func (h *enqueueRequestsForCaBundleEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	switch objNew := e.ObjectNew.(type) {
+	case *corev1.Secret:
+		objOld, ok := e.ObjectOld.(*corev1.Secret)
+		if ok && equality.Semantic.DeepEqual(objOld.Data, objNew.Data) {
+			return
+		}
+	case *corev1.ConfigMap:
+		objOld, ok := e.ObjectOld.(*corev1.ConfigMap)
+		if ok && equality.Semantic.DeepEqual(objOld.Data, objNew.Data) {
+			return
+		}
+	}
+	h.enqueueReferencingAlbconfigs(queue, e.ObjectNew)
+}
+
+func (h *enqueueRequestsForCaBundleEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+}
+
+func (h *enqueueRequestsForCaBundleEvent) Generic(e event.GenericEvent, queue workqueue.RateLimitingInterface) {
+}
+
+func (h *enqueueRequestsForCaBundleEvent) enqueueReferencingAlbconfigs(queue workqueue.RateLimitingInterface, obj client.Object) {
+	_, isSecret := obj.(*corev1.Secret)
+	_, isConfigMap := obj.(*corev1.ConfigMap)
+	if !isSecret && !isConfigMap {
+		return
+	}
+	albconfigs := &v1.AlbConfigList{}
+	if err := h.k8sClient.List(context.TODO(), albconfigs); err != nil {
+		h.logger.Error(err, "failed to list albconfigs", "caBundle", util.NamespacedName(obj).String())
+		return
+	}
+	for i := range albconfigs.Items {
+		albconfig := &albconfigs.Items[i]
+		for _, ls := range albconfig.Spec.Listeners {
+			if ls == nil || ls.CaBundle == nil || ls.CaBundle.Namespace != obj.GetNamespace() {
+				continue
+			}
+			if (isSecret && ls.CaBundle.SecretName == obj.GetName()) ||
+				(isConfigMap && ls.CaBundle.ConfigMapName == obj.GetName()) {
+				h.logger.Info("controller: caBundle change event",
+					"caBundle", util.NamespacedName(obj).String(),
+					"albconfig", util.NamespacedName(albconfig).String())
+				queue.Add(reconcile.Request{
+					NamespacedName: util.NamespacedName(albconfig),
+				})
+				break
+			}
+		}
+	}
+}
+
+// NewEnqueueRequestsForAccessControlListEvent enqueue the AlbConfigs whose listeners reference the AccessControlList,
+// when the id of its acl is changed
+func NewEnqueueRequestsForAccessControlListEvent(k8sClient client.Client, logger logr.Logger) *enqueueRequestsForAccessControlListEvent {
+	return &enqueueRequestsForAccessControlListEvent{
+		k8sClient: k8sClient,
+		logger:    logger,
+	}
+}
+
+var _ handler.EventHandler = (*enqueueRequestsForAccessControlListEvent)(nil)
+
+type enqueueRequestsForAccessControlListEvent struct {
+	k8sClient client.Client
+	logger    logr.Logger
+}
+
+func (h *enqueueRequestsForAccessControlListEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueReferencingAlbconfigs(queue, e.Object)
+}
+
+func (h *enqueueRequestsForAccessControlListEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	aclOld, ok1 := e.ObjectOld.(*v1.AccessControlList)
+	aclNew, ok2 := e.ObjectNew.(*v1.AccessControlList)
+	if ok1 && ok2 && aclOld.Status.AclID == aclNew.Status.AclID {
+		return
+	}
+	h.enqueueReferencingAlbconfigs(queue, e.ObjectNew)
+}
+
+func (h *enqueueRequestsForAccessControlListEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+}
+
+func (h *enqueueRequestsForAccessControlListEvent) Generic(e event.GenericEvent, queue workqueue.RateLimitingInterface) {
+}
+
+func (h *enqueueRequestsForAccessControlListEvent) enqueueReferencingAlbconfigs(queue workqueue.RateLimitingInterface, obj client.Object) {
+	albconfigs := &v1.AlbConfigList{}
+	if err := h.k8sClient.List(context.TODO(), albconfigs); err != nil {
+		h.logger.Error(err, "failed to list albconfigs", "accesscontrollist", obj.GetName())
+		return
+	}
+	for i := range albconfigs.Items {
+		albconfig := &albconfigs.Items[i]
+		for _, ls := range albconfig.Spec.Listeners {
+			if ls != nil && sets.NewString(ls.AclConfig.AccessControlLists...).Has(obj.GetName()) {
+				h.logger.Info("controller: accesscontrollist change event",
+					"accesscontrollist", obj.GetName(),
+					"albconfig", util.NamespacedName(albconfig).String())
+				queue.Add(reconcile.Request{
+					NamespacedName: util.NamespacedName(albconfig),
+				})
+				break
+			}
+		}
+	}
+}
+
+// NewEnqueueRequestsForServerGroupPolicyEvent enqueue the AlbConfigs of the Ingresses referencing the ServerGroupPolicy,
+// by the annotation of the Ingress or of the Services of its backends
+func NewEnqueueRequestsForServerGroupPolicyEvent(k8sClient client.Client, ingStore store.Storer,
+	groupLoader albconfigmanager.GroupLoader, logger logr.Logger) *enqueueRequestsForServerGroupPolicyEvent {
+	return &enqueueRequestsForServerGroupPolicyEvent{
+		k8sClient:   k8sClient,
+		store:       ingStore,
+		groupLoader: groupLoader,
+		logger:      logger,
+	}
+}
+
+var _ handler.EventHandler = (*enqueueRequestsForServerGroupPolicyEvent)(nil)
+
+type enqueueRequestsForServerGroupPolicyEvent struct {
+	k8sClient   client.Client
+	store       store.Storer
+	groupLoader albconfigmanager.GroupLoader
+	logger      logr.Logger
+}
+
+func (h *enqueueRequestsForServerGroupPolicyEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueReferencingAlbconfigs(queue, e.Object)
+}
+
+func (h *enqueueRequestsForServerGroupPolicyEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	policyOld, ok1 := e.ObjectOld.(*v1.ServerGroupPolicy)
+	policyNew, ok2 := e.ObjectNew.(*v1.ServerGroupPolicy)
+	if ok1 && ok2 && equality.Semantic.DeepEqual(policyOld.Spec, policyNew.Spec) {
+		return
+	}
+	h.enqueueReferencingAlbconfigs(queue, e.ObjectNew)
+}
+
+func (h *enqueueRequestsForServerGroupPolicyEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+}
+
+func (h *enqueueRequestsForServerGroupPolicyEvent) Generic(e event.GenericEvent, queue workqueue.RateLimitingInterface) {
+}
+
+func (h *enqueueRequestsForServerGroupPolicyEvent) enqueueReferencingAlbconfigs(queue workqueue.RateLimitingInterface, obj client.Object) {
+	policy, ok := obj.(*v1.ServerGroupPolicy)
+	if !ok {
+		return
+	}
+	svcs := &corev1.ServiceList{}
+	if err := h.k8sClient.List(context.TODO(), svcs, client.InNamespace(policy.Namespace)); err != nil {
+		h.logger.Error(err, "failed to list services", "servergrouppolicy", util.Key(policy))
+		return
+	}
+	referencingSvcs := sets.NewString()
+	for i := range svcs.Items {
+		if helper.IsServiceReferencingServerGroupPolicy(&svcs.Items[i], policy) {
+			referencingSvcs.Insert(svcs.Items[i].Name)
+		}
+	}
+
+	groupIDs := make(map[albconfigmanager.GroupID]bool)
+	for _, ing := range h.store.ListIngresses() {
+		if ing.Namespace != policy.Namespace || !isIngressReferencingServerGroupPolicy(&ing.Ingress, policy.Name, referencingSvcs) {
+			continue
+		}
+		groupID, err := h.groupLoader.LoadGroupID(context.TODO(), &ing.Ingress)
+		if err != nil {
+			h.logger.Error(err, "failed to load albconfig of ingress", "ingress", util.Key(ing))
+			continue
+		}
+		if groupIDs[*groupID] {
+			continue
+		}
+		groupIDs[*groupID] = true
+		h.logger.Info("controller: servergrouppolicy change event",
+			"servergrouppolicy", util.Key(policy), "albconfig", types.NamespacedName(*groupID).String())
+		queue.Add(reconcile.Request{
+			NamespacedName: types.NamespacedName(*groupID),
+		})
+	}
+}
+
+// isIngressReferencingServerGroupPolicy returns true if the ingress references the policy,
+// or does not reference a policy and one of its backend services references the policy
+func isIngressReferencingServerGroupPolicy(ing *networking.Ingress, policyName string, referencingSvcs sets.String) bool {
+	if name, ok := ing.Annotations[annotations.ServerGroupPolicy]; ok && name != "" {
+		return name == policyName
+	}
+	if ing.Spec.DefaultBackend != nil && ing.Spec.DefaultBackend.Service != nil &&
+		referencingSvcs.Has(ing.Spec.DefaultBackend.Service.Name) {
+		return true
+	}
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service != nil && referencingSvcs.Has(path.Backend.Service.Name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NewEnqueueRequestsForAlbRouteEvent enqueue the AlbConfig referenced by the AlbRoute
+func NewEnqueueRequestsForAlbRouteEvent(logger logr.Logger) *enqueueRequestsForAlbRouteEvent {
+	return &enqueueRequestsForAlbRouteEvent{
+		logger: logger,
+	}
+}
+
+var _ handler.EventHandler = (*enqueueRequestsForAlbRouteEvent)(nil)
+
+type enqueueRequestsForAlbRouteEvent struct {
+	logger logr.Logger
+}
+
+func (h *enqueueRequestsForAlbRouteEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+	route, ok := e.Object.(*v1.AlbRoute)
+	if ok {
+		h.logger.Info("controller: albroute Create event", "albroute", util.NamespacedName(route).String())
+		h.enqueueParentAlbconfig(queue, route)
+	}
+}
+
+func (h *enqueueRequestsForAlbRouteEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	routeOld, okOld := e.ObjectOld.(*v1.AlbRoute)
+	routeNew, okNew := e.ObjectNew.(*v1.AlbRoute)
+	if !okOld || !okNew {
+		return
+	}
+	if equality.Semantic.DeepEqual(routeOld.Spec, routeNew.Spec) &&
+		equality.Semantic.DeepEqual(routeOld.DeletionTimestamp.IsZero(), routeNew.DeletionTimestamp.IsZero()) &&
+		routeOld.Annotations[annotations.Order] == routeNew.Annotations[annotations.Order] &&
+		routeOld.Annotations[util.IngressSuffixAlbConfigOrder] == routeNew.Annotations[util.IngressSuffixAlbConfigOrder] {
+		return
+	}
+	h.logger.Info("controller: albroute Update event", "albroute", util.NamespacedName(routeNew).String())
+	if routeOld.Spec.ParentRef.AlbConfigName != routeNew.Spec.ParentRef.AlbConfigName {
+		h.enqueueParentAlbconfig(queue, routeOld)
+	}
+	h.enqueueParentAlbconfig(queue, routeNew)
+}
+
+func (h *enqueueRequestsForAlbRouteEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+	route, ok := e.Object.(*v1.AlbRoute)
+	if ok {
+		h.logger.Info("controller: albroute Delete event", "albroute", util.NamespacedName(route).String())
+		h.enqueueParentAlbconfig(queue, route)
+	}
+}
+
+func (h *enqueueRequestsForAlbRouteEvent) Generic(e event.GenericEvent, queue workqueue.RateLimitingInterface) {
+}
+
+func (h *enqueueRequestsForAlbRouteEvent) enqueueParentAlbconfig(queue workqueue.RateLimitingInterface, route *v1.AlbRoute) {
+	queue.Add(reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name: route.Spec.ParentRef.AlbConfigName,
+		},
+	})
+}
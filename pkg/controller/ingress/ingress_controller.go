@@ -2,11 +2,11 @@ package ingress
 
 import (
 	"context"
-	"time"
 
-	"golang.org/x/time/rate"
 	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
-	"k8s.io/client-go/util/workqueue"
+	ctrlCfg "k8s.io/alibaba-load-balancer-controller/pkg/config"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/helper"
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -33,11 +33,7 @@ func (ingC ingressController) Start(ctx context.Context) error {
 }
 
 func Add(mgr manager.Manager, ctx *shared.SharedContext) error {
-	rateLimit := workqueue.NewMaxOfRateLimiter(
-		workqueue.NewItemExponentialFailureRateLimiter(5*time.Second, 300*time.Second),
-		// 10 qps, 100 bucket size.  This is only for retry speed and its only the overall factor (not per item)
-		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
-	)
+	rateLimit := helper.NewControllerRateLimiter(ctrlCfg.ControllerCFG.ReconcileConfig)
 	r, err := NewAlbConfigReconciler(mgr, ctx)
 	if err != nil {
 		return err
@@ -65,6 +61,36 @@ func Add(mgr manager.Manager, ctx *shared.SharedContext) error {
 		return err
 	}
 
+	if err := c.Watch(&source.Kind{Type: &v1.AlbRoute{}}, NewEnqueueRequestsForAlbRouteEvent(r.logger)); err != nil {
+		return err
+	}
+
+	caBundleEventHandler := NewEnqueueRequestsForCaBundleEvent(r.k8sClient, r.logger)
+	if err := c.Watch(&source.Kind{Type: &corev1.Secret{}}, caBundleEventHandler); err != nil {
+		return err
+	}
+	if err := c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, caBundleEventHandler); err != nil {
+		return err
+	}
+
+	if err := c.Watch(&source.Kind{Type: &v1.ServerGroupPolicy{}},
+		NewEnqueueRequestsForServerGroupPolicyEvent(r.k8sClient, r.store, r.groupLoader, r.logger)); err != nil {
+		return err
+	}
+
+	if err := c.Watch(&source.Kind{Type: &v1.AccessControlList{}},
+		NewEnqueueRequestsForAccessControlListEvent(r.k8sClient, r.logger)); err != nil {
+		return err
+	}
+
+	if err := mgr.Add(newCertificateAuditor(r)); err != nil {
+		return err
+	}
+
+	if err := mgr.Add(newCanaryAnalyzer(r)); err != nil {
+		return err
+	}
+
 	klog.Infof("Add start")
 	return mgr.Add(&ingressController{c: c, recon: r})
 }
@@ -47,6 +47,8 @@ const (
 	HealthThreshold        = AnnotationLoadBalancerPrefix + "healthy-threshold-count"      // HealthCheckDomain health check domain
 	UnHealthThreshold      = AnnotationLoadBalancerPrefix + "unhealthy-threshold-count"    // HealthCheckHTTPCode health check http code
 	HealthCheckHTTPCode    = AnnotationLoadBalancerPrefix + "healthcheck-httpcode"
+	HealthCheckFromProbe   = AnnotationLoadBalancerPrefix + "healthcheck-from-readiness-probe" // HealthCheckFromProbe derive health check from the readinessProbe of pods
+	ServerGroupPolicy      = AnnotationLoadBalancerPrefix + "server-group-policy"              // ServerGroupPolicy the ServerGroupPolicy of the server groups
 	Order                  = AnnotationLoadBalancerPrefix + "order"
 	// VServerBackend Attribute
 	BackendLabel      = AnnotationLoadBalancerPrefix + "backend-label"              // BackendLabel backend labels
@@ -92,6 +94,8 @@ const (
 	AlbBackendKeepalive         = AnnotationAlbPrefix + "backend-keepalive"
 
 	AlbServerGroupId = AnnotationAlbPrefix + "server-group-id"
+	// AlbAdoptServerGroups maps "{svcName}:{port}" to the existing server groups to adopt instead of creating
+	AlbAdoptServerGroups = AnnotationAlbPrefix + "adopt-server-groups"
 )
 
 type ParseOptions struct {
@@ -132,11 +132,15 @@ func (s *albLoadBalancerApplier) CreateALB(ctx context.Context, resLB *albmodel.
 
 func (s *albLoadBalancerApplier) DeleteALB(ctx context.Context, lbID string) error {
 	var err error
-	var isReuseLb bool
+	var isReuseLb, isRetainLb bool
 	if v, ok := ctx.Value(util.IsReuseLb).(bool); ok {
 		isReuseLb = v
 	}
-	if isReuseLb {
+	if v, ok := ctx.Value(util.IsRetainLb).(bool); ok {
+		isRetainLb = v
+	}
+	// reused and retained loadBalancers are released by removing the tracking tags
+	if isReuseLb || isRetainLb {
 		err = s.albProvider.UnReuseALB(ctx, lbID, s.trackingProvider)
 	} else {
 		err = s.albProvider.DeleteALB(ctx, lbID)
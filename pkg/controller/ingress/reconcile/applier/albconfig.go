@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
 	"k8s.io/alibaba-load-balancer-controller/pkg/util"
 
 	"k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/tracking"
@@ -75,6 +76,15 @@ func (m *defaultAlbConfigManagerApplier) Apply(ctx context.Context, stack core.M
 	if isReuseLb && len(resLBs) == 1 && resLBs[0].Spec.ListenerForceOverride != nil && !*resLBs[0].Spec.ListenerForceOverride {
 		listenerCommonReuse = true
 	}
+	// the loadBalancer, listeners and rules of member clusters are managed by the primary cluster
+	multiCluster, _ := ctx.Value(util.MultiClusterConfig).(*v1.MultiClusterConfig)
+	if multiCluster != nil && multiCluster.Role == v1.MultiClusterRoleMember {
+		sgpApplier := NewServerGroupApplier(m.kubeClient, m.backendManager, m.albProvider, m.trackingProvider, stack, m.logger)
+		if err := sgpApplier.Apply(ctx); err != nil {
+			return err
+		}
+		return sgpApplier.PostApply(ctx)
+	}
 	// loadbalaner and servergroup apply if delete albconfig
 	if len(resLBs) == 0 {
 		var err error
@@ -101,8 +111,11 @@ func (m *defaultAlbConfigManagerApplier) Apply(ctx context.Context, stack core.M
 		NewAlbLoadBalancerApplier(m.albProvider, m.trackingProvider, stack, m.logger, commonReuse),
 		NewListenerApplier(m.albProvider, stack, m.logger, commonReuse, errRes, listenerCommonReuse),
 		NewAclApplier(m.albProvider, m.trackingProvider, stack, m.logger, errRes),
-		NewListenerRuleApplier(m.albProvider, stack, m.logger, errRes),
 	}
+	if multiCluster != nil {
+		appliers = append(appliers, NewMultiClusterApplier(m.albProvider, m.trackingProvider, stack, m.logger, multiCluster))
+	}
+	appliers = append(appliers, NewListenerRuleApplier(m.albProvider, stack, m.logger, errRes))
 
 	for _, applier := range appliers {
 		if err := applier.Apply(ctx); err != nil {
@@ -0,0 +1,153 @@
+package applier
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/tracking"
+	albmodel "k8s.io/alibaba-load-balancer-controller/pkg/model/alb"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model/alb/core"
+	prvd "k8s.io/alibaba-load-balancer-controller/pkg/provider"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+)
+
+// maxServerGroupWeight is the weight of the only server group of a forward action without weights
+const maxServerGroupWeight = 100
+
+func NewMultiClusterApplier(albProvider prvd.Provider, trackingProvider tracking.TrackingProvider, stack core.Manager, logger logr.Logger, config *v1.MultiClusterConfig) *multiClusterApplier {
+	return &multiClusterApplier{
+		albProvider:      albProvider,
+		trackingProvider: trackingProvider,
+		stack:            stack,
+		logger:           logger,
+		config:           config,
+	}
+}
+
+// multiClusterApplier spreads the forward actions of the rules of the primary cluster across the server
+// groups the member clusters build for the same resources, by the weights of the clusters
+type multiClusterApplier struct {
+	albProvider      prvd.Provider
+	trackingProvider tracking.TrackingProvider
+	stack            core.Manager
+	logger           logr.Logger
+	config           *v1.MultiClusterConfig
+}
+
+func (s *multiClusterApplier) Apply(ctx context.Context) error {
+	stackTags := s.trackingProvider.StackTags(s.stack)
+	clusterID := stackTags[s.trackingProvider.ClusterNameTagKey()]
+
+	// server groups of the clusters by the resource IDs
+	var resSGPs []*albmodel.ServerGroup
+	_ = s.stack.ListResources(&resSGPs)
+	resIDsBySGPID := make(map[string]string, len(resSGPs))
+	for _, sgp := range resSGPs {
+		if sgp.Status != nil {
+			resIDsBySGPID[sgp.Status.ServerGroupID] = sgp.ID()
+		}
+	}
+	ownWeight := maxServerGroupWeight
+	peers := make([]clusterServerGroups, 0, len(s.config.Clusters))
+	for _, cluster := range s.config.Clusters {
+		if cluster.ClusterID == clusterID {
+			ownWeight = cluster.Weight
+			continue
+		}
+		// a cluster without weight receives no traffic, its server groups are left out of the rules
+		if cluster.Weight == 0 {
+			continue
+		}
+		sdkSGPs, err := s.albProvider.ListALBServerGroupsWithTags(ctx, map[string]string{
+			s.trackingProvider.ClusterNameTagKey(): cluster.ClusterID,
+			s.trackingProvider.AlbConfigTagKey():   stackTags[s.trackingProvider.AlbConfigTagKey()],
+		})
+		if err != nil {
+			return err
+		}
+		peer := clusterServerGroups{weight: cluster.Weight, sgpIDsByResID: make(map[string]string, len(sdkSGPs))}
+		for _, sdkSGP := range sdkSGPs {
+			if resID, ok := sdkSGP.Tags[s.trackingProvider.ResourceIDTagKey()]; ok {
+				peer.sgpIDsByResID[resID] = sdkSGP.ServerGroupId
+			}
+		}
+		peers = append(peers, peer)
+	}
+
+	var resLRs []*albmodel.ListenerRule
+	_ = s.stack.ListResources(&resLRs)
+	for _, lr := range resLRs {
+		for i := range lr.Spec.RuleActions {
+			action := &lr.Spec.RuleActions[i]
+			if action.Type != util.RuleActionTypeForward || action.ForwardConfig == nil {
+				continue
+			}
+			tuples, err := spreadServerGroupTuples(ctx, action.ForwardConfig.ServerGroups, resIDsBySGPID, ownWeight, peers)
+			if err != nil {
+				return err
+			}
+			action.ForwardConfig.ServerGroups = tuples
+		}
+	}
+	s.logger.V(util.SynLogLevel).Info("spread rules across clusters",
+		"clusters", len(peers)+1,
+		"traceID", ctx.Value(util.TraceID))
+	return nil
+}
+
+func (s *multiClusterApplier) PostApply(ctx context.Context) error {
+	return nil
+}
+
+// clusterServerGroups is the weight and the server groups of a member cluster
+type clusterServerGroups struct {
+	weight        int
+	sgpIDsByResID map[string]string
+}
+
+// spreadServerGroupTuples adds the server groups of the peers built for the same resources as the tuples,
+// with the weights of the tuples shared by the weights of the clusters. A tuple is kept as it is if no peer
+// has its server group.
+func spreadServerGroupTuples(ctx context.Context, tuples []albmodel.ServerGroupTuple, resIDsBySGPID map[string]string,
+	ownWeight int, peers []clusterServerGroups) ([]albmodel.ServerGroupTuple, error) {
+	spread := make([]albmodel.ServerGroupTuple, 0, len(tuples))
+	for _, tuple := range tuples {
+		sgpID, err := tuple.ServerGroupID.Resolve(ctx)
+		if err != nil {
+			return nil, err
+		}
+		weight := tuple.Weight
+		if weight == 0 && len(tuples) == 1 {
+			weight = maxServerGroupWeight
+		}
+		var peerTuples []albmodel.ServerGroupTuple
+		for _, peer := range peers {
+			if peerSGPID, ok := peer.sgpIDsByResID[resIDsBySGPID[sgpID]]; ok {
+				peerTuple := tuple
+				peerTuple.ServerGroupID = core.LiteralStringToken(peerSGPID)
+				peerTuple.Weight = shareWeight(weight, peer.weight)
+				peerTuples = append(peerTuples, peerTuple)
+			}
+		}
+		if len(peerTuples) == 0 {
+			spread = append(spread, tuple)
+			continue
+		}
+		ownTuple := tuple
+		ownTuple.Weight = shareWeight(weight, ownWeight)
+		spread = append(spread, ownTuple)
+		spread = append(spread, peerTuples...)
+	}
+	return spread, nil
+}
+
+// shareWeight returns the share of the weight of a tuple by the weight of a cluster, which is at least 1
+// if neither weight is 0, so that a cluster with weight never loses the traffic by rounding
+func shareWeight(weight, clusterWeight int) int {
+	shared := weight * clusterWeight / maxServerGroupWeight
+	if shared == 0 && weight > 0 && clusterWeight > 0 {
+		return 1
+	}
+	return shared
+}
@@ -0,0 +1,93 @@
+package applier
+
+import (
+	"context"
+	"testing"
+
+	albsdk "github.com/aliyun/alibaba-cloud-sdk-go/services/alb"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/tracking"
+	albmodel "k8s.io/alibaba-load-balancer-controller/pkg/model/alb"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model/alb/core"
+	prvd "k8s.io/alibaba-load-balancer-controller/pkg/provider"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+)
+
+type fakeMultiClusterProvider struct {
+	prvd.Provider
+	trackingProvider tracking.TrackingProvider
+	// sgpIDsByCluster are the server groups of the clusters by the resource IDs
+	sgpIDsByCluster map[string]map[string]string
+}
+
+func (p *fakeMultiClusterProvider) ListALBServerGroupsWithTags(_ context.Context, tagFilters map[string]string) ([]albmodel.ServerGroupWithTags, error) {
+	var sgps []albmodel.ServerGroupWithTags
+	for resID, sgpID := range p.sgpIDsByCluster[tagFilters[p.trackingProvider.ClusterNameTagKey()]] {
+		sgps = append(sgps, albmodel.ServerGroupWithTags{
+			ServerGroup: albsdk.ServerGroup{ServerGroupId: sgpID},
+			Tags:        map[string]string{p.trackingProvider.ResourceIDTagKey(): resID},
+		})
+	}
+	return sgps, nil
+}
+
+func TestMultiClusterApply(t *testing.T) {
+	stack := core.NewDefaultManager(core.StackID{Name: "alb"})
+	trackingProvider := tracking.NewDefaultProvider("ingress.k8s.alibaba", "old")
+	newSGP := func(id, sgpID string) *albmodel.ServerGroup {
+		sgp := albmodel.NewServerGroup(stack, id, albmodel.ServerGroupSpec{})
+		sgp.SetStatus(albmodel.ServerGroupStatus{ServerGroupID: sgpID})
+		return sgp
+	}
+	api, web, canary := newSGP("api", "sgp-old-api"), newSGP("web", "sgp-old-web"), newSGP("canary", "sgp-old-canary")
+	newRule := func(id string, tuples ...albmodel.ServerGroupTuple) *albmodel.ListenerRule {
+		spec := albmodel.ListenerRuleSpec{ListenerID: core.LiteralStringToken("lsn-80")}
+		spec.RuleActions = []albmodel.Action{{Type: util.RuleActionTypeForward, ForwardConfig: &albmodel.ForwardActionConfig{ServerGroups: tuples}}}
+		return albmodel.NewListenerRule(stack, id, spec)
+	}
+	apiRule := newRule("api", albmodel.ServerGroupTuple{ServerGroupID: api.ServerGroupID()})
+	webRule := newRule("web", albmodel.ServerGroupTuple{ServerGroupID: web.ServerGroupID(), Weight: 80},
+		albmodel.ServerGroupTuple{ServerGroupID: canary.ServerGroupID(), Weight: 20})
+
+	provider := &fakeMultiClusterProvider{
+		trackingProvider: trackingProvider,
+		sgpIDsByCluster: map[string]map[string]string{
+			"new": {"api": "sgp-new-api", "web": "sgp-new-web"},
+		},
+	}
+	applier := NewMultiClusterApplier(provider, trackingProvider, stack, logr.Discard(), &v1.MultiClusterConfig{
+		Role:     v1.MultiClusterRolePrimary,
+		Clusters: []v1.ClusterWeight{{ClusterID: "old", Weight: 70}, {ClusterID: "new", Weight: 30}},
+	})
+	assert.NoError(t, applier.Apply(context.TODO()))
+
+	resolve := func(rule *albmodel.ListenerRule) map[string]int {
+		weights := make(map[string]int)
+		for _, tuple := range rule.Spec.RuleActions[0].ForwardConfig.ServerGroups {
+			sgpID, err := tuple.ServerGroupID.Resolve(context.TODO())
+			assert.NoError(t, err)
+			weights[sgpID] = tuple.Weight
+		}
+		return weights
+	}
+	assert.Equal(t, map[string]int{"sgp-old-api": 70, "sgp-new-api": 30}, resolve(apiRule))
+	// the canary server group isn't built by the new cluster yet, so it keeps its weight
+	assert.Equal(t, map[string]int{"sgp-old-web": 56, "sgp-new-web": 24, "sgp-old-canary": 20}, resolve(webRule))
+
+	// a cluster without weight is left out, and a small share never rounds to 0
+	stack = core.NewDefaultManager(core.StackID{Name: "alb"})
+	api, web = newSGP("api", "sgp-old-api"), newSGP("web", "sgp-old-web")
+	apiRule = newRule("api", albmodel.ServerGroupTuple{ServerGroupID: api.ServerGroupID()})
+	webRule = newRule("web", albmodel.ServerGroupTuple{ServerGroupID: web.ServerGroupID(), Weight: 2},
+		albmodel.ServerGroupTuple{ServerGroupID: canary.ServerGroupID(), Weight: 98})
+	provider.sgpIDsByCluster["drained"] = map[string]string{"api": "sgp-drained-api", "web": "sgp-drained-web"}
+	applier = NewMultiClusterApplier(provider, trackingProvider, stack, logr.Discard(), &v1.MultiClusterConfig{
+		Role:     v1.MultiClusterRolePrimary,
+		Clusters: []v1.ClusterWeight{{ClusterID: "old", Weight: 99}, {ClusterID: "new", Weight: 1}, {ClusterID: "drained", Weight: 0}},
+	})
+	assert.NoError(t, applier.Apply(context.TODO()))
+	assert.Equal(t, map[string]int{"sgp-old-api": 99, "sgp-new-api": 1}, resolve(apiRule))
+	assert.Equal(t, map[string]int{"sgp-old-web": 1, "sgp-new-web": 1, "sgp-old-canary": 98}, resolve(webRule))
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"sort"
-	"strconv"
 	"sync"
 
 	"k8s.io/alibaba-load-balancer-controller/pkg/util"
@@ -106,7 +105,10 @@ func (s *listenerRuleApplier) applyListenerRulesOnListenerBatch(ctx context.Cont
 		return err
 	}
 
-	matchedResAndSDKLRs, unmatchedResLRs, unmatchedSDKLRs := matchResAndSDKListenerRules(resLRs, sdkLRs)
+	matchedResAndSDKLRs, unmatchedResLRs, unmatchedSDKLRs, err := matchResAndSDKListenerRules(resLRs, sdkLRs)
+	if err != nil {
+		return err
+	}
 
 	if len(matchedResAndSDKLRs) != 0 {
 		s.logger.V(util.SynLogLevel).Info("apply rules batch",
@@ -181,48 +183,93 @@ func (s *listenerRuleApplier) findSDKListenersRulesOnLS(ctx context.Context, lsI
 	return rules, nil
 }
 
-func matchResAndSDKListenerRules(resLRs []*albmodel.ListenerRule, sdkLRs []albsdk.Rule) ([]albmodel.ResAndSDKListenerRulePair, []*albmodel.ListenerRule, []albsdk.Rule) {
+// matchResAndSDKListenerRules pairs desired and live rules by rule name, which is the identity of rule,
+// the rest live rules are updated in place to the rest desired rules of the same direction.
+// Priorities of desired rules are allocated with minimal changes to the live rules.
+func matchResAndSDKListenerRules(resLRs []*albmodel.ListenerRule, sdkLRs []albsdk.Rule) ([]albmodel.ResAndSDKListenerRulePair, []*albmodel.ListenerRule, []albsdk.Rule, error) {
 	var matchedResAndSDKLRs []albmodel.ResAndSDKListenerRulePair
 	var unmatchedResLRs []*albmodel.ListenerRule
 	var unmatchedSDKLRs []albsdk.Rule
 
-	resLRByPriorityDirection := mapResListenerRuleByPriority(resLRs)
-	sdkLRByPriorityDirection := mapSDKListenerRuleByPriority(sdkLRs)
-	resLRPriorityDirections := sets.StringKeySet(resLRByPriorityDirection)
-	sdkLRPriorityDirections := sets.StringKeySet(sdkLRByPriorityDirection)
-	for _, priorityDirection := range resLRPriorityDirections.Intersection(sdkLRPriorityDirections).List() {
-		resLR := resLRByPriorityDirection[priorityDirection]
-		sdkLR := sdkLRByPriorityDirection[priorityDirection]
-		matchedResAndSDKLRs = append(matchedResAndSDKLRs, albmodel.ResAndSDKListenerRulePair{
-			ResLR: resLR,
-			SdkLR: &sdkLR,
-		})
+	// the priorities built are the order of rules
+	resLRs = append([]*albmodel.ListenerRule{}, resLRs...)
+	sort.SliceStable(resLRs, func(i, j int) bool {
+		return resLRs[i].Spec.Priority < resLRs[j].Spec.Priority
+	})
+	sdkLRs = append([]albsdk.Rule{}, sdkLRs...)
+	sort.SliceStable(sdkLRs, func(i, j int) bool {
+		return sdkLRs[i].Priority < sdkLRs[j].Priority
+	})
+
+	pairedSDKLRs := make([]*albsdk.Rule, len(resLRs))
+	used := make([]bool, len(sdkLRs))
+	sdkLRIndexByNameDirection := make(map[string]int)
+	for i, sdkLR := range sdkLRs {
+		key := sdkLR.RuleName + "/" + ruleDirection(sdkLR.Direction)
+		if _, ok := sdkLRIndexByNameDirection[key]; !ok {
+			sdkLRIndexByNameDirection[key] = i
+		}
 	}
-	for _, priorityDirection := range resLRPriorityDirections.Difference(sdkLRPriorityDirections).List() {
-		unmatchedResLRs = append(unmatchedResLRs, resLRByPriorityDirection[priorityDirection])
+	for i, resLR := range resLRs {
+		if j, ok := sdkLRIndexByNameDirection[resLR.Spec.RuleName+"/"+ruleDirection(resLR.Spec.RuleDirection)]; ok && !used[j] {
+			pairedSDKLRs[i] = &sdkLRs[j]
+			used[j] = true
+		}
 	}
-	for _, priorityDirection := range sdkLRPriorityDirections.Difference(resLRPriorityDirections).List() {
-		unmatchedSDKLRs = append(unmatchedSDKLRs, sdkLRByPriorityDirection[priorityDirection])
+	// rules whose identity changed, such as the rules named after priority, are updated in place rather than recreated
+	for i, resLR := range resLRs {
+		if pairedSDKLRs[i] != nil {
+			continue
+		}
+		for j := range sdkLRs {
+			if !used[j] && ruleDirection(sdkLRs[j].Direction) == ruleDirection(resLR.Spec.RuleDirection) {
+				pairedSDKLRs[i] = &sdkLRs[j]
+				used[j] = true
+				break
+			}
+		}
 	}
 
-	return matchedResAndSDKLRs, unmatchedResLRs, unmatchedSDKLRs
-}
+	occupied := sets.NewInt()
+	for _, sdkLR := range sdkLRs {
+		occupied.Insert(sdkLR.Priority)
+	}
+	current := make([]int, len(resLRs))
+	for i := range resLRs {
+		if pairedSDKLRs[i] != nil {
+			current[i] = pairedSDKLRs[i].Priority
+		}
+	}
+	priorities, err := allocateListenerRulePriorities(current, occupied)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
-func mapResListenerRuleByPriority(resLRs []*albmodel.ListenerRule) map[string]*albmodel.ListenerRule {
-	resLRByPriorityDirection := make(map[string]*albmodel.ListenerRule)
-	for _, resLR := range resLRs {
-		resLRByPriorityDirection[strconv.Itoa(resLR.Spec.Priority)+resLR.Spec.RuleDirection] = resLR
+	for i, resLR := range resLRs {
+		resLR.Spec.Priority = priorities[i]
+		if pairedSDKLRs[i] == nil {
+			unmatchedResLRs = append(unmatchedResLRs, resLR)
+			continue
+		}
+		matchedResAndSDKLRs = append(matchedResAndSDKLRs, albmodel.ResAndSDKListenerRulePair{
+			ResLR: resLR,
+			SdkLR: pairedSDKLRs[i],
+		})
 	}
-	return resLRByPriorityDirection
+	for j, sdkLR := range sdkLRs {
+		if !used[j] {
+			unmatchedSDKLRs = append(unmatchedSDKLRs, sdkLR)
+		}
+	}
+
+	return matchedResAndSDKLRs, unmatchedResLRs, unmatchedSDKLRs, nil
 }
 
-func mapSDKListenerRuleByPriority(sdkLRs []albsdk.Rule) map[string]albsdk.Rule {
-	sdkLRByPriorityDirection := make(map[string]albsdk.Rule)
-	for _, sdkLR := range sdkLRs {
-		priority := strconv.Itoa(sdkLR.Priority) + sdkLR.Direction
-		sdkLRByPriorityDirection[priority] = sdkLR
+func ruleDirection(direction string) string {
+	if direction == "" {
+		return util.RuleRequestDirection
 	}
-	return sdkLRByPriorityDirection
+	return direction
 }
 
 func mapResListenerRuleByListenerID(ctx context.Context, resLRs []*albmodel.ListenerRule) (map[string][]*albmodel.ListenerRule, error) {
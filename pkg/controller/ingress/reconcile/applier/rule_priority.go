@@ -0,0 +1,110 @@
+package applier
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// listenerRuleMaxPriority is the max priority of rules on an ALB listener
+	listenerRuleMaxPriority = 10000
+	// listenerRulePriorityStep leaves room between rules appended, so that rules inserted later
+	// don't move the others
+	listenerRulePriorityStep = 10
+)
+
+// allocateListenerRulePriorities allocates priorities for rules in order. current is the priority of the
+// live rule paired with each rule, or 0 for rules to create. occupied is the priorities of all live rules.
+// The longest increasing run of current priorities are kept, the other rules are given free priorities
+// between their kept neighbours, so that none of the creations and updates conflicts with the live rules.
+func allocateListenerRulePriorities(current []int, occupied sets.Int) ([]int, error) {
+	if priorities, ok := fillListenerRulePriorities(current, longestIncreasingPriorities(current), occupied); ok {
+		return priorities, nil
+	}
+	// rebalance all rules if there is no room between the kept rules
+	if priorities, ok := fillListenerRulePriorities(current, make([]bool, len(current)), occupied); ok {
+		return priorities, nil
+	}
+	return nil, fmt.Errorf("no free priority for %d rules, %d priorities in use", len(current), occupied.Len())
+}
+
+// longestIncreasingPriorities marks the longest strictly increasing subsequence of current priorities
+func longestIncreasingPriorities(current []int) []bool {
+	keep := make([]bool, len(current))
+	length := make([]int, len(current))
+	prev := make([]int, len(current))
+	best := -1
+	for i := range current {
+		prev[i] = -1
+		if current[i] <= 0 {
+			continue
+		}
+		length[i] = 1
+		for j := 0; j < i; j++ {
+			if current[j] > 0 && current[j] < current[i] && length[j]+1 > length[i] {
+				length[i] = length[j] + 1
+				prev[i] = j
+			}
+		}
+		if best == -1 || length[i] > length[best] {
+			best = i
+		}
+	}
+	for i := best; i != -1; i = prev[i] {
+		keep[i] = true
+	}
+	return keep
+}
+
+func fillListenerRulePriorities(current []int, keep []bool, occupied sets.Int) ([]int, bool) {
+	priorities := make([]int, len(current))
+	last := 0
+	for i := 0; i < len(current); {
+		if keep[i] {
+			priorities[i] = current[i]
+			last = current[i]
+			i++
+			continue
+		}
+		next := i
+		for next < len(current) && !keep[next] {
+			next++
+		}
+		count := next - i
+		var step int
+		upper := listenerRuleMaxPriority + 1
+		if next < len(current) {
+			// spread the rules evenly between the kept neighbours
+			upper = current[next]
+			step = (upper - last) / (count + 1)
+		} else {
+			// append the rules with room between each other
+			step = (upper - 1 - last) / count
+			if step > listenerRulePriorityStep {
+				step = listenerRulePriorityStep
+			}
+		}
+		if step < 1 {
+			step = 1
+		}
+		prev := last
+		for k := 0; k < count; k++ {
+			priority := last + step*(k+1)
+			if priority <= prev {
+				priority = prev + 1
+			}
+			for priority < upper && occupied.Has(priority) {
+				priority++
+			}
+			if priority >= upper {
+				return nil, false
+			}
+			priorities[i+k] = priority
+			prev = priority
+		}
+		last = prev
+		i = next
+	}
+	return priorities, true
+}
@@ -0,0 +1,69 @@
+package applier
+
+import (
+	"testing"
+
+	albsdk "github.com/aliyun/alibaba-cloud-sdk-go/services/alb"
+	"github.com/stretchr/testify/assert"
+	albmodel "k8s.io/alibaba-load-balancer-controller/pkg/model/alb"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestAllocateListenerRulePriorities(t *testing.T) {
+	// fresh listener
+	priorities, err := allocateListenerRulePriorities([]int{0, 0, 0}, sets.NewInt())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{10, 20, 30}, priorities)
+
+	// insert at top and in the middle
+	priorities, err = allocateListenerRulePriorities([]int{0, 10, 0, 20, 30}, sets.NewInt(10, 20, 30))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{5, 10, 15, 20, 30}, priorities)
+
+	// move a rule, the others are kept
+	priorities, err = allocateListenerRulePriorities([]int{30, 10, 20}, sets.NewInt(10, 20, 30))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{5, 10, 20}, priorities)
+
+	// rebalance if there is no room
+	priorities, err = allocateListenerRulePriorities([]int{0, 1, 2}, sets.NewInt(1, 2))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{10, 20, 30}, priorities)
+}
+
+func TestMatchResAndSDKListenerRules(t *testing.T) {
+	newRes := func(name string, order int) *albmodel.ListenerRule {
+		return &albmodel.ListenerRule{Spec: albmodel.ListenerRuleSpec{ALBListenerRuleSpec: albmodel.ALBListenerRuleSpec{
+			RuleName: name, Priority: order, RuleDirection: "Request"}}}
+	}
+	sdkLRs := []albsdk.Rule{
+		{RuleId: "rule-a", RuleName: "a", Priority: 10, Direction: "Request"},
+		{RuleId: "rule-b", RuleName: "b", Priority: 20, Direction: "Request"},
+		{RuleId: "rule-c", RuleName: "c", Priority: 30, Direction: "Request"},
+	}
+
+	// a new rule on top doesn't move the others
+	resLRs := []*albmodel.ListenerRule{newRes("new", 1), newRes("a", 2), newRes("b", 3), newRes("c", 4)}
+	matched, unmatchedRes, unmatchedSDK, err := matchResAndSDKListenerRules(resLRs, sdkLRs)
+	assert.NoError(t, err)
+	assert.Len(t, matched, 3)
+	assert.Len(t, unmatchedSDK, 0)
+	if assert.Len(t, unmatchedRes, 1) {
+		assert.Equal(t, "new", unmatchedRes[0].Spec.RuleName)
+		assert.Equal(t, 5, unmatchedRes[0].Spec.Priority)
+	}
+	for _, pair := range matched {
+		assert.Equal(t, pair.SdkLR.RuleName, pair.ResLR.Spec.RuleName)
+		assert.Equal(t, pair.SdkLR.Priority, pair.ResLR.Spec.Priority)
+	}
+
+	// a changed rule is updated in place
+	resLRs = []*albmodel.ListenerRule{newRes("a", 1), newRes("b2", 2), newRes("c", 3)}
+	matched, unmatchedRes, unmatchedSDK, err = matchResAndSDKListenerRules(resLRs, sdkLRs)
+	assert.NoError(t, err)
+	assert.Len(t, matched, 3)
+	assert.Len(t, unmatchedRes, 0)
+	assert.Len(t, unmatchedSDK, 0)
+	assert.Equal(t, "rule-b", matched[1].SdkLR.RuleId)
+	assert.Equal(t, 20, matched[1].ResLR.Spec.Priority)
+}
@@ -32,6 +32,13 @@ type secretStackApplier struct {
 }
 
 func (s *secretStackApplier) Apply(ctx context.Context) error {
+	if err := s.applySecretCertificates(ctx); err != nil {
+		return err
+	}
+	return s.applySecretCACertificates(ctx)
+}
+
+func (s *secretStackApplier) applySecretCertificates(ctx context.Context) error {
 	traceID := ctx.Value(util.TraceID)
 
 	var resCerts []*albmodel.SecretCertificate
@@ -109,6 +116,44 @@ func (s *secretStackApplier) Apply(ctx context.Context) error {
 	return nil
 }
 
+// applySecretCACertificates uploads the ca bundles which are not in cas yet, the cert name contains the digest
+// of the bundle, so a rotated bundle is uploaded as a new ca certificate
+func (s *secretStackApplier) applySecretCACertificates(ctx context.Context) error {
+	traceID := ctx.Value(util.TraceID)
+
+	var resCerts []*albmodel.SecretCACertificate
+	_ = s.stack.ListResources(&resCerts)
+	if len(resCerts) == 0 {
+		return nil
+	}
+	sdkCerts, err := s.albProvider.DescribeCACertificateList(ctx)
+	if err != nil {
+		return err
+	}
+	sdkCertIdsByName := make(map[string]string, len(sdkCerts))
+	for _, cert := range sdkCerts {
+		sdkCertIdsByName[cert.Alias] = cert.Identifier
+	}
+
+	for _, resCert := range resCerts {
+		certId, ok := sdkCertIdsByName[resCert.Spec.CertName]
+		if !ok {
+			s.logger.V(util.SynLogLevel).Info("synthesize secretStack: upload ca certificate",
+				"certName", resCert.Spec.CertName,
+				"traceID", traceID)
+			certId, err = s.albProvider.CreateCACertificateWithName(ctx, resCert.Spec.CertName, resCert.Spec.Certificate)
+			if err != nil {
+				return err
+			}
+			sdkCertIdsByName[resCert.Spec.CertName] = certId
+		}
+		resCert.SetStatus(albmodel.SecretCertificateStatus{
+			CertIdentifier: certId,
+		})
+	}
+	return nil
+}
+
 func (s *secretStackApplier) PostApply(ctx context.Context) error {
 	return nil
 }
@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"strings"
 	"sync"
+	"time"
 
 	"k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/tracking"
 
@@ -24,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	albsdk "github.com/aliyun/alibaba-cloud-sdk-go/services/alb"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 )
@@ -107,6 +109,10 @@ func (s *serverGroupApplier) Apply(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	matchedResAndSDKSGPs, unmatchedResSGPs, unmatchedSDKSGPs, err = s.adoptServerGroups(ctx, matchedResAndSDKSGPs, unmatchedResSGPs, unmatchedSDKSGPs)
+	if err != nil {
+		return err
+	}
 
 	if len(matchedResAndSDKSGPs) != 0 {
 		s.logger.V(util.SynLogLevel).Info("apply serverGroups",
@@ -220,6 +226,14 @@ func (s *serverGroupApplier) PostApply(ctx context.Context) error {
 		wgDelete  sync.WaitGroup
 		chDelete  = make(chan struct{}, util.ServerGroupConcurrentNum)
 	)
+	if v, ok := ctx.Value(util.IsRetainLb).(bool); ok && v {
+		for _, sdkSGP := range s.unmatchedSDKSGPs {
+			if err := s.releaseServerGroup(ctx, sdkSGP); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
 	for _, sdkSGP := range s.unmatchedSDKSGPs {
 		chDelete <- struct{}{}
 		wgDelete.Add(1)
@@ -246,6 +260,155 @@ func (s *serverGroupApplier) PostApply(ctx context.Context) error {
 	return nil
 }
 
+// adoptServerGroups matches the unmatched resources with the existing server groups they adopt, which are
+// tagged as the resources so that they are updated instead of created, and found by the stack tags later.
+// A server group already matched is adopted by none, the resource gets a new one instead.
+// Only the server groups in the vpc of the cluster and forwarded to by the alb reused by the stack are adopted,
+// and their servers are kept until the service has backends.
+func (s *serverGroupApplier) adoptServerGroups(ctx context.Context, matched []resAndSDKServerGroupPairSGP, unmatchedResSGPs []*albmodel.ServerGroup,
+	unmatchedSDKSGPs []albmodel.ServerGroupWithTags) ([]resAndSDKServerGroupPairSGP, []*albmodel.ServerGroup, []albmodel.ServerGroupWithTags, error) {
+	lbID := s.reusedLoadBalancerID()
+	claimed := sets.NewString()
+	for _, pair := range matched {
+		claimed.Insert(pair.SdkSGP.ServerGroupId)
+	}
+	var remainingResSGPs []*albmodel.ServerGroup
+	for _, resSGP := range unmatchedResSGPs {
+		sgpID := resSGP.Spec.ServerGroupId
+		if sgpID == "" || claimed.Has(sgpID) {
+			remainingResSGPs = append(remainingResSGPs, resSGP)
+			continue
+		}
+		var sdkSGP albmodel.ServerGroupWithTags
+		found := false
+		for i := range unmatchedSDKSGPs {
+			if unmatchedSDKSGPs[i].ServerGroupId == sgpID {
+				sdkSGP, found = unmatchedSDKSGPs[i], true
+				unmatchedSDKSGPs = append(unmatchedSDKSGPs[:i], unmatchedSDKSGPs[i+1:]...)
+				break
+			}
+		}
+		if !found {
+			var err error
+			sdkSGP, err = s.albProvider.SelectALBServerGroupsByID(ctx, sgpID)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			if resID, ok := sdkSGP.Tags[s.trackingProvider.ResourceIDTagKey()]; ok {
+				return nil, nil, nil, errors.Errorf("serverGroup %s adopted by %s/%s is managed by resource %s of another stack",
+					sgpID, resSGP.Spec.Namespace, resSGP.Spec.IngressName, resID)
+			}
+			if sdkSGP.VpcId != resSGP.Spec.VpcId {
+				return nil, nil, nil, errors.Errorf("serverGroup %s adopted by %s/%s is in vpc %s, not in vpc %s of the cluster",
+					sgpID, resSGP.Spec.Namespace, resSGP.Spec.IngressName, sdkSGP.VpcId, resSGP.Spec.VpcId)
+			}
+			if lbID == "" || !sets.NewString(sdkSGP.RelatedLoadBalancerIds...).Has(lbID) {
+				return nil, nil, nil, errors.Errorf("serverGroup %s adopted by %s/%s is not forwarded to by the rules of the reused alb %q",
+					sgpID, resSGP.Spec.Namespace, resSGP.Spec.IngressName, lbID)
+			}
+		}
+		if err := s.tagAdoptedServerGroup(ctx, resSGP, sgpID, !found); err != nil {
+			return nil, nil, nil, err
+		}
+		claimed.Insert(sgpID)
+		matched = append(matched, resAndSDKServerGroupPairSGP{
+			ResSGP: resSGP,
+			SdkSGP: sdkSGP,
+		})
+	}
+	return matched, remainingResSGPs, unmatchedSDKSGPs, nil
+}
+
+// reusedLoadBalancerID returns the id of the existing alb reused by the stack, empty if the alb is created by the stack
+func (s *serverGroupApplier) reusedLoadBalancerID() string {
+	var lbs []*albmodel.AlbLoadBalancer
+	if err := s.stack.ListResources(&lbs); err != nil || len(lbs) == 0 {
+		return ""
+	}
+	return lbs[0].Spec.LoadBalancerId
+}
+
+// tagAdoptedServerGroup tags the server group as the resource, a newly adopted one is also marked so that
+// its servers are not removed before the service has backends
+func (s *serverGroupApplier) tagAdoptedServerGroup(ctx context.Context, resSGP *albmodel.ServerGroup, sgpID string, newlyAdopted bool) error {
+	traceID := ctx.Value(util.TraceID)
+
+	additionalTags := make(map[string]string, len(resSGP.Spec.Tags)+1)
+	for _, tag := range resSGP.Spec.Tags {
+		additionalTags[tag.Key] = tag.Value
+	}
+	if newlyAdopted {
+		additionalTags[util.ServerGroupAdoptedTagKey] = "true"
+	}
+	sgpTags := s.trackingProvider.ResourceTags(s.stack, resSGP, additionalTags)
+	tags := make([]albsdk.TagResourcesTag, 0, len(sgpTags))
+	for _, key := range sets.StringKeySet(sgpTags).List() {
+		tags = append(tags, albsdk.TagResourcesTag{Key: key, Value: sgpTags[key]})
+	}
+	resIDs := []string{sgpID}
+	tagReq := albsdk.CreateTagResourcesRequest()
+	tagReq.Tag = &tags
+	tagReq.ResourceId = &resIDs
+	tagReq.ResourceType = util.ServerGroupResourceType
+	startTime := time.Now()
+	s.logger.V(util.SynLogLevel).Info("adopting serverGroup",
+		"resourceID", resSGP.ID(),
+		"serverGroupID", sgpID,
+		"traceID", traceID,
+		"startTime", startTime,
+		util.Action, util.TagALBResource)
+	tagResp, err := s.albProvider.TagALBResources(tagReq)
+	if err != nil {
+		return err
+	}
+	s.logger.V(util.SynLogLevel).Info("adopted serverGroup",
+		"resourceID", resSGP.ID(),
+		"serverGroupID", sgpID,
+		"requestID", tagResp.RequestId,
+		"traceID", traceID,
+		"elapsedTime", time.Since(startTime).Milliseconds(),
+		util.Action, util.TagALBResource)
+	return nil
+}
+
+// releaseServerGroup removes the tracking tags of a retained server group, which is left to the rules
+// forwarding to it
+func (s *serverGroupApplier) releaseServerGroup(ctx context.Context, sdkSGP albmodel.ServerGroupWithTags) error {
+	traceID := ctx.Value(util.TraceID)
+
+	tags := make([]albsdk.UnTagResourcesTag, 0, len(sdkSGP.Tags))
+	for _, key := range sets.StringKeySet(sdkSGP.Tags).List() {
+		if s.trackingProvider.IsAlbIngressTagKey(key) {
+			tags = append(tags, albsdk.UnTagResourcesTag{Key: key, Value: sdkSGP.Tags[key]})
+		}
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	resIDs := []string{sdkSGP.ServerGroupId}
+	untagReq := albsdk.CreateUnTagResourcesRequest()
+	untagReq.Tag = &tags
+	untagReq.ResourceId = &resIDs
+	untagReq.ResourceType = util.ServerGroupResourceType
+	startTime := time.Now()
+	s.logger.V(util.SynLogLevel).Info("releasing serverGroup",
+		"serverGroupID", sdkSGP.ServerGroupId,
+		"traceID", traceID,
+		"startTime", startTime,
+		util.Action, util.UnTagALBResource)
+	untagResp, err := s.albProvider.UnTagALBResources(untagReq)
+	if err != nil {
+		return err
+	}
+	s.logger.V(util.SynLogLevel).Info("released serverGroup",
+		"serverGroupID", sdkSGP.ServerGroupId,
+		"requestID", untagResp.RequestId,
+		"traceID", traceID,
+		"elapsedTime", time.Since(startTime).Milliseconds(),
+		util.Action, util.UnTagALBResource)
+	return nil
+}
+
 func (s *serverGroupApplier) findSDKServerGroups(ctx context.Context) ([]albmodel.ServerGroupWithTags, error) {
 	stackTags := s.trackingProvider.StackTags(s.stack)
 	return s.albProvider.ListALBServerGroupsWithTags(ctx, stackTags)
@@ -0,0 +1,144 @@
+package applier
+
+import (
+	"context"
+	"testing"
+
+	albsdk "github.com/aliyun/alibaba-cloud-sdk-go/services/alb"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/tracking"
+	albmodel "k8s.io/alibaba-load-balancer-controller/pkg/model/alb"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model/alb/core"
+	prvd "k8s.io/alibaba-load-balancer-controller/pkg/provider"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+)
+
+type fakeAdoptProvider struct {
+	prvd.Provider
+	sdkSGPs  map[string]albmodel.ServerGroupWithTags
+	tagged   map[string]map[string]string
+	untagged map[string][]string
+}
+
+func (p *fakeAdoptProvider) SelectALBServerGroupsByID(_ context.Context, serverGroupID string) (albmodel.ServerGroupWithTags, error) {
+	return p.sdkSGPs[serverGroupID], nil
+}
+
+func (p *fakeAdoptProvider) TagALBResources(request *albsdk.TagResourcesRequest) (*albsdk.TagResourcesResponse, error) {
+	tags := make(map[string]string)
+	for _, tag := range *request.Tag {
+		tags[tag.Key] = tag.Value
+	}
+	for _, id := range *request.ResourceId {
+		p.tagged[id] = tags
+	}
+	return albsdk.CreateTagResourcesResponse(), nil
+}
+
+func (p *fakeAdoptProvider) UnTagALBResources(request *albsdk.UnTagResourcesRequest) (*albsdk.UnTagResourcesResponse, error) {
+	for _, id := range *request.ResourceId {
+		for _, tag := range *request.Tag {
+			p.untagged[id] = append(p.untagged[id], tag.Key)
+		}
+	}
+	return albsdk.CreateUnTagResourcesResponse(), nil
+}
+
+func TestAdoptServerGroups(t *testing.T) {
+	stack := core.NewDefaultManager(core.StackID{Name: "alb"})
+	albmodel.NewAlbLoadBalancer(stack, "alb", albmodel.ALBLoadBalancerSpec{LoadBalancerId: "alb-reused"})
+	newRes := func(id, svcName, sgpID string) *albmodel.ServerGroup {
+		spec := albmodel.ServerGroupSpec{}
+		spec.ServiceName = svcName
+		spec.ServerGroupId = sgpID
+		spec.VpcId = "vpc-cluster"
+		spec.Tags = []albmodel.ALBTag{{Key: "service", Value: svcName}}
+		return albmodel.NewServerGroup(stack, id, spec)
+	}
+	newSDK := func(sgpID string, tags map[string]string) albmodel.ServerGroupWithTags {
+		return albmodel.ServerGroupWithTags{ServerGroup: albsdk.ServerGroup{
+			ServerGroupId:          sgpID,
+			VpcId:                  "vpc-cluster",
+			RelatedLoadBalancerIds: []string{"alb-reused"},
+		}, Tags: tags}
+	}
+	otherVpc := newSDK("sgp-other-vpc", nil)
+	otherVpc.VpcId = "vpc-other"
+	otherAlb := newSDK("sgp-other-alb", nil)
+	otherAlb.RelatedLoadBalancerIds = []string{"alb-other"}
+	trackingProvider := tracking.NewDefaultProvider("ingress.k8s.alibaba", "cluster")
+	provider := &fakeAdoptProvider{
+		sdkSGPs: map[string]albmodel.ServerGroupWithTags{
+			"sgp-console":   newSDK("sgp-console", nil),
+			"sgp-other":     newSDK("sgp-other", map[string]string{trackingProvider.ResourceIDTagKey(): "other"}),
+			"sgp-other-vpc": otherVpc,
+			"sgp-other-alb": otherAlb,
+		},
+		tagged: make(map[string]map[string]string),
+	}
+	applier := &serverGroupApplier{
+		albProvider:      provider,
+		trackingProvider: trackingProvider,
+		stack:            stack,
+		logger:           logr.Discard(),
+	}
+
+	resConsole := newRes("console", "api", "sgp-console")
+	resShared := newRes("shared", "api", "sgp-console")
+	resStale := newRes("stale", "web", "sgp-stale")
+	resNew := newRes("new", "web", "")
+	matched, unmatchedRes, unmatchedSDK, err := applier.adoptServerGroups(context.TODO(), nil,
+		[]*albmodel.ServerGroup{resConsole, resShared, resStale, resNew},
+		[]albmodel.ServerGroupWithTags{newSDK("sgp-stale", nil), newSDK("sgp-unused", nil)})
+	assert.NoError(t, err)
+	if assert.Len(t, matched, 2) {
+		assert.Equal(t, resConsole, matched[0].ResSGP)
+		assert.Equal(t, "sgp-console", matched[0].SdkSGP.ServerGroupId)
+		assert.Equal(t, resStale, matched[1].ResSGP)
+	}
+	// a server group is adopted once, the others get new ones
+	assert.Equal(t, []*albmodel.ServerGroup{resShared, resNew}, unmatchedRes)
+	assert.Equal(t, []albmodel.ServerGroupWithTags{newSDK("sgp-unused", nil)}, unmatchedSDK)
+	assert.Equal(t, "console", provider.tagged["sgp-console"][trackingProvider.ResourceIDTagKey()])
+	assert.Equal(t, "api", provider.tagged["sgp-console"]["service"])
+	// servers of a newly adopted server group are kept until the service has backends
+	assert.Equal(t, "true", provider.tagged["sgp-console"][util.ServerGroupAdoptedTagKey])
+	assert.NotContains(t, provider.tagged["sgp-stale"], util.ServerGroupAdoptedTagKey)
+
+	// server groups of other stacks, other vpcs or not forwarded to by the reused alb are not taken over
+	for _, sgpID := range []string{"sgp-other", "sgp-other-vpc", "sgp-other-alb"} {
+		_, _, _, err = applier.adoptServerGroups(context.TODO(), nil,
+			[]*albmodel.ServerGroup{newRes("steal", "api", sgpID)}, nil)
+		assert.Error(t, err, sgpID)
+	}
+
+	// nothing is adopted by a stack creating its own alb
+	applier.stack = core.NewDefaultManager(core.StackID{Name: "alb"})
+	_, _, _, err = applier.adoptServerGroups(context.TODO(), nil,
+		[]*albmodel.ServerGroup{newRes("console", "api", "sgp-console")}, nil)
+	assert.Error(t, err)
+}
+
+func TestReleaseRetainedServerGroups(t *testing.T) {
+	trackingProvider := tracking.NewDefaultProvider("ingress.k8s.alibaba", "cluster")
+	provider := &fakeAdoptProvider{untagged: make(map[string][]string)}
+	applier := &serverGroupApplier{
+		albProvider:      provider,
+		trackingProvider: trackingProvider,
+		stack:            core.NewDefaultManager(core.StackID{Name: "alb"}),
+		logger:           logr.Discard(),
+		unmatchedSDKSGPs: []albmodel.ServerGroupWithTags{{
+			ServerGroup: albsdk.ServerGroup{ServerGroupId: "sgp-api"},
+			Tags: map[string]string{
+				trackingProvider.ResourceIDTagKey():  "api",
+				trackingProvider.ClusterNameTagKey(): "cluster",
+				"team":                               "web",
+			},
+		}},
+	}
+	ctx := context.WithValue(context.TODO(), util.IsRetainLb, true)
+	assert.NoError(t, applier.PostApply(ctx))
+	assert.ElementsMatch(t, []string{trackingProvider.ResourceIDTagKey(), trackingProvider.ClusterNameTagKey()},
+		provider.untagged["sgp-api"])
+}
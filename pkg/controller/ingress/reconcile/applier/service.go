@@ -15,6 +15,7 @@ import (
 	albmodel "k8s.io/alibaba-load-balancer-controller/pkg/model/alb"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	albsdk "github.com/aliyun/alibaba-cloud-sdk-go/services/alb"
 	"github.com/go-logr/logr"
 )
 
@@ -53,10 +54,19 @@ func (m *defaultServiceManagerApplier) Apply(ctx context.Context, albProvider pr
 		chApply = make(chan struct{}, util.ServerGroupConcurrentNum)
 	)
 	for _, v := range matchedResAndSDKSGPs {
+		// the servers of an adopted server group are kept until the service has backends
+		_, adopted := v.SdkSGP.Tags[util.ServerGroupAdoptedTagKey]
+		if adopted && len(v.ResSGP.Backends) == 0 {
+			m.logger.Info("skip replacing servers of adopted serverGroup without backends",
+				"serverGroupID", v.SdkSGP.ServerGroupId,
+				"service", serviceStack.Namespace+"/"+serviceStack.Name)
+			continue
+		}
+
 		chApply <- struct{}{}
 		wg.Add(1)
 
-		go func(serverGroupID string, backends []albmodel.BackendItem) {
+		go func(serverGroupID string, backends []albmodel.BackendItem, adopted bool) {
 			util.RandomSleepFunc(util.ConcurrentMaxSleepMillisecondTime)
 
 			defer func() {
@@ -65,11 +75,15 @@ func (m *defaultServiceManagerApplier) Apply(ctx context.Context, albProvider pr
 			}()
 
 			serverApplier := NewServerApplier(m.kubeClient, albProvider, serverGroupID, backends, serviceStack.TrafficPolicy, m.logger)
-			if errOnce := serverApplier.Apply(ctx); err == nil && errOnce != nil {
+			errOnce := serverApplier.Apply(ctx)
+			if errOnce == nil && adopted {
+				errOnce = unmarkAdoptedServerGroup(albProvider, serverGroupID)
+			}
+			if err == nil && errOnce != nil {
 				m.logger.Error(errOnce, "synthesize servers failed", "serverGroupID", serverGroupID)
 				err = errOnce
 			}
-		}(v.SdkSGP.ServerGroupId, v.ResSGP.Backends)
+		}(v.SdkSGP.ServerGroupId, v.ResSGP.Backends, adopted)
 	}
 	wg.Wait()
 	if err != nil {
@@ -79,6 +93,18 @@ func (m *defaultServiceManagerApplier) Apply(ctx context.Context, albProvider pr
 	return nil
 }
 
+// unmarkAdoptedServerGroup removes the adopted mark once the servers of the server group are synced from the service
+func unmarkAdoptedServerGroup(albProvider prvd.Provider, serverGroupID string) error {
+	tags := []albsdk.UnTagResourcesTag{{Key: util.ServerGroupAdoptedTagKey, Value: "true"}}
+	resIDs := []string{serverGroupID}
+	untagReq := albsdk.CreateUnTagResourcesRequest()
+	untagReq.Tag = &tags
+	untagReq.ResourceId = &resIDs
+	untagReq.ResourceType = util.ServerGroupResourceType
+	_, err := albProvider.UnTagALBResources(untagReq)
+	return err
+}
+
 func NewServiceStackApplier(albProvider prvd.Provider, serviceStack *albmodel.ServiceManager, logger logr.Logger) *serviceStackApplier {
 	tagFilters := make(map[string]string)
 	tagFilters[util.ClusterNameTagKey] = serviceStack.ClusterID
@@ -66,6 +66,11 @@ func (mgr *Manager) BuildServicePortSDKBackends(ctx context.Context, svcKey type
 		if err != nil {
 			return modelBackends, containsPotentialReadyEndpoints, err
 		}
+	case helper.IPTrafficPolicy:
+		endpoints, err = mgr.ResolveIPEndpoints(ctx, util.NamespacedName(svc), port)
+		if err != nil {
+			return modelBackends, containsPotentialReadyEndpoints, err
+		}
 	default:
 		return modelBackends, containsPotentialReadyEndpoints, fmt.Errorf("not supported traffic policy [%s]", policy)
 	}
@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	ctrlCfg "k8s.io/alibaba-load-balancer-controller/pkg/config"
 	"k8s.io/alibaba-load-balancer-controller/pkg/controller/helper"
 
 	"k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/store"
@@ -18,10 +19,12 @@ import (
 	"k8s.io/alibaba-load-balancer-controller/pkg/model/alb"
 	prvd "k8s.io/alibaba-load-balancer-controller/pkg/provider"
 	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/cache"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -37,6 +40,8 @@ type EndpointResolver interface {
 	ResolveLocalEndpoints(ctx context.Context, svcKey types.NamespacedName, port intstr.IntOrString) ([]NodePortEndpoint, bool, error)
 
 	ResolveClusterEndpoints(ctx context.Context, svcKey types.NamespacedName, port intstr.IntOrString) ([]NodePortEndpoint, bool, error)
+
+	ResolveIPEndpoints(ctx context.Context, svcKey types.NamespacedName, port intstr.IntOrString) ([]NodePortEndpoint, error)
 }
 
 type PodEndpoint struct {
@@ -373,6 +378,104 @@ func (r *defaultEndpointResolver) transPodEndpointsToEnis(backends []PodEndpoint
 
 }
 
+// ResolveIPEndpoints returns the ready addresses of the endpoints and the addresses of the backend-ips annotation
+// as ip type backends, the addresses are not required to be pods of the cluster
+func (r *defaultEndpointResolver) ResolveIPEndpoints(ctx context.Context, svcKey types.NamespacedName, port intstr.IntOrString) ([]NodePortEndpoint, error) {
+	svc, svcPort, err := r.findServiceAndServicePort(ctx, svcKey, port)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultPort := int(svcPort.Port)
+	if svcPort.TargetPort.Type == intstr.Int && svcPort.TargetPort.IntValue() != 0 {
+		defaultPort = svcPort.TargetPort.IntValue()
+	}
+
+	var ipEndpoints []NodePortEndpoint
+	eps := &corev1.Endpoints{}
+	if err := r.k8sClient.Get(ctx, util.NamespacedName(svc), eps); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	for _, ep := range eps.Subsets {
+		backendPort := defaultPort
+		for _, p := range ep.Ports {
+			if p.Name == svcPort.Name {
+				backendPort = int(p.Port)
+				break
+			}
+		}
+		for _, addr := range ep.Addresses {
+			ipEndpoints = append(ipEndpoints, buildNodePortEndpoint(addr.IP, addr.IP, backendPort, alb.IPBackendType, util.DefaultServerWeight, nil))
+		}
+	}
+
+	if utilfeature.DefaultMutableFeatureGate.Enabled(ctrlCfg.EndpointSlice) {
+		sliceEndpoints, err := r.resolveEndpointSliceIPs(ctx, svc, svcPort, defaultPort)
+		if err != nil {
+			return nil, err
+		}
+		ipEndpoints = append(ipEndpoints, sliceEndpoints...)
+	}
+
+	ips, err := helper.GetBackendIPs(svc)
+	if err != nil {
+		return nil, err
+	}
+	for _, ip := range ips {
+		backendPort := defaultPort
+		if ip.Port != 0 {
+			backendPort = int(ip.Port)
+		}
+		ipEndpoints = append(ipEndpoints, buildNodePortEndpoint(ip.IP, ip.IP, backendPort, alb.IPBackendType, util.DefaultServerWeight, nil))
+	}
+
+	// the servers of an ip type server group are identified by ip and port
+	uniq := make(map[string]struct{})
+	var uniqEndpoints []NodePortEndpoint
+	for _, ep := range ipEndpoints {
+		key := fmt.Sprintf("%s:%d", ep.ServerIp, ep.Port)
+		if _, ok := uniq[key]; ok {
+			continue
+		}
+		uniq[key] = struct{}{}
+		uniqEndpoints = append(uniqEndpoints, ep)
+	}
+	return uniqEndpoints, nil
+}
+
+// resolveEndpointSliceIPs returns the ready addresses of the endpointslices of the service, which are not
+// mirrored to the endpoints if they are managed by others than the endpointslice controller
+func (r *defaultEndpointResolver) resolveEndpointSliceIPs(ctx context.Context, svc *corev1.Service, svcPort corev1.ServicePort,
+	defaultPort int) ([]NodePortEndpoint, error) {
+	esList := &discovery.EndpointSliceList{}
+	if err := r.k8sClient.List(ctx, esList, client.InNamespace(svc.Namespace),
+		client.MatchingLabels{discovery.LabelServiceName: svc.Name}); err != nil {
+		return nil, err
+	}
+	var ipEndpoints []NodePortEndpoint
+	for _, es := range esList.Items {
+		backendPort := defaultPort
+		for _, p := range es.Ports {
+			if p.Name != nil && *p.Name == svcPort.Name && p.Port != nil {
+				backendPort = int(*p.Port)
+				break
+			}
+		}
+		for _, ep := range es.Endpoints {
+			// an endpoint of unknown readiness is ready, as the endpoints written by users usually have no conditions
+			if ep.Conditions.Ready != nil && !*ep.Conditions.Ready {
+				continue
+			}
+			for _, addr := range ep.Addresses {
+				ipEndpoints = append(ipEndpoints, buildNodePortEndpoint(addr, addr, backendPort, alb.IPBackendType, util.DefaultServerWeight, nil))
+			}
+		}
+	}
+	return ipEndpoints, nil
+}
+
 func buildPodEndpoint(epAddr corev1.EndpointAddress, port int, pod *corev1.Pod) PodEndpoint {
 	return PodEndpoint{
 		IP:       epAddr.IP,
@@ -0,0 +1,48 @@
+package backend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model/alb"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestResolveEndpointSliceIPs(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "external"},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromInt(8080)}}},
+	}
+	newSlice := func(name, svcName string, port int32, endpoints ...discovery.Endpoint) *discovery.EndpointSlice {
+		return &discovery.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name,
+				Labels: map[string]string{discovery.LabelServiceName: svcName}},
+			AddressType: discovery.AddressTypeIPv4,
+			Ports:       []discovery.EndpointPort{{Name: pointer.String("http"), Port: pointer.Int32(port)}},
+			Endpoints:   endpoints,
+		}
+	}
+	r := &defaultEndpointResolver{k8sClient: fake.NewClientBuilder().WithObjects(
+		newSlice("external-a", "external", 9090,
+			discovery.Endpoint{Addresses: []string{"172.16.0.1"}},
+			discovery.Endpoint{Addresses: []string{"172.16.0.2"}, Conditions: discovery.EndpointConditions{Ready: pointer.Bool(false)}}),
+		newSlice("external-b", "external", 9090,
+			discovery.Endpoint{Addresses: []string{"172.16.0.3"}, Conditions: discovery.EndpointConditions{Ready: pointer.Bool(true)}}),
+		newSlice("other", "other", 9090, discovery.Endpoint{Addresses: []string{"172.16.1.1"}}),
+	).Build()}
+
+	endpoints, err := r.resolveEndpointSliceIPs(context.TODO(), svc, svc.Spec.Ports[0], 8080)
+	assert.NoError(t, err)
+	// endpoints without conditions are ready, the not ready ones and the slices of other services are skipped
+	assert.ElementsMatch(t, []NodePortEndpoint{
+		buildNodePortEndpoint("172.16.0.1", "172.16.0.1", 9090, alb.IPBackendType, util.DefaultServerWeight, nil),
+		buildNodePortEndpoint("172.16.0.3", "172.16.0.3", 9090, alb.IPBackendType, util.DefaultServerWeight, nil),
+	}, endpoints)
+}
@@ -0,0 +1,163 @@
+package albconfigmanager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// catchAllHost is the host of the rules without host, which match the requests of all hosts
+const catchAllHost = "*"
+
+// RejectedMember is an Ingress or AlbRoute rejected by the admission policy of AlbConfig
+type RejectedMember struct {
+	Object client.Object
+	Reason string
+}
+
+// admissionChecker checks the namespaces and hosts of Ingresses and AlbRoutes against the admission policy
+type admissionChecker struct {
+	kubeClient client.Client
+	policy     *v1.AdmissionPolicy
+	selector   labels.Selector
+	// namespaces caches whether namespaces are allowed
+	namespaces map[string]bool
+}
+
+func newAdmissionChecker(kubeClient client.Client, groupID GroupID, albconfig *v1.AlbConfig) (*admissionChecker, error) {
+	checker := &admissionChecker{
+		kubeClient: kubeClient,
+		namespaces: make(map[string]bool),
+	}
+	if albconfig == nil {
+		return checker, nil
+	}
+	checker.policy = albconfig.Spec.Admission
+	if checker.policy != nil && checker.policy.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(checker.policy.NamespaceSelector)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid namespaceSelector of albconfig %v", groupID)
+		}
+		checker.selector = selector
+	}
+	return checker, nil
+}
+
+// check returns the reason why the object in namespace with hosts is rejected, or "" if it is admitted
+func (c *admissionChecker) check(ctx context.Context, namespace string, hosts []string) (string, error) {
+	if c.policy == nil {
+		return "", nil
+	}
+	allowed, err := c.isNamespaceAllowed(ctx, namespace)
+	if err != nil {
+		return "", err
+	}
+	if !allowed {
+		return fmt.Sprintf("namespace %s is not allowed by albconfig", namespace), nil
+	}
+	for _, host := range hosts {
+		if host == catchAllHost {
+			if len(c.policy.HostOwnerships) == 0 {
+				continue
+			}
+			// rules without host may shadow the owned hosts, so they are only admitted by the ownership of *
+			ownership := hostOwnershipOf(c.policy.HostOwnerships, host)
+			if ownership == nil || !sets.NewString(ownership.Namespaces...).Has(namespace) {
+				return fmt.Sprintf("rules without host match all hosts, which requires the ownership of host %s", catchAllHost), nil
+			}
+			continue
+		}
+		ownership := hostOwnershipOf(c.policy.HostOwnerships, host)
+		if ownership != nil && !sets.NewString(ownership.Namespaces...).Has(namespace) {
+			return fmt.Sprintf("host %s is owned by namespaces %v", host, ownership.Namespaces), nil
+		}
+	}
+	return "", nil
+}
+
+func (c *admissionChecker) isNamespaceAllowed(ctx context.Context, namespace string) (bool, error) {
+	if len(c.policy.AllowedNamespaces) == 0 && c.selector == nil {
+		return true, nil
+	}
+	if allowed, ok := c.namespaces[namespace]; ok {
+		return allowed, nil
+	}
+	allowed := sets.NewString(c.policy.AllowedNamespaces...).Has(namespace)
+	if !allowed && c.selector != nil {
+		ns := &corev1.Namespace{}
+		if err := c.kubeClient.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+			return false, errors.Wrapf(err, "get namespace %s", namespace)
+		}
+		allowed = c.selector.Matches(labels.Set(ns.Labels))
+	}
+	c.namespaces[namespace] = allowed
+	return allowed, nil
+}
+
+// hostOwnershipOf returns the most specific ownership covering host: the exact one, or else the wildcard
+// one with the longest suffix.
+func hostOwnershipOf(ownerships []v1.HostOwnership, host string) *v1.HostOwnership {
+	host = strings.ToLower(host)
+	var matched *v1.HostOwnership
+	for i := range ownerships {
+		pattern := strings.ToLower(ownerships[i].Host)
+		if pattern == host {
+			return &ownerships[i]
+		}
+		if !strings.HasPrefix(pattern, "*.") {
+			continue
+		}
+		suffix := pattern[1:]
+		if strings.HasSuffix(host, suffix) && len(host) > len(suffix) {
+			if matched == nil || len(matched.Host) < len(pattern) {
+				matched = &ownerships[i]
+			}
+		}
+	}
+	return matched
+}
+
+func admissionHostsOfIngress(ing *networking.Ingress) []string {
+	hosts := sets.NewString()
+	if ing.Spec.DefaultBackend != nil {
+		hosts.Insert(catchAllHost)
+	}
+	for _, rule := range ing.Spec.Rules {
+		if rule.Host == "" {
+			hosts.Insert(catchAllHost)
+			continue
+		}
+		hosts.Insert(rule.Host)
+	}
+	for _, tls := range ing.Spec.TLS {
+		hosts.Insert(tls.Hosts...)
+	}
+	return hosts.List()
+}
+
+func admissionHostsOfAlbRoute(route *v1.AlbRoute) []string {
+	hosts := sets.NewString()
+	for _, rule := range route.Spec.Rules {
+		withHost := false
+		for _, cond := range rule.Conditions {
+			if strings.EqualFold(cond.Type, "Host") {
+				hosts.Insert(cond.Values...)
+				withHost = true
+			}
+		}
+		if !withHost {
+			hosts.Insert(catchAllHost)
+		}
+	}
+	return hosts.List()
+}
@@ -0,0 +1,102 @@
+package albconfigmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestHostOwnershipOf(t *testing.T) {
+	ownerships := []v1.HostOwnership{
+		{Host: "*.example.com", Namespaces: []string{"platform"}},
+		{Host: "*.payments.example.com", Namespaces: []string{"payments"}},
+		{Host: "www.payments.example.com", Namespaces: []string{"web"}},
+	}
+	assert.Equal(t, "www.payments.example.com", hostOwnershipOf(ownerships, "WWW.payments.example.com").Host)
+	assert.Equal(t, "*.payments.example.com", hostOwnershipOf(ownerships, "api.payments.example.com").Host)
+	assert.Equal(t, "*.payments.example.com", hostOwnershipOf(ownerships, "*.payments.example.com").Host)
+	assert.Equal(t, "*.example.com", hostOwnershipOf(ownerships, "payments.example.com").Host)
+	assert.Nil(t, hostOwnershipOf(ownerships, "example.com"))
+	assert.Nil(t, hostOwnershipOf(ownerships, "www.example.org"))
+}
+
+func TestAdmissionCheckerCheck(t *testing.T) {
+	kubeClient := fake.NewClientBuilder().WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments", Labels: map[string]string{"team": "payments"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "web"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+	).Build()
+	checker := &admissionChecker{
+		kubeClient: kubeClient,
+		policy: &v1.AdmissionPolicy{
+			AllowedNamespaces: []string{"web"},
+			HostOwnerships:    []v1.HostOwnership{{Host: "*.payments.example.com", Namespaces: []string{"payments"}}},
+		},
+		selector:   labels.SelectorFromSet(labels.Set{"team": "payments"}),
+		namespaces: make(map[string]bool),
+	}
+	ctx := context.TODO()
+
+	reason, err := checker.check(ctx, "payments", []string{"api.payments.example.com"})
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+
+	reason, err = checker.check(ctx, "web", []string{"www.example.com", "api.payments.example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, "host api.payments.example.com is owned by namespaces [payments]", reason)
+
+	reason, err = checker.check(ctx, "other", []string{"www.example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, "namespace other is not allowed by albconfig", reason)
+}
+
+func TestAdmissionCheckerCheckCatchAllHost(t *testing.T) {
+	checker := &admissionChecker{
+		policy:     &v1.AdmissionPolicy{},
+		namespaces: make(map[string]bool),
+	}
+	ctx := context.TODO()
+
+	// rules without host are free without host ownerships
+	reason, err := checker.check(ctx, "web", []string{catchAllHost})
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+
+	checker.policy.HostOwnerships = []v1.HostOwnership{{Host: "*.payments.example.com", Namespaces: []string{"payments"}}}
+	reason, err = checker.check(ctx, "web", []string{catchAllHost})
+	assert.NoError(t, err)
+	assert.Equal(t, "rules without host match all hosts, which requires the ownership of host *", reason)
+
+	checker.policy.HostOwnerships = append(checker.policy.HostOwnerships, v1.HostOwnership{Host: "*", Namespaces: []string{"platform"}})
+	reason, err = checker.check(ctx, "platform", []string{catchAllHost})
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+	// the ownership of * doesn't cover the other hosts
+	reason, err = checker.check(ctx, "web", []string{"www.example.com"})
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+}
+
+func TestAdmissionHosts(t *testing.T) {
+	ing := &networking.Ingress{Spec: networking.IngressSpec{
+		Rules: []networking.IngressRule{{Host: "www.example.com"}, {}},
+		TLS:   []networking.IngressTLS{{Hosts: []string{"tls.example.com"}}},
+	}}
+	assert.Equal(t, []string{catchAllHost, "tls.example.com", "www.example.com"}, admissionHostsOfIngress(ing))
+
+	route := &v1.AlbRoute{Spec: v1.AlbRouteSpec{Rules: []v1.AlbRouteRule{
+		{Conditions: []v1.AlbRouteCondition{{Type: "Host", Values: []string{"www.example.com"}}}},
+	}}}
+	assert.Equal(t, []string{"www.example.com"}, admissionHostsOfAlbRoute(route))
+	route.Spec.Rules = append(route.Spec.Rules, v1.AlbRouteRule{
+		Conditions: []v1.AlbRouteCondition{{Type: "Path", Values: []string{"/api"}}},
+	})
+	assert.Equal(t, []string{catchAllHost, "www.example.com"}, admissionHostsOfAlbRoute(route))
+}
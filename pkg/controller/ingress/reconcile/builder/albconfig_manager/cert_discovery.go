@@ -2,104 +2,58 @@ package albconfigmanager
 
 import (
 	"context"
-	"strings"
+	"time"
 
-	"k8s.io/alibaba-load-balancer-controller/pkg/util"
-
-	"k8s.io/klog/v2"
-
-	prvd "k8s.io/alibaba-load-balancer-controller/pkg/provider"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/go-logr/logr"
-	"github.com/google/go-cmp/cmp"
-	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
 )
 
 type CertDiscovery interface {
-	Discover(ctx context.Context, tlsHosts []string) ([]string, error)
+	// Discover returns the certificates of the hosts, and the matches of the hosts whose certificates have all expired
+	Discover(ctx context.Context, tlsHosts []string) ([]string, []CertMatch, error)
 }
 
-func NewCASCertDiscovery(cloud prvd.Provider, logger logr.Logger) *casCertDiscovery {
+func NewCASCertDiscovery(inventory *CertInventory, logger logr.Logger) *casCertDiscovery {
 	return &casCertDiscovery{
-		logger: logger,
-		cloud:  cloud,
+		logger:    logger,
+		inventory: inventory,
 	}
 }
 
 var _ CertDiscovery = &casCertDiscovery{}
 
 type casCertDiscovery struct {
-	cloud  prvd.Provider
-	logger logr.Logger
+	inventory *CertInventory
+	logger    logr.Logger
 }
 
-func (d *casCertDiscovery) Discover(ctx context.Context, tlsHosts []string) ([]string, error) {
-	domainsByCertID, err := d.loadDomainsForAllCertificates(ctx)
+func (d *casCertDiscovery) Discover(ctx context.Context, tlsHosts []string) ([]string, []CertMatch, error) {
+	matches, missHosts, err := d.inventory.Lookup(ctx, tlsHosts)
 	if err != nil {
-		klog.Errorf("loadDomainsForAllCertificates err: %v", err)
-		return nil, err
+		d.logger.Error(err, "lookup certificate inventory failed")
+		return nil, nil, err
 	}
-	certIDs := sets.NewString()
-	for _, host := range tlsHosts {
-		var certIDsForHost []string
-		for certID, domains := range domainsByCertID {
-			for domain := range domains {
-				if d.domainMatchesHost(domain, host) {
-					certIDsForHost = append(certIDsForHost, certID)
-					break
-				}
-			}
-		}
-
-		if len(certIDsForHost) == 0 {
-			return nil, errors.Errorf("none certificate found for host: %s", host)
-		}
-		certIDs.Insert(certIDsForHost...)
+	if len(missHosts) != 0 {
+		return nil, nil, errors.Errorf("none valid certificate found for host: %v", missHosts)
 	}
-	return certIDs.List(), nil
-}
-
-func (d *casCertDiscovery) loadDomainsForAllCertificates(ctx context.Context) (map[string]sets.String, error) {
-	certs, err := d.cloud.DescribeSSLCertificateList(ctx)
-	if err != nil {
-		klog.Errorf("loadAllCertificates error: %v", err)
-		return nil, err
-	}
-	domainsByCertID := make(map[string]sets.String, len(certs))
-	for _, cert := range certs {
-		if cert.Algorithm == util.CertAlgorithmSM2 {
-			continue
-		}
-		domains := sets.NewString(cert.CommonName, cert.Sans)
-		domainsByCertID[cert.CertIdentifier] = domains
-	}
-	return domainsByCertID, nil
-}
-
-func (d *casCertDiscovery) domainMatchesHost(domainName string, tlsHost string) bool {
-	isMatch := false
-	domains := strings.Split(domainName, ",")
-	lower_host := strings.ToLower(tlsHost)
-	for _, dom := range domains {
-		lower_dom := strings.ToLower(dom)
-		if strings.HasPrefix(lower_dom, "*.") {
-			ds := strings.Split(lower_dom, ".")
-			hs := strings.Split(lower_host, ".")
-			if len(ds) != len(hs) {
-				continue
-			}
-
-			if cmp.Equal(ds[1:], hs[1:], cmpopts.EquateEmpty()) {
-				isMatch = true
-				break
-			}
-		}
-		if lower_dom == lower_host {
-			isMatch = true
-			break
+	certIDs := sets.NewString()
+	var expired []CertMatch
+	for _, m := range matches {
+		d.logger.V(util.SynLogLevel).Info("certificate discovered",
+			"host", m.Host,
+			"certificateId", m.CertificateId,
+			"matchedDomain", m.MatchedDomain,
+			"notAfter", m.NotAfter.Format(time.RFC3339),
+			"candidates", m.Candidates,
+			"reason", m.Reason,
+			"expired", m.Expired)
+		if m.Expired {
+			expired = append(expired, m)
 		}
+		certIDs.Insert(m.CertificateId)
 	}
-	return isMatch
+	return certIDs.List(), expired, nil
 }
@@ -0,0 +1,226 @@
+package albconfigmanager
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/helper"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model"
+	prvd "k8s.io/alibaba-load-balancer-controller/pkg/provider"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+)
+
+const (
+	// certInventoryMinRefreshInterval limits the reloads triggered by hosts without certificate
+	certInventoryMinRefreshInterval = 30 * time.Second
+)
+
+// CertInventory is the cas certificates shared by all the model builds, indexed by exact host and wildcard suffix.
+// It is reloaded when expired, invalidated, or when a host has no certificate and the inventory is not refreshed recently.
+type CertInventory struct {
+	cas    prvd.ICAS
+	ttl    time.Duration
+	logger logr.Logger
+
+	mu       sync.Mutex
+	loadedAt time.Time
+	certs    map[string]model.CertificateInfo
+	// byHost index certificates by exact domain, e.g. www.example.com
+	byHost map[string][]string
+	// byWildcardSuffix index wildcard certificates by the domain after "*.", e.g. example.com for *.example.com
+	byWildcardSuffix map[string][]string
+}
+
+func NewCertInventory(cas prvd.ICAS, ttl time.Duration, logger logr.Logger) *CertInventory {
+	return &CertInventory{
+		cas:    cas,
+		ttl:    ttl,
+		logger: logger.WithName("cert-inventory"),
+	}
+}
+
+// CertMatch is the certificate selected for a host and the reason why
+type CertMatch struct {
+	Host          string
+	CertificateId string
+	MatchedDomain string
+	NotAfter      time.Time
+	Candidates    int
+	Reason        string
+	// Expired is true if all the certificates matching the host have expired, and the newest one is selected
+	Expired bool
+}
+
+// Invalidate drops the inventory, it is reloaded on next lookup
+func (i *CertInventory) Invalidate() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.loadedAt = time.Time{}
+}
+
+// Update rebuilds the inventory from a fresh certificate list
+func (i *CertInventory) Update(certs []model.CertificateInfo) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.index(certs)
+}
+
+// Contains check if the certificate is in the inventory
+func (i *CertInventory) Contains(certId string) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	_, ok := i.certs[certId]
+	return ok
+}
+
+// Lookup selects the certificate for each host. When several certificates match a host,
+// the valid one expires latest is preferred, not yet valid certificates are skipped. The expired
+// certificate expires latest is selected if all the certificates of the host have expired.
+func (i *CertInventory) Lookup(ctx context.Context, hosts []string) ([]CertMatch, []string, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if time.Since(i.loadedAt) > i.ttl {
+		if err := i.load(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+	matches, missHosts := i.lookup(hosts, time.Now())
+	if len(missHosts) != 0 && time.Since(i.loadedAt) > certInventoryMinRefreshInterval {
+		i.logger.Info("hosts have no certificate, reload inventory", "hosts", missHosts)
+		if err := i.load(ctx); err != nil {
+			return nil, nil, err
+		}
+		matches, missHosts = i.lookup(hosts, time.Now())
+	}
+	return matches, missHosts, nil
+}
+
+func (i *CertInventory) load(ctx context.Context) error {
+	certs, err := i.cas.DescribeSSLCertificateList(ctx)
+	if err != nil {
+		return err
+	}
+	i.index(certs)
+	return nil
+}
+
+func (i *CertInventory) index(certs []model.CertificateInfo) {
+	i.certs = make(map[string]model.CertificateInfo, len(certs))
+	i.byHost = make(map[string][]string)
+	i.byWildcardSuffix = make(map[string][]string)
+	for _, cert := range certs {
+		if cert.Algorithm == util.CertAlgorithmSM2 {
+			continue
+		}
+		i.certs[cert.CertIdentifier] = cert
+		for _, domain := range helper.CertificateDomains(cert) {
+			domain = strings.ToLower(domain)
+			if strings.HasPrefix(domain, "*.") {
+				suffix := strings.TrimPrefix(domain, "*.")
+				i.byWildcardSuffix[suffix] = appendIfMissing(i.byWildcardSuffix[suffix], cert.CertIdentifier)
+			} else {
+				i.byHost[domain] = appendIfMissing(i.byHost[domain], cert.CertIdentifier)
+			}
+		}
+	}
+	i.loadedAt = time.Now()
+	i.logger.Info("certificate inventory loaded", "certificates", len(i.certs),
+		"hosts", len(i.byHost), "wildcards", len(i.byWildcardSuffix))
+}
+
+func (i *CertInventory) lookup(hosts []string, now time.Time) ([]CertMatch, []string) {
+	var matches []CertMatch
+	var missHosts []string
+	for _, host := range hosts {
+		match, ok := i.lookupHost(host, now)
+		if !ok {
+			missHosts = append(missHosts, host)
+			continue
+		}
+		matches = append(matches, match)
+	}
+	return matches, missHosts
+}
+
+type candidate struct {
+	certId string
+	domain string
+}
+
+func (i *CertInventory) lookupHost(host string, now time.Time) (CertMatch, bool) {
+	lowerHost := strings.ToLower(host)
+	var candidates []candidate
+	for _, certId := range i.byHost[lowerHost] {
+		candidates = append(candidates, candidate{certId: certId, domain: lowerHost})
+	}
+	if labels := strings.SplitN(lowerHost, ".", 2); len(labels) == 2 && labels[0] != "" {
+		for _, certId := range i.byWildcardSuffix[labels[1]] {
+			candidates = append(candidates, candidate{certId: certId, domain: "*." + labels[1]})
+		}
+	}
+
+	var best, newestExpired *candidate
+	var bestNotAfter, newestExpiredNotAfter time.Time
+	for idx := range candidates {
+		c := &candidates[idx]
+		cert := i.certs[c.certId]
+		notAfter := helper.CertificateNotAfter(cert)
+		if cert.BeforeDate != 0 && time.UnixMilli(cert.BeforeDate).After(now) {
+			continue
+		}
+		if !notAfter.After(now) {
+			if isPreferredCertificate(c.certId, notAfter, newestExpired, newestExpiredNotAfter) {
+				newestExpired, newestExpiredNotAfter = c, notAfter
+			}
+			continue
+		}
+		if isPreferredCertificate(c.certId, notAfter, best, bestNotAfter) {
+			best, bestNotAfter = c, notAfter
+		}
+	}
+	if best == nil && newestExpired != nil {
+		return CertMatch{
+			Host:          host,
+			CertificateId: newestExpired.certId,
+			MatchedDomain: newestExpired.domain,
+			NotAfter:      newestExpiredNotAfter,
+			Candidates:    len(candidates),
+			Reason:        "all the matched certificates have expired, the one expires latest is used",
+			Expired:       true,
+		}, true
+	}
+	if best == nil {
+		return CertMatch{}, false
+	}
+	reason := "the only valid certificate matches the host"
+	if len(candidates) > 1 {
+		reason = "the valid certificate expires latest among the matched certificates"
+	}
+	return CertMatch{
+		Host:          host,
+		CertificateId: best.certId,
+		MatchedDomain: best.domain,
+		NotAfter:      bestNotAfter,
+		Candidates:    len(candidates),
+		Reason:        reason,
+	}, true
+}
+
+// isPreferredCertificate prefers the certificate expires latest, and the smaller id for the same expiry to be stable
+func isPreferredCertificate(certId string, notAfter time.Time, best *candidate, bestNotAfter time.Time) bool {
+	return best == nil || notAfter.After(bestNotAfter) ||
+		(notAfter.Equal(bestNotAfter) && certId < best.certId)
+}
+
+func appendIfMissing(ids []string, id string) []string {
+	for _, i := range ids {
+		if i == id {
+			return ids
+		}
+	}
+	return append(ids, id)
+}
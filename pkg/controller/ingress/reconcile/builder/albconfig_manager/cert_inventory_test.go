@@ -0,0 +1,42 @@
+package albconfigmanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model"
+)
+
+func TestCertInventoryLookup(t *testing.T) {
+	now := time.Now()
+	inventory := NewCertInventory(nil, time.Hour, logr.Discard())
+	inventory.Update([]model.CertificateInfo{
+		{CertIdentifier: "exact", CommonName: "www.example.com", AfterDate: now.Add(24 * time.Hour).UnixMilli()},
+		{CertIdentifier: "wildcard", CommonName: "*.example.com", AfterDate: now.Add(48 * time.Hour).UnixMilli()},
+		{CertIdentifier: "expired", CommonName: "old.example.org", AfterDate: now.Add(-time.Hour).UnixMilli()},
+		{CertIdentifier: "expired-earlier", CommonName: "old.example.org", AfterDate: now.Add(-48 * time.Hour).UnixMilli()},
+		{CertIdentifier: "not-yet-valid", CommonName: "new.example.org", BeforeDate: now.Add(time.Hour).UnixMilli(),
+			AfterDate: now.Add(48 * time.Hour).UnixMilli()},
+	})
+
+	matches, missHosts, err := inventory.Lookup(context.TODO(), []string{"WWW.example.com", "a.example.com",
+		"old.example.org", "new.example.org", "a.b.example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"new.example.org", "a.b.example.com"}, missHosts)
+	if assert.Len(t, matches, 3) {
+		// the wildcard certificate expires later than the exact one
+		assert.Equal(t, "wildcard", matches[0].CertificateId)
+		assert.Equal(t, "*.example.com", matches[0].MatchedDomain)
+		assert.Equal(t, 2, matches[0].Candidates)
+		assert.False(t, matches[0].Expired)
+		assert.Equal(t, "wildcard", matches[1].CertificateId)
+		// the newest expired certificate is used if all the certificates have expired
+		assert.Equal(t, "expired", matches[2].CertificateId)
+		assert.True(t, matches[2].Expired)
+	}
+	assert.True(t, inventory.Contains("exact"))
+	assert.False(t, inventory.Contains("unknown"))
+}
@@ -17,6 +17,7 @@ import (
 	"k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/store"
 
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
 	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
 	"k8s.io/alibaba-load-balancer-controller/pkg/util"
@@ -46,6 +47,18 @@ type Group struct {
 	Members []*networking.Ingress
 
 	InactiveMembers []*networking.Ingress
+
+	// Routes are the AlbRoutes attached to the listeners of AlbConfig
+	Routes []*v1.AlbRoute
+
+	// Rejected are the Ingresses and AlbRoutes rejected by the admission policy of AlbConfig
+	Rejected []*RejectedMember
+
+	// Conflicts are the rules shadowed by the rules of other members, found while building the group
+	Conflicts []v1.RuleConflict
+
+	// ExpiredCertificates are the expired certificates used by the hosts without valid certificate
+	ExpiredCertificates []CertMatch
 }
 
 type GroupLoader interface {
@@ -77,6 +90,15 @@ func (m *defaultGroupLoader) Load(ctx context.Context, groupID GroupID, ingress
 	var inactiveMembers []*networking.Ingress
 	var acrdCache = make(map[string]*apiextv1.CustomResourceDefinition)
 	var groupIdCache = make(map[string]*GroupID)
+	var rejected []*RejectedMember
+	albconfig, err := m.loadAlbConfig(ctx, groupID)
+	if err != nil {
+		return nil, err, nil
+	}
+	checker, err := newAdmissionChecker(m.kubeClient, groupID, albconfig)
+	if err != nil {
+		return nil, err, nil
+	}
 	for _, ing := range ingress {
 		groupName := ""
 		if exists := m.annotationParser.ParseStringAnnotation(util.IngressSuffixAlbConfigName, &groupName, ing.Annotations); !exists {
@@ -118,6 +140,18 @@ func (m *defaultGroupLoader) Load(ctx context.Context, groupID GroupID, ingress
 			return nil, errors.Wrapf(err, "ingress: %v", util.NamespacedName(ing)), &ing.Ingress
 		}
 		if isGroupMember {
+			reason, err := checker.check(ctx, ing.Namespace, admissionHostsOfIngress(&ing.Ingress))
+			if err != nil {
+				return nil, errors.Wrapf(err, "ingress: %v", util.NamespacedName(ing)), &ing.Ingress
+			}
+			if reason != "" {
+				rejected = append(rejected, &RejectedMember{Object: &ing.Ingress, Reason: reason})
+				// the rules applied before the Ingress is rejected are cleaned up
+				if m.containsGroupFinalizer(GetIngressFinalizer(), &ing.Ingress) {
+					inactiveMembers = append(inactiveMembers, &ing.Ingress)
+				}
+				continue
+			}
 			members = append(members, &ing.Ingress)
 		} else if m.containsGroupFinalizer(GetIngressFinalizer(), &ing.Ingress) {
 			inactiveMembers = append(inactiveMembers, &ing.Ingress)
@@ -129,13 +163,68 @@ func (m *defaultGroupLoader) Load(ctx context.Context, groupID GroupID, ingress
 	if err != nil {
 		return nil, err, errIngress
 	}
+	routes, rejectedRoutes, err := m.loadRoutes(ctx, groupID, albconfig, checker)
+	if err != nil {
+		return nil, err, nil
+	}
 	return &Group{
 		ID:              groupID,
 		Members:         sortedMembers,
 		InactiveMembers: inactiveMembers,
+		Routes:          routes,
+		Rejected:        append(rejected, rejectedRoutes...),
 	}, nil, nil
 }
 
+// loadAlbConfig gets the AlbConfig of group, or nil if it is not found
+func (m *defaultGroupLoader) loadAlbConfig(ctx context.Context, groupID GroupID) (*v1.AlbConfig, error) {
+	albconfig := &v1.AlbConfig{}
+	if err := m.kubeClient.Get(ctx, types.NamespacedName(groupID), albconfig); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "get albconfig %v", groupID)
+	}
+	return albconfig, nil
+}
+
+// loadRoutes lists the AlbRoutes referencing the AlbConfig, sorted by namespace and name,
+// and the AlbRoutes rejected by the admission policy or invalid for the AlbConfig
+func (m *defaultGroupLoader) loadRoutes(ctx context.Context, groupID GroupID, albconfig *v1.AlbConfig, checker *admissionChecker) ([]*v1.AlbRoute, []*RejectedMember, error) {
+	routeList := &v1.AlbRouteList{}
+	if err := m.kubeClient.List(ctx, routeList); err != nil {
+		return nil, nil, errors.Wrapf(err, "list albroutes")
+	}
+	var routes []*v1.AlbRoute
+	var rejected []*RejectedMember
+	for i := range routeList.Items {
+		route := &routeList.Items[i]
+		if !route.DeletionTimestamp.IsZero() || route.Spec.ParentRef.AlbConfigName != groupID.Name {
+			continue
+		}
+		// albconfig in namespace only accepts routes in the same namespace
+		if groupID.Namespace != ALBConfigNamespace && route.Namespace != groupID.Namespace {
+			continue
+		}
+		reason, err := checker.check(ctx, route.Namespace, admissionHostsOfAlbRoute(route))
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "albroute: %v", util.NamespacedName(route))
+		}
+		if reason == "" {
+			reason = validateAlbRoute(route, albconfig)
+		}
+		if reason != "" {
+			rejected = append(rejected, &RejectedMember{Object: route, Reason: reason})
+			continue
+		}
+		routes = append(routes, route)
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		return util.NamespacedName(routes[i]).String() < util.NamespacedName(routes[j]).String()
+	})
+	return routes, rejected, nil
+}
+
 func (m *defaultGroupLoader) isGroupMember(ctx context.Context, groupID GroupID, ing *networking.Ingress, groupIdCache map[string]*GroupID) (bool, error) {
 	if !ing.DeletionTimestamp.IsZero() {
 		return false, nil
@@ -264,22 +353,11 @@ func (m *defaultGroupLoader) sortGroupMembers(members []*networking.Ingress) ([]
 	groupMemberWithOrderList := make([]groupMemberWithOrder, 0, len(members))
 	explicitOrders := make(map[int64]*networking.Ingress)
 	for _, member := range members {
-		var order = defaultGroupOrder
-		exists := false
-		v := annotations.GetStringAnnotationMutil(util.IngressSuffixAlbConfigOrder, annotations.Order, member)
-		if v != "" {
-			exists = true
-			i, err := strconv.ParseInt(v, 10, 64)
-			if err != nil {
-				return nil, errors.Wrapf(err, "failed to load Ingress group order for ingress: %v", util.NamespacedName(member)), member
-			}
-			order = i
+		order, exists, err := groupOrderOf(member)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load Ingress group order for ingress: %v", util.NamespacedName(member)), member
 		}
 		if exists {
-			if order < minGroupOrder || order > maxGroupOder {
-				return nil, errors.Errorf("explicit Ingress group order must be within [%v:%v], Ingress: %v, order: %v",
-					minGroupOrder, maxGroupOder, util.NamespacedName(member), order), member
-			}
 			if conflictIngress, ok := explicitOrders[order]; ok {
 				return nil, errors.Errorf("conflict Ingress group order: %v, conflict ingress :%v, %v", order, conflictIngress.Name, member.Name), member
 			}
@@ -307,6 +385,25 @@ func (m *defaultGroupLoader) sortGroupMembers(members []*networking.Ingress) ([]
 	return sortedMembers, nil, nil
 }
 
+// groupOrderOf returns the order annotation of an Ingress or AlbRoute, and whether it is set explicitly
+func groupOrderOf(obj metav1.Object) (int64, bool, error) {
+	v, ok := obj.GetAnnotations()[util.IngressSuffixAlbConfigOrder]
+	if !ok {
+		v = obj.GetAnnotations()[annotations.Order]
+	}
+	if v == "" {
+		return defaultGroupOrder, false, nil
+	}
+	order, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, true, err
+	}
+	if order < minGroupOrder || order > maxGroupOder {
+		return 0, true, errors.Errorf("explicit group order must be within [%v:%v], order: %v", minGroupOrder, maxGroupOder, order)
+	}
+	return order, true, nil
+}
+
 func (m *defaultGroupLoader) IngressClass(ing *networking.Ingress) (string, error) {
 	alb := ing.Spec.IngressClassName
 	if alb == nil {
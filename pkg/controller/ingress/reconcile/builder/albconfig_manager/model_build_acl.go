@@ -7,6 +7,7 @@ import (
 	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
 	"k8s.io/alibaba-load-balancer-controller/pkg/model/alb"
 	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 func (t *defaultModelBuildTask) buildAcl(ctx context.Context, ls *alb.Listener, lsSpec *v1.ListenerSpec, lb *alb.AlbLoadBalancer) error {
@@ -28,8 +29,12 @@ func (t *defaultModelBuildTask) buildAcl(ctx context.Context, ls *alb.Listener,
 		entries = append(entries, alb.AclEntry{Entry: cidr})
 	}
 
-	if len(entries) > 0 && len(lsSpec.AclConfig.AclIds) > 0 {
-		return fmt.Errorf("aclEntry and aclIds cannot use together")
+	aclIds, err := t.buildAclIds(ctx, lsSpec.AclConfig)
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 && len(aclIds) > 0 {
+		return fmt.Errorf("aclEntry and aclIds or accessControlLists cannot use together")
 	}
 
 	aclName := lsSpec.AclConfig.AclName
@@ -42,10 +47,32 @@ func (t *defaultModelBuildTask) buildAcl(ctx context.Context, ls *alb.Listener,
 		AclName:    aclName,
 		AclType:    aclType,
 		AclEntries: entries,
-		AclIds:     lsSpec.AclConfig.AclIds,
+		AclIds:     aclIds,
 	}
 
 	aclResID := fmt.Sprintf("%v", lsSpec.Port.String())
 	alb.NewAcl(t.stack, aclResID, *aclSpec)
 	return nil
 }
+
+// buildAclIds returns the aclIds together with the acls of the referenced AccessControlLists,
+// which must have been synced before they are associated with the listener
+func (t *defaultModelBuildTask) buildAclIds(ctx context.Context, aclConfig v1.AclConfig) ([]string, error) {
+	if len(aclConfig.AccessControlLists) == 0 {
+		return aclConfig.AclIds, nil
+	}
+	aclIds := append([]string{}, aclConfig.AclIds...)
+	for _, name := range aclConfig.AccessControlLists {
+		acl := &v1.AccessControlList{}
+		if err := t.kubeClient.Get(ctx, types.NamespacedName{Name: name}, acl); err != nil {
+			return nil, fmt.Errorf("get accesscontrollist %s error: %s", name, err.Error())
+		}
+		if acl.Status.AclID == "" {
+			return nil, fmt.Errorf("accesscontrollist %s is not synced: %s", name, acl.Status.Message)
+		}
+		if !contains(aclIds, acl.Status.AclID) {
+			aclIds = append(aclIds, acl.Status.AclID)
+		}
+	}
+	return aclIds, nil
+}
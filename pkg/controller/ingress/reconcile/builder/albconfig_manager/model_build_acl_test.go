@@ -0,0 +1,42 @@
+package albconfigmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestBuildAclIds(t *testing.T) {
+	s := runtime.NewScheme()
+	assert.NoError(t, v1.SchemeBuilder.AddToScheme(s))
+	kubeClient := fake.NewClientBuilder().WithScheme(s).WithObjects(
+		&v1.AccessControlList{
+			ObjectMeta: metav1.ObjectMeta{Name: "office"},
+			Status:     v1.AccessControlListStatus{AclID: "acl-office"},
+		},
+		&v1.AccessControlList{
+			ObjectMeta: metav1.ObjectMeta{Name: "nodes"},
+			Status:     v1.AccessControlListStatus{Phase: v1.AccessControlListPhaseFailed, Message: "quota exceeded"},
+		},
+	).Build()
+	task := &defaultModelBuildTask{kubeClient: kubeClient}
+	ctx := context.TODO()
+
+	aclIds, err := task.buildAclIds(ctx, v1.AclConfig{AclIds: []string{"acl-1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"acl-1"}, aclIds)
+
+	aclIds, err = task.buildAclIds(ctx, v1.AclConfig{AclIds: []string{"acl-1", "acl-office"}, AccessControlLists: []string{"office"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"acl-1", "acl-office"}, aclIds)
+
+	_, err = task.buildAclIds(ctx, v1.AclConfig{AccessControlLists: []string{"nodes"}})
+	assert.Error(t, err)
+	_, err = task.buildAclIds(ctx, v1.AclConfig{AccessControlLists: []string{"missing"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,299 @@
+package albconfigmanager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model/alb"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model/alb/configcache"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model/alb/core"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	albRouteBackendOwnerPrefix = "albroute-"
+	// albRouteActionTypeForward is the Forward action of AlbRoute, named ForwardGroup in annotations
+	albRouteActionTypeForward = "Forward"
+)
+
+// AlbRouteBackendOwnerName is used in place of the ingress name for the server groups of AlbRoute,
+// the prefix avoids sharing server groups with the Ingress of the same name.
+func AlbRouteBackendOwnerName(route *v1.AlbRoute) string {
+	return albRouteBackendOwnerPrefix + route.Name
+}
+
+// AlbRouteProtocol returns the protocol of the listener referenced by AlbRoute
+func AlbRouteProtocol(route *v1.AlbRoute) Protocol {
+	if route.Spec.ParentRef.Protocol == "" {
+		return ProtocolHTTP
+	}
+	return Protocol(route.Spec.ParentRef.Protocol)
+}
+
+// AlbRouteServiceBackends returns the Services forwarded by AlbRoute
+func AlbRouteServiceBackends(route *v1.AlbRoute) []v1.AlbRouteBackend {
+	var backends []v1.AlbRouteBackend
+	for _, rule := range route.Spec.Rules {
+		for _, action := range rule.Actions {
+			if action.Forward == nil {
+				continue
+			}
+			for _, backend := range action.Forward.Backends {
+				if backend.ServiceName != "" {
+					backends = append(backends, backend)
+				}
+			}
+		}
+	}
+	return backends
+}
+
+// validateAlbRoute returns the reason why the AlbRoute can't be applied to the AlbConfig, or "" if it is valid.
+// An invalid AlbRoute is skipped, so it doesn't fail the other members of the AlbConfig.
+func validateAlbRoute(route *v1.AlbRoute, albconfig *v1.AlbConfig) string {
+	if _, _, err := groupOrderOf(route); err != nil {
+		return fmt.Sprintf("invalid order annotation: %s", err.Error())
+	}
+	for i, rule := range route.Spec.Rules {
+		if len(rule.Conditions) == 0 {
+			return fmt.Sprintf("rule %d has no conditions", i)
+		}
+	}
+	if albconfig == nil {
+		return ""
+	}
+	pp := PortProtocol{Port: route.Spec.ParentRef.Port, Protocol: AlbRouteProtocol(route)}
+	for _, ls := range albconfig.Spec.Listeners {
+		if int32(ls.Port.IntValue()) == pp.Port && Protocol(ls.Protocol) == pp.Protocol {
+			return ""
+		}
+	}
+	return fmt.Sprintf("listener %d/%s not found in albconfig %s", pp.Port, pp.Protocol, albconfig.Name)
+}
+
+func (t *defaultModelBuildTask) buildAlbRouteRules(ctx context.Context, lsID core.StringToken, routes []*v1.AlbRoute) ([]alb.ListenerRule, error) {
+	var rules []alb.ListenerRule
+	for _, route := range routes {
+		// server groups of AlbRoute are built as if they are referenced by an Ingress in the namespace of AlbRoute
+		owner := &networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: route.Namespace,
+				Name:      AlbRouteBackendOwnerName(route),
+			},
+		}
+		for i, rule := range route.Spec.Rules {
+			conditions, err := t.buildAlbRouteConditions(ctx, rule)
+			if err != nil {
+				return nil, errors.Wrapf(err, "buildListenerRules-Conditions(albroute: %v, rule: %d)", util.NamespacedName(route), i)
+			}
+			actions, err := t.buildAlbRouteActions(ctx, owner, rule)
+			if err != nil {
+				return nil, errors.Wrapf(err, "buildListenerRules-Actions(albroute: %v, rule: %d)", util.NamespacedName(route), i)
+			}
+			direction := rule.Direction
+			if direction == "" {
+				direction = util.RuleRequestDirection
+			}
+			rules = append(rules, alb.ListenerRule{
+				Spec: alb.ListenerRuleSpec{
+					ListenerID: lsID,
+					Origin:     albRouteRuleOrigin(route),
+					ALBListenerRuleSpec: alb.ALBListenerRuleSpec{
+						RuleActions:    actions,
+						RuleConditions: conditions,
+						RuleDirection:  direction,
+						RuleName:       albRouteRuleIdentity(route, direction, conditions),
+					},
+				},
+			})
+		}
+	}
+	return rules, nil
+}
+
+func (t *defaultModelBuildTask) buildAlbRouteConditions(ctx context.Context, rule v1.AlbRouteRule) ([]alb.Condition, error) {
+	conditionConfig := make([]configcache.Condition, 0, len(rule.Conditions))
+	for _, cond := range rule.Conditions {
+		var keyValues []configcache.Value
+		for _, kv := range cond.KeyValues {
+			keyValues = append(keyValues, configcache.Value{Key: kv.Key, Value: kv.Value})
+		}
+		conditionConfig = append(conditionConfig, configcache.Condition{
+			Type:                     cond.Type,
+			HostConfig:               configcache.HostConfig{Values: cond.Values},
+			PathConfig:               configcache.PathConfig{Values: cond.Values},
+			MethodConfig:             configcache.MethodConfig{Values: cond.Values},
+			SourceIpConfig:           configcache.SourceIpConfig{Values: cond.Values},
+			ResponseStatusCodeConfig: configcache.ResponseStatusCodeConfig{Values: cond.Values},
+			HeaderConfig:             configcache.HeaderConfig{Key: cond.Key, Values: cond.Values},
+			ResponseHeaderConfig:     configcache.ResponseHeaderConfig{Key: cond.Key, Values: cond.Values},
+			CookieConfig:             configcache.CookieConfig{Values: keyValues},
+			QueryStringConfig:        configcache.QueryStringConfig{Values: keyValues},
+		})
+	}
+	custom, err := parseCustomConditions(conditionConfig)
+	if err != nil {
+		return nil, err
+	}
+	return t.buildCustomConditions(ctx, custom), nil
+}
+
+func (t *defaultModelBuildTask) buildAlbRouteActions(ctx context.Context, owner *networking.Ingress, rule v1.AlbRouteRule) ([]alb.Action, error) {
+	rawActions := make([]alb.Action, 0, len(rule.Actions))
+	for _, action := range rule.Actions {
+		actionCfg, err := transAlbRouteActionToConfig(action)
+		if err != nil {
+			return nil, err
+		}
+		act, err := t.toCustomAction(ctx, owner, actionCfg)
+		if err != nil {
+			return nil, err
+		}
+		rawActions = append(rawActions, act)
+	}
+	return orderRuleActions(rawActions)
+}
+
+// transAlbRouteActionToConfig converts the action of AlbRoute to the action of annotation
+func transAlbRouteActionToConfig(action v1.AlbRouteAction) (configcache.Action, error) {
+	cfg := configcache.Action{Type: action.Type}
+	missing := fmt.Errorf("missing config of action %s", action.Type)
+	actionType := strings.ToLower(action.Type)
+	if strings.EqualFold(action.Type, albRouteActionTypeForward) {
+		actionType = lowerRuleActionTypeForward
+	}
+	switch actionType {
+	case lowerRuleActionTypeForward:
+		if action.Forward == nil {
+			return cfg, missing
+		}
+		cfg.Type = util.RuleActionTypeForward
+		cfg.ForwardConfig = &configcache.ForwardActionConfig{}
+		for _, backend := range action.Forward.Backends {
+			if backend.ServerGroupId == "" && (backend.ServiceName == "" || backend.ServicePort == 0) {
+				return cfg, fmt.Errorf("backend of forward action must be serviceName and servicePort, or serverGroupId")
+			}
+			cfg.ForwardConfig.ServerGroups = append(cfg.ForwardConfig.ServerGroups, configcache.ServerGroupTuple{
+				ServerGroupID: backend.ServerGroupId,
+				ServiceName:   backend.ServiceName,
+				ServicePort:   int(backend.ServicePort),
+				Weight:        int(backend.Weight),
+			})
+		}
+	case lowerRuleActionTypeRedirect:
+		if action.Redirect == nil {
+			return cfg, missing
+		}
+		cfg.RedirectConfig = &configcache.RedirectConfig{
+			Host:     defaultIfEmpty(action.Redirect.Host, "${host}"),
+			HttpCode: defaultIfEmpty(action.Redirect.HttpCode, "301"),
+			Path:     defaultIfEmpty(action.Redirect.Path, "${path}"),
+			Port:     defaultIfEmpty(action.Redirect.Port, "${port}"),
+			Protocol: defaultIfEmpty(action.Redirect.Protocol, "${protocol}"),
+			Query:    defaultIfEmpty(action.Redirect.Query, "${query}"),
+		}
+	case lowerRuleActionTypeFixedResponse:
+		if action.FixedResponse == nil {
+			return cfg, missing
+		}
+		cfg.FixedResponseConfig = &configcache.FixedResponseConfig{
+			Content:     action.FixedResponse.Content,
+			ContentType: action.FixedResponse.ContentType,
+			HttpCode:    action.FixedResponse.HttpCode,
+		}
+	case lowerRuleActionTypeRewrite:
+		if action.Rewrite == nil {
+			return cfg, missing
+		}
+		cfg.RewriteConfig = &configcache.RewriteConfig{
+			Host:  defaultIfEmpty(action.Rewrite.Host, "${host}"),
+			Path:  defaultIfEmpty(action.Rewrite.Path, "${path}"),
+			Query: defaultIfEmpty(action.Rewrite.Query, "${query}"),
+		}
+	case lowerRuleActionTypeInsertHeader:
+		if action.InsertHeader == nil {
+			return cfg, missing
+		}
+		cfg.InsertHeaderConfig = &configcache.InsertHeaderConfig{
+			CoverEnabled: action.InsertHeader.CoverEnabled,
+			Key:          action.InsertHeader.Key,
+			Value:        action.InsertHeader.Value,
+			ValueType:    defaultIfEmpty(action.InsertHeader.ValueType, "UserDefined"),
+		}
+	case lowerRuleActionTypeRemoveHeader:
+		if action.RemoveHeader == nil {
+			return cfg, missing
+		}
+		cfg.RemoveHeaderConfig = &configcache.RemoveHeaderConfig{Key: action.RemoveHeader.Key}
+	case lowerRuleActionTypeTrafficMirror:
+		if action.TrafficMirror == nil {
+			return cfg, missing
+		}
+		cfg.TrafficMirrorConfig = &configcache.TrafficMirrorConfig{TargetType: "ForwardGroupMirror"}
+		for _, backend := range action.TrafficMirror.Backends {
+			if backend.ServerGroupId == "" {
+				return cfg, fmt.Errorf("backend of trafficMirror action must be serverGroupId")
+			}
+			cfg.TrafficMirrorConfig.MirrorGroupConfig.ServerGroupTuples = append(cfg.TrafficMirrorConfig.MirrorGroupConfig.ServerGroupTuples,
+				configcache.TrafficMirrorServerGroupTuple{
+					ServerGroupID: backend.ServerGroupId,
+					Weight:        int(backend.Weight),
+				})
+		}
+	case lowerRuleActionTypeTrafficLimit:
+		if action.TrafficLimit == nil {
+			return cfg, missing
+		}
+		cfg.TrafficLimitConfig = &configcache.TrafficLimitConfig{}
+		if action.TrafficLimit.QPS != 0 {
+			cfg.TrafficLimitConfig.QPS = strconv.Itoa(int(action.TrafficLimit.QPS))
+		}
+		if action.TrafficLimit.QPSPerIp != 0 {
+			cfg.TrafficLimitConfig.QPSPerIp = strconv.Itoa(int(action.TrafficLimit.QPSPerIp))
+		}
+	case lowerRuleActionTypeCors:
+		if action.Cors == nil {
+			return cfg, missing
+		}
+		cfg.CorsConfig = &configcache.CorsConfig{
+			AllowCredentials: "off",
+			MaxAge:           util.DefaultCorsMaxAge,
+			AllowOrigin:      action.Cors.AllowOrigin,
+			AllowMethods:     action.Cors.AllowMethods,
+			AllowHeaders:     action.Cors.AllowHeaders,
+			ExposeHeaders:    action.Cors.ExposeHeaders,
+		}
+		if action.Cors.AllowCredentials {
+			cfg.CorsConfig.AllowCredentials = "on"
+		}
+		if action.Cors.MaxAge != 0 {
+			cfg.CorsConfig.MaxAge = strconv.Itoa(int(action.Cors.MaxAge))
+		}
+		if len(cfg.CorsConfig.AllowOrigin) == 0 {
+			cfg.CorsConfig.AllowOrigin = splitAndTrim(util.DefaultCorsAllowOrigin)
+		}
+		if len(cfg.CorsConfig.AllowMethods) == 0 {
+			cfg.CorsConfig.AllowMethods = splitAndTrim(util.DefaultCorsAllowMethods)
+		}
+		if len(cfg.CorsConfig.AllowHeaders) == 0 {
+			cfg.CorsConfig.AllowHeaders = splitAndTrim(util.DefaultCorsAllowHeaders)
+		}
+	default:
+		return cfg, fmt.Errorf("readAction Failed(unknown action type): %s", action.Type)
+	}
+	return cfg, nil
+}
+
+func defaultIfEmpty(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
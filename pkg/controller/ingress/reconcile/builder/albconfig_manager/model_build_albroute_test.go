@@ -0,0 +1,111 @@
+package albconfigmanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/annotations"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model/alb"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestTransAlbRouteActionToConfig(t *testing.T) {
+	cfg, err := transAlbRouteActionToConfig(v1.AlbRouteAction{
+		Type:     "Redirect",
+		Redirect: &v1.AlbRouteRedirectConfig{Protocol: "https"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "https", cfg.RedirectConfig.Protocol)
+	assert.Equal(t, "${host}", cfg.RedirectConfig.Host)
+	assert.Equal(t, "301", cfg.RedirectConfig.HttpCode)
+
+	cfg, err = transAlbRouteActionToConfig(v1.AlbRouteAction{
+		Type: "Forward",
+		Forward: &v1.AlbRouteForwardConfig{Backends: []v1.AlbRouteBackend{
+			{ServiceName: "svc", ServicePort: 80, Weight: 100},
+		}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "svc", cfg.ForwardConfig.ServerGroups[0].ServiceName)
+	assert.Equal(t, 80, cfg.ForwardConfig.ServerGroups[0].ServicePort)
+
+	_, err = transAlbRouteActionToConfig(v1.AlbRouteAction{Type: "Forward"})
+	assert.Error(t, err)
+	_, err = transAlbRouteActionToConfig(v1.AlbRouteAction{
+		Type:          "TrafficMirror",
+		TrafficMirror: &v1.AlbRouteTrafficMirrorConfig{Backends: []v1.AlbRouteBackend{{ServiceName: "svc", ServicePort: 80}}},
+	})
+	assert.Error(t, err)
+	_, err = transAlbRouteActionToConfig(v1.AlbRouteAction{Type: "Unknown"})
+	assert.Error(t, err)
+}
+
+func TestAlbRouteServiceBackends(t *testing.T) {
+	route := &v1.AlbRoute{Spec: v1.AlbRouteSpec{Rules: []v1.AlbRouteRule{{
+		Actions: []v1.AlbRouteAction{
+			{Type: "Forward", Forward: &v1.AlbRouteForwardConfig{Backends: []v1.AlbRouteBackend{
+				{ServiceName: "svc", ServicePort: 80},
+				{ServerGroupId: "sgp-xxx"},
+			}}},
+			{Type: "RemoveHeader", RemoveHeader: &v1.AlbRouteRemoveHeaderConfig{Key: "x"}},
+		},
+	}}}}
+	backends := AlbRouteServiceBackends(route)
+	assert.Len(t, backends, 1)
+	assert.Equal(t, "svc", backends[0].ServiceName)
+	assert.Equal(t, ProtocolHTTP, AlbRouteProtocol(route))
+}
+
+func TestValidateAlbRoute(t *testing.T) {
+	albconfig := &v1.AlbConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec: v1.AlbConfigSpec{Listeners: []*v1.ListenerSpec{
+			{Port: intstr.FromInt(80), Protocol: "HTTP"},
+		}},
+	}
+	route := &v1.AlbRoute{Spec: v1.AlbRouteSpec{
+		ParentRef: v1.AlbRouteParentRef{AlbConfigName: "default", Port: 80},
+		Rules: []v1.AlbRouteRule{{
+			Conditions: []v1.AlbRouteCondition{{Type: "Path", Values: []string{"/*"}}},
+		}},
+	}}
+	assert.Equal(t, "", validateAlbRoute(route, albconfig))
+
+	missing := route.DeepCopy()
+	missing.Spec.ParentRef.Port = 443
+	assert.Equal(t, "listener 443/HTTP not found in albconfig default", validateAlbRoute(missing, albconfig))
+
+	catchAll := route.DeepCopy()
+	catchAll.Spec.Rules = append(catchAll.Spec.Rules, v1.AlbRouteRule{})
+	assert.Equal(t, "rule 1 has no conditions", validateAlbRoute(catchAll, albconfig))
+
+	invalidOrder := route.DeepCopy()
+	invalidOrder.Annotations = map[string]string{annotations.Order: "0"}
+	assert.Contains(t, validateAlbRoute(invalidOrder, albconfig), "invalid order annotation")
+}
+
+func TestSortRulesByGroupOrder(t *testing.T) {
+	route := &v1.AlbRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "route"}}
+	early := networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "early",
+		Annotations: map[string]string{annotations.Order: "1"}}}
+	late := networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "late"}}
+	ruleOf := func(origin alb.RuleOrigin, name string) alb.ListenerRule {
+		return alb.ListenerRule{Spec: alb.ListenerRuleSpec{Origin: origin, ALBListenerRuleSpec: alb.ALBListenerRuleSpec{RuleName: name}}}
+	}
+	rules := []alb.ListenerRule{
+		ruleOf(albRouteRuleOrigin(route), "route-1"),
+		ruleOf(albRouteRuleOrigin(route), "route-2"),
+		ruleOf(ingressRuleOrigin(&early), "early"),
+		ruleOf(ingressRuleOrigin(&late), "late"),
+	}
+	sortRulesByGroupOrder(rules, groupOrdersOfRuleOrigins([]networking.Ingress{early, late}, []*v1.AlbRoute{route}))
+	var names []string
+	for _, rule := range rules {
+		names = append(names, rule.Spec.RuleName)
+	}
+	// the route of the default order is after the Ingress of a lower order, and before the Ingress of the same order
+	assert.Equal(t, []string{"early", "route-1", "route-2", "late"}, names)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"fmt"
 
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
 	"k8s.io/alibaba-load-balancer-controller/pkg/model/alb"
 	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
@@ -50,6 +51,57 @@ func (t *defaultModelBuildTask) buildSecretCertificateSpec(ctx context.Context,
 	return sc, nil
 }
 
+const defaultCaBundleKey = "ca.crt"
+
+func (t *defaultModelBuildTask) buildSecretCACertificate(ctx context.Context, caBundle *v1.CaBundle, lsPort int) (*alb.SecretCACertificate, error) {
+	crt, source, err := t.loadCaBundle(ctx, caBundle)
+	if err != nil {
+		return nil, err
+	}
+	certName := fmt.Sprintf("%s-%s-%s", caBundle.Namespace, source, computeDigest(t.clusterID, crt))
+	scResID := fmt.Sprintf("%v-ca-%v", lsPort, certName)
+	sc := alb.NewSecretCACertificate(t.stack, scResID, alb.SecretCACertificateSpec{
+		CertName:    certName,
+		Certificate: crt,
+	})
+	return sc, nil
+}
+
+// loadCaBundle returns the ca bundle and the name of the Secret or ConfigMap it comes from
+func (t *defaultModelBuildTask) loadCaBundle(ctx context.Context, caBundle *v1.CaBundle) (string, string, error) {
+	key := caBundle.Key
+	if key == "" {
+		key = defaultCaBundleKey
+	}
+	var crt, source string
+	switch {
+	case caBundle.SecretName != "":
+		secret := &corev1.Secret{}
+		if err := t.kubeClient.Get(ctx, types.NamespacedName{
+			Namespace: caBundle.Namespace,
+			Name:      caBundle.SecretName,
+		}, secret); err != nil {
+			return "", "", err
+		}
+		crt, source = string(secret.Data[key]), caBundle.SecretName
+	case caBundle.ConfigMapName != "":
+		cm := &corev1.ConfigMap{}
+		if err := t.kubeClient.Get(ctx, types.NamespacedName{
+			Namespace: caBundle.Namespace,
+			Name:      caBundle.ConfigMapName,
+		}, cm); err != nil {
+			return "", "", err
+		}
+		crt, source = cm.Data[key], caBundle.ConfigMapName
+	default:
+		return "", "", fmt.Errorf("caBundle requires secretName or configMapName")
+	}
+	if crt == "" {
+		return "", "", fmt.Errorf("caBundle %s/%s has no key %s", caBundle.Namespace, source, key)
+	}
+	return crt, source, nil
+}
+
 func computeDigest(args ...string) string {
 	data := ""
 	for _, a := range args {
@@ -89,6 +89,16 @@ func (t *defaultModelBuildTask) buildListenerSpec(ctx context.Context, lbID core
 		if len(apiLs.CaCertificates) != 0 {
 			modelLs.CaCertificates = transCertificatesFromAPIToSDK(apiLs.CaCertificates)
 		}
+		modelLs.CaEnabled = apiLs.CaEnabled
+		if apiLs.CaBundle != nil {
+			caCert, err := t.buildSecretCACertificate(ctx, apiLs.CaBundle, modelLs.ListenerPort)
+			if err != nil {
+				return alb.ListenerSpec{}, fmt.Errorf("build caBundle of listener %d error: %s", modelLs.ListenerPort, err.Error())
+			}
+			modelLs.CaCertificates = append(modelLs.CaCertificates, caCert)
+			modelLs.CaEnabled = true
+			modelLs.CaManaged = true
+		}
 		if len(modelLs.SecurityPolicyId) == 0 {
 			modelLs.SecurityPolicyId = t.defaultListenerSecurityPolicyId
 		}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
 	"k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/store"
 	"k8s.io/alibaba-load-balancer-controller/pkg/model/alb/configcache"
 
@@ -38,6 +40,7 @@ var (
 	lowerRuleActionTypeForward       = strings.ToLower(util.RuleActionTypeForward)
 	lowerRuleActionTypeRewrite       = strings.ToLower(util.RuleActionTypeRewrite)
 	lowerRuleActionTypeTrafficLimit  = strings.ToLower(util.RuleActionTypeTrafficLimit)
+	lowerRuleActionTypeCors          = strings.ToLower(util.RuleActionTypeCors)
 
 	lowerRuleConditionFieldHost          = strings.ToLower(util.RuleConditionFieldHost)
 	lowerRuleConditionFieldPath          = strings.ToLower(util.RuleConditionFieldPath)
@@ -68,7 +71,12 @@ type canarySGPWithIngress struct {
 	canaryIngress          networking.Ingress
 }
 
-func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsID core.StringToken, port int32, protocol Protocol, ingList []networking.Ingress) error {
+func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsID core.StringToken, port int32, protocol Protocol, ingList []networking.Ingress, routes []*v1.AlbRoute) error {
+	routeRules, err := t.buildAlbRouteRules(ctx, lsID, routes)
+	if err != nil {
+		return err
+	}
+	orders := groupOrdersOfRuleOrigins(ingList, routes)
 	if len(ingList) > 0 {
 		ing := ingList[0]
 		if _, ok := ing.Labels[util.KnativeIngress]; ok {
@@ -80,11 +88,11 @@ func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsID cor
 				}
 			}
 			if oldVersion {
-				return t.buildListenerRulesCommon(ctx, lsID, port, ingList)
+				return t.buildListenerRulesCommon(ctx, lsID, port, protocol, ingList, routeRules, orders)
 			}
 		}
 	}
-	var rules []alb.ListenerRule
+	rules := routeRules
 	canaryServerGroupWithIngress := make(map[string][]canarySGPWithIngress, 0)
 	nonCanaryPath := make(map[string]bool, 0)
 	for _, ing := range ingList {
@@ -176,10 +184,12 @@ func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsID cor
 				rules = append(rules, alb.ListenerRule{
 					Spec: alb.ListenerRuleSpec{
 						ListenerID: lsID,
+						Origin:     ingressRuleOrigin(&ing),
 						ALBListenerRuleSpec: alb.ALBListenerRuleSpec{
 							RuleActions:    actions,
 							RuleConditions: conditions,
 							RuleDirection:  direction,
+							RuleName:       ingressRuleIdentity(&ing, rule.Host, path.Path, direction, conditions),
 						},
 					},
 				})
@@ -187,17 +197,23 @@ func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsID cor
 		}
 	}
 
+	sortRulesByGroupOrder(rules, orders)
+	rules = prioritizeCanaryRules(rules, canaryRuleOrigins(ingList))
+	rules, conflicts := resolveRuleConflicts(fmt.Sprintf("%v/%v", port, protocol), rules)
+	t.ruleConflicts = append(t.ruleConflicts, conflicts...)
+	namer := newListenerRuleNamer(port)
 	priority := 1
 	for _, rule := range rules {
 		ruleResID := fmt.Sprintf("%v-%v:%v", port, protocol, priority)
 		klog.Infof("ruleResID: %s", ruleResID)
 		lrs := alb.ListenerRuleSpec{
 			ListenerID: lsID,
+			Origin:     rule.Spec.Origin,
 			ALBListenerRuleSpec: alb.ALBListenerRuleSpec{
 				Priority:       priority,
 				RuleConditions: rule.Spec.RuleConditions,
 				RuleActions:    rule.Spec.RuleActions,
-				RuleName:       fmt.Sprintf("%v-%v-%v", ListenerRuleNamePrefix, port, priority),
+				RuleName:       namer.name(rule.Spec.RuleName),
 				RuleDirection:  rule.Spec.RuleDirection,
 			},
 		}
@@ -208,6 +224,41 @@ func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsID cor
 	return nil
 }
 
+// groupOrdersOfRuleOrigins returns the order annotations of the Ingresses and AlbRoutes of a listener,
+// which are validated while loading the group.
+func groupOrdersOfRuleOrigins(ingList []networking.Ingress, routes []*v1.AlbRoute) map[alb.RuleOrigin]int64 {
+	orders := make(map[alb.RuleOrigin]int64, len(ingList)+len(routes))
+	for i := range ingList {
+		order, _, _ := groupOrderOf(&ingList[i])
+		orders[ingressRuleOrigin(&ingList[i])] = order
+	}
+	for _, route := range routes {
+		order, _, _ := groupOrderOf(route)
+		orders[albRouteRuleOrigin(route)] = order
+	}
+	return orders
+}
+
+// canaryRuleOrigins returns the canary Ingresses, whose rules are prior to the rules of the stable Ingresses
+func canaryRuleOrigins(ingList []networking.Ingress) map[alb.RuleOrigin]bool {
+	canaries := make(map[alb.RuleOrigin]bool)
+	for i := range ingList {
+		if annotations.GetStringAnnotationMutil(annotations.NginxCanary, annotations.AlbCanary, &ingList[i]) == "true" {
+			canaries[ingressRuleOrigin(&ingList[i])] = true
+		}
+	}
+	return canaries
+}
+
+// sortRulesByGroupOrder orders the rules of Ingresses and AlbRoutes together by the order annotations of
+// their origins. The rules of AlbRoutes come before the rules of Ingresses, so the stable sort keeps them
+// first on the same order, and keeps the rules of the same origin in order.
+func sortRulesByGroupOrder(rules []alb.ListenerRule, orders map[alb.RuleOrigin]int64) {
+	sort.SliceStable(rules, func(i, j int) bool {
+		return orders[rules[i].Spec.Origin] < orders[rules[j].Spec.Origin]
+	})
+}
+
 /*
  * true if rule need config on https listener
  */
@@ -245,8 +296,8 @@ func contains(s []string, e string) bool {
 	return false
 }
 
-func (t *defaultModelBuildTask) buildListenerRulesCommon(ctx context.Context, lsID core.StringToken, port int32, ingList []networking.Ingress) error {
-	var rules []alb.ListenerRule
+func (t *defaultModelBuildTask) buildListenerRulesCommon(ctx context.Context, lsID core.StringToken, port int32, protocol Protocol, ingList []networking.Ingress, routeRules []alb.ListenerRule, orders map[alb.RuleOrigin]int64) error {
+	rules := routeRules
 	for _, ing := range ingList {
 		for _, rule := range ing.Spec.Rules {
 			if rule.HTTP == nil {
@@ -280,9 +331,11 @@ func (t *defaultModelBuildTask) buildListenerRulesCommon(ctx context.Context, ls
 				}
 				lrs := alb.ListenerRuleSpec{
 					ListenerID: lsID,
+					Origin:     ingressRuleOrigin(&ing),
 				}
 				lrs.RuleActions = actions
 				lrs.RuleConditions = conditions
+				lrs.RuleName = ingressRuleIdentity(&ing, rule.Host, path.Path, "", conditions)
 				rules = append(rules, alb.ListenerRule{
 					Spec: lrs,
 				})
@@ -290,17 +343,23 @@ func (t *defaultModelBuildTask) buildListenerRulesCommon(ctx context.Context, ls
 		}
 	}
 
+	sortRulesByGroupOrder(rules, orders)
+	rules, conflicts := resolveRuleConflicts(fmt.Sprintf("%v/%v", port, protocol), rules)
+	t.ruleConflicts = append(t.ruleConflicts, conflicts...)
+	namer := newListenerRuleNamer(port)
 	priority := 1
 	for _, rule := range rules {
 		ruleResID := fmt.Sprintf("%v:%v", port, priority)
 		klog.Infof("ruleResID: %s", ruleResID)
 		lrs := alb.ListenerRuleSpec{
 			ListenerID: lsID,
+			Origin:     rule.Spec.Origin,
 		}
 		lrs.Priority = priority
 		lrs.RuleConditions = rule.Spec.RuleConditions
 		lrs.RuleActions = rule.Spec.RuleActions
-		lrs.RuleName = fmt.Sprintf("%v-%v-%v", ListenerRuleNamePrefix, port, priority)
+		lrs.RuleDirection = rule.Spec.RuleDirection
+		lrs.RuleName = namer.name(rule.Spec.RuleName)
 		_ = alb.NewListenerRule(t.stack, ruleResID, lrs)
 		priority += 1
 	}
@@ -476,12 +535,7 @@ func (t *defaultModelBuildTask) buildRuleDirection(ctx context.Context, rule net
 
 func (t *defaultModelBuildTask) buildRuleConditions(ctx context.Context, rule networking.IngressRule,
 	path networking.HTTPIngressPath, ing networking.Ingress) ([]alb.Condition, error) {
-	var conditions []alb.Condition
-	var hosts []string
-	var paths []string
-	var sourceIps []string
-	var methods []string
-	var statusCodes []string
+	custom := &customConditions{}
 	conditionStr, exist := ing.Annotations[fmt.Sprintf(annotations.INGRESS_ALB_CONDITIONS_ANNOTATIONS, path.Backend.Service.Name)]
 	if exist && conditionStr != "" {
 		conditionConfig := []configcache.Condition{}
@@ -490,98 +544,25 @@ func (t *defaultModelBuildTask) buildRuleConditions(ctx context.Context, rule ne
 			klog.Errorf("buildRuleConditions: %s Unmarshal: %s", conditionStr, err.Error())
 			return nil, err
 		}
-		for _, cond := range conditionConfig {
-			switch strings.ToLower(cond.Type) {
-			case lowerRuleConditionFieldHost:
-				hosts = append(hosts, cond.HostConfig.Values...)
-			case lowerRuleConditionFieldPath:
-				paths = append(paths, cond.PathConfig.Values...)
-			case lowerRuleConditionFieldMethod:
-				methods = append(methods, cond.MethodConfig.Values...)
-			case lowerRuleConditionFieldSourceIp:
-				sourceIps = append(sourceIps, cond.SourceIpConfig.Values...)
-			case lowerRuleConditionResponseStatusCode:
-				statusCodes = append(statusCodes, cond.ResponseStatusCodeConfig.Values...)
-			case lowerRuleConditionFieldHeader:
-				headerCondition := alb.Condition{
-					Type: util.RuleConditionFieldHeader,
-					HeaderConfig: alb.HeaderConfig{
-						Key:    cond.HeaderConfig.Key,
-						Values: cond.HeaderConfig.Values,
-					},
-				}
-				conditions = append(conditions, headerCondition)
-			case lowerRuleConditionFieldQueryString:
-				queryValues := make([]alb.Value, 0)
-				for _, value := range cond.QueryStringConfig.Values {
-					queryValues = append(queryValues, alb.Value{
-						Key:   value.Key,
-						Value: value.Value,
-					})
-				}
-				queryStringCondition := alb.Condition{
-					Type: util.RuleConditionFieldQueryString,
-					QueryStringConfig: alb.QueryStringConfig{
-						Values: queryValues,
-					},
-				}
-				conditions = append(conditions, queryStringCondition)
-			case lowerRuleConditionFieldCookie:
-				cookieValues := make([]alb.Value, 0)
-				for _, value := range cond.CookieConfig.Values {
-					cookieValues = append(cookieValues, alb.Value{
-						Key:   value.Key,
-						Value: value.Value,
-					})
-				}
-				cookieCondition := alb.Condition{
-					Type: util.RuleConditionFieldCookie,
-					CookieConfig: alb.CookieConfig{
-						Values: cookieValues,
-					},
-				}
-				conditions = append(conditions, cookieCondition)
-			case lowerRuleConditionResponseHeader:
-				responseHeaderCondition := alb.Condition{
-					Type: util.RuleConditionResponseHeader,
-					ResponseHeaderConfig: alb.ResponseHeaderConfig{
-						Key:    cond.ResponseHeaderConfig.Key,
-						Values: cond.ResponseHeaderConfig.Values,
-					},
-				}
-				conditions = append(conditions, responseHeaderCondition)
-			default:
-				return nil, fmt.Errorf("readCondition Failed(unknown condition type): %s", cond.Type)
-			}
+		custom, err = parseCustomConditions(conditionConfig)
+		if err != nil {
+			return nil, err
 		}
 	}
 
 	if rule.Host != "" {
-		hosts = append(hosts, rule.Host)
+		custom.hosts = append(custom.hosts, rule.Host)
 	}
 	if path.Path != "" {
 		pathPatterns, err := t.buildPathPatterns(path.Path, path.PathType, ing)
 		if err != nil {
 			return nil, err
 		}
-		paths = append(paths, pathPatterns...)
+		custom.paths = append(custom.paths, pathPatterns...)
 	}
 
-	if len(hosts) != 0 {
-		conditions = append(conditions, t.buildHostHeaderCondition(ctx, hosts))
-	}
-	if len(paths) != 0 {
-		conditions = append(conditions, t.buildPathPatternCondition(ctx, paths))
-	}
-	if len(methods) != 0 {
-		conditions = append(conditions, t.buildMethodCondition(ctx, methods))
-	}
-	if len(sourceIps) != 0 {
-		conditions = append(conditions, t.buildSourceIpCondition(ctx, sourceIps))
-	}
-	if len(statusCodes) != 0 {
-		conditions = append(conditions, t.buildResponseStatusCodeCondition(ctx, statusCodes))
-	}
+	conditions := t.buildCustomConditions(ctx, custom)
+
 	if v := annotations.GetStringAnnotationMutil(annotations.NginxCanary, annotations.AlbCanary, &ing); v == "true" {
 		header := annotations.GetStringAnnotationMutil(annotations.NginxCanaryByHeader, annotations.AlbCanaryByHeader, &ing)
 		if header != "" {
@@ -601,6 +582,106 @@ func (t *defaultModelBuildTask) buildRuleConditions(ctx context.Context, rule ne
 	return conditions, nil
 }
 
+// customConditions are the conditions of configcache, the values of host, path, method, sourceIp and
+// response status code are merged into one condition each
+type customConditions struct {
+	hosts       []string
+	paths       []string
+	methods     []string
+	sourceIps   []string
+	statusCodes []string
+	conditions  []alb.Condition
+}
+
+func parseCustomConditions(conditionConfig []configcache.Condition) (*customConditions, error) {
+	custom := &customConditions{}
+	for _, cond := range conditionConfig {
+		switch strings.ToLower(cond.Type) {
+		case lowerRuleConditionFieldHost:
+			custom.hosts = append(custom.hosts, cond.HostConfig.Values...)
+		case lowerRuleConditionFieldPath:
+			custom.paths = append(custom.paths, cond.PathConfig.Values...)
+		case lowerRuleConditionFieldMethod:
+			custom.methods = append(custom.methods, cond.MethodConfig.Values...)
+		case lowerRuleConditionFieldSourceIp:
+			custom.sourceIps = append(custom.sourceIps, cond.SourceIpConfig.Values...)
+		case lowerRuleConditionResponseStatusCode:
+			custom.statusCodes = append(custom.statusCodes, cond.ResponseStatusCodeConfig.Values...)
+		case lowerRuleConditionFieldHeader:
+			headerCondition := alb.Condition{
+				Type: util.RuleConditionFieldHeader,
+				HeaderConfig: alb.HeaderConfig{
+					Key:    cond.HeaderConfig.Key,
+					Values: cond.HeaderConfig.Values,
+				},
+			}
+			custom.conditions = append(custom.conditions, headerCondition)
+		case lowerRuleConditionFieldQueryString:
+			queryValues := make([]alb.Value, 0)
+			for _, value := range cond.QueryStringConfig.Values {
+				queryValues = append(queryValues, alb.Value{
+					Key:   value.Key,
+					Value: value.Value,
+				})
+			}
+			queryStringCondition := alb.Condition{
+				Type: util.RuleConditionFieldQueryString,
+				QueryStringConfig: alb.QueryStringConfig{
+					Values: queryValues,
+				},
+			}
+			custom.conditions = append(custom.conditions, queryStringCondition)
+		case lowerRuleConditionFieldCookie:
+			cookieValues := make([]alb.Value, 0)
+			for _, value := range cond.CookieConfig.Values {
+				cookieValues = append(cookieValues, alb.Value{
+					Key:   value.Key,
+					Value: value.Value,
+				})
+			}
+			cookieCondition := alb.Condition{
+				Type: util.RuleConditionFieldCookie,
+				CookieConfig: alb.CookieConfig{
+					Values: cookieValues,
+				},
+			}
+			custom.conditions = append(custom.conditions, cookieCondition)
+		case lowerRuleConditionResponseHeader:
+			responseHeaderCondition := alb.Condition{
+				Type: util.RuleConditionResponseHeader,
+				ResponseHeaderConfig: alb.ResponseHeaderConfig{
+					Key:    cond.ResponseHeaderConfig.Key,
+					Values: cond.ResponseHeaderConfig.Values,
+				},
+			}
+			custom.conditions = append(custom.conditions, responseHeaderCondition)
+		default:
+			return nil, fmt.Errorf("readCondition Failed(unknown condition type): %s", cond.Type)
+		}
+	}
+	return custom, nil
+}
+
+func (t *defaultModelBuildTask) buildCustomConditions(ctx context.Context, custom *customConditions) []alb.Condition {
+	conditions := custom.conditions
+	if len(custom.hosts) != 0 {
+		conditions = append(conditions, t.buildHostHeaderCondition(ctx, custom.hosts))
+	}
+	if len(custom.paths) != 0 {
+		conditions = append(conditions, t.buildPathPatternCondition(ctx, custom.paths))
+	}
+	if len(custom.methods) != 0 {
+		conditions = append(conditions, t.buildMethodCondition(ctx, custom.methods))
+	}
+	if len(custom.sourceIps) != 0 {
+		conditions = append(conditions, t.buildSourceIpCondition(ctx, custom.sourceIps))
+	}
+	if len(custom.statusCodes) != 0 {
+		conditions = append(conditions, t.buildResponseStatusCodeCondition(ctx, custom.statusCodes))
+	}
+	return conditions
+}
+
 func (t *defaultModelBuildTask) buildRuleConditionsCommon(ctx context.Context, rule networking.IngressRule,
 	path networking.HTTPIngressPath, ing networking.Ingress) ([]alb.Condition, error) {
 	var hosts []string
@@ -794,7 +875,6 @@ func (t *defaultModelBuildTask) buildQpsLimitAction(ctx context.Context, qps, qp
 }
 
 func (t *defaultModelBuildTask) buildRuleActions(ctx context.Context, ing *networking.Ingress, path *networking.HTTPIngressPath, canaryWithIngress []canarySGPWithIngress, listen443 bool) ([]alb.Action, error) {
-	actions := make([]alb.Action, 0)
 	rawActions := make([]alb.Action, 0)
 	sslRedirectActions := make([]alb.Action, 0)
 	var extAction alb.Action
@@ -916,6 +996,12 @@ func (t *defaultModelBuildTask) buildRuleActions(ctx context.Context, ing *netwo
 	if hasFinal {
 		rawActions = append(rawActions, finalAction)
 	}
+	return orderRuleActions(rawActions)
+}
+
+// orderRuleActions puts the traffic limit action first, then the extended actions, and the final action last
+func orderRuleActions(rawActions []alb.Action) ([]alb.Action, error) {
+	actions := make([]alb.Action, 0)
 	// buildTrafficLimitAction
 	for _, act := range rawActions {
 		if act.Type == util.RuleActionTypeTrafficLimit {
@@ -1074,6 +1160,18 @@ func (t *defaultModelBuildTask) toCustomAction(ctx context.Context, ing *network
 				Query: action.RewriteConfig.Query,
 			},
 		}
+	case lowerRuleActionTypeCors:
+		toAct = &alb.Action{
+			Type: util.RuleActionTypeCors,
+			CorsConfig: &alb.CorsConfig{
+				AllowCredentials: action.CorsConfig.AllowCredentials,
+				MaxAge:           action.CorsConfig.MaxAge,
+				AllowOrigin:      action.CorsConfig.AllowOrigin,
+				AllowMethods:     action.CorsConfig.AllowMethods,
+				AllowHeaders:     action.CorsConfig.AllowHeaders,
+				ExposeHeaders:    action.CorsConfig.ExposeHeaders,
+			},
+		}
 	case lowerRuleActionTypeTrafficLimit:
 		QpsLimitAction, aErr := t.buildQpsLimitAction(ctx, action.TrafficLimitConfig.QPS, action.TrafficLimitConfig.QPSPerIp, ing)
 		if aErr != nil {
@@ -9,6 +9,8 @@ import (
 	"strconv"
 	"strings"
 
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/helper"
 	"k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/annotations"
 	"k8s.io/alibaba-load-balancer-controller/pkg/model/alb"
 	"k8s.io/alibaba-load-balancer-controller/pkg/util"
@@ -41,6 +43,11 @@ func calServerGroupResourceIDHashUUID(resourceID string) string {
 }
 
 func (t *defaultModelBuildTask) buildServerGroupResourceID(ingKey types.NamespacedName, svcKey types.NamespacedName, port int) string {
+	return ServerGroupResourceID(ingKey, svcKey, port)
+}
+
+// ServerGroupResourceID returns the resource ID the server group of the service port in ingress is tagged by
+func ServerGroupResourceID(ingKey types.NamespacedName, svcKey types.NamespacedName, port int) string {
 	resourceID := fmt.Sprintf("%s/%s-%s:%s", ingKey.Namespace, ingKey.Name, svcKey.Name, fmt.Sprintf("%v", port))
 	return calServerGroupResourceIDHashUUID(resourceID)
 }
@@ -49,7 +56,7 @@ func (t *defaultModelBuildTask) buildServerGroupName(ing *networking.Ingress, sv
 	return fmt.Sprintf("%s-%s-%s", svc.Namespace, svc.Name, fmt.Sprintf("%v", port))
 }
 
-func (t *defaultModelBuildTask) buildServerGroupSpec(_ context.Context,
+func (t *defaultModelBuildTask) buildServerGroupSpec(ctx context.Context,
 	ing *networking.Ingress, svc *corev1.Service, port int) (alb.ServerGroupSpec, error) {
 
 	// preCheck tag value
@@ -64,6 +71,10 @@ func (t *defaultModelBuildTask) buildServerGroupSpec(_ context.Context,
 	if err := checkBackendSchedulerAnnotations(ing); err != nil {
 		return alb.ServerGroupSpec{}, err
 	}
+	policy, err := t.buildServerGroupPolicy(ctx, ing, svc)
+	if err != nil {
+		return alb.ServerGroupSpec{}, err
+	}
 
 	tags := make([]alb.ALBTag, 0)
 	tags = append(tags, []alb.ALBTag{
@@ -96,18 +107,45 @@ func (t *defaultModelBuildTask) buildServerGroupSpec(_ context.Context,
 	var sgpSpec alb.ServerGroupSpec
 	sgpSpec.ServerGroupNamedKey = sgpNameKey
 	sgpSpec.Tags = tags
-	sgpSpec.HealthCheckConfig = buildServerGroupHealthCheckConfig(ing)
+	probe, err := t.buildServerGroupReadinessProbe(ctx, ing, svc, port)
+	if err != nil {
+		return alb.ServerGroupSpec{}, err
+	}
+	sgpSpec.HealthCheckConfig = buildServerGroupHealthCheckConfig(ing, policy, probe)
 	sgpSpec.ServerGroupName = t.buildServerGroupName(ing, svc, port)
-	sgpSpec.UpstreamKeepaliveEnabled = buildServerGroupKeepalived(ing)
-	sgpSpec.Scheduler = t.buildServerGroupScheduler(ing)
-	sgpSpec.UchConfig = t.buildServerGroupUchSchedulerConfig(ing)
+	sgpSpec.UpstreamKeepaliveEnabled = buildServerGroupKeepalived(ing, policy)
+	sgpSpec.Scheduler = t.buildServerGroupScheduler(ing, policy)
+	sgpSpec.UchConfig = t.buildServerGroupUchSchedulerConfig(ing, policy)
 	sgpSpec.Protocol = t.buildServerGroupProtocol(ing)
-	sgpSpec.StickySessionConfig = buildServerGroupStickySessionConfig(ing)
+	sgpSpec.StickySessionConfig = buildServerGroupStickySessionConfig(ing, policy)
+	sgpSpec.SlowStartConfig = buildServerGroupSlowStartConfig(policy)
 	sgpSpec.ServerGroupType = t.defaultServerGroupType
+	if helper.IsIPBackendType(svc) {
+		sgpSpec.ServerGroupType = util.IpServerGroupType
+	}
 	sgpSpec.VpcId = t.vpcID
+	sgpID, err := buildServerGroupAdoptedID(ing, svc, port)
+	if err != nil {
+		return alb.ServerGroupSpec{}, err
+	}
+	sgpSpec.ServerGroupId = sgpID
 	return sgpSpec, nil
 }
 
+// buildServerGroupAdoptedID returns the existing server group adopted for the service port by the
+// adopt-server-groups annotation, which is tagged and updated instead of creating a new one
+func buildServerGroupAdoptedID(ing *networking.Ingress, svc *corev1.Service, port int) (string, error) {
+	raw, ok := ing.Annotations[annotations.AlbAdoptServerGroups]
+	if !ok {
+		return "", nil
+	}
+	adopted := make(map[string]string)
+	if err := json.Unmarshal([]byte(raw), &adopted); err != nil {
+		return "", fmt.Errorf("failed to parse %s: `%s` [%v]", annotations.AlbAdoptServerGroups, raw, err)
+	}
+	return adopted[fmt.Sprintf("%s:%d", svc.Name, port)], nil
+}
+
 func checkBackendSchedulerAnnotations(ing *networking.Ingress) error {
 	if v, ok := ing.Annotations[annotations.AlbBackendScheduler]; ok {
 		switch v {
@@ -126,6 +164,41 @@ func checkBackendSchedulerAnnotations(ing *networking.Ingress) error {
 	return nil
 }
 
+// buildServerGroupPolicy returns the ServerGroupPolicy referenced by the ingress, or by the service if the ingress
+// does not reference one, so that the backends of an ingress can use different policies.
+func (t *defaultModelBuildTask) buildServerGroupPolicy(ctx context.Context,
+	ing *networking.Ingress, svc *corev1.Service) (*v1.ServerGroupPolicy, error) {
+	name := ing.Annotations[annotations.ServerGroupPolicy]
+	if name == "" {
+		name = svc.Annotations[helper.ServerGroupPolicyAnnotation]
+	}
+	policy, err := helper.GetServerGroupPolicy(ctx, t.kubeClient, ing.Namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkServerGroupPolicy(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func checkServerGroupPolicy(policy *v1.ServerGroupPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	if slowStart := policy.Spec.SlowStart; slowStart != nil && slowStart.DurationSeconds != nil &&
+		(*slowStart.DurationSeconds < 30 || *slowStart.DurationSeconds > 900) {
+		return fmt.Errorf("slow start duration [%d] of servergrouppolicy %s must be within [30, 900]",
+			*slowStart.DurationSeconds, policy.Name)
+	}
+	switch strings.ToLower(policy.Spec.Scheduler) {
+	case "", "wrr", "wlc", "sch", "uch":
+		return nil
+	default:
+		return fmt.Errorf("unkown backend scheduler [%s] of servergrouppolicy %s", policy.Spec.Scheduler, policy.Name)
+	}
+}
+
 func checkIngressProtocolAnnotations(ing *networking.Ingress) error {
 	if v, ok := ing.Annotations[annotations.AlbBackendProtocol]; ok && v == "grpc" {
 		if len(ing.Spec.TLS) == 0 {
@@ -163,28 +236,38 @@ func checkIngressProtocolAnnotations(ing *networking.Ingress) error {
 	return nil
 }
 
-func (t *defaultModelBuildTask) buildServerGroupScheduler(ing *networking.Ingress) string {
+func (t *defaultModelBuildTask) buildServerGroupScheduler(ing *networking.Ingress, policy *v1.ServerGroupPolicy) string {
 	backendScheduler := t.defaultServerGroupScheduler
+	scheduler := ""
+	if policy != nil {
+		scheduler = strings.ToLower(policy.Spec.Scheduler)
+	}
 	if v, ok := ing.Annotations[annotations.AlbBackendScheduler]; ok {
-		switch v {
-		case "wrr":
-			backendScheduler = util.ServerGroupSchedulerWrr
-		case "wlc":
-			backendScheduler = util.ServerGroupSchedulerWlc
-		case "sch":
-			backendScheduler = util.ServerGroupSchedulerSch
-		case "uch":
-			backendScheduler = util.ServerGroupSchedulerUch
-		default:
-			backendScheduler = util.ServerGroupSchedulerWrr
-		}
+		scheduler = v
+	}
+	switch scheduler {
+	case "":
+		// keep the default scheduler
+	case "wrr":
+		backendScheduler = util.ServerGroupSchedulerWrr
+	case "wlc":
+		backendScheduler = util.ServerGroupSchedulerWlc
+	case "sch":
+		backendScheduler = util.ServerGroupSchedulerSch
+	case "uch":
+		backendScheduler = util.ServerGroupSchedulerUch
+	default:
+		backendScheduler = util.ServerGroupSchedulerWrr
 	}
 	return backendScheduler
 }
 
-func (t *defaultModelBuildTask) buildServerGroupUchSchedulerConfig(ing *networking.Ingress) alb.UchConfig {
+func (t *defaultModelBuildTask) buildServerGroupUchSchedulerConfig(ing *networking.Ingress, policy *v1.ServerGroupPolicy) alb.UchConfig {
 	uchSchedulerType := util.ServerGroupSchedulerUchType
 	uchSchedulerValue := ""
+	if policy != nil {
+		uchSchedulerValue = policy.Spec.UchKey
+	}
 	if v, ok := ing.Annotations[annotations.AlbBackendUchSchedulerValue]; ok {
 		uchSchedulerValue = v
 	}
@@ -209,32 +292,129 @@ func (t *defaultModelBuildTask) buildServerGroupProtocol(ing *networking.Ingress
 	return backendProtocol
 }
 
-func buildServerGroupHealthCheckConfig(ing *networking.Ingress) alb.HealthCheckConfig {
+// buildServerGroupReadinessProbe returns the health check derived from the readinessProbe of the pods of the service port,
+// or nil if it is not enabled by the annotation or can not be used by the backends of the service.
+func (t *defaultModelBuildTask) buildServerGroupReadinessProbe(ctx context.Context,
+	ing *networking.Ingress, svc *corev1.Service, port int) (*healthCheckProbe, error) {
+	if ing.Annotations[annotations.HealthCheckFromProbe] != "true" {
+		return nil, nil
+	}
+	var svcPort *corev1.ServicePort
+	for i := range svc.Spec.Ports {
+		if int(svc.Spec.Ports[i].Port) == port {
+			svcPort = &svc.Spec.Ports[i]
+		}
+	}
+	if svcPort == nil {
+		return nil, nil
+	}
+	pods, err := helper.ListServicePods(ctx, t.kubeClient, svc)
+	if err != nil {
+		return nil, err
+	}
+	hc := helper.GetReadinessProbeHealthCheck(pods, *svcPort)
+	if hc == nil {
+		return nil, nil
+	}
+	policy, err := helper.GetServiceTrafficPolicy(svc)
+	if err != nil {
+		return nil, err
+	}
+	connectPort, ok := hc.ConnectPort(policy == helper.ENITrafficPolicy || policy == helper.IPTrafficPolicy)
+	if !ok {
+		return nil, nil
+	}
+	return &healthCheckProbe{ProbeHealthCheck: *hc, connectPort: int(connectPort)}, nil
+}
+
+type healthCheckProbe struct {
+	helper.ProbeHealthCheck
+	connectPort int
+}
+
+// buildServerGroupHealthCheckConfig builds the health check from the annotations,
+// which override the readinessProbe, which overrides the ServerGroupPolicy
+func buildServerGroupHealthCheckConfig(ing *networking.Ingress, policy *v1.ServerGroupPolicy,
+	probe *healthCheckProbe) alb.HealthCheckConfig {
 	healthCheckEnabled := util.DefaultServerGroupHealthCheckEnabled
-	if v, ok := ing.Annotations[annotations.HealthCheckEnabled]; ok && v == "true" {
+	healthcheckPath := util.DefaultServerGroupHealthCheckPath
+	healthcheckMethod := util.DefaultServerGroupHealthCheckMethod
+	healthcheckProtocol := util.DefaultServerGroupHealthCheckProtocol
+	healthcheckHost := util.DefaultServerGroupHealthCheckHost
+	healthcheckTimeout := util.DefaultServerGroupHealthCheckTimeout
+	healthCheckInterval := util.DefaultServerGroupHealthCheckInterval
+	healthyThreshold := util.DefaultServerGroupHealthyThreshold
+	unhealthyThreshold := util.DefaultServerGroupUnhealthyThreshold
+	healthyCheckConnectPort := util.DefaultServerGroupHealthCheckConnectPort
+	var healthcheckCodes []string
+	if policy != nil && policy.Spec.HealthCheck != nil {
+		hc := policy.Spec.HealthCheck
+		healthCheckEnabled = hc.Enabled == nil || *hc.Enabled
+		if hc.Protocol != "" {
+			healthcheckProtocol = strings.ToUpper(hc.Protocol)
+		}
+		if hc.Path != "" {
+			healthcheckPath = hc.Path
+		}
+		if hc.Method != "" {
+			healthcheckMethod = strings.ToUpper(hc.Method)
+		}
+		if hc.Host != "" {
+			healthcheckHost = hc.Host
+		}
+		healthcheckCodes = hc.HTTPCodes
+		if hc.TimeoutSeconds != nil {
+			healthcheckTimeout = *hc.TimeoutSeconds
+		}
+		if hc.IntervalSeconds != nil {
+			healthCheckInterval = *hc.IntervalSeconds
+		}
+		if hc.HealthyThreshold != nil {
+			healthyThreshold = *hc.HealthyThreshold
+		}
+		if hc.UnhealthyThreshold != nil {
+			unhealthyThreshold = *hc.UnhealthyThreshold
+		}
+		if hc.ConnectPort != nil {
+			healthyCheckConnectPort = *hc.ConnectPort
+		}
+	}
+	if probe != nil {
 		healthCheckEnabled = true
+		healthcheckProtocol = probe.Protocol
+		if probe.Path != "" {
+			healthcheckPath = probe.Path
+			// the same method as kubelet
+			healthcheckMethod = util.ServerGroupHealthCheckMethodGET
+		}
+		if probe.Host != "" {
+			healthcheckHost = probe.Host
+		}
+		healthcheckTimeout = int(probe.Timeout)
+		healthCheckInterval = int(probe.Interval)
+		healthyThreshold = int(probe.HealthyThreshold)
+		unhealthyThreshold = int(probe.UnhealthyThreshold)
+		healthyCheckConnectPort = probe.connectPort
 	}
 
-	healthcheckPath := util.DefaultServerGroupHealthCheckPath
+	if v, ok := ing.Annotations[annotations.HealthCheckEnabled]; ok {
+		healthCheckEnabled = v == "true"
+	}
 	if v, ok := ing.Annotations[annotations.HealthCheckPath]; ok {
 		healthcheckPath = v
 	}
-	healthcheckMethod := util.DefaultServerGroupHealthCheckMethod
 	if v, ok := ing.Annotations[annotations.HealthCheckMethod]; ok {
 		healthcheckMethod = v
 	}
-	healthcheckProtocol := util.DefaultServerGroupHealthCheckProtocol
 	if v, ok := ing.Annotations[annotations.HealthCheckProtocol]; ok {
 		healthcheckProtocol = v
 	}
-	var healthcheckCodes []string
 	if v, ok := ing.Annotations[annotations.HealthCheckHTTPCode]; ok {
 		healthcheckCodes = strings.Split(v, ",")
 	}
 	if len(healthcheckCodes) == 0 {
 		healthcheckCodes = append(healthcheckCodes, util.DefaultServerGroupHealthCheckHTTPCodes)
 	}
-	healthcheckTimeout := util.DefaultServerGroupHealthCheckTimeout
 	if v, ok := ing.Annotations[annotations.HealthCheckTimeout]; ok {
 		if val, err := strconv.Atoi(v); err != nil {
 			klog.Error(err.Error())
@@ -242,7 +422,6 @@ func buildServerGroupHealthCheckConfig(ing *networking.Ingress) alb.HealthCheckC
 			healthcheckTimeout = val
 		}
 	}
-	healthCheckInterval := util.DefaultServerGroupHealthCheckInterval
 	if v, ok := ing.Annotations[annotations.HealthCheckInterval]; ok {
 		if val, err := strconv.Atoi(v); err != nil {
 			klog.Error(err.Error())
@@ -250,7 +429,6 @@ func buildServerGroupHealthCheckConfig(ing *networking.Ingress) alb.HealthCheckC
 			healthCheckInterval = val
 		}
 	}
-	healthyThreshold := util.DefaultServerGroupHealthyThreshold
 	if v, ok := ing.Annotations[annotations.HealthThreshold]; ok {
 		if val, err := strconv.Atoi(v); err != nil {
 			klog.Error(err.Error())
@@ -258,7 +436,6 @@ func buildServerGroupHealthCheckConfig(ing *networking.Ingress) alb.HealthCheckC
 			healthyThreshold = val
 		}
 	}
-	unhealthyThreshold := util.DefaultServerGroupUnhealthyThreshold
 	if v, ok := ing.Annotations[annotations.UnHealthThreshold]; ok {
 		if val, err := strconv.Atoi(v); err != nil {
 			klog.Error(err.Error())
@@ -266,7 +443,6 @@ func buildServerGroupHealthCheckConfig(ing *networking.Ingress) alb.HealthCheckC
 			unhealthyThreshold = val
 		}
 	}
-	healthyCheckConnectPort := util.DefaultServerGroupHealthCheckConnectPort
 	if v, ok := ing.Annotations[annotations.HealthCheckConnectPort]; ok {
 		if val, err := strconv.Atoi(v); err != nil {
 			klog.Error(err.Error())
@@ -277,7 +453,7 @@ func buildServerGroupHealthCheckConfig(ing *networking.Ingress) alb.HealthCheckC
 	return alb.HealthCheckConfig{
 		HealthCheckConnectPort:         healthyCheckConnectPort,
 		HealthCheckEnabled:             healthCheckEnabled,
-		HealthCheckHost:                util.DefaultServerGroupHealthCheckHost,
+		HealthCheckHost:                healthcheckHost,
 		HealthCheckHttpVersion:         util.DefaultServerGroupHealthCheckHttpVersion,
 		HealthCheckInterval:            healthCheckInterval,
 		HealthCheckMethod:              healthcheckMethod,
@@ -294,16 +470,28 @@ func buildServerGroupHealthCheckConfig(ing *networking.Ingress) alb.HealthCheckC
 	}
 }
 
-func buildServerGroupStickySessionConfig(ing *networking.Ingress) alb.StickySessionConfig {
+func buildServerGroupStickySessionConfig(ing *networking.Ingress, policy *v1.ServerGroupPolicy) alb.StickySessionConfig {
 	sessionStickEnabled := util.DefaultServerGroupStickySessionEnabled
-	if v, ok := ing.Annotations[annotations.SessionStick]; ok && v == "true" {
-		sessionStickEnabled = true
-	}
 	sessionStickType := util.DefaultServerGroupStickySessionType
+	cookie := ""
+	cookieTimeout := util.DefaultServerGroupStickySessionCookieTimeout
+	if policy != nil && policy.Spec.StickySession != nil {
+		ss := policy.Spec.StickySession
+		sessionStickEnabled = ss.Enabled == nil || *ss.Enabled
+		if ss.Type != "" {
+			sessionStickType = ss.Type
+		}
+		cookie = ss.Cookie
+		if ss.CookieTimeoutSeconds != nil {
+			cookieTimeout = *ss.CookieTimeoutSeconds
+		}
+	}
+	if v, ok := ing.Annotations[annotations.SessionStick]; ok {
+		sessionStickEnabled = v == "true"
+	}
 	if v, ok := ing.Annotations[annotations.SessionStickType]; ok {
 		sessionStickType = v
 	}
-	cookieTimeout := util.DefaultServerGroupStickySessionCookieTimeout
 	if v, ok := ing.Annotations[annotations.CookieTimeout]; ok {
 		if val, err := strconv.Atoi(v); err != nil {
 			klog.Error(err.Error())
@@ -312,17 +500,39 @@ func buildServerGroupStickySessionConfig(ing *networking.Ingress) alb.StickySess
 		}
 	}
 	return alb.StickySessionConfig{
-		Cookie:               "",
+		Cookie:               cookie,
 		CookieTimeout:        cookieTimeout,
 		StickySessionEnabled: sessionStickEnabled,
 		StickySessionType:    sessionStickType,
 	}
 }
 
-func buildServerGroupKeepalived(ing *networking.Ingress) bool {
+// buildServerGroupSlowStartConfig builds the slow start of the ServerGroupPolicy, which has no annotations
+func buildServerGroupSlowStartConfig(policy *v1.ServerGroupPolicy) alb.SlowStartConfig {
+	if policy == nil || policy.Spec.SlowStart == nil {
+		return alb.SlowStartConfig{}
+	}
+	slowStart := policy.Spec.SlowStart
+	if slowStart.Enabled != nil && !*slowStart.Enabled {
+		return alb.SlowStartConfig{}
+	}
+	duration := util.DefaultServerGroupSlowStartDuration
+	if slowStart.DurationSeconds != nil {
+		duration = *slowStart.DurationSeconds
+	}
+	return alb.SlowStartConfig{
+		SlowStartEnabled:  true,
+		SlowStartDuration: duration,
+	}
+}
+
+func buildServerGroupKeepalived(ing *networking.Ingress, policy *v1.ServerGroupPolicy) bool {
 	serverGroupUpstreamKeepaliveEnabled := util.DefaultServerGroupUpstreamKeepaliveEnabled
-	if v, ok := ing.Annotations[annotations.AlbBackendKeepalive]; ok && v == "true" {
-		serverGroupUpstreamKeepaliveEnabled = true
+	if policy != nil && policy.Spec.UpstreamKeepaliveEnabled != nil {
+		serverGroupUpstreamKeepaliveEnabled = *policy.Spec.UpstreamKeepaliveEnabled
+	}
+	if v, ok := ing.Annotations[annotations.AlbBackendKeepalive]; ok {
+		serverGroupUpstreamKeepaliveEnabled = v == "true"
 	}
 	return serverGroupUpstreamKeepaliveEnabled
 }
@@ -0,0 +1,93 @@
+package albconfigmanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/annotations"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model/alb"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuildServerGroupFromPolicy(t *testing.T) {
+	enabled, disabled, interval, cookieTimeout := true, false, 10, 600
+	policy := &v1.ServerGroupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "policy"},
+		Spec: v1.ServerGroupPolicySpec{
+			HealthCheck: &v1.ServerGroupPolicyHealthCheck{
+				Protocol:        "http",
+				Path:            "/healthz",
+				Method:          "get",
+				HTTPCodes:       []string{"http_2xx", "http_3xx"},
+				IntervalSeconds: &interval,
+			},
+			StickySession: &v1.ServerGroupPolicyStickySession{
+				Type:                 util.ServerGroupStickySessionTypeInsert,
+				CookieTimeoutSeconds: &cookieTimeout,
+			},
+			Scheduler:                "uch",
+			UchKey:                   "user",
+			UpstreamKeepaliveEnabled: &enabled,
+		},
+	}
+	ing := &networking.Ingress{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "default", Name: "ing",
+		Annotations: map[string]string{
+			annotations.HealthCheckPath:     "/ready",
+			annotations.AlbBackendKeepalive: "false",
+		},
+	}}
+	task := &defaultModelBuildTask{defaultServerGroupScheduler: util.ServerGroupSchedulerWrr}
+
+	hc := buildServerGroupHealthCheckConfig(ing, policy, nil)
+	assert.True(t, hc.HealthCheckEnabled)
+	assert.Equal(t, util.ServerGroupHealthCheckProtocolHTTP, hc.HealthCheckProtocol)
+	assert.Equal(t, util.ServerGroupHealthCheckMethodGET, hc.HealthCheckMethod)
+	assert.Equal(t, "/ready", hc.HealthCheckPath)
+	assert.Equal(t, []string{"http_2xx", "http_3xx"}, hc.HealthCheckHttpCodes)
+	assert.Equal(t, 10, hc.HealthCheckInterval)
+	assert.Equal(t, util.DefaultServerGroupHealthCheckTimeout, hc.HealthCheckTimeout)
+
+	ss := buildServerGroupStickySessionConfig(ing, policy)
+	assert.True(t, ss.StickySessionEnabled)
+	assert.Equal(t, 600, ss.CookieTimeout)
+	assert.False(t, buildServerGroupKeepalived(ing, policy))
+	assert.Equal(t, util.ServerGroupSchedulerUch, task.buildServerGroupScheduler(ing, policy))
+	assert.Equal(t, "user", task.buildServerGroupUchSchedulerConfig(ing, policy).Value)
+
+	policy.Spec.HealthCheck.Enabled = &disabled
+	assert.False(t, buildServerGroupHealthCheckConfig(ing, policy, nil).HealthCheckEnabled)
+	ing.Annotations[annotations.HealthCheckEnabled] = "true"
+	assert.True(t, buildServerGroupHealthCheckConfig(ing, policy, nil).HealthCheckEnabled)
+	ing.Annotations[annotations.AlbBackendScheduler] = "wlc"
+	assert.Equal(t, util.ServerGroupSchedulerWlc, task.buildServerGroupScheduler(ing, policy))
+
+	policy.Spec.Scheduler = "tch"
+	assert.Error(t, checkServerGroupPolicy(policy))
+}
+
+func TestBuildServerGroupSlowStartConfig(t *testing.T) {
+	disabled, duration := false, 120
+	policy := &v1.ServerGroupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "policy"},
+		Spec:       v1.ServerGroupPolicySpec{SlowStart: &v1.ServerGroupPolicySlowStart{}},
+	}
+	assert.Equal(t, alb.SlowStartConfig{}, buildServerGroupSlowStartConfig(nil))
+	assert.Equal(t, alb.SlowStartConfig{
+		SlowStartEnabled: true, SlowStartDuration: util.DefaultServerGroupSlowStartDuration,
+	}, buildServerGroupSlowStartConfig(policy))
+
+	policy.Spec.SlowStart.DurationSeconds = &duration
+	assert.NoError(t, checkServerGroupPolicy(policy))
+	assert.Equal(t, alb.SlowStartConfig{SlowStartEnabled: true, SlowStartDuration: 120},
+		buildServerGroupSlowStartConfig(policy))
+
+	policy.Spec.SlowStart.Enabled = &disabled
+	assert.Equal(t, alb.SlowStartConfig{}, buildServerGroupSlowStartConfig(policy))
+
+	duration = 1000
+	assert.Error(t, checkServerGroupPolicy(policy))
+}
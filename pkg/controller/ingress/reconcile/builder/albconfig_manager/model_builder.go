@@ -32,16 +32,18 @@ type Builder interface {
 var _ Builder = &defaultAlbConfigManagerBuilder{}
 
 type defaultAlbConfigManagerBuilder struct {
-	kubeClient client.Client
-	cloud      prvd.Provider
-	logger     logr.Logger
+	kubeClient    client.Client
+	cloud         prvd.Provider
+	certInventory *CertInventory
+	logger        logr.Logger
 }
 
-func NewDefaultAlbConfigManagerBuilder(kubeClient client.Client, cloud prvd.Provider, logger logr.Logger) *defaultAlbConfigManagerBuilder {
+func NewDefaultAlbConfigManagerBuilder(kubeClient client.Client, cloud prvd.Provider, certInventory *CertInventory, logger logr.Logger) *defaultAlbConfigManagerBuilder {
 	return &defaultAlbConfigManagerBuilder{
-		kubeClient: kubeClient,
-		cloud:      cloud,
-		logger:     logger,
+		kubeClient:    kubeClient,
+		cloud:         cloud,
+		certInventory: certInventory,
+		logger:        logger,
 	}
 }
 
@@ -68,7 +70,7 @@ func (b defaultAlbConfigManagerBuilder) Build(ctx context.Context, albconfig *v1
 		backendServices: make(map[types.NamespacedName]*corev1.Service),
 
 		annotationParser: annotations.NewSuffixAnnotationParser(annotations.DefaultAnnotationsPrefix),
-		certDiscovery:    NewCASCertDiscovery(b.cloud, b.logger),
+		certDiscovery:    NewCASCertDiscovery(b.certInventory, b.logger),
 		vSwitchResolver:  NewDefaultVSwitchResolver(b.cloud, vpcID, b.logger),
 
 		defaultServerGroupScheduler:     util.DefaultServerGroupScheduler,
@@ -85,6 +87,8 @@ func (b defaultAlbConfigManagerBuilder) Build(ctx context.Context, albconfig *v1
 	if err := task.run(ctx); err != nil {
 		return nil, nil, errResultWithIngress, err
 	}
+	ingGroup.Conflicts = task.ruleConflicts
+	ingGroup.ExpiredCertificates = task.expiredCertMatches
 
 	return task.stack, task.loadBalancer, errResultWithIngress, nil
 }
@@ -96,6 +100,8 @@ type defaultModelBuildTask struct {
 	ingGroup             *Group
 	kubeClient           client.Client
 	errResultWithIngress map[*networking.Ingress]error
+	ruleConflicts        []v1.RuleConflict
+	expiredCertMatches   []CertMatch
 
 	clusterID string
 	vpcID     string
@@ -212,12 +218,27 @@ func (t *defaultModelBuildTask) run(ctx context.Context) error {
 			ingListByPort[pp] = append(ingListByPort[pp], *member)
 		}
 	}
+	routesByPort := make(map[PortProtocol][]*v1.AlbRoute)
+	for _, route := range t.ingGroup.Routes {
+		pp := PortProtocol{
+			Port:     route.Spec.ParentRef.Port,
+			Protocol: AlbRouteProtocol(route),
+		}
+		// the AlbRoutes referencing missing listeners are rejected while loading the group
+		if _, ok := lss[pp]; !ok {
+			continue
+		}
+		routesByPort[pp] = append(routesByPort[pp], route)
+		if _, ok := ingListByPort[pp]; !ok {
+			ingListByPort[pp] = nil
+		}
+	}
 	for pp, ingList := range ingListByPort {
 		ls, ok := lss[pp]
 		if !ok {
 			continue
 		}
-		if err := t.buildListenerRules(ctx, ls.ListenerID(), pp.Port, pp.Protocol, ingList); err != nil {
+		if err := t.buildListenerRules(ctx, ls.ListenerID(), pp.Port, pp.Protocol, ingList, routesByPort[pp]); err != nil {
 			return err
 		}
 		if pp.Protocol != ProtocolHTTPS && pp.Protocol != ProtocolQUIC {
@@ -292,7 +313,12 @@ func (t *defaultModelBuildTask) computeHostsInferredTLSCertIDs(ctx context.Conte
 	for _, h := range hosts {
 		dHosts.Insert(h)
 	}
-	return t.certDiscovery.Discover(ctx, dHosts.List())
+	certIDs, expired, err := t.certDiscovery.Discover(ctx, dHosts.List())
+	if err != nil {
+		return nil, err
+	}
+	t.expiredCertMatches = append(t.expiredCertMatches, expired...)
+	return certIDs, nil
 }
 
 func ComputeIngressListenPorts(ing *networking.Ingress) ([]PortProtocol, error) {
@@ -0,0 +1,40 @@
+package albconfigmanager
+
+import (
+	"fmt"
+
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ValidateMultiCluster checks the clusters sharing the ALB instance of albconfig, clusterID is the cluster
+// of the controller
+func ValidateMultiCluster(albconfig *v1.AlbConfig, clusterID string) error {
+	cfg := albconfig.Spec.MultiCluster
+	if cfg == nil {
+		return nil
+	}
+	if cfg.Role != v1.MultiClusterRolePrimary && cfg.Role != v1.MultiClusterRoleMember {
+		return fmt.Errorf("invalid multiCluster role %s, either %s or %s", cfg.Role, v1.MultiClusterRolePrimary, v1.MultiClusterRoleMember)
+	}
+	if ShardingEnabled(albconfig) {
+		return fmt.Errorf("sharding can't be enabled with multiCluster")
+	}
+	clusterIDs := sets.NewString()
+	for _, cluster := range cfg.Clusters {
+		if cluster.ClusterID == "" {
+			return fmt.Errorf("multiCluster clusterID is empty")
+		}
+		if clusterIDs.Has(cluster.ClusterID) {
+			return fmt.Errorf("multiCluster cluster %s is duplicated", cluster.ClusterID)
+		}
+		if cluster.Weight < 0 || cluster.Weight > 100 {
+			return fmt.Errorf("multiCluster weight %d of cluster %s out of range [0, 100]", cluster.Weight, cluster.ClusterID)
+		}
+		clusterIDs.Insert(cluster.ClusterID)
+	}
+	if cfg.Role == v1.MultiClusterRolePrimary && len(cfg.Clusters) != 0 && !clusterIDs.Has(clusterID) {
+		return fmt.Errorf("multiCluster clusters miss the weight of the primary cluster %s", clusterID)
+	}
+	return nil
+}
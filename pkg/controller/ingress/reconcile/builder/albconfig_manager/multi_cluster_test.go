@@ -0,0 +1,30 @@
+package albconfigmanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+)
+
+func TestValidateMultiCluster(t *testing.T) {
+	newAlbConfig := func(role string, clusters ...v1.ClusterWeight) *v1.AlbConfig {
+		return &v1.AlbConfig{Spec: v1.AlbConfigSpec{MultiCluster: &v1.MultiClusterConfig{Role: role, Clusters: clusters}}}
+	}
+	assert.NoError(t, ValidateMultiCluster(&v1.AlbConfig{}, "old"))
+	assert.NoError(t, ValidateMultiCluster(newAlbConfig(v1.MultiClusterRoleMember), "new"))
+	assert.NoError(t, ValidateMultiCluster(newAlbConfig(v1.MultiClusterRolePrimary,
+		v1.ClusterWeight{ClusterID: "old", Weight: 100}, v1.ClusterWeight{ClusterID: "new"}), "old"))
+
+	assert.Error(t, ValidateMultiCluster(newAlbConfig("Secondary"), "old"))
+	assert.Error(t, ValidateMultiCluster(newAlbConfig(v1.MultiClusterRolePrimary,
+		v1.ClusterWeight{ClusterID: "new", Weight: 100}), "old"))
+	assert.Error(t, ValidateMultiCluster(newAlbConfig(v1.MultiClusterRolePrimary,
+		v1.ClusterWeight{ClusterID: "old", Weight: 101}), "old"))
+	assert.Error(t, ValidateMultiCluster(newAlbConfig(v1.MultiClusterRoleMember,
+		v1.ClusterWeight{ClusterID: "old"}, v1.ClusterWeight{ClusterID: "old"}), "new"))
+
+	sharded := newAlbConfig(v1.MultiClusterRolePrimary)
+	sharded.Spec.Sharding = &v1.ShardingConfig{Enabled: true}
+	assert.Error(t, ValidateMultiCluster(sharded, "old"))
+}
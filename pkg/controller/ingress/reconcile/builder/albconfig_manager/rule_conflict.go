@@ -0,0 +1,259 @@
+package albconfigmanager
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model/alb"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// prioritizeCanaryRules moves each rule of canary Ingresses ahead of the first rule of other Ingresses or
+// AlbRoutes shadowing it, that is the rule of its stable Ingress with the same host and path, so the canary
+// rule takes effect regardless of the order of the Ingresses.
+func prioritizeCanaryRules(rules []alb.ListenerRule, canaries map[alb.RuleOrigin]bool) []alb.ListenerRule {
+	if len(canaries) == 0 {
+		return rules
+	}
+	prioritized := make([]alb.ListenerRule, 0, len(rules))
+	for _, rule := range rules {
+		pos := len(prioritized)
+		if canaries[rule.Spec.Origin] {
+			for i, prior := range prioritized {
+				if canaries[prior.Spec.Origin] || ruleDirectionOf(prior) != ruleDirectionOf(rule) {
+					continue
+				}
+				if ruleShadows(prior, rule) {
+					pos = i
+					break
+				}
+			}
+		}
+		prioritized = append(prioritized, alb.ListenerRule{})
+		copy(prioritized[pos+1:], prioritized[pos:])
+		prioritized[pos] = rule
+	}
+	return prioritized
+}
+
+// resolveRuleConflicts finds the rules of a listener shadowed by the rules of other Ingresses or AlbRoutes.
+// The rules are in precedence order: rules of Ingresses and AlbRoutes by the order annotation, AlbRoutes first
+// on the same order, and then namespace/name, with the canary rules prioritized. A rule with the same conditions
+// as a prior rule never matches,
+// so it is dropped; a rule with part of its requests matched by a prior rule is kept after the prior rule.
+func resolveRuleConflicts(listener string, rules []alb.ListenerRule) ([]alb.ListenerRule, []v1.RuleConflict) {
+	var conflicts []v1.RuleConflict
+	resolved := make([]alb.ListenerRule, 0, len(rules))
+	for _, rule := range rules {
+		var conflict *v1.RuleConflict
+		for _, prior := range resolved {
+			if prior.Spec.Origin == rule.Spec.Origin || ruleDirectionOf(prior) != ruleDirectionOf(rule) {
+				continue
+			}
+			if sameConditions(prior.Spec.RuleConditions, rule.Spec.RuleConditions) {
+				conflict = newRuleConflict(listener, v1.RuleConflictTypeExact, prior, rule)
+				break
+			}
+			if conflict == nil && ruleShadows(prior, rule) {
+				conflict = newRuleConflict(listener, v1.RuleConflictTypeOverlap, prior, rule)
+			}
+		}
+		if conflict != nil {
+			conflicts = append(conflicts, *conflict)
+			if conflict.Type == v1.RuleConflictTypeExact {
+				continue
+			}
+		}
+		resolved = append(resolved, rule)
+	}
+	return resolved, conflicts
+}
+
+func newRuleConflict(listener, conflictType string, winner, shadowed alb.ListenerRule) *v1.RuleConflict {
+	return &v1.RuleConflict{
+		Listener:   listener,
+		Type:       conflictType,
+		Winner:     winner.Spec.Origin.String(),
+		Shadowed:   shadowed.Spec.Origin.String(),
+		Conditions: describeConditions(shadowed.Spec.RuleConditions),
+	}
+}
+
+func ruleDirectionOf(rule alb.ListenerRule) string {
+	if rule.Spec.RuleDirection == "" {
+		return util.RuleRequestDirection
+	}
+	return rule.Spec.RuleDirection
+}
+
+// ruleShadows returns whether prior matches some of the paths of rule: the other conditions of prior are
+// all conditions of rule, and a path pattern of prior covers a path pattern of rule.
+func ruleShadows(prior, rule alb.ListenerRule) bool {
+	priorPaths, priorOthers := splitPathConditions(prior.Spec.RuleConditions)
+	rulePaths, ruleOthers := splitPathConditions(rule.Spec.RuleConditions)
+	for cond := range priorOthers {
+		if !ruleOthers[cond] {
+			return false
+		}
+	}
+	if len(priorPaths) == 0 {
+		return true
+	}
+	if len(rulePaths) == 0 {
+		rulePaths = []string{"/*"}
+	}
+	for _, pattern := range rulePaths {
+		for _, priorPattern := range priorPaths {
+			if pathPatternCovers(priorPattern, pattern) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func splitPathConditions(conditions []alb.Condition) ([]string, map[conditionKey]bool) {
+	var paths []string
+	others := make(map[conditionKey]bool)
+	for _, cond := range conditions {
+		if strings.EqualFold(cond.Type, util.RuleConditionFieldPath) {
+			paths = append(paths, cond.PathConfig.Values...)
+			continue
+		}
+		others[conditionKeyOf(cond)] = true
+	}
+	return paths, others
+}
+
+// conditionKey is the comparable form of a condition, regardless of the order of its values, and the case
+// of the hosts, methods and header names, which are matched case-insensitively.
+type conditionKey struct {
+	Type   string
+	Key    string
+	Values string
+}
+
+func conditionKeyOf(cond alb.Condition) conditionKey {
+	key := conditionKey{Type: strings.ToLower(cond.Type)}
+	var values []string
+	keyValues := func(kvs []alb.Value) []string {
+		pairs := make([]string, 0, len(kvs))
+		for _, kv := range kvs {
+			pairs = append(pairs, kv.Key+"="+kv.Value)
+		}
+		return pairs
+	}
+	switch key.Type {
+	case lowerRuleConditionFieldHost:
+		for _, v := range cond.HostConfig.Values {
+			values = append(values, strings.ToLower(v))
+		}
+	case lowerRuleConditionFieldPath:
+		values = append(values, cond.PathConfig.Values...)
+	case lowerRuleConditionFieldMethod:
+		for _, v := range cond.MethodConfig.Values {
+			values = append(values, strings.ToUpper(v))
+		}
+	case lowerRuleConditionFieldHeader:
+		key.Key = strings.ToLower(cond.HeaderConfig.Key)
+		values = append(values, cond.HeaderConfig.Values...)
+	case lowerRuleConditionResponseHeader:
+		key.Key = strings.ToLower(cond.ResponseHeaderConfig.Key)
+		values = append(values, cond.ResponseHeaderConfig.Values...)
+	case lowerRuleConditionFieldCookie:
+		values = keyValues(cond.CookieConfig.Values)
+	case lowerRuleConditionFieldQueryString:
+		values = keyValues(cond.QueryStringConfig.Values)
+	case lowerRuleConditionFieldSourceIp:
+		values = append(values, cond.SourceIpConfig.Values...)
+	case lowerRuleConditionResponseStatusCode:
+		values = append(values, cond.ResponseStatusCodeConfig.Values...)
+	}
+	values = sets.NewString(values...).List()
+	key.Values = strings.Join(values, "\n")
+	return key
+}
+
+// sameConditions returns whether both conditions match the same requests
+func sameConditions(a, b []alb.Condition) bool {
+	keysA := make(map[conditionKey]bool, len(a))
+	for _, cond := range a {
+		keysA[conditionKeyOf(cond)] = true
+	}
+	keysB := make(map[conditionKey]bool, len(b))
+	for _, cond := range b {
+		keysB[conditionKeyOf(cond)] = true
+	}
+	if len(keysA) != len(keysB) {
+		return false
+	}
+	for key := range keysA {
+		if !keysB[key] {
+			return false
+		}
+	}
+	return true
+}
+
+// pathPatternCovers returns whether all paths matched by pattern are matched by prior. Patterns are
+// paths with wildcards * and ?, or regular expressions prefixed by ~ or ~*. A regular expression only
+// covers the patterns without wildcards it matches.
+func pathPatternCovers(prior, pattern string) bool {
+	if prior == pattern || prior == "/*" {
+		return true
+	}
+	if re, ok := pathPatternRegexp(prior); ok {
+		if re == nil || strings.HasPrefix(pattern, "~") || strings.ContainsAny(pattern, "*?") {
+			return false
+		}
+		return re.MatchString(pattern)
+	}
+	if strings.HasPrefix(pattern, "~") || !strings.ContainsAny(prior, "*?") {
+		return false
+	}
+	// the wildcards of pattern are matched literally, which are covered by the wildcards of prior
+	return globRegexp(prior).MatchString(pattern)
+}
+
+// pathPatternRegexp compiles the regular expression of pattern, ok is false if pattern is not a regular expression
+func pathPatternRegexp(pattern string) (re *regexp.Regexp, ok bool) {
+	var expr string
+	switch {
+	case strings.HasPrefix(pattern, "~*"):
+		expr = "(?i)" + strings.TrimPrefix(pattern, "~*")
+	case strings.HasPrefix(pattern, "~"):
+		expr = strings.TrimPrefix(pattern, "~")
+	default:
+		return nil, false
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, true
+	}
+	return re, true
+}
+
+func globRegexp(pattern string) *regexp.Regexp {
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+	return regexp.MustCompile("^" + expr + "$")
+}
+
+func describeConditions(conditions []alb.Condition) string {
+	var parts []string
+	for _, cond := range conditions {
+		switch cond.Type {
+		case util.RuleConditionFieldHost:
+			parts = append(parts, fmt.Sprintf("host %s", strings.Join(cond.HostConfig.Values, ",")))
+		case util.RuleConditionFieldPath:
+			parts = append(parts, fmt.Sprintf("path %s", strings.Join(cond.PathConfig.Values, ",")))
+		default:
+			parts = append(parts, strings.ToLower(cond.Type))
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,99 @@
+package albconfigmanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model/alb"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+)
+
+func newConflictTestRule(owner string, host string, paths ...string) alb.ListenerRule {
+	rule := alb.ListenerRule{Spec: alb.ListenerRuleSpec{
+		Origin: alb.RuleOrigin{Kind: alb.RuleOriginKindIngress, Namespace: "default", Name: owner},
+	}}
+	if host != "" {
+		rule.Spec.RuleConditions = append(rule.Spec.RuleConditions, alb.Condition{
+			Type: util.RuleConditionFieldHost, HostConfig: alb.HostConfig{Values: []string{host}},
+		})
+	}
+	if len(paths) > 0 {
+		rule.Spec.RuleConditions = append(rule.Spec.RuleConditions, alb.Condition{
+			Type: util.RuleConditionFieldPath, PathConfig: alb.PathConfig{Values: paths},
+		})
+	}
+	return rule
+}
+
+func TestResolveRuleConflicts(t *testing.T) {
+	rules := []alb.ListenerRule{
+		newConflictTestRule("a", "a.example.com", "/api", "/api/*"),
+		newConflictTestRule("a", "a.example.com", "/api/v1"),
+		newConflictTestRule("b", "a.example.com", "/api", "/api/*"),
+		newConflictTestRule("c", "a.example.com", "/api/v2"),
+		newConflictTestRule("d", "b.example.com", "/api/v2"),
+		newConflictTestRule("e", "", "/static/*"),
+		newConflictTestRule("f", "b.example.com", "/static/js"),
+	}
+	resolved, conflicts := resolveRuleConflicts("80/HTTP", rules)
+	assert.Len(t, resolved, 6)
+	assert.Equal(t, []v1.RuleConflict{
+		{Listener: "80/HTTP", Type: v1.RuleConflictTypeExact, Winner: "Ingress default/a", Shadowed: "Ingress default/b",
+			Conditions: "host a.example.com path /api,/api/*"},
+		{Listener: "80/HTTP", Type: v1.RuleConflictTypeOverlap, Winner: "Ingress default/a", Shadowed: "Ingress default/c",
+			Conditions: "host a.example.com path /api/v2"},
+		{Listener: "80/HTTP", Type: v1.RuleConflictTypeOverlap, Winner: "Ingress default/e", Shadowed: "Ingress default/f",
+			Conditions: "host b.example.com path /static/js"},
+	}, conflicts)
+
+	// a more specific rule prior to a general one is not a conflict
+	_, conflicts = resolveRuleConflicts("80/HTTP", []alb.ListenerRule{
+		newConflictTestRule("a", "a.example.com", "/api/v1"),
+		newConflictTestRule("b", "a.example.com", "/api", "/api/*"),
+		newConflictTestRule("c", "a.example.com"),
+	})
+	assert.Empty(t, conflicts)
+}
+
+func TestPathPatternCovers(t *testing.T) {
+	assert.True(t, pathPatternCovers("/*", "~*^/api"))
+	assert.True(t, pathPatternCovers("/api/*", "/api/v1/*"))
+	assert.False(t, pathPatternCovers("/api/*", "/api"))
+	assert.False(t, pathPatternCovers("/api", "/api/*"))
+	assert.True(t, pathPatternCovers("~*^/API/v[0-9]+", "/api/v1/users"))
+	assert.False(t, pathPatternCovers("~^/API", "/api/v1"))
+	assert.False(t, pathPatternCovers("~*^/api", "~*^/api/v1"))
+	assert.False(t, pathPatternCovers("~*[", "/api"))
+}
+
+func TestPrioritizeCanaryRules(t *testing.T) {
+	stable := newConflictTestRule("stable", "a.example.com", "/api")
+	other := newConflictTestRule("other", "b.example.com", "/api")
+	canary := newConflictTestRule("canary", "a.example.com", "/api")
+	canary.Spec.RuleConditions = append(canary.Spec.RuleConditions, alb.Condition{
+		Type: util.RuleConditionFieldHeader, HeaderConfig: alb.HeaderConfig{Key: "location", Values: []string{"hz"}},
+	})
+	canaries := map[alb.RuleOrigin]bool{canary.Spec.Origin: true}
+
+	prioritized := prioritizeCanaryRules([]alb.ListenerRule{other, stable, canary}, canaries)
+	assert.Equal(t, []alb.ListenerRule{other, canary, stable}, prioritized)
+	// the canary rule is not shadowed by the stable rule any more
+	_, conflicts := resolveRuleConflicts("80/HTTP", prioritized)
+	assert.Empty(t, conflicts)
+}
+
+func TestSameConditions(t *testing.T) {
+	a := []alb.Condition{
+		{Type: util.RuleConditionFieldHost, HostConfig: alb.HostConfig{Values: []string{"A.example.com", "b.example.com"}}},
+		{Type: util.RuleConditionFieldHeader, HeaderConfig: alb.HeaderConfig{Key: "X-Env", Values: []string{"dev", "test"}}},
+	}
+	b := []alb.Condition{
+		{Type: util.RuleConditionFieldHeader, HeaderConfig: alb.HeaderConfig{Key: "x-env", Values: []string{"test", "dev"}}},
+		{Type: util.RuleConditionFieldHost, HostConfig: alb.HostConfig{Values: []string{"b.example.com", "a.example.com"}}},
+	}
+	assert.True(t, sameConditions(a, b))
+	b[0].HeaderConfig.Values = []string{"Test", "dev"}
+	assert.False(t, sameConditions(a, b))
+	assert.False(t, sameConditions(a, a[:1]))
+}
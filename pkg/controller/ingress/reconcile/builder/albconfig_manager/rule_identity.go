@@ -0,0 +1,71 @@
+package albconfigmanager
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model/alb"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ruleIdentityHashLength is the length of the identity hash in rule name
+const ruleIdentityHashLength = 16
+
+// The identity of a rule is where the rule comes from plus the hash of its conditions. While building,
+// the identity is kept in RuleName of the rule, and is hashed into the final rule name by listenerRuleNamer.
+// The rule applier pairs desired and live rules by name, so a rule keeps its name and its ALB rule
+// when other rules of the listener are added or removed.
+
+func ingressRuleIdentity(ing *networking.Ingress, host, path, direction string, conditions []alb.Condition) string {
+	return fmt.Sprintf("ingress|%s/%s|%s|%s|%s|%s", ing.Namespace, ing.Name, host, path, direction, conditionsHash(conditions))
+}
+
+func albRouteRuleIdentity(route *v1.AlbRoute, direction string, conditions []alb.Condition) string {
+	return fmt.Sprintf("albroute|%s/%s|%s|%s", route.Namespace, route.Name, direction, conditionsHash(conditions))
+}
+
+func ingressRuleOrigin(ing *networking.Ingress) alb.RuleOrigin {
+	return alb.RuleOrigin{Kind: alb.RuleOriginKindIngress, Namespace: ing.Namespace, Name: ing.Name}
+}
+
+func albRouteRuleOrigin(route *v1.AlbRoute) alb.RuleOrigin {
+	return alb.RuleOrigin{Kind: alb.RuleOriginKindAlbRoute, Namespace: route.Namespace, Name: route.Name}
+}
+
+func conditionsHash(conditions []alb.Condition) string {
+	raw, _ := json.Marshal(conditions)
+	return hashString(string(raw))
+}
+
+func hashString(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])[:ruleIdentityHashLength]
+}
+
+// listenerRuleNamer names the rules of a listener after their identities,
+// rules with the same identity are suffixed by their occurrences.
+type listenerRuleNamer struct {
+	port  int32
+	names sets.String
+}
+
+func newListenerRuleNamer(port int32) *listenerRuleNamer {
+	return &listenerRuleNamer{
+		port:  port,
+		names: sets.NewString(),
+	}
+}
+
+func (n *listenerRuleNamer) name(identity string) string {
+	base := fmt.Sprintf("%v-%v-%v", ListenerRuleNamePrefix, n.port, hashString(identity))
+	name := base
+	for i := 2; n.names.Has(name); i++ {
+		name = fmt.Sprintf("%v-%v", base, i)
+	}
+	n.names.Insert(name)
+	return name
+}
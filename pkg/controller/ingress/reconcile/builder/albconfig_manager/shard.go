@@ -0,0 +1,413 @@
+package albconfigmanager
+
+import (
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	shardNameSuffix = "-shard-"
+
+	DefaultMaxShards                      = 5
+	DefaultMaxRulesPerListener            = 100
+	DefaultMaxServerGroupsPerLoadBalancer = 100
+)
+
+// Shard is the part of Ingress group placed onto one ALB instance.
+// The first shard is the ALB instance of AlbConfig, and uses the stack of AlbConfig.
+type Shard struct {
+	Index int
+	Group *Group
+	// Hosts are the hosts of the Ingresses in the shard
+	Hosts []string
+}
+
+func ShardingEnabled(albconfig *v1.AlbConfig) bool {
+	return albconfig.Spec.Sharding != nil && albconfig.Spec.Sharding.Enabled
+}
+
+// ShardGroupID returns the id of the stack of shard
+func ShardGroupID(groupID GroupID, index int) GroupID {
+	if index == 0 {
+		return groupID
+	}
+	return GroupID{
+		Namespace: groupID.Namespace,
+		Name:      fmt.Sprintf("%s%s%d", groupID.Name, shardNameSuffix, index),
+	}
+}
+
+// ShardAlbConfig returns the AlbConfig to build the ALB instance of shard.
+// Shards other than the first one always create their own ALB instances.
+func ShardAlbConfig(albconfig *v1.AlbConfig, index int) *v1.AlbConfig {
+	if index == 0 {
+		return albconfig
+	}
+	shardConfig := albconfig.DeepCopy()
+	if shardConfig.Spec.LoadBalancer != nil {
+		shardConfig.Spec.LoadBalancer.Id = ""
+		if shardConfig.Spec.LoadBalancer.Name != "" {
+			shardConfig.Spec.LoadBalancer.Name = fmt.Sprintf("%s%s%d", shardConfig.Spec.LoadBalancer.Name, shardNameSuffix, index)
+		}
+	}
+	shardConfig.Status.LoadBalancer = v1.LoadBalancerStatus{}
+	shardConfig.Status.Shards = nil
+	for _, shard := range albconfig.Status.Shards {
+		if shard.Index == index {
+			shardConfig.Status.LoadBalancer.Id = shard.Id
+			shardConfig.Status.LoadBalancer.DNSName = shard.DNSName
+		}
+	}
+	return shardConfig
+}
+
+// ShardIndexOfIngress returns the shard of Ingress recorded in the status of AlbConfig
+func ShardIndexOfIngress(albconfig *v1.AlbConfig, ing *networking.Ingress) int {
+	if !ShardingEnabled(albconfig) {
+		return 0
+	}
+	hosts := shardHostsOfIngress(ing)
+	for _, shard := range albconfig.Status.Shards {
+		for _, host := range shard.Hosts {
+			if hosts.Has(host) {
+				return shard.Index
+			}
+		}
+	}
+	return 0
+}
+
+// listenerRules are the numbers of forwarding rules by listener port, the rule quota of ALB is per listener
+type listenerRules map[int32]int
+
+func (r listenerRules) add(other listenerRules) {
+	for port, rules := range other {
+		r[port] += rules
+	}
+}
+
+// max returns the rules of the busiest listener
+func (r listenerRules) max() int {
+	busiest := 0
+	for _, rules := range r {
+		if rules > busiest {
+			busiest = rules
+		}
+	}
+	return busiest
+}
+
+// shardUnit is the Ingresses sharing hosts, which are always placed onto the same shard
+type shardUnit struct {
+	key          string
+	members      []*networking.Ingress
+	hosts        sets.String
+	rules        listenerRules
+	serverGroups int
+	// previous is the shard the unit was placed onto, -1 for new unit
+	previous int
+}
+
+type shardLoad struct {
+	rules        listenerRules
+	serverGroups int
+	units        []*shardUnit
+}
+
+type shardPlanner struct {
+	maxShards       int
+	maxRules        int
+	maxServerGroups int
+	loads           []*shardLoad
+}
+
+// ShardGroup places the Ingresses of group onto shards by host. Hosts keep their shards while there is
+// room, new hosts are placed onto the first shard with room, and the last shards are consolidated into
+// the others once the group shrinks. Ingresses without host and AlbRoutes stay on the first shard.
+func ShardGroup(albconfig *v1.AlbConfig, group *Group) ([]*Shard, error) {
+	if !ShardingEnabled(albconfig) {
+		return []*Shard{{Index: 0, Group: group}}, nil
+	}
+	planner := newShardPlanner(albconfig.Spec.Sharding)
+
+	pinned := &shardUnit{key: "", hosts: sets.NewString(), rules: listenerRules{}, previous: 0}
+	for _, route := range group.Routes {
+		pinned.rules[route.Spec.ParentRef.Port] += len(route.Spec.Rules)
+		pinned.serverGroups += len(AlbRouteServiceBackends(route))
+	}
+	allUnits, err := buildShardUnits(group.Members, previousShardOfHosts(albconfig))
+	if err != nil {
+		return nil, err
+	}
+	var units []*shardUnit
+	for _, unit := range allUnits {
+		if unit.hosts.Has("") {
+			pinned.members = append(pinned.members, unit.members...)
+			pinned.hosts = pinned.hosts.Union(unit.hosts)
+			pinned.rules.add(unit.rules)
+			pinned.serverGroups += unit.serverGroups
+			continue
+		}
+		units = append(units, unit)
+	}
+	if !planner.fits(0, pinned) {
+		return nil, fmt.Errorf("ingresses without host and albroutes need %d rules on a listener and %d server groups, exceed the quota of one alb",
+			pinned.rules.max(), pinned.serverGroups)
+	}
+	planner.place(0, pinned)
+
+	var rest []*shardUnit
+	for _, unit := range units {
+		if unit.previous >= 0 && unit.previous < planner.maxShards && planner.fits(unit.previous, unit) {
+			planner.place(unit.previous, unit)
+			continue
+		}
+		rest = append(rest, unit)
+	}
+	sort.SliceStable(rest, func(i, j int) bool {
+		if rest[i].rules.max() != rest[j].rules.max() {
+			return rest[i].rules.max() > rest[j].rules.max()
+		}
+		return rest[i].serverGroups > rest[j].serverGroups
+	})
+	for _, unit := range rest {
+		if !planner.placeFirstFit(unit, planner.maxShards) {
+			return nil, fmt.Errorf("no room for ingresses of hosts %v on %d albs, %d rules on a listener and %d server groups needed",
+				unit.hosts.List(), planner.maxShards, unit.rules.max(), unit.serverGroups)
+		}
+	}
+	planner.consolidate()
+
+	shards := make([]*Shard, 0, len(planner.loads))
+	for i, load := range planner.loads {
+		shardGroup := &Group{ID: ShardGroupID(group.ID, i)}
+		if i == 0 {
+			shardGroup.InactiveMembers = group.InactiveMembers
+			shardGroup.Routes = group.Routes
+		}
+		hosts := sets.NewString()
+		for _, unit := range load.units {
+			shardGroup.Members = append(shardGroup.Members, unit.members...)
+			hosts = hosts.Union(unit.hosts)
+		}
+		sort.SliceStable(shardGroup.Members, func(i, j int) bool {
+			return util.Key(shardGroup.Members[i]) < util.Key(shardGroup.Members[j])
+		})
+		hosts.Delete("")
+		shards = append(shards, &Shard{
+			Index: i,
+			Group: shardGroup,
+			Hosts: hosts.List(),
+		})
+	}
+	return shards, nil
+}
+
+func newShardPlanner(cfg *v1.ShardingConfig) *shardPlanner {
+	p := &shardPlanner{
+		maxShards:       cfg.MaxShards,
+		maxRules:        cfg.MaxRulesPerListener,
+		maxServerGroups: cfg.MaxServerGroupsPerLoadBalancer,
+	}
+	if p.maxShards <= 0 {
+		p.maxShards = DefaultMaxShards
+	}
+	if p.maxRules <= 0 {
+		p.maxRules = DefaultMaxRulesPerListener
+	}
+	if p.maxServerGroups <= 0 {
+		p.maxServerGroups = DefaultMaxServerGroupsPerLoadBalancer
+	}
+	return p
+}
+
+func (p *shardPlanner) load(index int) *shardLoad {
+	for len(p.loads) <= index {
+		p.loads = append(p.loads, &shardLoad{rules: listenerRules{}})
+	}
+	return p.loads[index]
+}
+
+func (p *shardPlanner) fits(index int, unit *shardUnit) bool {
+	load := p.load(index)
+	for port, rules := range unit.rules {
+		if load.rules[port]+rules > p.maxRules {
+			return false
+		}
+	}
+	return load.serverGroups+unit.serverGroups <= p.maxServerGroups
+}
+
+func (p *shardPlanner) place(index int, unit *shardUnit) {
+	load := p.load(index)
+	load.rules.add(unit.rules)
+	load.serverGroups += unit.serverGroups
+	load.units = append(load.units, unit)
+}
+
+func (p *shardPlanner) placeFirstFit(unit *shardUnit, limit int) bool {
+	for i := 0; i < limit; i++ {
+		if p.fits(i, unit) {
+			p.place(i, unit)
+			return true
+		}
+	}
+	return false
+}
+
+// consolidate moves the units of the last shard onto the others, until the last shard can't be emptied
+func (p *shardPlanner) consolidate() {
+	for last := len(p.loads) - 1; last > 0; last-- {
+		trial := &shardPlanner{
+			maxShards:       p.maxShards,
+			maxRules:        p.maxRules,
+			maxServerGroups: p.maxServerGroups,
+		}
+		for _, load := range p.loads[:last] {
+			rules := listenerRules{}
+			rules.add(load.rules)
+			trial.loads = append(trial.loads, &shardLoad{
+				rules:        rules,
+				serverGroups: load.serverGroups,
+				units:        append([]*shardUnit{}, load.units...),
+			})
+		}
+		for _, unit := range p.loads[last].units {
+			if !trial.placeFirstFit(unit, last) {
+				return
+			}
+		}
+		p.loads = trial.loads
+	}
+}
+
+func previousShardOfHosts(albconfig *v1.AlbConfig) map[string]int {
+	previous := make(map[string]int)
+	for _, shard := range albconfig.Status.Shards {
+		for _, host := range shard.Hosts {
+			previous[host] = shard.Index
+		}
+	}
+	return previous
+}
+
+// buildShardUnits groups the Ingresses sharing hosts
+func buildShardUnits(members []*networking.Ingress, previous map[string]int) ([]*shardUnit, error) {
+	sorted := append([]*networking.Ingress{}, members...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return util.Key(sorted[i]) < util.Key(sorted[j])
+	})
+	var units []*shardUnit
+	unitByHost := make(map[string]*shardUnit)
+	for _, ing := range sorted {
+		hosts := shardHostsOfIngress(ing)
+		rules, err := shardRulesOfIngress(ing)
+		if err != nil {
+			return nil, fmt.Errorf("compute listen ports of ingress %s error: %s", util.Key(ing), err.Error())
+		}
+		unit := &shardUnit{
+			key:          util.Key(ing),
+			members:      []*networking.Ingress{ing},
+			hosts:        hosts,
+			rules:        rules,
+			serverGroups: shardServerGroupsOfIngress(ing),
+			previous:     -1,
+		}
+		// merge the units sharing hosts with the Ingress
+		merged := make(map[*shardUnit]bool)
+		for _, host := range hosts.List() {
+			other, ok := unitByHost[host]
+			if !ok || merged[other] {
+				continue
+			}
+			merged[other] = true
+			if other.key < unit.key {
+				unit.key = other.key
+			}
+			members := make([]*networking.Ingress, 0, len(other.members)+len(unit.members))
+			members = append(members, other.members...)
+			unit.members = append(members, unit.members...)
+			unit.hosts = unit.hosts.Union(other.hosts)
+			unit.rules.add(other.rules)
+			unit.serverGroups += other.serverGroups
+		}
+		for _, host := range unit.hosts.List() {
+			unitByHost[host] = unit
+		}
+		var rest []*shardUnit
+		for _, u := range units {
+			if !merged[u] {
+				rest = append(rest, u)
+			}
+		}
+		units = append(rest, unit)
+	}
+	for _, unit := range units {
+		for _, host := range unit.hosts.List() {
+			if index, ok := previous[host]; ok && (unit.previous == -1 || index < unit.previous) {
+				unit.previous = index
+			}
+		}
+	}
+	sort.SliceStable(units, func(i, j int) bool {
+		return units[i].key < units[j].key
+	})
+	return units, nil
+}
+
+// shardHostsOfIngress returns the hosts of the rules of Ingress, "" stands for the rules without host
+func shardHostsOfIngress(ing *networking.Ingress) sets.String {
+	hosts := sets.NewString()
+	if ing.Spec.DefaultBackend != nil {
+		hosts.Insert("")
+	}
+	for _, rule := range ing.Spec.Rules {
+		hosts.Insert(rule.Host)
+	}
+	return hosts
+}
+
+// shardRulesOfIngress returns the rules of Ingress on each of its listeners
+func shardRulesOfIngress(ing *networking.Ingress) (listenerRules, error) {
+	paths := 0
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP != nil {
+			paths += len(rule.HTTP.Paths)
+		}
+	}
+	listenPorts, err := ComputeIngressListenPorts(ing)
+	if err != nil {
+		return nil, err
+	}
+	rules := listenerRules{}
+	for _, pp := range listenPorts {
+		rules[pp.Port] += paths
+	}
+	return rules, nil
+}
+
+func shardServerGroupsOfIngress(ing *networking.Ingress) int {
+	backends := sets.NewString()
+	addBackend := func(backend *networking.IngressServiceBackend) {
+		if backend != nil {
+			backends.Insert(fmt.Sprintf("%s:%d", backend.Name, backend.Port.Number))
+		}
+	}
+	if ing.Spec.DefaultBackend != nil {
+		addBackend(ing.Spec.DefaultBackend.Service)
+	}
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			addBackend(path.Backend.Service)
+		}
+	}
+	return backends.Len()
+}
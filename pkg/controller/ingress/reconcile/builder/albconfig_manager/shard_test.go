@@ -0,0 +1,124 @@
+package albconfigmanager
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/annotations"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newShardTestIngress(name string, host string, paths int) *networking.Ingress {
+	ing := &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+	rule := networking.IngressRule{Host: host, IngressRuleValue: networking.IngressRuleValue{HTTP: &networking.HTTPIngressRuleValue{}}}
+	for i := 0; i < paths; i++ {
+		rule.HTTP.Paths = append(rule.HTTP.Paths, networking.HTTPIngressPath{
+			Path: fmt.Sprintf("/%d", i),
+			Backend: networking.IngressBackend{Service: &networking.IngressServiceBackend{
+				Name: name, Port: networking.ServiceBackendPort{Number: int32(80 + i)},
+			}},
+		})
+	}
+	ing.Spec.Rules = append(ing.Spec.Rules, rule)
+	return ing
+}
+
+func TestShardGroup(t *testing.T) {
+	albconfig := &v1.AlbConfig{Spec: v1.AlbConfigSpec{Sharding: &v1.ShardingConfig{
+		Enabled: true, MaxShards: 3, MaxRulesPerListener: 4, MaxServerGroupsPerLoadBalancer: 10,
+	}}}
+	group := &Group{
+		ID: GroupID{Namespace: ALBConfigNamespace, Name: "default"},
+		Members: []*networking.Ingress{
+			newShardTestIngress("a", "a.example.com", 2),
+			newShardTestIngress("a2", "a.example.com", 1),
+			newShardTestIngress("b", "b.example.com", 3),
+			newShardTestIngress("c", "", 1),
+		},
+	}
+
+	shards, err := ShardGroup(albconfig, group)
+	assert.NoError(t, err)
+	if assert.Len(t, shards, 2) {
+		// ingresses without host stay on the first shard, ingresses sharing host stay together
+		assert.Equal(t, group.ID, shards[0].Group.ID)
+		assert.Equal(t, []string{"a.example.com"}, shards[0].Hosts)
+		assert.Len(t, shards[0].Group.Members, 3)
+		assert.Equal(t, "default-shard-1", shards[1].Group.ID.Name)
+		assert.Equal(t, []string{"b.example.com"}, shards[1].Hosts)
+	}
+
+	// hosts keep their shards
+	albconfig.Status.Shards = []v1.ShardStatus{
+		{Index: 0, Hosts: []string{"b.example.com"}},
+		{Index: 1, Hosts: []string{"a.example.com"}},
+	}
+	shards, err = ShardGroup(albconfig, group)
+	assert.NoError(t, err)
+	if assert.Len(t, shards, 2) {
+		assert.Equal(t, []string{"b.example.com"}, shards[0].Hosts)
+		assert.Equal(t, []string{"a.example.com"}, shards[1].Hosts)
+		assert.Equal(t, 1, ShardIndexOfIngress(albconfig, group.Members[1]))
+	}
+
+	// the last shard is consolidated once the group shrinks
+	group.Members = []*networking.Ingress{group.Members[0], group.Members[1], group.Members[3]}
+	shards, err = ShardGroup(albconfig, group)
+	assert.NoError(t, err)
+	if assert.Len(t, shards, 1) {
+		assert.Equal(t, []string{"a.example.com"}, shards[0].Hosts)
+	}
+
+	// a shard can't hold the ingresses of a host
+	group.Members = append(group.Members, newShardTestIngress("d", "d.example.com", 5))
+	_, err = ShardGroup(albconfig, group)
+	assert.Error(t, err)
+}
+
+func TestShardGroupRulesPerListener(t *testing.T) {
+	albconfig := &v1.AlbConfig{Spec: v1.AlbConfigSpec{Sharding: &v1.ShardingConfig{
+		Enabled: true, MaxShards: 3, MaxRulesPerListener: 4, MaxServerGroupsPerLoadBalancer: 10,
+	}}}
+	https := newShardTestIngress("b", "b.example.com", 3)
+	https.Annotations = map[string]string{annotations.ListenPorts: `[{"HTTPS":443}]`}
+	group := &Group{
+		ID:      GroupID{Namespace: ALBConfigNamespace, Name: "default"},
+		Members: []*networking.Ingress{newShardTestIngress("a", "a.example.com", 3), https},
+	}
+
+	// the rules on different listeners share one alb
+	shards, err := ShardGroup(albconfig, group)
+	assert.NoError(t, err)
+	if assert.Len(t, shards, 1) {
+		assert.Equal(t, []string{"a.example.com", "b.example.com"}, shards[0].Hosts)
+	}
+
+	// the rules on the same listener are split
+	https.Annotations[annotations.ListenPorts] = `[{"HTTP":80},{"HTTPS":443}]`
+	shards, err = ShardGroup(albconfig, group)
+	assert.NoError(t, err)
+	assert.Len(t, shards, 2)
+
+	https.Annotations[annotations.ListenPorts] = "invalid"
+	_, err = ShardGroup(albconfig, group)
+	assert.Error(t, err)
+}
+
+func TestShardAlbConfig(t *testing.T) {
+	albconfig := &v1.AlbConfig{
+		Spec: v1.AlbConfigSpec{LoadBalancer: &v1.LoadBalancerSpec{Id: "alb-reused", Name: "alb"}},
+		Status: v1.IngressStatus{
+			LoadBalancer: v1.LoadBalancerStatus{Id: "alb-reused", DNSName: "reused.alb.aliyuncs.com"},
+			Shards:       []v1.ShardStatus{{Index: 1, Id: "alb-1", DNSName: "1.alb.aliyuncs.com"}},
+		},
+	}
+	assert.Equal(t, albconfig, ShardAlbConfig(albconfig, 0))
+	shardConfig := ShardAlbConfig(albconfig, 1)
+	assert.Equal(t, "", shardConfig.Spec.LoadBalancer.Id)
+	assert.Equal(t, "alb-shard-1", shardConfig.Spec.LoadBalancer.Name)
+	assert.Equal(t, "alb-1", shardConfig.Status.LoadBalancer.Id)
+	assert.Equal(t, "alb-reused", albconfig.Spec.LoadBalancer.Id)
+}
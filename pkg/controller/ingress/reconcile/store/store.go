@@ -27,6 +27,7 @@ import (
 
 	"k8s.io/alibaba-load-balancer-controller/pkg/model/alb/configcache"
 
+	ctrlCfg "k8s.io/alibaba-load-balancer-controller/pkg/config"
 	"k8s.io/alibaba-load-balancer-controller/pkg/controller/helper"
 	"k8s.io/alibaba-load-balancer-controller/pkg/model/alb"
 
@@ -38,10 +39,12 @@ import (
 	"github.com/eapache/channels"
 
 	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
 	networking "k8s.io/api/networking/v1"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/client-go/informers"
 	clientset "k8s.io/client-go/kubernetes"
 	clientcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -53,6 +56,9 @@ import (
 // IngressFilterFunc decides if an Ingress should be omitted or not
 type IngressFilterFunc func(*Ingress) bool
 
+// ServiceReferrerFunc decides if a Service is referenced by resources other than Ingresses, e.g. AlbRoutes
+type ServiceReferrerFunc func(*corev1.Service) bool
+
 // Storer is the interface that wraps the required methods to gather information
 // about ingresses, services, secrets and ingress annotations.
 type Storer interface {
@@ -75,6 +81,10 @@ type Storer interface {
 	Run(stopCh chan struct{})
 
 	WaitCache(stopCh chan struct{}) (bool, error)
+
+	// SetServiceReferrer sets the func to check if the changed Service needs to sync servers
+	// although it is not referenced by Ingresses
+	SetServiceReferrer(referrer ServiceReferrerFunc)
 }
 
 // Informer defines the required SharedIndexInformers that interact with the API server.
@@ -87,6 +97,9 @@ type Informer struct {
 	Pod          cache.SharedIndexInformer
 	Secret       cache.SharedIndexInformer
 	k8s118       bool
+
+	// EndpointSlice is nil unless the EndpointSlice feature gate is enabled
+	EndpointSlice cache.SharedIndexInformer
 }
 
 // Lister contains object listers (stores).
@@ -126,6 +139,14 @@ func (i *Informer) Run(stopCh chan struct{}) {
 	) {
 		runtime.HandleError(fmt.Errorf("timed out waiting for caches to sync"))
 	}
+	if i.EndpointSlice != nil {
+		go i.EndpointSlice.Run(stopCh)
+		if !cache.WaitForCacheSync(stopCh,
+			i.EndpointSlice.HasSynced,
+		) {
+			runtime.HandleError(fmt.Errorf("timed out waiting for caches to sync"))
+		}
+	}
 	if i.k8s118 {
 		go i.IngressClass.Run(stopCh)
 		if !cache.WaitForCacheSync(stopCh,
@@ -175,6 +196,8 @@ type k8sStore struct {
 	// backendConfigMu protects against simultaneous read/write of backendConfig
 	backendConfigMu *sync.RWMutex
 
+	serviceReferrer ServiceReferrerFunc
+
 	k8s118 bool
 }
 
@@ -215,6 +238,11 @@ func New(
 	store.informers.Endpoint = infFactory.Core().V1().Endpoints().Informer()
 	store.listers.Endpoint.Store = store.informers.Endpoint.GetStore()
 
+	// the addresses of ip type backends are also read from endpointslices
+	if utilfeature.DefaultMutableFeatureGate.Enabled(ctrlCfg.EndpointSlice) {
+		store.informers.EndpointSlice = infFactory.Discovery().V1beta1().EndpointSlices().Informer()
+	}
+
 	store.informers.Service = infFactory.Core().V1().Services().Informer()
 	store.listers.Service.Store = store.informers.Service.GetStore()
 
@@ -399,6 +427,30 @@ func New(
 			}
 		},
 	}
+	// an endpointslice changes the servers of its service, which is found by the service name label
+	esEventHandler := func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		es, ok := obj.(*discovery.EndpointSlice)
+		if !ok {
+			return
+		}
+		svcName := es.Labels[discovery.LabelServiceName]
+		if svcName == "" {
+			return
+		}
+		svc, exist, err := store.listers.Service.GetByKey(es.Namespace + "/" + svcName)
+		if err != nil {
+			klog.Error(err, "get service GetByKey by endpointslice failed", "endpointslice", util.NamespacedName(es))
+			return
+		}
+		if !exist {
+			return
+		}
+		klog.Info("controller: endpointslice event", util.NamespacedName(es).String())
+		store.enqueueImpactedSvcIngresses(updateServerCh, helper.EndPointEvent, svc.(*corev1.Service))
+	}
 	podEventHandler := cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			err := store.listers.Pod.Add(obj)
@@ -545,6 +597,18 @@ func New(
 
 	store.informers.Ingress.AddEventHandler(ingEventHandler)
 	store.informers.Endpoint.AddEventHandler(epEventHandler)
+	if store.informers.EndpointSlice != nil {
+		store.informers.EndpointSlice.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    esEventHandler,
+			DeleteFunc: esEventHandler,
+			UpdateFunc: func(old, cur interface{}) {
+				es1, es2 := old.(*discovery.EndpointSlice), cur.(*discovery.EndpointSlice)
+				if !reflect.DeepEqual(es1.Endpoints, es2.Endpoints) || !reflect.DeepEqual(es1.Ports, es2.Ports) {
+					esEventHandler(cur)
+				}
+			},
+		})
+	}
 	store.informers.Node.AddEventHandler(podEventHandler)
 	store.informers.Service.AddEventHandler(serviceHandler)
 	store.informers.Node.AddEventHandler(nodeEventHandler)
@@ -584,6 +648,7 @@ func (s *k8sStore) enqueueImpactedIngresses(updateCh *channels.RingChannel, svc
 
 func (s *k8sStore) enqueueImpactedSvcIngresses(updateCh *channels.RingChannel, eventType helper.EventType, svc *corev1.Service) {
 	ingList := s.listers.Ingress.List()
+	enqueued := false
 
 	for _, t := range ingList {
 		ing := t.(*networking.Ingress)
@@ -652,10 +717,18 @@ func (s *k8sStore) enqueueImpactedSvcIngresses(updateCh *channels.RingChannel, e
 				Type: eventType,
 				Obj:  svc,
 			}
+			enqueued = true
 			break
 		}
 	}
 
+	if !enqueued && s.serviceReferrer != nil && s.serviceReferrer(svc) {
+		updateCh.In() <- helper.Event{
+			Type: eventType,
+			Obj:  svc,
+		}
+	}
+
 	if sgp, ok := svc.Annotations[annotations.AlbServerGroupId]; ok && sgp != "" {
 		updateCh.In() <- helper.Event{
 			Type: eventType,
@@ -784,6 +857,13 @@ func (s *k8sStore) waitCache(stopCh chan struct{}) (bool, error) {
 	) {
 		runtime.HandleError(fmt.Errorf("timed out waiting for caches to sync"))
 	}
+	if s.informers.EndpointSlice != nil {
+		if !cache.WaitForCacheSync(stopCh,
+			s.informers.EndpointSlice.HasSynced,
+		) {
+			runtime.HandleError(fmt.Errorf("timed out waiting for caches to sync"))
+		}
+	}
 	if s.informers.k8s118 {
 		if !cache.WaitForCacheSync(stopCh,
 			s.informers.IngressClass.HasSynced,
@@ -844,6 +924,10 @@ func (s *k8sStore) Run(stopCh chan struct{}) {
 	s.informers.Run(stopCh)
 }
 
+func (s *k8sStore) SetServiceReferrer(referrer ServiceReferrerFunc) {
+	s.serviceReferrer = referrer
+}
+
 func (s *k8sStore) WaitCache(stopCh chan struct{}) (bool, error) {
 	return s.waitCache(stopCh)
 }
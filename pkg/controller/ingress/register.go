@@ -6,6 +6,8 @@ import (
 
 	"k8s.io/alibaba-load-balancer-controller/cmd/health"
 	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/accesscontrollist"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/servergrouppolicy"
 
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apiext "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
@@ -44,6 +46,10 @@ func RegisterCRD(cfg *rest.Config) error {
 	client := crd.NewClient(extc)
 	for _, crd := range []CRD{
 		NewAlbConfigCRD(client),
+		NewAlbRouteCRD(client),
+		NewAlbCanaryCRD(client),
+		servergrouppolicy.NewServerGroupPolicyCRD(client),
+		accesscontrollist.NewAccessControlListCRD(client),
 	} {
 		err := crd.Initialize()
 		if err != nil {
@@ -85,6 +91,33 @@ func (p *AlbConfigCRD) Initialize() error {
 		Version:                 "v1",
 		Scope:                   apiextv1.ClusterScoped,
 		EnableStatusSubresource: true,
+		PrinterColumns: []apiextv1.CustomResourceColumnDefinition{
+			{
+				Name:     "ALBID",
+				Type:     "string",
+				JSONPath: ".status.loadBalancer.id",
+			},
+			{
+				Name:     "DNSNAME",
+				Type:     "string",
+				JSONPath: ".status.loadBalancer.dnsname",
+			},
+			{
+				Name:     "PORT&PROTOCOL",
+				Type:     "string",
+				JSONPath: ".status.loadBalancer.listeners[*].portAndProtocol",
+			},
+			{
+				Name:     "CERTID",
+				Type:     "string",
+				JSONPath: ".status.loadBalancer.listeners[*].certificates[*].certificateId",
+			},
+			{
+				Name:     "AGE",
+				Type:     "date",
+				JSONPath: ".metadata.creationTimestamp",
+			},
+		},
 	}
 
 	return p.crdc.EnsurePresent(crd)
@@ -97,3 +130,119 @@ func (p *AlbConfigCRD) GetListerWatcher() cache.ListerWatcher {
 
 // GetObject satisfies resource.crd interface (and retrieve.Retriever).
 func (p *AlbConfigCRD) GetObject() runtime.Object { return &v1.AlbConfig{} }
+
+// AlbRouteCRD is the namespaced crd declaring listener rules.
+type AlbRouteCRD struct {
+	crdc crd.Interface
+}
+
+func NewAlbRouteCRD(crdClient crd.Interface) *AlbRouteCRD {
+	return &AlbRouteCRD{
+		crdc: crdClient,
+	}
+}
+
+// Initialize satisfies resource.crd interface.
+func (p *AlbRouteCRD) Initialize() error {
+	crd := crd.Conf{
+		Kind:                    "AlbRoute",
+		NamePlural:              "albroutes",
+		Group:                   "alibabacloud.com",
+		Version:                 "v1",
+		Scope:                   apiextv1.NamespaceScoped,
+		EnableStatusSubresource: true,
+		Schema:                  albRouteSchema(),
+		PrinterColumns: []apiextv1.CustomResourceColumnDefinition{
+			{
+				Name:     "ALBCONFIG",
+				Type:     "string",
+				JSONPath: ".spec.parentRef.albConfigName",
+			},
+			{
+				Name:     "PORT",
+				Type:     "integer",
+				JSONPath: ".spec.parentRef.port",
+			},
+			{
+				Name:     "PROTOCOL",
+				Type:     "string",
+				JSONPath: ".spec.parentRef.protocol",
+			},
+			{
+				Name:     "ACCEPTED",
+				Type:     "boolean",
+				JSONPath: ".status.accepted",
+			},
+			{
+				Name:     "AGE",
+				Type:     "date",
+				JSONPath: ".metadata.creationTimestamp",
+			},
+		},
+	}
+
+	return p.crdc.EnsurePresent(crd)
+}
+
+// GetListerWatcher satisfies resource.crd interface (and retrieve.Retriever).
+func (p *AlbRouteCRD) GetListerWatcher() cache.ListerWatcher {
+	return nil
+}
+
+// GetObject satisfies resource.crd interface (and retrieve.Retriever).
+func (p *AlbRouteCRD) GetObject() runtime.Object { return &v1.AlbRoute{} }
+
+// AlbCanaryCRD is the namespaced crd stepping the canary weight of canary Ingresses.
+type AlbCanaryCRD struct {
+	crdc crd.Interface
+}
+
+func NewAlbCanaryCRD(crdClient crd.Interface) *AlbCanaryCRD {
+	return &AlbCanaryCRD{
+		crdc: crdClient,
+	}
+}
+
+// Initialize satisfies resource.crd interface.
+func (p *AlbCanaryCRD) Initialize() error {
+	crd := crd.Conf{
+		Kind:                    "AlbCanary",
+		NamePlural:              "albcanaries",
+		Group:                   "alibabacloud.com",
+		Version:                 "v1",
+		Scope:                   apiextv1.NamespaceScoped,
+		EnableStatusSubresource: true,
+		PrinterColumns: []apiextv1.CustomResourceColumnDefinition{
+			{
+				Name:     "INGRESS",
+				Type:     "string",
+				JSONPath: ".spec.ingressName",
+			},
+			{
+				Name:     "PHASE",
+				Type:     "string",
+				JSONPath: ".status.phase",
+			},
+			{
+				Name:     "WEIGHT",
+				Type:     "integer",
+				JSONPath: ".status.weight",
+			},
+			{
+				Name:     "AGE",
+				Type:     "date",
+				JSONPath: ".metadata.creationTimestamp",
+			},
+		},
+	}
+
+	return p.crdc.EnsurePresent(crd)
+}
+
+// GetListerWatcher satisfies resource.crd interface (and retrieve.Retriever).
+func (p *AlbCanaryCRD) GetListerWatcher() cache.ListerWatcher {
+	return nil
+}
+
+// GetObject satisfies resource.crd interface (and retrieve.Retriever).
+func (p *AlbCanaryCRD) GetObject() runtime.Object { return &v1.AlbCanary{} }
@@ -0,0 +1,78 @@
+package servergroupbinding
+
+import (
+	"context"
+	"reflect"
+
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// mapServiceToBindings enqueues the bindings of the service, it is used for both services and endpoints
+func (r *serverGroupBindingReconciler) mapServiceToBindings(obj client.Object) []reconcile.Request {
+	bindings := &v1.ServerGroupBindingList{}
+	if err := r.kubeClient.List(context.TODO(), bindings, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.logger.Error(err, "list servergroupbindings failed", "namespace", obj.GetNamespace())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, sgb := range bindings.Items {
+		if sgb.Spec.ServiceRef.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: sgb.Namespace, Name: sgb.Name},
+			})
+		}
+	}
+	return requests
+}
+
+// mapNodeToBindings enqueues all the bindings which may add nodes
+func (r *serverGroupBindingReconciler) mapNodeToBindings(obj client.Object) []reconcile.Request {
+	bindings := &v1.ServerGroupBindingList{}
+	if err := r.kubeClient.List(context.TODO(), bindings); err != nil {
+		r.logger.Error(err, "list servergroupbindings failed")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, sgb := range bindings.Items {
+		if sgb.Spec.TargetType == v1.ServerGroupBindingTargetENI || sgb.Spec.TargetType == v1.ServerGroupBindingTargetIP {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: sgb.Namespace, Name: sgb.Name},
+		})
+	}
+	return requests
+}
+
+// nodeChangedPredicate skips the node updates which do not change the ecs targets
+func nodeChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, ok1 := e.ObjectOld.(*corev1.Node)
+			newNode, ok2 := e.ObjectNew.(*corev1.Node)
+			if !ok1 || !ok2 {
+				return true
+			}
+			return !reflect.DeepEqual(oldNode.Labels, newNode.Labels) ||
+				oldNode.Spec.Unschedulable != newNode.Spec.Unschedulable ||
+				oldNode.Spec.ProviderID != newNode.Spec.ProviderID ||
+				!reflect.DeepEqual(oldNode.Spec.Taints, newNode.Spec.Taints) ||
+				nodeReady(oldNode) != nodeReady(newNode)
+		},
+	}
+}
+
+func nodeReady(node *corev1.Node) bool {
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
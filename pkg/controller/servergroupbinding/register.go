@@ -0,0 +1,89 @@
+package servergroupbinding
+
+import (
+	"fmt"
+
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util/crd"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiext "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+func RegisterCRD(cfg *rest.Config) error {
+	extc, err := apiext.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error create incluster client: %s", err.Error())
+	}
+	if err := NewServerGroupBindingCRD(crd.NewClient(extc)).Initialize(); err != nil {
+		return fmt.Errorf("initialize crd: ServerGroupBindingCRD, %s", err.Error())
+	}
+	return nil
+}
+
+// ServerGroupBindingCRD is the namespaced crd binding a Service port to an existing server group.
+type ServerGroupBindingCRD struct {
+	crdc crd.Interface
+}
+
+func NewServerGroupBindingCRD(crdClient crd.Interface) *ServerGroupBindingCRD {
+	return &ServerGroupBindingCRD{
+		crdc: crdClient,
+	}
+}
+
+// Initialize satisfies resource.crd interface.
+func (p *ServerGroupBindingCRD) Initialize() error {
+	crd := crd.Conf{
+		Kind:                    "ServerGroupBinding",
+		NamePlural:              "servergroupbindings",
+		Group:                   "alibabacloud.com",
+		Version:                 "v1",
+		Scope:                   apiextv1.NamespaceScoped,
+		EnableStatusSubresource: true,
+		PrinterColumns: []apiextv1.CustomResourceColumnDefinition{
+			{
+				Name:     "LBTYPE",
+				Type:     "string",
+				JSONPath: ".spec.loadBalancerType",
+			},
+			{
+				Name:     "SERVERGROUP",
+				Type:     "string",
+				JSONPath: ".spec.serverGroupID",
+			},
+			{
+				Name:     "SERVICE",
+				Type:     "string",
+				JSONPath: ".spec.serviceRef.name",
+			},
+			{
+				Name:     "PHASE",
+				Type:     "string",
+				JSONPath: ".status.phase",
+			},
+			{
+				Name:     "SERVERS",
+				Type:     "integer",
+				JSONPath: ".status.servers",
+			},
+			{
+				Name:     "AGE",
+				Type:     "date",
+				JSONPath: ".metadata.creationTimestamp",
+			},
+		},
+	}
+
+	return p.crdc.EnsurePresent(crd)
+}
+
+// GetListerWatcher satisfies resource.crd interface (and retrieve.Retriever).
+func (p *ServerGroupBindingCRD) GetListerWatcher() cache.ListerWatcher {
+	return nil
+}
+
+// GetObject satisfies resource.crd interface (and retrieve.Retriever).
+func (p *ServerGroupBindingCRD) GetObject() runtime.Object { return &v1.ServerGroupBinding{} }
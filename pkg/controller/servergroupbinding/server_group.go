@@ -0,0 +1,332 @@
+package servergroupbinding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	albsdk "github.com/aliyun/alibaba-cloud-sdk-go/services/alb"
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	reconbackend "k8s.io/alibaba-load-balancer-controller/pkg/controller/service/reconcile/backend"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model"
+	albmodel "k8s.io/alibaba-load-balancer-controller/pkg/model/alb"
+	nlbmodel "k8s.io/alibaba-load-balancer-controller/pkg/model/nlb"
+	prvd "k8s.io/alibaba-load-balancer-controller/pkg/provider"
+	"k8s.io/alibaba-load-balancer-controller/pkg/provider/alibaba/base"
+)
+
+// server is a server in the bound server group
+type server struct {
+	target
+	Description string
+}
+
+// serverGroupClient changes the servers of a server group of a type of load balancer
+type serverGroupClient interface {
+	listServers(ctx context.Context, sgId string) ([]server, error)
+	addServers(ctx context.Context, sgId string, targets []target, description string) error
+	removeServers(ctx context.Context, sgId string, targets []target) error
+	updateServers(ctx context.Context, sgId string, targets []target, description string) error
+}
+
+func newServerGroupClient(cloud prvd.Provider, lbType string) (serverGroupClient, error) {
+	switch lbType {
+	case v1.ServerGroupBindingLoadBalancerALB:
+		return &albServerGroupClient{cloud: cloud}, nil
+	case v1.ServerGroupBindingLoadBalancerNLB:
+		return &nlbServerGroupClient{cloud: cloud}, nil
+	case v1.ServerGroupBindingLoadBalancerCLB:
+		return &clbServerGroupClient{cloud: cloud}, nil
+	}
+	return nil, fmt.Errorf("loadBalancerType %q is not supported", lbType)
+}
+
+// getServerDescription returns the description the servers added by the binding are marked by,
+// so that the servers added by others to the same server group are kept
+func getServerDescription(sgb *v1.ServerGroupBinding) string {
+	return fmt.Sprintf("k8s.%s.%s.%s", base.CLUSTER_ID, sgb.Namespace, sgb.Name)
+}
+
+// diffServers returns the servers to add, remove and update, only the servers with the description are removed or updated.
+// A target is not added if the same server is added by others.
+func diffServers(remote []server, local []target, description string) ([]target, []target, []target) {
+	var add, del, update []target
+
+	remoteByKey := make(map[string]server, len(remote))
+	for _, r := range remote {
+		remoteByKey[r.key()] = r
+	}
+	localByKey := make(map[string]bool, len(local))
+	for _, l := range local {
+		localByKey[l.key()] = true
+		r, ok := remoteByKey[l.key()]
+		if !ok {
+			add = append(add, l)
+			continue
+		}
+		if r.Description == description && r.Weight != l.Weight {
+			update = append(update, l)
+		}
+	}
+	for _, r := range remote {
+		if r.Description == description && !localByKey[r.key()] {
+			del = append(del, r.target)
+		}
+	}
+	return add, del, update
+}
+
+// syncServers updates the servers of the server group to the targets, and returns the number of servers of the binding
+func syncServers(ctx context.Context, client serverGroupClient, sgId string, local []target, description string) (int, error) {
+	remote, err := client.listServers(ctx, sgId)
+	if err != nil {
+		return 0, fmt.Errorf("list servers of server group %s error: %s", sgId, err.Error())
+	}
+	add, del, update := diffServers(remote, local, description)
+	if len(add) > 0 {
+		if err := batchTargets(add, func(batch []target) error {
+			return client.addServers(ctx, sgId, batch, description)
+		}); err != nil {
+			return 0, fmt.Errorf("add servers to server group %s error: %s", sgId, err.Error())
+		}
+	}
+	if len(del) > 0 {
+		if err := batchTargets(del, func(batch []target) error {
+			return client.removeServers(ctx, sgId, batch)
+		}); err != nil {
+			return 0, fmt.Errorf("remove servers from server group %s error: %s", sgId, err.Error())
+		}
+	}
+	if len(update) > 0 {
+		if err := batchTargets(update, func(batch []target) error {
+			return client.updateServers(ctx, sgId, batch, description)
+		}); err != nil {
+			return 0, fmt.Errorf("update servers of server group %s error: %s", sgId, err.Error())
+		}
+	}
+	return len(local), nil
+}
+
+// cleanupServers removes all the servers added by the binding
+func cleanupServers(ctx context.Context, client serverGroupClient, sgId string, description string) error {
+	_, err := syncServers(ctx, client, sgId, nil, description)
+	return err
+}
+
+func batchTargets(targets []target, batch func([]target) error) error {
+	return reconbackend.Batch(targets, reconbackend.MaxBackendNum,
+		func(list []interface{}) error {
+			var items []target
+			for _, item := range list {
+				item, _ := item.(target)
+				items = append(items, item)
+			}
+			return batch(items)
+		})
+}
+
+func toTargetType(serverType string) string {
+	switch strings.ToLower(serverType) {
+	case "eni", "eci":
+		return v1.ServerGroupBindingTargetENI
+	case "ip":
+		return v1.ServerGroupBindingTargetIP
+	}
+	return v1.ServerGroupBindingTargetECS
+}
+
+type nlbServerGroupClient struct {
+	cloud prvd.Provider
+}
+
+func (c *nlbServerGroupClient) listServers(ctx context.Context, sgId string) ([]server, error) {
+	servers, err := c.cloud.ListNLBServers(ctx, sgId)
+	if err != nil {
+		return nil, err
+	}
+	var ret []server
+	for _, s := range servers {
+		ret = append(ret, server{
+			target: target{
+				ServerId: s.ServerId, ServerIp: s.ServerIp, Port: s.Port, Weight: s.Weight,
+				Type: toTargetType(string(s.ServerType)),
+			},
+			Description: s.Description,
+		})
+	}
+	return ret, nil
+}
+
+func (c *nlbServerGroupClient) toServers(targets []target, description string) []nlbmodel.ServerGroupServer {
+	var servers []nlbmodel.ServerGroupServer
+	for _, t := range targets {
+		s := nlbmodel.ServerGroupServer{
+			ServerId:    t.ServerId,
+			Port:        t.Port,
+			Weight:      t.Weight,
+			Description: description,
+		}
+		switch t.Type {
+		case v1.ServerGroupBindingTargetENI:
+			s.ServerType = nlbmodel.EniServerType
+			s.ServerIp = t.ServerIp
+		case v1.ServerGroupBindingTargetIP:
+			s.ServerType = nlbmodel.IpServerType
+			s.ServerIp = t.ServerIp
+		default:
+			s.ServerType = nlbmodel.EcsServerType
+		}
+		servers = append(servers, s)
+	}
+	return servers
+}
+
+func (c *nlbServerGroupClient) addServers(ctx context.Context, sgId string, targets []target, description string) error {
+	return c.cloud.AddNLBServers(ctx, sgId, c.toServers(targets, description))
+}
+
+func (c *nlbServerGroupClient) removeServers(ctx context.Context, sgId string, targets []target) error {
+	return c.cloud.RemoveNLBServers(ctx, sgId, c.toServers(targets, ""))
+}
+
+func (c *nlbServerGroupClient) updateServers(ctx context.Context, sgId string, targets []target, description string) error {
+	return c.cloud.UpdateNLBServers(ctx, sgId, c.toServers(targets, description))
+}
+
+type albServerGroupClient struct {
+	cloud prvd.Provider
+}
+
+func (c *albServerGroupClient) listServers(ctx context.Context, sgId string) ([]server, error) {
+	servers, err := c.cloud.ListALBServers(ctx, sgId)
+	if err != nil {
+		return nil, err
+	}
+	var ret []server
+	for _, s := range servers {
+		ret = append(ret, server{
+			target: target{
+				ServerId: s.ServerId, ServerIp: s.ServerIp, Port: int32(s.Port), Weight: int32(s.Weight),
+				Type: toTargetType(s.ServerType),
+			},
+			Description: s.Description,
+		})
+	}
+	return ret, nil
+}
+
+func albServerType(t target) string {
+	switch t.Type {
+	case v1.ServerGroupBindingTargetENI:
+		return albmodel.ENIBackendType
+	case v1.ServerGroupBindingTargetIP:
+		return albmodel.IPBackendType
+	}
+	return albmodel.ECSBackendType
+}
+
+func (c *albServerGroupClient) toBackendItems(targets []target, description string) []albmodel.BackendItem {
+	var items []albmodel.BackendItem
+	for _, t := range targets {
+		items = append(items, albmodel.BackendItem{
+			Description: description,
+			ServerId:    t.ServerId,
+			ServerIp:    t.ServerIp,
+			Weight:      int(t.Weight),
+			Port:        int(t.Port),
+			Type:        albServerType(t),
+		})
+	}
+	return items
+}
+
+func (c *albServerGroupClient) addServers(ctx context.Context, sgId string, targets []target, description string) error {
+	return c.cloud.RegisterALBServers(ctx, sgId, c.toBackendItems(targets, description))
+}
+
+func (c *albServerGroupClient) removeServers(ctx context.Context, sgId string, targets []target) error {
+	var servers []albsdk.BackendServer
+	for _, t := range targets {
+		servers = append(servers, albsdk.BackendServer{
+			ServerId:   t.ServerId,
+			ServerIp:   t.ServerIp,
+			Port:       int(t.Port),
+			ServerType: albServerType(t),
+		})
+	}
+	return c.cloud.DeregisterALBServers(ctx, sgId, servers)
+}
+
+func (c *albServerGroupClient) updateServers(ctx context.Context, sgId string, targets []target, description string) error {
+	return c.cloud.UpdateALBServers(ctx, sgId, c.toBackendItems(targets, description))
+}
+
+type clbServerGroupClient struct {
+	cloud prvd.Provider
+}
+
+func (c *clbServerGroupClient) listServers(ctx context.Context, sgId string) ([]server, error) {
+	vg, err := c.cloud.DescribeVServerGroupAttribute(ctx, sgId)
+	if err != nil {
+		return nil, err
+	}
+	var ret []server
+	for _, b := range vg.Backends {
+		ret = append(ret, server{
+			target: target{
+				ServerId: b.ServerId, ServerIp: b.ServerIp, Port: int32(b.Port), Weight: int32(b.Weight),
+				Type: toTargetType(b.Type),
+			},
+			Description: b.Description,
+		})
+	}
+	return ret, nil
+}
+
+func (c *clbServerGroupClient) toBackends(targets []target, description string) (string, error) {
+	var backends []model.BackendAttribute
+	for _, t := range targets {
+		b := model.BackendAttribute{
+			Description: description,
+			ServerId:    t.ServerId,
+			Weight:      int(t.Weight),
+			Port:        int(t.Port),
+			Type:        model.ECSBackendType,
+		}
+		if t.Type == v1.ServerGroupBindingTargetENI {
+			b.Type = model.ENIBackendType
+			b.ServerIp = t.ServerIp
+		}
+		backends = append(backends, b)
+	}
+	raw, err := json.Marshal(backends)
+	if err != nil {
+		return "", fmt.Errorf("marshal backends error: %s", err.Error())
+	}
+	return string(raw), nil
+}
+
+func (c *clbServerGroupClient) addServers(ctx context.Context, sgId string, targets []target, description string) error {
+	backends, err := c.toBackends(targets, description)
+	if err != nil {
+		return err
+	}
+	return c.cloud.AddVServerGroupBackendServers(ctx, sgId, backends)
+}
+
+func (c *clbServerGroupClient) removeServers(ctx context.Context, sgId string, targets []target) error {
+	backends, err := c.toBackends(targets, "")
+	if err != nil {
+		return err
+	}
+	return c.cloud.RemoveVServerGroupBackendServers(ctx, sgId, backends)
+}
+
+func (c *clbServerGroupClient) updateServers(ctx context.Context, sgId string, targets []target, description string) error {
+	backends, err := c.toBackends(targets, description)
+	if err != nil {
+		return err
+	}
+	return c.cloud.SetVServerGroupAttribute(ctx, sgId, backends)
+}
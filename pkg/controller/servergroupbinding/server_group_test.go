@@ -0,0 +1,183 @@
+package servergroupbinding
+
+import (
+	"context"
+	"testing"
+
+	albsdk "github.com/aliyun/alibaba-cloud-sdk-go/services/alb"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/helper"
+	albmodel "k8s.io/alibaba-load-balancer-controller/pkg/model/alb"
+	prvd "k8s.io/alibaba-load-balancer-controller/pkg/provider"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestDiffServers(t *testing.T) {
+	desc := "k8s.cluster.default.sgb"
+	remote := []server{
+		// added by the binding and still an endpoint, weight changed
+		{target: target{ServerId: "10.0.0.1", ServerIp: "10.0.0.1", Port: 80, Weight: 50, Type: v1.ServerGroupBindingTargetIP}, Description: desc},
+		// added by the binding and not an endpoint any more
+		{target: target{ServerId: "10.0.0.2", ServerIp: "10.0.0.2", Port: 80, Weight: 100, Type: v1.ServerGroupBindingTargetIP}, Description: desc},
+		// added by others
+		{target: target{ServerId: "10.0.0.3", ServerIp: "10.0.0.3", Port: 80, Weight: 10, Type: v1.ServerGroupBindingTargetIP}, Description: "terraform"},
+	}
+	local := []target{
+		{ServerId: "10.0.0.1", ServerIp: "10.0.0.1", Port: 80, Weight: 100, Type: v1.ServerGroupBindingTargetIP},
+		{ServerId: "10.0.0.3", ServerIp: "10.0.0.3", Port: 80, Weight: 100, Type: v1.ServerGroupBindingTargetIP},
+		{ServerId: "10.0.0.4", ServerIp: "10.0.0.4", Port: 80, Weight: 100, Type: v1.ServerGroupBindingTargetIP},
+	}
+
+	add, del, update := diffServers(remote, local, desc)
+	assert.Equal(t, []target{local[2]}, add)
+	assert.Equal(t, []target{remote[1].target}, del)
+	assert.Equal(t, []target{local[0]}, update)
+
+	add, del, update = diffServers(remote, nil, desc)
+	assert.Empty(t, add)
+	assert.Equal(t, []target{remote[0].target, remote[1].target}, del)
+	assert.Empty(t, update)
+}
+
+func TestValidateServerGroupBinding(t *testing.T) {
+	weight := 80
+	sgb := &v1.ServerGroupBinding{Spec: v1.ServerGroupBindingSpec{
+		LoadBalancerType: v1.ServerGroupBindingLoadBalancerCLB,
+		ServerGroupID:    "rsp-xxx",
+		ServiceRef:       v1.ServerGroupBindingServiceRef{Name: "svc", Port: intstr.FromInt(80)},
+		Weight:           &weight,
+	}}
+	assert.NoError(t, validateServerGroupBinding(sgb, v1.ServerGroupBindingTargetECS))
+	assert.Error(t, validateServerGroupBinding(sgb, v1.ServerGroupBindingTargetIP))
+	assert.Error(t, validateServerGroupBinding(sgb, "pod"))
+
+	sgb.Spec.LoadBalancerType = v1.ServerGroupBindingLoadBalancerNLB
+	assert.NoError(t, validateServerGroupBinding(sgb, v1.ServerGroupBindingTargetIP))
+
+	weight = 101
+	assert.Error(t, validateServerGroupBinding(sgb, v1.ServerGroupBindingTargetIP))
+	weight = 100
+
+	sgb.Spec.ServerGroupID = ""
+	assert.Error(t, validateServerGroupBinding(sgb, v1.ServerGroupBindingTargetIP))
+	sgb.Spec.ServerGroupID = "sgp-xxx"
+	sgb.Spec.LoadBalancerType = "gwlb"
+	assert.Error(t, validateServerGroupBinding(sgb, v1.ServerGroupBindingTargetIP))
+}
+
+func TestBuildIPTargets(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "svc", Namespace: "default",
+			Annotations: map[string]string{helper.BackendIPs: "192.168.0.1,192.168.0.2:8443"},
+		},
+		Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{
+			{Name: "http", Port: 80, TargetPort: intstr.FromInt(8080)},
+		}},
+	}
+	eps := &corev1.Endpoints{Subsets: []corev1.EndpointSubset{{
+		Addresses: []corev1.EndpointAddress{{IP: "172.16.0.1"}},
+		Ports:     []corev1.EndpointPort{{Name: "http", Port: 9090}},
+	}}}
+
+	svcPort, err := lookupServicePort(svc, intstr.FromString("http"))
+	assert.NoError(t, err)
+	targets, err := buildIPTargets(svc, svcPort, eps)
+	assert.NoError(t, err)
+	assert.Equal(t, []target{
+		{ServerId: "172.16.0.1", ServerIp: "172.16.0.1", Port: 9090},
+		{ServerId: "192.168.0.1", ServerIp: "192.168.0.1", Port: 8080},
+		{ServerId: "192.168.0.2", ServerIp: "192.168.0.2", Port: 8443},
+	}, targets)
+
+	_, err = lookupServicePort(svc, intstr.FromInt(443))
+	assert.Error(t, err)
+}
+
+// fakeALBServerProvider keeps the servers of the alb server groups in memory
+type fakeALBServerProvider struct {
+	prvd.Provider
+	servers map[string][]albsdk.BackendServer
+}
+
+func (p *fakeALBServerProvider) ListALBServers(_ context.Context, sgId string) ([]albsdk.BackendServer, error) {
+	return p.servers[sgId], nil
+}
+
+func (p *fakeALBServerProvider) RegisterALBServers(_ context.Context, sgId string, items []albmodel.BackendItem) error {
+	for _, item := range items {
+		p.servers[sgId] = append(p.servers[sgId], albsdk.BackendServer{ServerId: item.ServerId, ServerIp: item.ServerIp,
+			Port: item.Port, Weight: item.Weight, ServerType: item.Type, Description: item.Description})
+	}
+	return nil
+}
+
+func (p *fakeALBServerProvider) DeregisterALBServers(_ context.Context, sgId string, servers []albsdk.BackendServer) error {
+	var kept []albsdk.BackendServer
+	for _, s := range p.servers[sgId] {
+		removed := false
+		for _, r := range servers {
+			removed = removed || (s.ServerId == r.ServerId && s.Port == r.Port)
+		}
+		if !removed {
+			kept = append(kept, s)
+		}
+	}
+	p.servers[sgId] = kept
+	return nil
+}
+
+func (p *fakeALBServerProvider) UpdateALBServers(_ context.Context, sgId string, items []albmodel.BackendItem) error {
+	for _, item := range items {
+		for i, s := range p.servers[sgId] {
+			if s.ServerId == item.ServerId && s.Port == item.Port {
+				p.servers[sgId][i].Weight = item.Weight
+			}
+		}
+	}
+	return nil
+}
+
+func TestReleasePreviousServerGroup(t *testing.T) {
+	sgb := &v1.ServerGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "sgb"},
+		Spec: v1.ServerGroupBindingSpec{
+			LoadBalancerType: v1.ServerGroupBindingLoadBalancerALB,
+			ServerGroupID:    "sgp-a",
+		},
+	}
+	desc := getServerDescription(sgb)
+	cloud := &fakeALBServerProvider{servers: map[string][]albsdk.BackendServer{
+		"sgp-a": {
+			{ServerId: "10.0.0.1", ServerIp: "10.0.0.1", Port: 80, Weight: 50, ServerType: albmodel.IPBackendType, Description: desc},
+			{ServerId: "10.0.0.9", ServerIp: "10.0.0.9", Port: 80, Weight: 10, ServerType: albmodel.IPBackendType, Description: "terraform"},
+		},
+	}}
+	r := &serverGroupBindingReconciler{cloud: cloud, logger: logr.Discard()}
+
+	// the weights of the servers of alb server groups are updated
+	sgClient, err := newServerGroupClient(cloud, v1.ServerGroupBindingLoadBalancerALB)
+	assert.NoError(t, err)
+	servers, err := syncServers(context.TODO(), sgClient, "sgp-a", []target{
+		{ServerId: "10.0.0.1", ServerIp: "10.0.0.1", Port: 80, Weight: 100, Type: v1.ServerGroupBindingTargetIP},
+	}, desc)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, servers)
+	assert.Equal(t, 100, cloud.servers["sgp-a"][0].Weight)
+
+	// nothing is released while the binding is bound to the same server group
+	sgb.Status.ServerGroupID, sgb.Status.LoadBalancerType = "sgp-a", v1.ServerGroupBindingLoadBalancerALB
+	assert.NoError(t, r.releasePreviousServerGroup(context.TODO(), sgb))
+	assert.Len(t, cloud.servers["sgp-a"], 2)
+
+	// the servers of the binding are removed from the previous server group, the others are kept
+	sgb.Spec.ServerGroupID = "sgp-b"
+	assert.NoError(t, r.releasePreviousServerGroup(context.TODO(), sgb))
+	if assert.Len(t, cloud.servers["sgp-a"], 1) {
+		assert.Equal(t, "terraform", cloud.servers["sgp-a"][0].Description)
+	}
+}
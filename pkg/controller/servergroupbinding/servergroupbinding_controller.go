@@ -0,0 +1,257 @@
+package servergroupbinding
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	ctrlCfg "k8s.io/alibaba-load-balancer-controller/pkg/config"
+	"k8s.io/alibaba-load-balancer-controller/pkg/context/shared"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/helper"
+	prvd "k8s.io/alibaba-load-balancer-controller/pkg/provider"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	serverGroupBindingControllerName = "servergroupbinding-controller"
+	// ServerGroupBindingFinalizer removes the servers added by the binding before it is deleted
+	ServerGroupBindingFinalizer = "servergroupbinding.k8s.alibaba/resources"
+)
+
+func Add(mgr manager.Manager, ctx *shared.SharedContext) error {
+	if err := RegisterCRD(mgr.GetConfig()); err != nil {
+		return fmt.Errorf("register servergroupbinding crd error: %s", err.Error())
+	}
+	return add(mgr, newReconciler(mgr, ctx))
+}
+
+func newReconciler(mgr manager.Manager, ctx *shared.SharedContext) *serverGroupBindingReconciler {
+	return &serverGroupBindingReconciler{
+		cloud:            ctx.Provider(),
+		kubeClient:       mgr.GetClient(),
+		logger:           ctrl.Log.WithName("controller").WithName(serverGroupBindingControllerName),
+		record:           mgr.GetEventRecorderFor(serverGroupBindingControllerName),
+		finalizerManager: helper.NewDefaultFinalizerManager(mgr.GetClient()),
+	}
+}
+
+func add(mgr manager.Manager, r *serverGroupBindingReconciler) error {
+	recoverPanic := true
+	c, err := controller.New(serverGroupBindingControllerName, mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: ctrlCfg.ControllerCFG.ReconcileConfig.EndpointMaxConcurrentReconciles,
+		RateLimiter:             helper.NewControllerRateLimiter(ctrlCfg.ControllerCFG.ReconcileConfig),
+		RecoverPanic:            &recoverPanic,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := c.Watch(&source.Kind{Type: &v1.ServerGroupBinding{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("watch resource servergroupbinding error: %s", err.Error())
+	}
+	if err := c.Watch(&source.Kind{Type: &corev1.Service{}},
+		handler.EnqueueRequestsFromMapFunc(r.mapServiceToBindings)); err != nil {
+		return fmt.Errorf("watch resource svc error: %s", err.Error())
+	}
+	if err := c.Watch(&source.Kind{Type: &corev1.Endpoints{}},
+		handler.EnqueueRequestsFromMapFunc(r.mapServiceToBindings)); err != nil {
+		return fmt.Errorf("watch resource endpoint error: %s", err.Error())
+	}
+	if err := c.Watch(&source.Kind{Type: &corev1.Node{}},
+		handler.EnqueueRequestsFromMapFunc(r.mapNodeToBindings), nodeChangedPredicate()); err != nil {
+		return fmt.Errorf("watch resource node error: %s", err.Error())
+	}
+	return nil
+}
+
+type serverGroupBindingReconciler struct {
+	cloud            prvd.Provider
+	kubeClient       client.Client
+	logger           logr.Logger
+	record           record.EventRecorder
+	finalizerManager helper.FinalizerManager
+}
+
+func (r *serverGroupBindingReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	sgb := &v1.ServerGroupBinding{}
+	if err := r.kubeClient.Get(ctx, request.NamespacedName, sgb); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	log := r.logger.WithValues("servergroupbinding", request.NamespacedName)
+
+	if sgb.DeletionTimestamp != nil {
+		return reconcile.Result{}, r.cleanup(ctx, sgb)
+	}
+
+	if err := r.finalizerManager.AddFinalizers(ctx, sgb, ServerGroupBindingFinalizer); err != nil {
+		r.record.Event(sgb, corev1.EventTypeWarning, helper.FailedAddFinalizer,
+			fmt.Sprintf("Error adding finalizer: %s", err.Error()))
+		return reconcile.Result{}, err
+	}
+
+	fail := func(err error, bound bool) (reconcile.Result, error) {
+		log.Error(err, "sync servers failed")
+		r.record.Event(sgb, corev1.EventTypeWarning, helper.FailedSyncServerGroupBinding, helper.GetLogMessage(err))
+		if updateErr := r.updateStatus(ctx, sgb, func(status *v1.ServerGroupBindingStatus) {
+			status.Phase = v1.ServerGroupBindingPhaseFailed
+			status.Message = helper.GetLogMessage(err)
+			if bound {
+				setBoundServerGroup(sgb, status)
+			}
+		}); updateErr != nil {
+			log.Error(updateErr, "update status failed")
+		}
+		return reconcile.Result{}, err
+	}
+
+	// the servers are removed from the previous server group before added to the new one,
+	// which is recorded once the previous one is released
+	if err := r.releasePreviousServerGroup(ctx, sgb); err != nil {
+		return fail(err, false)
+	}
+	servers, err := r.sync(ctx, sgb)
+	if err != nil {
+		return fail(err, true)
+	}
+
+	if sgb.Status.Phase != v1.ServerGroupBindingPhaseSynced || sgb.Status.Servers != servers {
+		r.record.Event(sgb, corev1.EventTypeNormal, helper.SucceedSyncServerGroupBinding,
+			fmt.Sprintf("Synced %d servers to server group %s", servers, sgb.Spec.ServerGroupID))
+	}
+	now := metav1.Now()
+	return reconcile.Result{}, r.updateStatus(ctx, sgb, func(status *v1.ServerGroupBindingStatus) {
+		status.Phase = v1.ServerGroupBindingPhaseSynced
+		status.Message = ""
+		status.Servers = servers
+		status.LastSyncTime = &now
+		setBoundServerGroup(sgb, status)
+	})
+}
+
+func setBoundServerGroup(sgb *v1.ServerGroupBinding, status *v1.ServerGroupBindingStatus) {
+	status.ServerGroupID = sgb.Spec.ServerGroupID
+	status.LoadBalancerType = sgb.Spec.LoadBalancerType
+}
+
+// releasePreviousServerGroup removes the servers added by the binding from the server group recorded in status,
+// if the binding is bound to another server group or another type of load balancer since then
+func (r *serverGroupBindingReconciler) releasePreviousServerGroup(ctx context.Context, sgb *v1.ServerGroupBinding) error {
+	prevID, prevType := sgb.Status.ServerGroupID, sgb.Status.LoadBalancerType
+	if prevID == "" || (prevID == sgb.Spec.ServerGroupID && prevType == sgb.Spec.LoadBalancerType) {
+		return nil
+	}
+	sgClient, err := newServerGroupClient(r.cloud, prevType)
+	if err != nil {
+		return err
+	}
+	err = cleanupServers(ctx, sgClient, prevID, getServerDescription(sgb))
+	if err != nil && isServerGroupNotFound(err) {
+		r.logger.Info("previous server group not found, skip removing servers",
+			"servergroupbinding", util.Key(sgb), "serverGroupID", prevID)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("remove servers from previous server group %s error: %s", prevID, err.Error())
+	}
+	r.logger.Info("removed servers from previous server group",
+		"servergroupbinding", util.Key(sgb), "serverGroupID", prevID, "loadBalancerType", prevType)
+	return nil
+}
+
+// sync adds the endpoints of the service port to the server group, and removes the servers of the binding
+// which are not endpoints any more. The servers of the binding are removed if the service is not found.
+func (r *serverGroupBindingReconciler) sync(ctx context.Context, sgb *v1.ServerGroupBinding) (int, error) {
+	svc := &corev1.Service{}
+	err := r.kubeClient.Get(ctx, client.ObjectKey{Namespace: sgb.Namespace, Name: sgb.Spec.ServiceRef.Name}, svc)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return 0, fmt.Errorf("get service %s error: %s", sgb.Spec.ServiceRef.Name, err.Error())
+	}
+	svcFound := err == nil
+
+	targetType := sgb.Spec.TargetType
+	if svcFound {
+		targetType = getTargetType(sgb, svc)
+	} else if targetType == "" {
+		targetType = v1.ServerGroupBindingTargetECS
+	}
+	if err := validateServerGroupBinding(sgb, targetType); err != nil {
+		return 0, err
+	}
+	sgClient, err := newServerGroupClient(r.cloud, sgb.Spec.LoadBalancerType)
+	if err != nil {
+		return 0, err
+	}
+
+	var targets []target
+	if svcFound {
+		targets, err = r.buildTargets(ctx, sgb, svc, targetType)
+		if err != nil {
+			return 0, fmt.Errorf("build servers error: %s", err.Error())
+		}
+	} else {
+		r.logger.Info("service not found, remove the servers",
+			"servergroupbinding", util.Key(sgb), "service", sgb.Spec.ServiceRef.Name)
+	}
+	return syncServers(ctx, sgClient, sgb.Spec.ServerGroupID, targets, getServerDescription(sgb))
+}
+
+// cleanup removes the servers added by the binding before removing the finalizer
+func (r *serverGroupBindingReconciler) cleanup(ctx context.Context, sgb *v1.ServerGroupBinding) error {
+	if !helper.HasFinalizer(sgb, ServerGroupBindingFinalizer) {
+		return nil
+	}
+	if err := r.releasePreviousServerGroup(ctx, sgb); err != nil {
+		r.record.Event(sgb, corev1.EventTypeWarning, helper.FailedCleanServerGroupBinding, helper.GetLogMessage(err))
+		return err
+	}
+	sgClient, err := newServerGroupClient(r.cloud, sgb.Spec.LoadBalancerType)
+	if err == nil && sgb.Spec.ServerGroupID != "" {
+		err = cleanupServers(ctx, sgClient, sgb.Spec.ServerGroupID, getServerDescription(sgb))
+		if err != nil && isServerGroupNotFound(err) {
+			r.logger.Info("server group not found, skip removing servers",
+				"servergroupbinding", util.Key(sgb), "serverGroupID", sgb.Spec.ServerGroupID)
+			err = nil
+		}
+		if err != nil {
+			r.record.Event(sgb, corev1.EventTypeWarning, helper.FailedCleanServerGroupBinding, helper.GetLogMessage(err))
+			return err
+		}
+	}
+	if err := r.finalizerManager.RemoveFinalizers(ctx, sgb, ServerGroupBindingFinalizer); err != nil {
+		r.record.Event(sgb, corev1.EventTypeWarning, helper.FailedRemoveFinalizer,
+			fmt.Sprintf("Error removing finalizer: %s", err.Error()))
+		return err
+	}
+	return nil
+}
+
+func (r *serverGroupBindingReconciler) updateStatus(ctx context.Context, sgb *v1.ServerGroupBinding,
+	update func(status *v1.ServerGroupBindingStatus)) error {
+	updated := sgb.DeepCopy()
+	updated.Status.ObservedGeneration = sgb.Generation
+	update(&updated.Status)
+	return r.kubeClient.Status().Patch(ctx, updated, client.MergeFrom(sgb))
+}
+
+// isServerGroupNotFound returns true if the server group was deleted outside the cluster
+func isServerGroupNotFound(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "NotExist") || strings.Contains(msg, "NotFound")
+}
@@ -0,0 +1,275 @@
+package servergroupbinding
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/helper"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/service/reconcile/annotation"
+	reconbackend "k8s.io/alibaba-load-balancer-controller/pkg/controller/service/reconcile/backend"
+	svcCtx "k8s.io/alibaba-load-balancer-controller/pkg/controller/service/reconcile/context"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// target is a server of the bound server group
+type target struct {
+	ServerId string
+	ServerIp string
+	Port     int32
+	Weight   int32
+	// Type is the target type of ServerGroupBinding
+	Type string
+}
+
+// key identifies the server in the server group, ecs servers are identified by the instance and the port,
+// the others are identified by the ip address as well
+func (t target) key() string {
+	if t.Type == v1.ServerGroupBindingTargetECS {
+		return fmt.Sprintf("%s:%d", t.ServerId, t.Port)
+	}
+	return fmt.Sprintf("%s:%s:%d", t.ServerId, t.ServerIp, t.Port)
+}
+
+// getTargetType returns the target type of the binding, which defaults to the backend type of the service
+func getTargetType(sgb *v1.ServerGroupBinding, svc *corev1.Service) string {
+	if sgb.Spec.TargetType != "" {
+		return sgb.Spec.TargetType
+	}
+	if helper.IsIPBackendType(svc) {
+		return v1.ServerGroupBindingTargetIP
+	}
+	if helper.IsENIBackendType(svc) {
+		return v1.ServerGroupBindingTargetENI
+	}
+	return v1.ServerGroupBindingTargetECS
+}
+
+func getTargetWeight(sgb *v1.ServerGroupBinding) int32 {
+	if sgb.Spec.Weight == nil {
+		return util.DefaultServerWeight
+	}
+	return int32(*sgb.Spec.Weight)
+}
+
+// validateServerGroupBinding checks the spec before any server is changed
+func validateServerGroupBinding(sgb *v1.ServerGroupBinding, targetType string) error {
+	spec := sgb.Spec
+	switch spec.LoadBalancerType {
+	case v1.ServerGroupBindingLoadBalancerALB, v1.ServerGroupBindingLoadBalancerNLB, v1.ServerGroupBindingLoadBalancerCLB:
+	default:
+		return fmt.Errorf("loadBalancerType %q is not supported, valid values: alb, nlb, clb", spec.LoadBalancerType)
+	}
+	if spec.ServerGroupID == "" {
+		return fmt.Errorf("serverGroupID is required")
+	}
+	if spec.ServiceRef.Name == "" {
+		return fmt.Errorf("serviceRef.name is required")
+	}
+	switch targetType {
+	case v1.ServerGroupBindingTargetECS, v1.ServerGroupBindingTargetENI:
+	case v1.ServerGroupBindingTargetIP:
+		if spec.LoadBalancerType == v1.ServerGroupBindingLoadBalancerCLB {
+			return fmt.Errorf("targetType ip is not supported by clb")
+		}
+	default:
+		return fmt.Errorf("targetType %q is not supported, valid values: ecs, eni, ip", targetType)
+	}
+	if spec.Weight != nil && (*spec.Weight < 0 || *spec.Weight > 100) {
+		return fmt.Errorf("weight %d is not in range [0, 100]", *spec.Weight)
+	}
+	return nil
+}
+
+// buildTargets returns the servers the bound server group should contain for the service port
+func (r *serverGroupBindingReconciler) buildTargets(ctx context.Context, sgb *v1.ServerGroupBinding,
+	svc *corev1.Service, targetType string) ([]target, error) {
+	svcPort, err := lookupServicePort(svc, sgb.Spec.ServiceRef.Port)
+	if err != nil {
+		return nil, err
+	}
+
+	eps := &corev1.Endpoints{}
+	if err := r.kubeClient.Get(ctx, util.NamespacedName(svc), eps); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("get endpoints error: %s", err.Error())
+		}
+	}
+
+	var targets []target
+	switch targetType {
+	case v1.ServerGroupBindingTargetECS:
+		targets, err = r.buildECSTargets(ctx, sgb, svc, svcPort, eps)
+	case v1.ServerGroupBindingTargetENI:
+		targets, err = r.buildENITargets(svcPort, eps)
+	case v1.ServerGroupBindingTargetIP:
+		targets, err = buildIPTargets(svc, svcPort, eps)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	weight := getTargetWeight(sgb)
+	uniq := make(map[string]bool)
+	var ret []target
+	for _, t := range targets {
+		t.Type = targetType
+		t.Weight = weight
+		if uniq[t.key()] {
+			continue
+		}
+		uniq[t.key()] = true
+		ret = append(ret, t)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].key() < ret[j].key() })
+	return ret, nil
+}
+
+// buildECSTargets adds the nodes with the node port, only the nodes with endpoints are added for the local mode services
+func (r *serverGroupBindingReconciler) buildECSTargets(ctx context.Context, sgb *v1.ServerGroupBinding,
+	svc *corev1.Service, svcPort corev1.ServicePort, eps *corev1.Endpoints) ([]target, error) {
+	if svcPort.NodePort == 0 {
+		return nil, fmt.Errorf("service port %s has no node port for ecs targets", svcPort.Name)
+	}
+
+	reqCtx := &svcCtx.RequestContext{
+		Ctx:     ctx,
+		Service: svc,
+		Anno:    annotation.NewAnnotationRequest(svc),
+		Log:     r.logger,
+	}
+	nodes, err := reconbackend.GetNodes(reqCtx, r.kubeClient)
+	if err != nil {
+		return nil, err
+	}
+	selector := labels.Everything()
+	if sgb.Spec.NodeSelector != nil {
+		selector, err = metav1.LabelSelectorAsSelector(sgb.Spec.NodeSelector)
+		if err != nil {
+			return nil, fmt.Errorf("parse nodeSelector error: %s", err.Error())
+		}
+	}
+
+	var endpointNodes map[string]bool
+	if helper.IsLocalModeService(svc) {
+		endpointNodes = make(map[string]bool)
+		for _, subset := range eps.Subsets {
+			for _, addr := range subset.Addresses {
+				if addr.NodeName != nil {
+					endpointNodes[*addr.NodeName] = true
+				}
+			}
+		}
+	}
+
+	var targets []target
+	for _, node := range nodes {
+		if helper.HasExcludeLabel(&node) || node.Labels["type"] == helper.LabelNodeTypeVK {
+			continue
+		}
+		if !selector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		if endpointNodes != nil && !endpointNodes[node.Name] {
+			continue
+		}
+		_, instanceId, err := helper.NodeFromProviderID(node.Spec.ProviderID)
+		if err != nil {
+			return nil, fmt.Errorf("get instance id of node %s error: %s", node.Name, err.Error())
+		}
+		targets = append(targets, target{ServerId: instanceId, Port: svcPort.NodePort})
+	}
+	return targets, nil
+}
+
+// buildENITargets adds the ENIs of the pods with the target port
+func (r *serverGroupBindingReconciler) buildENITargets(svcPort corev1.ServicePort, eps *corev1.Endpoints) ([]target, error) {
+	targets := buildEndpointTargets(svcPort, eps)
+	if len(targets) == 0 {
+		return nil, nil
+	}
+
+	vpcId, err := r.cloud.VpcID()
+	if err != nil {
+		return nil, fmt.Errorf("get vpc id error: %s", err.Error())
+	}
+	var ips []string
+	for _, t := range targets {
+		ips = append(ips, t.ServerIp)
+	}
+	enis, err := r.cloud.DescribeNetworkInterfaces(vpcId, ips, model.IPv4)
+	if err != nil {
+		return nil, fmt.Errorf("call DescribeNetworkInterfaces: %s", err.Error())
+	}
+	for i := range targets {
+		eniId, ok := enis[targets[i].ServerIp]
+		if !ok {
+			return nil, fmt.Errorf("can not find eniid for ip %s in vpc %s", targets[i].ServerIp, vpcId)
+		}
+		targets[i].ServerId = eniId
+	}
+	return targets, nil
+}
+
+// buildIPTargets adds the ip addresses of the endpoints and the backend-ips annotation
+func buildIPTargets(svc *corev1.Service, svcPort corev1.ServicePort, eps *corev1.Endpoints) ([]target, error) {
+	targets := buildEndpointTargets(svcPort, eps)
+	for i := range targets {
+		targets[i].ServerId = targets[i].ServerIp
+	}
+
+	ips, err := helper.GetBackendIPs(svc)
+	if err != nil {
+		return nil, err
+	}
+	for _, ip := range ips {
+		port := ip.Port
+		if port == 0 {
+			port = defaultTargetPort(svcPort)
+		}
+		targets = append(targets, target{ServerId: ip.IP, ServerIp: ip.IP, Port: port})
+	}
+	return targets, nil
+}
+
+// buildEndpointTargets returns the ready addresses of the endpoints with the target port
+func buildEndpointTargets(svcPort corev1.ServicePort, eps *corev1.Endpoints) []target {
+	var targets []target
+	for _, subset := range eps.Subsets {
+		port := defaultTargetPort(svcPort)
+		for _, p := range subset.Ports {
+			if p.Name == svcPort.Name {
+				port = p.Port
+				break
+			}
+		}
+		for _, addr := range subset.Addresses {
+			targets = append(targets, target{ServerIp: addr.IP, Port: port})
+		}
+	}
+	return targets
+}
+
+func defaultTargetPort(svcPort corev1.ServicePort) int32 {
+	if svcPort.TargetPort.Type == intstr.Int && svcPort.TargetPort.IntValue() != 0 {
+		return int32(svcPort.TargetPort.IntValue())
+	}
+	return svcPort.Port
+}
+
+func lookupServicePort(svc *corev1.Service, port intstr.IntOrString) (corev1.ServicePort, error) {
+	for _, p := range svc.Spec.Ports {
+		if (port.Type == intstr.String && p.Name == port.StrVal) ||
+			(port.Type == intstr.Int && p.Port == port.IntVal) {
+			return p, nil
+		}
+	}
+	return corev1.ServicePort{}, fmt.Errorf("unable to find port %s on service %s", port.String(), util.Key(svc))
+}
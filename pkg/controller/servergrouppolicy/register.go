@@ -0,0 +1,74 @@
+package servergrouppolicy
+
+import (
+	"fmt"
+
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util/crd"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiext "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+// RegisterCRD registers ServerGroupPolicy, which is used by both the ingress and the service controllers
+func RegisterCRD(cfg *rest.Config) error {
+	extc, err := apiext.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error create incluster client: %s", err.Error())
+	}
+	if err := NewServerGroupPolicyCRD(crd.NewClient(extc)).Initialize(); err != nil {
+		return fmt.Errorf("initialize crd: ServerGroupPolicyCRD, %s", err.Error())
+	}
+	return nil
+}
+
+// ServerGroupPolicyCRD is the namespaced crd sharing server group attributes between Services and Ingresses.
+type ServerGroupPolicyCRD struct {
+	crdc crd.Interface
+}
+
+func NewServerGroupPolicyCRD(crdClient crd.Interface) *ServerGroupPolicyCRD {
+	return &ServerGroupPolicyCRD{
+		crdc: crdClient,
+	}
+}
+
+// Initialize satisfies resource.crd interface.
+func (p *ServerGroupPolicyCRD) Initialize() error {
+	crd := crd.Conf{
+		Kind:       "ServerGroupPolicy",
+		NamePlural: "servergrouppolicies",
+		Group:      "alibabacloud.com",
+		Version:    "v1",
+		Scope:      apiextv1.NamespaceScoped,
+		PrinterColumns: []apiextv1.CustomResourceColumnDefinition{
+			{
+				Name:     "SCHEDULER",
+				Type:     "string",
+				JSONPath: ".spec.scheduler",
+			},
+			{
+				Name:     "HEALTHCHECK",
+				Type:     "boolean",
+				JSONPath: ".spec.healthCheck.enabled",
+			},
+			{
+				Name:     "AGE",
+				Type:     "date",
+				JSONPath: ".metadata.creationTimestamp",
+			},
+		},
+	}
+
+	return p.crdc.EnsurePresent(crd)
+}
+
+// GetListerWatcher satisfies resource.crd interface (and retrieve.Retriever).
+func (p *ServerGroupPolicyCRD) GetListerWatcher() cache.ListerWatcher {
+	return nil
+}
+
+// GetObject satisfies resource.crd interface (and retrieve.Retriever).
+func (p *ServerGroupPolicyCRD) GetObject() runtime.Object { return &v1.ServerGroupPolicy{} }
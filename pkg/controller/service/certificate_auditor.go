@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	ctrlCfg "k8s.io/alibaba-load-balancer-controller/pkg/config"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/helper"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/service/reconcile/annotation"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model"
+	prvd "k8s.io/alibaba-load-balancer-controller/pkg/provider"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util/metric"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// certificateAuditor periodically checks the certificates of the TCPSSL listeners of nlb services,
+// exports the days left before they expire and records events for the certificates need attention
+type certificateAuditor struct {
+	cloud      prvd.Provider
+	kubeClient client.Client
+	record     record.EventRecorder
+	logger     logr.Logger
+	period     time.Duration
+	threshold  time.Duration
+}
+
+func newCertificateAuditor(r *ReconcileNLB) *certificateAuditor {
+	return &certificateAuditor{
+		cloud:      r.cloud,
+		kubeClient: r.kubeClient,
+		record:     r.record,
+		logger:     r.logger.WithName("certificate-auditor"),
+		period:     ctrlCfg.ControllerCFG.CertificateAuditPeriod,
+		threshold:  ctrlCfg.ControllerCFG.CertificateExpiryThreshold,
+	}
+}
+
+// Start implements manager.Runnable
+func (a *certificateAuditor) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, a.audit, a.period)
+	return nil
+}
+
+func (a *certificateAuditor) audit(ctx context.Context) {
+	svcs := &v1.ServiceList{}
+	if err := a.kubeClient.List(ctx, svcs); err != nil {
+		a.logger.Error(err, "failed to list services")
+		return
+	}
+
+	var certSvcs []*v1.Service
+	for i := range svcs.Items {
+		svc := &svcs.Items[i]
+		if !helper.NeedNLB(svc) || svc.DeletionTimestamp != nil {
+			continue
+		}
+		anno := &annotation.AnnotationRequest{Service: svc}
+		if anno.Get(annotation.CertID) == "" && anno.Get(annotation.CertSecret) == "" {
+			continue
+		}
+		certSvcs = append(certSvcs, svc)
+	}
+	if len(certSvcs) == 0 {
+		metric.NLBCertificateExpireDays.Reset()
+		return
+	}
+
+	certs, err := a.cloud.DescribeSSLCertificateList(ctx)
+	if err != nil {
+		// keep the metrics of the last audit
+		a.logger.Error(err, "failed to describe ssl certificates")
+		return
+	}
+	certMap := make(map[string]model.CertificateInfo, len(certs))
+	certByName := make(map[string]model.CertificateInfo, len(certs))
+	for _, cert := range certs {
+		certMap[cert.CertIdentifier] = cert
+		certByName[cert.CertName] = cert
+	}
+
+	metric.NLBCertificateExpireDays.Reset()
+	now := time.Now()
+	for _, svc := range certSvcs {
+		a.auditService(ctx, svc, certMap, certByName, now)
+	}
+}
+
+func (a *certificateAuditor) auditService(ctx context.Context, svc *v1.Service,
+	certMap, certByName map[string]model.CertificateInfo, now time.Time) {
+	anno := &annotation.AnnotationRequest{Service: svc}
+	var certIds []string
+	if anno.Get(annotation.CertID) != "" {
+		certIds = strings.Split(anno.Get(annotation.CertID), ",")
+	}
+	secretCerts := a.getSecretCertificates(ctx, svc, certByName)
+	for _, port := range svc.Spec.Ports {
+		proto, err := nlbListenerProtocol(anno.Get(annotation.ProtocolPort), port)
+		if err != nil || !isTCPSSL(proto) {
+			continue
+		}
+		listener := fmt.Sprintf("%d/%s", port.Port, proto)
+		for _, certId := range certIds {
+			cert, ok := certMap[certId]
+			if !ok {
+				a.record.Event(svc, v1.EventTypeWarning, helper.CertificateNotFound,
+					fmt.Sprintf("Certificate %s of listener %s is not found", certId, listener))
+				continue
+			}
+			a.auditCertificate(svc, listener, cert, now)
+		}
+		for _, cert := range secretCerts {
+			a.auditCertificate(svc, listener, cert, now)
+		}
+	}
+}
+
+// getSecretCertificates returns the certificates uploaded from the secrets of the cert-secret annotation.
+// The secrets not uploaded yet are skipped, the errors of them are reported by the reconcile of the service.
+func (a *certificateAuditor) getSecretCertificates(ctx context.Context, svc *v1.Service,
+	certByName map[string]model.CertificateInfo) []model.CertificateInfo {
+	anno := &annotation.AnnotationRequest{Service: svc}
+	var certs []model.CertificateInfo
+	for _, name := range splitSecretNames(anno.Get(annotation.CertSecret)) {
+		secret := &v1.Secret{}
+		if err := a.kubeClient.Get(ctx, types.NamespacedName{Namespace: svc.Namespace, Name: name}, secret); err != nil {
+			a.logger.Error(err, "failed to get secret", "service", util.Key(svc), "secret", name)
+			continue
+		}
+		certName := secretCertificateName(secret, string(secret.Data[v1.TLSCertKey]), string(secret.Data[v1.TLSPrivateKeyKey]))
+		if cert, ok := certByName[certName]; ok {
+			certs = append(certs, cert)
+		}
+	}
+	return certs
+}
+
+func (a *certificateAuditor) auditCertificate(svc *v1.Service, listener string, cert model.CertificateInfo, now time.Time) {
+	certId := cert.CertIdentifier
+	metric.NLBCertificateExpireDays.WithLabelValues(util.Key(svc), listener, certId).
+		Set(helper.CertificateDaysToExpiry(cert, now))
+
+	notAfter := helper.CertificateNotAfter(cert)
+	if notAfter.Before(now) {
+		a.record.Event(svc, v1.EventTypeWarning, helper.CertificateExpired,
+			fmt.Sprintf("Certificate %s of listener %s expired at %s", certId, listener, notAfter.Format(time.RFC3339)))
+	} else if notAfter.Before(now.Add(a.threshold)) {
+		a.record.Event(svc, v1.EventTypeWarning, helper.CertificateExpiring,
+			fmt.Sprintf("Certificate %s of listener %s expires at %s", certId, listener, notAfter.Format(time.RFC3339)))
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/helper"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/service/reconcile/annotation"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model"
+	prvd "k8s.io/alibaba-load-balancer-controller/pkg/provider"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util/metric"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeCASProvider keeps the cas certificates in memory
+type fakeCASProvider struct {
+	prvd.Provider
+	certs       []model.CertificateInfo
+	caCerts     []model.CACertificateInfo
+	describeErr error
+	// describes counts the calls to DescribeSSLCertificateList
+	describes int
+}
+
+func (p *fakeCASProvider) DescribeSSLCertificateList(ctx context.Context) ([]model.CertificateInfo, error) {
+	p.describes++
+	return p.certs, p.describeErr
+}
+
+func (p *fakeCASProvider) DeleteSSLCertificate(ctx context.Context, certId string) error {
+	for i, cert := range p.certs {
+		if cert.CertIdentifier == certId {
+			p.certs = append(p.certs[:i], p.certs[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (p *fakeCASProvider) CreateSSLCertificateWithName(ctx context.Context, certName, certificate, privateKey string) (string, error) {
+	certId := "cert-" + certName
+	p.certs = append(p.certs, model.CertificateInfo{CertName: certName, CertIdentifier: certId})
+	return certId, nil
+}
+
+func (p *fakeCASProvider) DescribeCACertificateList(ctx context.Context) ([]model.CACertificateInfo, error) {
+	return p.caCerts, p.describeErr
+}
+
+func (p *fakeCASProvider) CreateCACertificateWithName(ctx context.Context, certName, certificate string) (string, error) {
+	certId := "ca-" + certName
+	p.caCerts = append(p.caCerts, model.CACertificateInfo{Alias: certName, Identifier: certId})
+	return certId, nil
+}
+
+func getTLSSecret(name, crt string) *v1.Secret {
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Data: map[string][]byte{
+			v1.TLSCertKey:       []byte(crt),
+			v1.TLSPrivateKeyKey: []byte("key"),
+		},
+	}
+}
+
+func countCertificateMetrics() int {
+	ch := make(chan prometheus.Metric, 100)
+	metric.NLBCertificateExpireDays.Collect(ch)
+	close(ch)
+	return len(ch)
+}
+
+func TestCertificateAuditorAudit(t *testing.T) {
+	nlbClass := helper.NLBClass
+	secret := getTLSSecret("tls", "crt")
+	svc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "svc", Annotations: map[string]string{
+			annotation.Annotation(annotation.ProtocolPort): "tcpssl:443",
+			annotation.Annotation(annotation.CertID):       "cert-id",
+			annotation.Annotation(annotation.CertSecret):   "tls",
+		}},
+		Spec: v1.ServiceSpec{
+			Type:              v1.ServiceTypeLoadBalancer,
+			LoadBalancerClass: &nlbClass,
+			Ports:             []v1.ServicePort{{Port: 443, Protocol: v1.ProtocolTCP}},
+		},
+	}
+	now := time.Now()
+	cloud := &fakeCASProvider{certs: []model.CertificateInfo{
+		{CertIdentifier: "cert-id", AfterDate: now.Add(100 * 24 * time.Hour).UnixMilli()},
+		{CertIdentifier: "cert-secret", CertName: secretCertificateName(secret, "crt", "key"),
+			AfterDate: now.Add(-time.Hour).UnixMilli()},
+	}}
+	recorder := record.NewFakeRecorder(10)
+	auditor := &certificateAuditor{
+		cloud:      cloud,
+		kubeClient: fake.NewClientBuilder().WithObjects(svc, secret).Build(),
+		record:     recorder,
+		logger:     logr.Discard(),
+		threshold:  30 * 24 * time.Hour,
+	}
+	metric.NLBCertificateExpireDays.Reset()
+
+	auditor.audit(context.TODO())
+	assert.Equal(t, 2, countCertificateMetrics())
+	assert.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "cert-secret")
+
+	// the metrics of the last audit are kept if the certificates can not be described
+	cloud.describeErr = errors.New("throttling")
+	auditor.audit(context.TODO())
+	assert.Equal(t, 2, countCertificateMetrics())
+}
@@ -7,6 +7,7 @@ import (
 	"sort"
 	"strings"
 
+	alibabacloudv1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
 	"k8s.io/alibaba-load-balancer-controller/pkg/controller/helper"
 	"k8s.io/alibaba-load-balancer-controller/pkg/util"
 	v1 "k8s.io/api/core/v1"
@@ -23,18 +24,22 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
-func NewEnqueueRequestForServiceEvent(eventRecorder record.EventRecorder) *enqueueRequestForServiceEvent {
-	return &enqueueRequestForServiceEvent{eventRecorder: eventRecorder}
+func NewEnqueueRequestForServiceEvent(eventRecorder record.EventRecorder, secretRefs *secretReferences) *enqueueRequestForServiceEvent {
+	return &enqueueRequestForServiceEvent{eventRecorder: eventRecorder, secretRefs: secretRefs}
 }
 
 type enqueueRequestForServiceEvent struct {
 	eventRecorder record.EventRecorder
+	secretRefs    *secretReferences
 }
 
 var _ handler.EventHandler = (*enqueueRequestForServiceEvent)(nil)
 
 func (h *enqueueRequestForServiceEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
 	svc, ok := e.Object.(*v1.Service)
+	if ok {
+		h.secretRefs.update(svc)
+	}
 	if ok && needAdd(svc) {
 		util.NLBLog.Info("controller: service create event", "service", util.Key(svc))
 		h.enqueueManagedService(queue, svc)
@@ -44,6 +49,9 @@ func (h *enqueueRequestForServiceEvent) Create(e event.CreateEvent, queue workqu
 func (h *enqueueRequestForServiceEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
 	oldSvc, ok1 := e.ObjectOld.(*v1.Service)
 	newSvc, ok2 := e.ObjectNew.(*v1.Service)
+	if ok2 {
+		h.secretRefs.update(newSvc)
+	}
 
 	if ok1 && ok2 && needUpdate(oldSvc, newSvc, h.eventRecorder) {
 		util.NLBLog.Info("controller: service update event", "service", util.Key(oldSvc))
@@ -52,6 +60,9 @@ func (h *enqueueRequestForServiceEvent) Update(e event.UpdateEvent, queue workqu
 }
 
 func (h *enqueueRequestForServiceEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+	if svc, ok := e.Object.(*v1.Service); ok {
+		h.secretRefs.remove(svc)
+	}
 	// Services have the finalizer. When a service is deleted, it will update the deletionTimestamp of the service.
 	// Since a delete event has changed to an update event, it is safe to ignore it.
 }
@@ -503,3 +514,113 @@ func canNodeSkipEventHandler(node *v1.Node) bool {
 	}
 	return false
 }
+
+// NewEnqueueRequestForSecretEvent, event handler for secret events, only the secrets referenced by nlb services are handled
+func NewEnqueueRequestForSecretEvent(record record.EventRecorder, secretRefs *secretReferences) *enqueueRequestForSecretEvent {
+	return &enqueueRequestForSecretEvent{record: record, secretRefs: secretRefs}
+}
+
+type enqueueRequestForSecretEvent struct {
+	record     record.EventRecorder
+	secretRefs *secretReferences
+}
+
+var _ handler.EventHandler = (*enqueueRequestForSecretEvent)(nil)
+
+func (h *enqueueRequestForSecretEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+	secret, ok := e.Object.(*v1.Secret)
+	if ok {
+		h.enqueueReferencingServices(queue, secret)
+	}
+}
+
+func (h *enqueueRequestForSecretEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	oldSecret, ok1 := e.ObjectOld.(*v1.Secret)
+	newSecret, ok2 := e.ObjectNew.(*v1.Secret)
+	if ok1 && ok2 && !reflect.DeepEqual(oldSecret.Data, newSecret.Data) {
+		h.enqueueReferencingServices(queue, newSecret)
+	}
+}
+
+func (h *enqueueRequestForSecretEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+	// the certificates uploaded are kept in use until the service stops referencing the secret
+}
+
+func (h *enqueueRequestForSecretEvent) Generic(e event.GenericEvent, queue workqueue.RateLimitingInterface) {
+	// unknown event, ignore
+}
+
+func (h *enqueueRequestForSecretEvent) enqueueReferencingServices(queue workqueue.RateLimitingInterface, secret *v1.Secret) {
+	for _, name := range h.secretRefs.servicesOf(util.NamespacedName(secret)) {
+		util.NLBLog.Info("controller: secret change event", "secret", util.Key(secret), "service", name)
+		queue.Add(reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: secret.Namespace,
+				Name:      name,
+			},
+		})
+	}
+}
+
+// NewEnqueueRequestForServerGroupPolicyEvent, event handler for ServerGroupPolicy events
+func NewEnqueueRequestForServerGroupPolicyEvent(record record.EventRecorder) *enqueueRequestForServerGroupPolicyEvent {
+	return &enqueueRequestForServerGroupPolicyEvent{record: record}
+}
+
+type enqueueRequestForServerGroupPolicyEvent struct {
+	client client.Client
+	record record.EventRecorder
+}
+
+var _ handler.EventHandler = (*enqueueRequestForServerGroupPolicyEvent)(nil)
+
+func (h *enqueueRequestForServerGroupPolicyEvent) InjectClient(c client.Client) error {
+	h.client = c
+	return nil
+}
+
+func (h *enqueueRequestForServerGroupPolicyEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+	policy, ok := e.Object.(*alibabacloudv1.ServerGroupPolicy)
+	if ok {
+		h.enqueueReferencingServices(queue, policy)
+	}
+}
+
+func (h *enqueueRequestForServerGroupPolicyEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	oldPolicy, ok1 := e.ObjectOld.(*alibabacloudv1.ServerGroupPolicy)
+	newPolicy, ok2 := e.ObjectNew.(*alibabacloudv1.ServerGroupPolicy)
+	if ok1 && ok2 && !reflect.DeepEqual(oldPolicy.Spec, newPolicy.Spec) {
+		h.enqueueReferencingServices(queue, newPolicy)
+	}
+}
+
+func (h *enqueueRequestForServerGroupPolicyEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+	// the server groups keep the attributes until the service stops referencing the policy
+}
+
+func (h *enqueueRequestForServerGroupPolicyEvent) Generic(e event.GenericEvent, queue workqueue.RateLimitingInterface) {
+	// unknown event, ignore
+}
+
+func (h *enqueueRequestForServerGroupPolicyEvent) enqueueReferencingServices(queue workqueue.RateLimitingInterface,
+	policy *alibabacloudv1.ServerGroupPolicy) {
+	svcs := &v1.ServiceList{}
+	if err := h.client.List(context.TODO(), svcs, client.InNamespace(policy.Namespace)); err != nil {
+		util.NLBLog.Error(err, "fail to list services, skip servergrouppolicy event", "servergrouppolicy", util.Key(policy))
+		return
+	}
+	for i := range svcs.Items {
+		svc := &svcs.Items[i]
+		if !helper.NeedNLB(svc) || !helper.IsServiceReferencingServerGroupPolicy(svc, policy) {
+			continue
+		}
+		util.NLBLog.Info("controller: servergrouppolicy change event",
+			"servergrouppolicy", util.Key(policy), "service", util.Key(svc))
+		queue.Add(reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: svc.Namespace,
+				Name:      svc.Name,
+			},
+		})
+	}
+}
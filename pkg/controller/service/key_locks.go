@@ -0,0 +1,34 @@
+package service
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// keyLocks are the non-blocking locks of keys, such as the services reconciled by multiple queues
+type keyLocks struct {
+	lock   sync.Mutex
+	locked sets.String
+}
+
+func newKeyLocks() *keyLocks {
+	return &keyLocks{locked: sets.NewString()}
+}
+
+// TryLock locks key and returns true if key is not locked
+func (l *keyLocks) TryLock(key string) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.locked.Has(key) {
+		return false
+	}
+	l.locked.Insert(key)
+	return true
+}
+
+func (l *keyLocks) Unlock(key string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.locked.Delete(key)
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyLocks(t *testing.T) {
+	locks := newKeyLocks()
+	assert.True(t, locks.TryLock("default/nginx"))
+	assert.False(t, locks.TryLock("default/nginx"))
+	assert.True(t, locks.TryLock("default/redis"))
+
+	locks.Unlock("default/nginx")
+	assert.True(t, locks.TryLock("default/nginx"))
+}
@@ -0,0 +1,354 @@
+package service
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alibabacloud-go/tea/tea"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/helper"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/service/reconcile/annotation"
+	svcCtx "k8s.io/alibaba-load-balancer-controller/pkg/controller/service/reconcile/context"
+	nlbmodel "k8s.io/alibaba-load-balancer-controller/pkg/model/nlb"
+	"k8s.io/alibaba-load-balancer-controller/pkg/provider/alibaba/base"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	secretCACertKey = "ca.crt"
+	// secretCertDigestLen is the length of the digest suffix in the cert name of secret certificates
+	secretCertDigestLen = 6
+)
+
+// secretCertificates are the cas certificates uploaded from the secrets referenced by the service
+type secretCertificates struct {
+	certIds   []string
+	caCertIds []string
+}
+
+// setListenerCertificates append the certificates uploaded from secrets to the TCPSSL listener
+func (s *secretCertificates) setListenerCertificates(listener *nlbmodel.ListenerAttribute) {
+	if len(s.certIds) != 0 {
+		listener.CertificateIds = append(listener.CertificateIds, s.certIds...)
+	}
+	if len(s.caCertIds) != 0 {
+		listener.CaCertificateIds = append(listener.CaCertificateIds, s.caCertIds...)
+		if listener.CaEnabled == nil {
+			listener.CaEnabled = tea.Bool(true)
+		}
+	}
+}
+
+// buildSecretCertificates uploads the certificates in the secrets referenced by cert-secret & cacert-secret to cas.
+// The cert name contains the digest of the secret content, so a new certificate is uploaded once the secret is
+// rotated, and the listener is updated to use it.
+func (mgr *ListenerManager) buildSecretCertificates(reqCtx *svcCtx.RequestContext) (*secretCertificates, error) {
+	certs := &secretCertificates{}
+	if reqCtx.Anno.Get(annotation.CertSecret) != "" {
+		for _, name := range splitSecretNames(reqCtx.Anno.Get(annotation.CertSecret)) {
+			certId, err := mgr.ensureSecretCertificate(reqCtx, name)
+			if err != nil {
+				return nil, err
+			}
+			certs.certIds = append(certs.certIds, certId)
+		}
+	}
+	if reqCtx.Anno.Get(annotation.CaCertSecret) != "" {
+		for _, name := range splitSecretNames(reqCtx.Anno.Get(annotation.CaCertSecret)) {
+			caCertId, err := mgr.ensureSecretCACertificate(reqCtx, name)
+			if err != nil {
+				return nil, err
+			}
+			certs.caCertIds = append(certs.caCertIds, caCertId)
+		}
+	}
+	return certs, nil
+}
+
+func (mgr *ListenerManager) ensureSecretCertificate(reqCtx *svcCtx.RequestContext, secretName string) (string, error) {
+	secret, err := mgr.getSecret(reqCtx, secretName)
+	if err != nil {
+		return "", err
+	}
+	crt := string(secret.Data[v1.TLSCertKey])
+	key := string(secret.Data[v1.TLSPrivateKeyKey])
+	if crt == "" || key == "" {
+		return "", fmt.Errorf("secret %s/%s has no %s or %s", secret.Namespace, secret.Name, v1.TLSCertKey, v1.TLSPrivateKeyKey)
+	}
+	certName := secretCertificateName(secret, crt, key)
+
+	certId, err := mgr.certCache.lookup(certName, func() (map[string]string, error) {
+		certs, err := mgr.cloud.DescribeSSLCertificateList(reqCtx.Ctx)
+		if err != nil {
+			return nil, fmt.Errorf("describe ssl certificates error: %s", err.Error())
+		}
+		names := make(map[string]string, len(certs))
+		for _, cert := range certs {
+			names[cert.CertName] = cert.CertIdentifier
+		}
+		return names, nil
+	})
+	if err != nil || certId != "" {
+		return certId, err
+	}
+
+	reqCtx.Log.Info("upload certificate from secret", "secret", secretName, "certName", certName)
+	certId, err = mgr.cloud.CreateSSLCertificateWithName(reqCtx.Ctx, certName, crt, key)
+	if err != nil {
+		return "", fmt.Errorf("upload certificate from secret %s error: %s", secretName, err.Error())
+	}
+	mgr.certCache.add(certName, certId)
+	return certId, nil
+}
+
+func (mgr *ListenerManager) ensureSecretCACertificate(reqCtx *svcCtx.RequestContext, secretName string) (string, error) {
+	secret, err := mgr.getSecret(reqCtx, secretName)
+	if err != nil {
+		return "", err
+	}
+	crt := string(secret.Data[secretCACertKey])
+	if crt == "" {
+		return "", fmt.Errorf("secret %s/%s has no %s", secret.Namespace, secret.Name, secretCACertKey)
+	}
+	certName := secretCertificateName(secret, crt)
+
+	certId, err := mgr.caCertCache.lookup(certName, func() (map[string]string, error) {
+		certs, err := mgr.cloud.DescribeCACertificateList(reqCtx.Ctx)
+		if err != nil {
+			return nil, fmt.Errorf("describe ca certificates error: %s", err.Error())
+		}
+		names := make(map[string]string, len(certs))
+		for _, cert := range certs {
+			names[cert.Alias] = cert.Identifier
+		}
+		return names, nil
+	})
+	if err != nil || certId != "" {
+		return certId, err
+	}
+
+	reqCtx.Log.Info("upload ca certificate from secret", "secret", secretName, "certName", certName)
+	certId, err = mgr.cloud.CreateCACertificateWithName(reqCtx.Ctx, certName, crt)
+	if err != nil {
+		return "", fmt.Errorf("upload ca certificate from secret %s error: %s", secretName, err.Error())
+	}
+	mgr.caCertCache.add(certName, certId)
+	return certId, nil
+}
+
+// cleanupSecretCertificates deletes the certificates uploaded from the previous content of the secrets referenced by
+// the service, once they are removed from the listener and no nlb listener in the region uses them any more.
+// Errors are logged only, the certificates are cleaned up on the next rotation.
+func (mgr *ListenerManager) cleanupSecretCertificates(reqCtx *svcCtx.RequestContext, removedCertIds []string) {
+	var stale []string
+	for _, certId := range removedCertIds {
+		if isSecretCertificateOf(reqCtx, mgr.certCache.nameOf(certId)) {
+			stale = append(stale, certId)
+		}
+	}
+	if len(stale) == 0 {
+		return
+	}
+
+	listeners, err := mgr.cloud.ListNLBListeners(reqCtx.Ctx, "")
+	if err != nil {
+		reqCtx.Log.Error(err, "list nlb listeners error, skip deleting certificates", "certIds", stale)
+		return
+	}
+	inUse := sets.NewString()
+	for _, lis := range listeners {
+		inUse.Insert(lis.CertificateIds...)
+	}
+	for _, certId := range stale {
+		if inUse.Has(certId) {
+			reqCtx.Log.Info("certificate is still used by other listeners, skip deleting", "certId", certId)
+			continue
+		}
+		reqCtx.Log.Info("delete certificate uploaded from the previous secret content", "certId", certId)
+		if err := mgr.cloud.DeleteSSLCertificate(reqCtx.Ctx, certId); err != nil {
+			reqCtx.Log.Error(err, "delete certificate error", "certId", certId)
+			continue
+		}
+		mgr.certCache.remove(certId)
+	}
+}
+
+// isSecretCertificateOf check if the cert name is in format of namespace-name-digest for a cert-secret of the service
+func isSecretCertificateOf(reqCtx *svcCtx.RequestContext, certName string) bool {
+	for _, name := range splitSecretNames(reqCtx.Anno.Get(annotation.CertSecret)) {
+		prefix := fmt.Sprintf("%s-%s-", reqCtx.Service.Namespace, name)
+		digest := strings.TrimPrefix(certName, prefix)
+		if digest == certName || len(digest) != secretCertDigestLen {
+			continue
+		}
+		if _, err := hex.DecodeString(digest); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (mgr *ListenerManager) getSecret(reqCtx *svcCtx.RequestContext, name string) (*v1.Secret, error) {
+	secret := &v1.Secret{}
+	if err := mgr.kubeClient.Get(reqCtx.Ctx, types.NamespacedName{
+		Namespace: reqCtx.Service.Namespace,
+		Name:      name,
+	}, secret); err != nil {
+		return nil, fmt.Errorf("get secret %s/%s error: %s", reqCtx.Service.Namespace, name, err.Error())
+	}
+	return secret, nil
+}
+
+func splitSecretNames(anno string) []string {
+	var names []string
+	for _, name := range strings.Split(anno, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// secretCertificateName returns the cas cert name of the secret content, in format of namespace-name-digest
+func secretCertificateName(secret *v1.Secret, data ...string) string {
+	shaSum := sha1.Sum([]byte(base.CLUSTER_ID + strings.Join(data, "")))
+	return fmt.Sprintf("%s-%s-%s", secret.Namespace, secret.Name, hex.EncodeToString(shaSum[:])[0:secretCertDigestLen])
+}
+
+// secretCertificateCache caches the cas certificates uploaded from secrets by cert name. The certificate list is
+// described when the cache expires or a cert name is missing, e.g. after a secret is rotated.
+type secretCertificateCache struct {
+	lock     sync.Mutex
+	ttl      time.Duration
+	loadedAt time.Time
+	// certs are the cert ids indexed by cert name
+	certs map[string]string
+}
+
+func newSecretCertificateCache(ttl time.Duration) *secretCertificateCache {
+	return &secretCertificateCache{ttl: ttl, certs: make(map[string]string)}
+}
+
+// lookup returns the id of the cert name, or empty if no certificate has the name after reloading by load
+func (c *secretCertificateCache) lookup(certName string, load func() (map[string]string, error)) (string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if certId, ok := c.certs[certName]; ok && time.Since(c.loadedAt) < c.ttl {
+		return certId, nil
+	}
+	certs, err := load()
+	if err != nil {
+		return "", err
+	}
+	c.certs = certs
+	c.loadedAt = time.Now()
+	return c.certs[certName], nil
+}
+
+// add records the certificate uploaded
+func (c *secretCertificateCache) add(certName, certId string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.certs[certName] = certId
+}
+
+// remove forgets the certificate deleted
+func (c *secretCertificateCache) remove(certId string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for name, id := range c.certs {
+		if id == certId {
+			delete(c.certs, name)
+		}
+	}
+}
+
+// nameOf returns the cert name of the certificate, or empty if it is not cached
+func (c *secretCertificateCache) nameOf(certId string) string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for name, id := range c.certs {
+		if id == certId {
+			return name
+		}
+	}
+	return ""
+}
+
+// referencedSecrets returns the secrets referenced by cert-secret or cacert-secret annotation of the service
+func referencedSecrets(svc *v1.Service) sets.String {
+	anno := &annotation.AnnotationRequest{Service: svc}
+	secrets := sets.NewString()
+	for _, key := range []string{annotation.CertSecret, annotation.CaCertSecret} {
+		secrets.Insert(splitSecretNames(anno.Get(key))...)
+	}
+	return secrets
+}
+
+// secretReferences tracks the secrets referenced by nlb services, so that the events of
+// the other secrets in the cluster are dropped without listing services
+type secretReferences struct {
+	lock sync.RWMutex
+	// services are the services referencing the secret
+	services map[types.NamespacedName]sets.String
+	// secrets are the secrets referenced by the service
+	secrets map[types.NamespacedName]sets.String
+}
+
+func newSecretReferences() *secretReferences {
+	return &secretReferences{
+		services: make(map[types.NamespacedName]sets.String),
+		secrets:  make(map[types.NamespacedName]sets.String),
+	}
+}
+
+// update records the secrets referenced by the service, the service references no secret if it is not an nlb service
+func (r *secretReferences) update(svc *v1.Service) {
+	secrets := sets.NewString()
+	if helper.NeedNLB(svc) {
+		secrets = referencedSecrets(svc)
+	}
+	svcKey := types.NamespacedName{Namespace: svc.Namespace, Name: svc.Name}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, name := range r.secrets[svcKey].Difference(secrets).UnsortedList() {
+		secretKey := types.NamespacedName{Namespace: svc.Namespace, Name: name}
+		r.services[secretKey].Delete(svc.Name)
+		if r.services[secretKey].Len() == 0 {
+			delete(r.services, secretKey)
+		}
+	}
+	for _, name := range secrets.UnsortedList() {
+		secretKey := types.NamespacedName{Namespace: svc.Namespace, Name: name}
+		if r.services[secretKey] == nil {
+			r.services[secretKey] = sets.NewString()
+		}
+		r.services[secretKey].Insert(svc.Name)
+	}
+	if secrets.Len() == 0 {
+		delete(r.secrets, svcKey)
+	} else {
+		r.secrets[svcKey] = secrets
+	}
+}
+
+// remove forgets the secrets referenced by the deleted service
+func (r *secretReferences) remove(svc *v1.Service) {
+	deleted := svc.DeepCopy()
+	deleted.Annotations = nil
+	r.update(deleted)
+}
+
+// servicesOf returns the names of the services referencing the secret
+func (r *secretReferences) servicesOf(secret types.NamespacedName) []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.services[secret].List()
+}
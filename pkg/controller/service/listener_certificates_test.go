@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/helper"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/service/reconcile/annotation"
+	svcCtx "k8s.io/alibaba-load-balancer-controller/pkg/controller/service/reconcile/context"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model"
+	nlbmodel "k8s.io/alibaba-load-balancer-controller/pkg/model/nlb"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func getSecretCertRequestContext(annotations map[string]string) *svcCtx.RequestContext {
+	svc := &v1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "svc", Annotations: annotations}}
+	return &svcCtx.RequestContext{
+		Ctx:     context.TODO(),
+		Service: svc,
+		Anno:    &annotation.AnnotationRequest{Service: svc},
+		Log:     logr.Discard(),
+	}
+}
+
+func TestBuildSecretCertificates(t *testing.T) {
+	secret := getTLSSecret("tls", "crt")
+	caSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ca"},
+		Data:       map[string][]byte{secretCACertKey: []byte("ca")},
+	}
+	kubeClient := fake.NewClientBuilder().WithObjects(secret, caSecret).Build()
+	cloud := &fakeCASProvider{}
+	mgr := &ListenerManager{
+		kubeClient:  kubeClient,
+		cloud:       cloud,
+		certCache:   newSecretCertificateCache(time.Hour),
+		caCertCache: newSecretCertificateCache(time.Hour),
+	}
+	reqCtx := getSecretCertRequestContext(map[string]string{
+		annotation.Annotation(annotation.CertSecret):   "tls",
+		annotation.Annotation(annotation.CaCertSecret): "ca",
+	})
+
+	// the certificates are uploaded the first time
+	certs, err := mgr.buildSecretCertificates(reqCtx)
+	assert.NoError(t, err)
+	certName := secretCertificateName(secret, "crt", "key")
+	assert.Equal(t, []string{"cert-" + certName}, certs.certIds)
+	assert.Equal(t, []string{"ca-" + secretCertificateName(caSecret, "ca")}, certs.caCertIds)
+	assert.Len(t, cloud.certs, 1)
+	assert.Len(t, cloud.caCerts, 1)
+	assert.Equal(t, 1, cloud.describes)
+
+	listener := &nlbmodel.ListenerAttribute{CertificateIds: []string{"cert-id"}}
+	certs.setListenerCertificates(listener)
+	assert.Equal(t, []string{"cert-id", "cert-" + certName}, listener.CertificateIds)
+	assert.True(t, *listener.CaEnabled)
+
+	// the uploaded certificates are reused without describing the certificates again
+	certs, err = mgr.buildSecretCertificates(reqCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cert-" + certName}, certs.certIds)
+	assert.Len(t, cloud.certs, 1)
+	assert.Len(t, cloud.caCerts, 1)
+	assert.Equal(t, 1, cloud.describes)
+
+	// a new certificate is uploaded after the secret is rotated
+	secret.Data[v1.TLSCertKey] = []byte("rotated")
+	assert.NoError(t, kubeClient.Update(context.TODO(), secret))
+	certs, err = mgr.buildSecretCertificates(reqCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cert-" + secretCertificateName(secret, "rotated", "key")}, certs.certIds)
+	assert.Len(t, cloud.certs, 2)
+
+	reqCtx = getSecretCertRequestContext(map[string]string{annotation.Annotation(annotation.CertSecret): "missing"})
+	_, err = mgr.buildSecretCertificates(reqCtx)
+	assert.Error(t, err)
+	reqCtx = getSecretCertRequestContext(map[string]string{annotation.Annotation(annotation.CertSecret): "ca"})
+	_, err = mgr.buildSecretCertificates(reqCtx)
+	assert.Error(t, err)
+}
+
+// fakeListenerCASProvider keeps the nlb listeners and the cas certificates in memory
+type fakeListenerCASProvider struct {
+	fakeCASProvider
+	listeners []*nlbmodel.ListenerAttribute
+}
+
+func (p *fakeListenerCASProvider) ListNLBListeners(ctx context.Context, lbId string) ([]*nlbmodel.ListenerAttribute, error) {
+	return p.listeners, nil
+}
+
+func (p *fakeListenerCASProvider) UpdateNLBListener(ctx context.Context, lis *nlbmodel.ListenerAttribute) error {
+	for i := range p.listeners {
+		if p.listeners[i].ListenerId == lis.ListenerId {
+			p.listeners[i] = lis
+		}
+	}
+	return nil
+}
+
+func TestCleanupSecretCertificates(t *testing.T) {
+	secret := getTLSSecret("tls", "crt")
+	oldCertName := secretCertificateName(secret, "crt", "key")
+	cloud := &fakeListenerCASProvider{}
+	cloud.certs = []model.CertificateInfo{
+		{CertName: oldCertName, CertIdentifier: "cert-old"},
+		{CertName: "default-tls-other", CertIdentifier: "cert-user"},
+	}
+	cloud.listeners = []*nlbmodel.ListenerAttribute{
+		{ListenerId: "lsn-1", ListenerProtocol: nlbmodel.TCPSSL, CertificateIds: []string{"cert-old", "cert-user"}},
+		{ListenerId: "lsn-2", ListenerProtocol: nlbmodel.TCPSSL, CertificateIds: []string{"cert-old"}},
+	}
+	secret.Data[v1.TLSCertKey] = []byte("rotated")
+	mgr := &ListenerManager{
+		kubeClient:  fake.NewClientBuilder().WithObjects(secret).Build(),
+		cloud:       cloud,
+		certCache:   newSecretCertificateCache(time.Hour),
+		caCertCache: newSecretCertificateCache(time.Hour),
+	}
+	reqCtx := getSecretCertRequestContext(map[string]string{annotation.Annotation(annotation.CertSecret): "tls"})
+	certs, err := mgr.buildSecretCertificates(reqCtx)
+	assert.NoError(t, err)
+
+	// the previous certificate is kept while another listener still uses it
+	local := &nlbmodel.ListenerAttribute{ListenerProtocol: nlbmodel.TCPSSL}
+	certs.setListenerCertificates(local)
+	assert.NoError(t, mgr.UpdateNLBListener(reqCtx, local, cloud.listeners[0]))
+	assert.Equal(t, certs.certIds, cloud.listeners[0].CertificateIds)
+	assert.Len(t, cloud.certs, 3)
+
+	// and deleted once no listener uses it, the certificates not uploaded from the secret are kept
+	assert.NoError(t, mgr.UpdateNLBListener(reqCtx, local, cloud.listeners[1]))
+	assert.Len(t, cloud.certs, 2)
+	assert.Equal(t, "cert-user", cloud.certs[0].CertIdentifier)
+	assert.Equal(t, "", mgr.certCache.nameOf("cert-old"))
+}
+
+func TestSecretReferences(t *testing.T) {
+	nlbClass := helper.NLBClass
+	svc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "svc", Annotations: map[string]string{
+			annotation.Annotation(annotation.CertSecret):   "tls, tls-2",
+			annotation.Annotation(annotation.CaCertSecret): "ca",
+		}},
+		Spec: v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer, LoadBalancerClass: &nlbClass},
+	}
+	refs := newSecretReferences()
+	refs.update(svc)
+	assert.Equal(t, []string{"svc"}, refs.servicesOf(types.NamespacedName{Namespace: "default", Name: "tls-2"}))
+	assert.Equal(t, []string{"svc"}, refs.servicesOf(types.NamespacedName{Namespace: "default", Name: "ca"}))
+	assert.Empty(t, refs.servicesOf(types.NamespacedName{Namespace: "other", Name: "tls"}))
+
+	svc2 := svc.DeepCopy()
+	svc2.Name = "svc-2"
+	refs.update(svc2)
+	assert.Equal(t, []string{"svc", "svc-2"}, refs.servicesOf(types.NamespacedName{Namespace: "default", Name: "tls"}))
+
+	svc.Annotations[annotation.Annotation(annotation.CertSecret)] = "tls"
+	refs.update(svc)
+	assert.Equal(t, []string{"svc-2"}, refs.servicesOf(types.NamespacedName{Namespace: "default", Name: "tls-2"}))
+
+	refs.remove(svc2)
+	assert.Empty(t, refs.servicesOf(types.NamespacedName{Namespace: "default", Name: "tls-2"}))
+	assert.Equal(t, []string{"svc"}, refs.servicesOf(types.NamespacedName{Namespace: "default", Name: "tls"}))
+
+	// the secrets are not tracked once the service is not an nlb service
+	svc.Spec.Type = v1.ServiceTypeClusterIP
+	refs.update(svc)
+	assert.Empty(t, refs.servicesOf(types.NamespacedName{Namespace: "default", Name: "tls"}))
+	assert.Empty(t, refs.secrets)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/alibabacloud-go/tea/tea"
 	"github.com/mohae/deepcopy"
+	ctrlCfg "k8s.io/alibaba-load-balancer-controller/pkg/config"
 	"k8s.io/alibaba-load-balancer-controller/pkg/controller/service/reconcile/annotation"
 	svcCtx "k8s.io/alibaba-load-balancer-controller/pkg/controller/service/reconcile/context"
 	"k8s.io/alibaba-load-balancer-controller/pkg/model"
@@ -17,24 +18,40 @@ import (
 	"k8s.io/alibaba-load-balancer-controller/pkg/provider/dryrun"
 	"k8s.io/alibaba-load-balancer-controller/pkg/util"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func NewListenerManager(cloud prvd.Provider) *ListenerManager {
+func NewListenerManager(kubeClient client.Client, cloud prvd.Provider) *ListenerManager {
 	return &ListenerManager{
-		cloud: cloud,
+		kubeClient:  kubeClient,
+		cloud:       cloud,
+		certCache:   newSecretCertificateCache(ctrlCfg.ControllerCFG.CertificateInventoryTTL),
+		caCertCache: newSecretCertificateCache(ctrlCfg.ControllerCFG.CertificateInventoryTTL),
 	}
 }
 
 type ListenerManager struct {
-	cloud prvd.Provider
+	kubeClient client.Client
+	cloud      prvd.Provider
+	// certCache and caCertCache are the cas certificates uploaded from secrets, indexed by cert name
+	certCache   *secretCertificateCache
+	caCertCache *secretCertificateCache
 }
 
 func (mgr *ListenerManager) BuildLocalModel(reqCtx *svcCtx.RequestContext, mdl *nlbmodel.NetworkLoadBalancer) error {
+	secretCerts, err := mgr.buildSecretCertificates(reqCtx)
+	if err != nil {
+		return fmt.Errorf("build certificates from secrets error: %s", err.Error())
+	}
 	for _, port := range reqCtx.Service.Spec.Ports {
 		listener, err := mgr.buildListenerFromServicePort(reqCtx, port)
 		if err != nil {
 			return fmt.Errorf("build listener from servicePort %d error: %s", port.Port, err.Error())
 		}
+		if isTCPSSL(listener.ListenerProtocol) {
+			secretCerts.setListenerCertificates(listener)
+		}
 		mdl.Listeners = append(mdl.Listeners, listener)
 	}
 	return nil
@@ -200,7 +217,14 @@ func (mgr *ListenerManager) UpdateNLBListener(reqCtx *svcCtx.RequestContext, loc
 		reqCtx.Ctx = context.WithValue(reqCtx.Ctx, dryrun.ContextMessage, updateDetail)
 		reqCtx.Log.Info(fmt.Sprintf("update listener: %s [%d] changed, detail %s", local.ListenerProtocol, local.ListenerPort, updateDetail))
 
-		return mgr.cloud.UpdateNLBListener(reqCtx.Ctx, update)
+		if err := mgr.cloud.UpdateNLBListener(reqCtx.Ctx, update); err != nil {
+			return err
+		}
+		if isTCPSSL(local.ListenerProtocol) && len(local.CertificateIds) != 0 {
+			mgr.cleanupSecretCertificates(reqCtx,
+				sets.NewString(remote.CertificateIds...).Difference(sets.NewString(local.CertificateIds...)).List())
+		}
+		return nil
 	}
 
 	reqCtx.Log.Info(fmt.Sprintf("update listener: %s [%d] not changed, skip", local.ListenerProtocol, local.ListenerPort))
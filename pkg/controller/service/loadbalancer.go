@@ -4,12 +4,15 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+
 	"k8s.io/alibaba-load-balancer-controller/pkg/controller/helper"
 	"k8s.io/alibaba-load-balancer-controller/pkg/controller/service/reconcile/annotation"
 	svcCtx "k8s.io/alibaba-load-balancer-controller/pkg/controller/service/reconcile/context"
 	nlbmodel "k8s.io/alibaba-load-balancer-controller/pkg/model/nlb"
 	"k8s.io/alibaba-load-balancer-controller/pkg/model/tag"
 	prvd "k8s.io/alibaba-load-balancer-controller/pkg/provider"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
 )
 
 func NewNLBManager(cloud prvd.Provider) *NLBManager {
@@ -34,8 +37,6 @@ func (mgr *NLBManager) BuildLocalModel(reqCtx *svcCtx.RequestContext, mdl *nlbmo
 			return err
 		}
 		mdl.LoadBalancerAttribute.ZoneMappings = zoneMappings
-	} else if !mdl.LoadBalancerAttribute.IsUserManaged {
-		return fmt.Errorf("ParameterMissing, zone mappings are required")
 	}
 
 	mdl.LoadBalancerAttribute.AddressType = nlbmodel.GetAddressType(reqCtx.Anno.Get(annotation.AddressType))
@@ -86,6 +87,16 @@ func (mgr *NLBManager) Create(reqCtx *svcCtx.RequestContext, mdl *nlbmodel.Netwo
 		return fmt.Errorf("set model default value error: %s", err.Error())
 	}
 
+	// zone mappings are discovered only on creation, so that the zones of nlb are not changed
+	// by the available ips of vSwitches later
+	if len(mdl.LoadBalancerAttribute.ZoneMappings) == 0 {
+		zoneMappings, err := mgr.discoverZoneMappings(reqCtx, mdl.LoadBalancerAttribute.VpcId)
+		if err != nil {
+			return fmt.Errorf("discover zone mappings error: %s", err.Error())
+		}
+		mdl.LoadBalancerAttribute.ZoneMappings = zoneMappings
+	}
+
 	err := mgr.cloud.CreateNLB(reqCtx.Ctx, mdl)
 	if err != nil {
 		return err
@@ -103,6 +114,27 @@ func (mgr *NLBManager) Delete(reqCtx *svcCtx.RequestContext, mdl *nlbmodel.Netwo
 	return mgr.cloud.DeleteNLB(reqCtx.Ctx, mdl)
 }
 
+// Release removes the tags the controller finds the nlb by, and leaves the nlb as it is
+func (mgr *NLBManager) Release(reqCtx *svcCtx.RequestContext, mdl *nlbmodel.NetworkLoadBalancer) error {
+	if mdl.LoadBalancerAttribute.LoadBalancerId == "" {
+		return nil
+	}
+	tags, err := mgr.cloud.ListNLBTagResources(reqCtx.Ctx, mdl.LoadBalancerAttribute.LoadBalancerId)
+	if err != nil {
+		return fmt.Errorf("ListNLBTagResources: %s", err.Error())
+	}
+	var keys []string
+	for _, t := range tags {
+		if t.Key == helper.TAGKEY || t.Key == util.ClusterTagKey {
+			keys = append(keys, t.Key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	return mgr.cloud.UntagNLBResource(reqCtx.Ctx, mdl.LoadBalancerAttribute.LoadBalancerId, nlbmodel.LoadBalancerTagType, keys)
+}
+
 func (mgr *NLBManager) Update(reqCtx *svcCtx.RequestContext, local, remote *nlbmodel.NetworkLoadBalancer) error {
 	local.LoadBalancerAttribute.LoadBalancerId = remote.LoadBalancerAttribute.LoadBalancerId
 	// immutable attributes
@@ -165,6 +197,74 @@ func (mgr *NLBManager) Update(reqCtx *svcCtx.RequestContext, local, remote *nlbm
 	return nil
 }
 
+// discoverZoneMappings picks a vSwitch of vpc for each zone supporting nlb
+func (mgr *NLBManager) discoverZoneMappings(reqCtx *svcCtx.RequestContext, vpcId string) ([]nlbmodel.ZoneMapping, error) {
+	zones, err := mgr.cloud.DescribeNLBZones(reqCtx.Ctx)
+	if err != nil {
+		return nil, fmt.Errorf("DescribeNLBZones: %s", err.Error())
+	}
+	vSwitches, err := mgr.cloud.DescribeVSwitches(reqCtx.Ctx, vpcId)
+	if err != nil {
+		return nil, fmt.Errorf("DescribeVSwitches: %s", err.Error())
+	}
+
+	zoneMappings := SelectZoneMappings(zones, vSwitches, reqCtx.Anno.GetVSwitchTags())
+	if len(zoneMappings) < minNLBZones {
+		return nil, fmt.Errorf("ParameterMissing, zone mappings are required, found vSwitches in %d zones of %v, "+
+			"at least %d zones needed", len(zoneMappings), zones, minNLBZones)
+	}
+	reqCtx.Log.Info(fmt.Sprintf("discovered zone mappings %+v", zoneMappings))
+	return zoneMappings, nil
+}
+
+// minNLBZones is the least zones a nlb is created in
+const minNLBZones = 2
+
+// SelectZoneMappings picks the vSwitch with the most available ips for each of zones,
+// the vSwitches must have all the tags
+func SelectZoneMappings(zones []string, vSwitches []vpc.VSwitch, tags map[string]string) []nlbmodel.ZoneMapping {
+	chosen := make(map[string]vpc.VSwitch)
+	for _, vsw := range vSwitches {
+		if vsw.Status != "" && vsw.Status != vSwitchAvailable {
+			continue
+		}
+		if vsw.AvailableIpAddressCount <= 0 || !vSwitchHasTags(vsw, tags) {
+			continue
+		}
+		cur, ok := chosen[vsw.ZoneId]
+		if !ok || vsw.AvailableIpAddressCount > cur.AvailableIpAddressCount ||
+			(vsw.AvailableIpAddressCount == cur.AvailableIpAddressCount && vsw.VSwitchId < cur.VSwitchId) {
+			chosen[vsw.ZoneId] = vsw
+		}
+	}
+
+	var zoneMappings []nlbmodel.ZoneMapping
+	for _, zone := range zones {
+		if vsw, ok := chosen[zone]; ok {
+			zoneMappings = append(zoneMappings, nlbmodel.ZoneMapping{ZoneId: zone, VSwitchId: vsw.VSwitchId})
+		}
+	}
+	return zoneMappings
+}
+
+const vSwitchAvailable = "Available"
+
+func vSwitchHasTags(vsw vpc.VSwitch, tags map[string]string) bool {
+	for k, v := range tags {
+		found := false
+		for _, t := range vsw.Tags.Tag {
+			if t.Key == k && t.Value == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
 func setDefaultValueForLoadBalancer(mgr *NLBManager, mdl *nlbmodel.NetworkLoadBalancer, anno *annotation.AnnotationRequest,
 ) error {
 	if mdl.LoadBalancerAttribute.AddressType == "" {
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/stretchr/testify/assert"
+	nlbmodel "k8s.io/alibaba-load-balancer-controller/pkg/model/nlb"
+)
+
+func TestSelectZoneMappings(t *testing.T) {
+	vSwitch := func(id, zone string, ips int64, tags ...vpc.Tag) vpc.VSwitch {
+		return vpc.VSwitch{VSwitchId: id, ZoneId: zone, Status: "Available", AvailableIpAddressCount: ips,
+			Tags: vpc.TagsInDescribeVSwitches{Tag: tags}}
+	}
+	nlbTag := vpc.Tag{Key: "usage", Value: "nlb"}
+	vSwitches := []vpc.VSwitch{
+		vSwitch("vsw-a1", "zone-a", 10),
+		vSwitch("vsw-a2", "zone-a", 200, nlbTag),
+		vSwitch("vsw-b1", "zone-b", 100, nlbTag),
+		vSwitch("vsw-b2", "zone-b", 300),
+		vSwitch("vsw-c1", "zone-c", 0, nlbTag),
+		vSwitch("vsw-d1", "zone-d", 100, nlbTag),
+	}
+
+	assert.Equal(t, []nlbmodel.ZoneMapping{
+		{ZoneId: "zone-a", VSwitchId: "vsw-a2"},
+		{ZoneId: "zone-b", VSwitchId: "vsw-b2"},
+	}, SelectZoneMappings([]string{"zone-a", "zone-b", "zone-c"}, vSwitches, nil))
+
+	assert.Equal(t, []nlbmodel.ZoneMapping{
+		{ZoneId: "zone-a", VSwitchId: "vsw-a2"},
+		{ZoneId: "zone-b", VSwitchId: "vsw-b1"},
+	}, SelectZoneMappings([]string{"zone-a", "zone-b", "zone-c"}, vSwitches, map[string]string{"usage": "nlb"}))
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	ctrlCfg "k8s.io/alibaba-load-balancer-controller/pkg/config"
@@ -41,6 +42,13 @@ func (m *ModelApplier) Apply(reqCtx *svcCtx.RequestContext, local *nlbmodel.Netw
 	}
 	reqCtx.Ctx = context.WithValue(reqCtx.Ctx, dryrun.ContextNLB, remote.LoadBalancerAttribute.LoadBalancerId)
 
+	if helper.NeedDeleteLoadBalancer(reqCtx.Service) && reqCtx.Anno.IsRetain() {
+		if err := m.retain(reqCtx, remote); err != nil {
+			return remote, fmt.Errorf("retain nlb error: %s", err.Error())
+		}
+		return remote, nil
+	}
+
 	serviceHashChanged := helper.IsServiceHashChanged(reqCtx.Service)
 	if serviceHashChanged || ctrlCfg.ControllerCFG.DryRun {
 		if err := m.applyLoadBalancerAttribute(reqCtx, local, remote); err != nil {
@@ -78,6 +86,61 @@ func (m *ModelApplier) Apply(reqCtx *svcCtx.RequestContext, local *nlbmodel.Netw
 	return remote, nil
 }
 
+// errServerGroupNotFound is returned by ApplyBackends if a server group is not created yet
+var errServerGroupNotFound = errors.New("server group not found")
+
+// ApplyBackends updates the servers of the server groups of local only
+func (m *ModelApplier) ApplyBackends(reqCtx *svcCtx.RequestContext, local *nlbmodel.NetworkLoadBalancer) error {
+	remote := &nlbmodel.NetworkLoadBalancer{
+		NamespacedName:        util.NamespacedName(reqCtx.Service),
+		LoadBalancerAttribute: &nlbmodel.LoadBalancerAttribute{},
+	}
+	if err := m.sgMgr.BuildRemoteModel(reqCtx, remote); err != nil {
+		return fmt.Errorf("get server group from remote error: %s", err.Error())
+	}
+
+	// the reused server groups are managed by user and have no tags of the service, so they are found by ids
+	var reusedIds []string
+	for _, sg := range local.ServerGroups {
+		if sg.ServerGroupId != "" && findServerGroupOf(sg, remote.ServerGroups) == nil {
+			reusedIds = append(reusedIds, sg.ServerGroupId)
+		}
+	}
+	reused, err := m.sgMgr.cloud.ListNLBServerGroupsByIds(reqCtx.Ctx, reusedIds)
+	if err != nil {
+		return fmt.Errorf("get reused server groups %v error: %s", reusedIds, err.Error())
+	}
+	remote.ServerGroups = append(remote.ServerGroups, reused...)
+
+	olds := make([]*nlbmodel.ServerGroup, len(local.ServerGroups))
+	for i, sg := range local.ServerGroups {
+		olds[i] = findServerGroupOf(sg, remote.ServerGroups)
+		if olds[i] == nil {
+			return errServerGroupNotFound
+		}
+		sg.ServerGroupId = olds[i].ServerGroupId
+	}
+
+	for i, sg := range local.ServerGroups {
+		if err := m.sgMgr.updateServerGroupServers(reqCtx, sg, olds[i]); err != nil {
+			return fmt.Errorf("reconcile backends of server group %s error: %s", sg.ServerGroupId, err.Error())
+		}
+	}
+	return nil
+}
+
+// findServerGroupOf returns the remote server group of local, found by the id of the reused server group,
+// or by name
+func findServerGroupOf(local *nlbmodel.ServerGroup, remotes []*nlbmodel.ServerGroup) *nlbmodel.ServerGroup {
+	for _, rv := range remotes {
+		if (local.ServerGroupId != "" && local.ServerGroupId == rv.ServerGroupId) ||
+			(local.ServerGroupId == "" && local.ServerGroupName == rv.ServerGroupName) {
+			return rv
+		}
+	}
+	return nil
+}
+
 func (m *ModelApplier) applyLoadBalancerAttribute(reqCtx *svcCtx.RequestContext, local, remote *nlbmodel.NetworkLoadBalancer) error {
 	if local == nil || remote == nil {
 		return fmt.Errorf("local or remote mdl is nil")
@@ -285,6 +348,30 @@ func (m *ModelApplier) cleanup(reqCtx *svcCtx.RequestContext, local, remote *nlb
 	return nil
 }
 
+// retain releases the nlb and the server groups of a deleted service, which are left with the listeners
+// instead of deleted
+func (m *ModelApplier) retain(reqCtx *svcCtx.RequestContext, remote *nlbmodel.NetworkLoadBalancer) error {
+	if remote.LoadBalancerAttribute.LoadBalancerId == "" {
+		return nil
+	}
+	if err := m.nlbMgr.Release(reqCtx, remote); err != nil {
+		return fmt.Errorf("release nlb %s error: %s", remote.LoadBalancerAttribute.LoadBalancerId, err.Error())
+	}
+	if err := m.sgMgr.BuildRemoteModel(reqCtx, remote); err != nil {
+		return fmt.Errorf("get server group from remote error: %s", err.Error())
+	}
+	for _, r := range remote.ServerGroups {
+		if r.NamedKey == nil || !r.NamedKey.IsManagedByService(reqCtx.Service, base.CLUSTER_ID) {
+			continue
+		}
+		if err := m.sgMgr.ReleaseServerGroup(reqCtx, r.ServerGroupId); err != nil {
+			return fmt.Errorf("release server group %s error: %s", r.ServerGroupId, err.Error())
+		}
+	}
+	reqCtx.Log.Info(fmt.Sprintf("nlb %s is retained", remote.LoadBalancerAttribute.LoadBalancerId))
+	return nil
+}
+
 func isNLBReusable(service *v1.Service, tags []tag.Tag, dnsName string) (bool, string) {
 	for _, t := range tags {
 		// the tag of the apiserver slb is "ack.aliyun.com": "${clusterid}",
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/service/reconcile/annotation"
+	svcCtx "k8s.io/alibaba-load-balancer-controller/pkg/controller/service/reconcile/context"
+	nlbmodel "k8s.io/alibaba-load-balancer-controller/pkg/model/nlb"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model/tag"
+	prvd "k8s.io/alibaba-load-balancer-controller/pkg/provider"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// fakeBackendProvider records the servers added to and removed from the server groups
+type fakeBackendProvider struct {
+	prvd.Provider
+	serverGroups []*nlbmodel.ServerGroup
+	// untagged are the server groups without tags, which are only found by ids
+	untagged []*nlbmodel.ServerGroup
+	added    map[string][]nlbmodel.ServerGroupServer
+	removed  map[string][]nlbmodel.ServerGroupServer
+}
+
+func (p *fakeBackendProvider) ListNLBServerGroups(ctx context.Context, tags []tag.Tag) ([]*nlbmodel.ServerGroup, error) {
+	return p.serverGroups, nil
+}
+
+func (p *fakeBackendProvider) ListNLBServerGroupsByIds(ctx context.Context, sgIds []string) ([]*nlbmodel.ServerGroup, error) {
+	var sgs []*nlbmodel.ServerGroup
+	for _, sg := range p.untagged {
+		for _, id := range sgIds {
+			if sg.ServerGroupId == id {
+				sgs = append(sgs, sg)
+			}
+		}
+	}
+	return sgs, nil
+}
+
+func (p *fakeBackendProvider) AddNLBServers(ctx context.Context, sgId string, backends []nlbmodel.ServerGroupServer) error {
+	p.added[sgId] = append(p.added[sgId], backends...)
+	return nil
+}
+
+func (p *fakeBackendProvider) RemoveNLBServers(ctx context.Context, sgId string, backends []nlbmodel.ServerGroupServer) error {
+	p.removed[sgId] = append(p.removed[sgId], backends...)
+	return nil
+}
+
+func TestApplyBackends(t *testing.T) {
+	ecs := func(id string) nlbmodel.ServerGroupServer {
+		return nlbmodel.ServerGroupServer{ServerId: id, ServerType: nlbmodel.EcsServerType, Port: 30080, Weight: 100}
+	}
+	cloud := &fakeBackendProvider{
+		serverGroups: []*nlbmodel.ServerGroup{
+			{ServerGroupId: "sgp-80", ServerGroupName: "sg-80", Servers: []nlbmodel.ServerGroupServer{ecs("i-1"), ecs("i-2")}},
+		},
+		added:   map[string][]nlbmodel.ServerGroupServer{},
+		removed: map[string][]nlbmodel.ServerGroupServer{},
+	}
+	applier := NewModelApplier(nil, nil, &ServerGroupManager{cloud: cloud})
+	svc := &v1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx"}}
+	reqCtx := &svcCtx.RequestContext{
+		Ctx:     context.TODO(),
+		Service: svc,
+		Anno:    annotation.NewAnnotationRequest(svc),
+		Log:     ctrl.Log,
+	}
+
+	local := &nlbmodel.NetworkLoadBalancer{ServerGroups: []*nlbmodel.ServerGroup{
+		{ServerGroupName: "sg-80", Servers: []nlbmodel.ServerGroupServer{ecs("i-2"), ecs("i-3")}},
+	}}
+	assert.NoError(t, applier.ApplyBackends(reqCtx, local))
+	assert.Equal(t, "sgp-80", local.ServerGroups[0].ServerGroupId)
+	assert.Equal(t, []nlbmodel.ServerGroupServer{ecs("i-3")}, cloud.added["sgp-80"])
+	assert.Equal(t, []nlbmodel.ServerGroupServer{ecs("i-1")}, cloud.removed["sgp-80"])
+
+	// nothing is applied if a server group is not created yet
+	cloud.added = map[string][]nlbmodel.ServerGroupServer{}
+	local.ServerGroups = append(local.ServerGroups, &nlbmodel.ServerGroup{
+		ServerGroupName: "sg-443", Servers: []nlbmodel.ServerGroupServer{ecs("i-4")}})
+	assert.ErrorIs(t, applier.ApplyBackends(reqCtx, local), errServerGroupNotFound)
+	assert.Empty(t, cloud.added)
+
+	// the reused server groups without tags are found by ids
+	cloud.untagged = []*nlbmodel.ServerGroup{{ServerGroupId: "sgp-user", ServerGroupName: "user-managed"}}
+	local.ServerGroups[1].ServerGroupId = "sgp-user"
+	assert.NoError(t, applier.ApplyBackends(reqCtx, local))
+	assert.Equal(t, []nlbmodel.ServerGroupServer{ecs("i-4")}, cloud.added["sgp-user"])
+}
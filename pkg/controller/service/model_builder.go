@@ -53,6 +53,26 @@ func (builder *ModelBuilder) BuildModel(reqCtx *svcCtx.RequestContext, modelType
 	return builder.Instance(modelType).Build(reqCtx)
 }
 
+// BuildBackendModel builds the server groups of the local model with their servers,
+// the listeners are built only for the ports and protocols of the server groups
+func (builder *ModelBuilder) BuildBackendModel(reqCtx *svcCtx.RequestContext) (*nlbmodel.NetworkLoadBalancer, error) {
+	lbMdl := &nlbmodel.NetworkLoadBalancer{
+		NamespacedName:        util.NamespacedName(reqCtx.Service),
+		LoadBalancerAttribute: &nlbmodel.LoadBalancerAttribute{},
+	}
+	for _, port := range reqCtx.Service.Spec.Ports {
+		listener, err := builder.LisMgr.buildListenerFromServicePort(reqCtx, port)
+		if err != nil {
+			return nil, fmt.Errorf("build listener from servicePort %d error: %s", port.Port, err.Error())
+		}
+		lbMdl.Listeners = append(lbMdl.Listeners, listener)
+	}
+	if err := builder.SGMgr.BuildLocalModel(reqCtx, lbMdl); err != nil {
+		return nil, fmt.Errorf("build nlb server group error: %s", err.Error())
+	}
+	return lbMdl, nil
+}
+
 // localModel build model according to the Kubernetes cluster info
 type localModel struct{ *ModelBuilder }
 
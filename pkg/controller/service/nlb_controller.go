@@ -3,16 +3,21 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
-	"golang.org/x/time/rate"
+	alibabacloudv1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	ctrlCfg "k8s.io/alibaba-load-balancer-controller/pkg/config"
 	"k8s.io/alibaba-load-balancer-controller/pkg/context/shared"
 	"k8s.io/alibaba-load-balancer-controller/pkg/controller/helper"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/helper/quota"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/servergrouppolicy"
 	"k8s.io/alibaba-load-balancer-controller/pkg/controller/service/reconcile/annotation"
 	svcCtx "k8s.io/alibaba-load-balancer-controller/pkg/controller/service/reconcile/context"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model"
 	nlbmodel "k8s.io/alibaba-load-balancer-controller/pkg/model/nlb"
 	prvd "k8s.io/alibaba-load-balancer-controller/pkg/provider"
 	"k8s.io/alibaba-load-balancer-controller/pkg/provider/dryrun"
@@ -20,10 +25,11 @@ import (
 	"k8s.io/alibaba-load-balancer-controller/pkg/util/metric"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/record"
-	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	v1helper "k8s.io/kubernetes/pkg/apis/core/v1/helper"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -34,7 +40,24 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
+// conditions of the service status
+const (
+	LoadBalancerActiveCondition         = "LoadBalancerActive"
+	LoadBalancerBusinessNormalCondition = "LoadBalancerBusinessNormal"
+	// LoadBalancerJobPendingCondition records the async jobs the next reconcile waits for
+	LoadBalancerJobPendingCondition = "LoadBalancerJobPending"
+)
+
+const (
+	asyncJobProcessingReason   = "AsyncJobProcessing"
+	asyncJobRequeuePeriod      = 10 * time.Second
+	serviceLockedRequeuePeriod = 1 * time.Second
+)
+
 func Add(mgr manager.Manager, ctx *shared.SharedContext) error {
+	if err := servergrouppolicy.RegisterCRD(mgr.GetConfig()); err != nil {
+		return fmt.Errorf("register servergrouppolicy crd error: %s", err.Error())
+	}
 	reconciler, err := newReconciler(mgr, ctx)
 	if err != nil {
 		return fmt.Errorf("new nlb reconciler error: %s", err.Error())
@@ -50,10 +73,12 @@ func newReconciler(mgr manager.Manager, ctx *shared.SharedContext) (*ReconcileNL
 		logger:           ctrl.Log.WithName("controller").WithName("nlb-controller"),
 		record:           mgr.GetEventRecorderFor("nlb-controller"),
 		finalizerManager: helper.NewDefaultFinalizerManager(mgr.GetClient()),
+		quotaValidator:   quota.NewValidator(quota.NewStaticProvider(ctrlCfg.ControllerCFG.QuotaConfig)),
+		serviceLocks:     newKeyLocks(),
 	}
 
 	nlbManager := NewNLBManager(recon.cloud)
-	listenerManager := NewListenerManager(recon.cloud)
+	listenerManager := NewListenerManager(recon.kubeClient, recon.cloud)
 	serverGroupManager, err := NewServerGroupManager(recon.kubeClient, recon.cloud)
 	if err != nil {
 		return nil, fmt.Errorf("NewServerGroupManager error:%s", err.Error())
@@ -73,20 +98,14 @@ func (n nlbController) Start(ctx context.Context) error {
 }
 
 func add(mgr manager.Manager, r *ReconcileNLB) error {
-	rateLimit := workqueue.NewMaxOfRateLimiter(
-		workqueue.NewItemExponentialFailureRateLimiter(5*time.Second, 300*time.Second),
-		// 10 qps, 100 bucket size.  This is only for retry speed and its only the overall factor (not per item)
-		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
-	)
-
 	recoverPanic := true
 	// Create a new controller
 	c, err := controller.NewUnmanaged(
 		"nlb-controller", mgr,
 		controller.Options{
 			Reconciler:              r,
-			MaxConcurrentReconciles: 2,
-			RateLimiter:             rateLimit,
+			MaxConcurrentReconciles: ctrlCfg.ControllerCFG.ServiceMaxConcurrentReconciles,
+			RateLimiter:             helper.NewControllerRateLimiter(ctrlCfg.ControllerCFG.ReconcileConfig),
 			RecoverPanic:            &recoverPanic,
 		},
 	)
@@ -94,12 +113,29 @@ func add(mgr manager.Manager, r *ReconcileNLB) error {
 		return err
 	}
 
+	// endpoint changes go through a separate queue, so that they are not stuck behind
+	// the full load balancer reconciles during mass deployments
+	ec, err := controller.NewUnmanaged(
+		"nlb-endpoint-controller", mgr,
+		controller.Options{
+			Reconciler:              &endpointReconciler{recon: r},
+			MaxConcurrentReconciles: ctrlCfg.ControllerCFG.ReconcileConfig.EndpointMaxConcurrentReconciles,
+			RateLimiter:             helper.NewControllerRateLimiter(ctrlCfg.ControllerCFG.ReconcileConfig),
+			RecoverPanic:            &recoverPanic,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// the secrets are handled only if they are referenced by the services seen by the service watch
+	secretRefs := newSecretReferences()
 	if err := c.Watch(&source.Kind{Type: &v1.Service{}},
-		NewEnqueueRequestForServiceEvent(mgr.GetEventRecorderFor("nlb-controller"))); err != nil {
+		NewEnqueueRequestForServiceEvent(mgr.GetEventRecorderFor("nlb-controller"), secretRefs)); err != nil {
 		return fmt.Errorf("watch resource svc error: %s", err.Error())
 	}
 
-	if err := c.Watch(&source.Kind{Type: &v1.Endpoints{}},
+	if err := ec.Watch(&source.Kind{Type: &v1.Endpoints{}},
 		NewEnqueueRequestForEndpointEvent(mgr.GetEventRecorderFor("nlb-controller"))); err != nil {
 		return fmt.Errorf("watch resource endpoint error: %s", err.Error())
 	}
@@ -108,9 +144,39 @@ func add(mgr manager.Manager, r *ReconcileNLB) error {
 		NewEnqueueRequestForNodeEvent(mgr.GetEventRecorderFor("nlb-controller"))); err != nil {
 		return fmt.Errorf("watch resource node error: %s", err.Error())
 	}
+
+	if err := c.Watch(&source.Kind{Type: &v1.Secret{}},
+		NewEnqueueRequestForSecretEvent(mgr.GetEventRecorderFor("nlb-controller"), secretRefs)); err != nil {
+		return fmt.Errorf("watch resource secret error: %s", err.Error())
+	}
+
+	if err := c.Watch(&source.Kind{Type: &alibabacloudv1.ServerGroupPolicy{}},
+		NewEnqueueRequestForServerGroupPolicyEvent(mgr.GetEventRecorderFor("nlb-controller"))); err != nil {
+		return fmt.Errorf("watch resource servergrouppolicy error: %s", err.Error())
+	}
+
+	if err := mgr.Add(newCertificateAuditor(r)); err != nil {
+		return fmt.Errorf("add certificate auditor error: %s", err.Error())
+	}
+	if err := mgr.Add(&nlbController{c: ec, recon: r}); err != nil {
+		return fmt.Errorf("add nlb endpoint controller error: %s", err.Error())
+	}
 	return mgr.Add(&nlbController{c: c, recon: r})
 }
 
+// endpointReconciler reconciles the services whose endpoints changed from the endpoint queue
+type endpointReconciler struct {
+	recon *ReconcileNLB
+}
+
+func (e *endpointReconciler) Reconcile(_ context.Context, request reconcile.Request) (reconcile.Result, error) {
+	if !e.recon.serviceLocks.TryLock(request.String()) {
+		return reconcile.Result{RequeueAfter: serviceLockedRequeuePeriod}, nil
+	}
+	defer e.recon.serviceLocks.Unlock(request.String())
+	return e.recon.reconcileBackends(request)
+}
+
 var _ reconcile.Reconciler = &ReconcileNLB{}
 
 type ReconcileNLB struct {
@@ -127,34 +193,37 @@ type ReconcileNLB struct {
 	//record event recorder
 	record           record.EventRecorder
 	finalizerManager helper.FinalizerManager
+	quotaValidator   *quota.Validator
+	serviceLocks     *keyLocks
 }
 
 func (m *ReconcileNLB) Reconcile(_ context.Context, request reconcile.Request) (reconcile.Result, error) {
-	return reconcile.Result{}, m.reconcile(request)
+	// a service is reconciled by one worker at a time across the queues,
+	// the other queue retries later instead of waiting for the worker
+	if !m.serviceLocks.TryLock(request.String()) {
+		return reconcile.Result{RequeueAfter: serviceLockedRequeuePeriod}, nil
+	}
+	defer m.serviceLocks.Unlock(request.String())
+	return m.reconcile(request)
 }
 
-func (m *ReconcileNLB) reconcile(request reconcile.Request) error {
+func (m *ReconcileNLB) reconcile(request reconcile.Request) (reconcile.Result, error) {
 	startTime := time.Now()
 	svc := &v1.Service{}
 	err := m.kubeClient.Get(context.Background(), request.NamespacedName, svc)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			m.logger.Info("service not found, skip", "service", request.NamespacedName)
-			return nil
+			return reconcile.Result{}, nil
 		}
 		m.logger.Error(err, "reconcile: get service failed", "service", request.NamespacedName)
 	}
 
-	anno := &annotation.AnnotationRequest{Service: svc}
-	// new context for each request
-	ctx := context.Background()
-	ctx = context.WithValue(ctx, dryrun.ContextService, svc)
-	reqCtx := &svcCtx.RequestContext{
-		Ctx:      ctx,
-		Service:  svc,
-		Anno:     anno,
-		Log:      m.logger.WithValues("service", util.Key(svc)),
-		Recorder: m.record,
+	reqCtx, tracker := m.newRequestContext(svc)
+
+	if jobs := getAsyncJobs(svc); len(jobs) > 0 && !m.asyncJobsFinished(reqCtx, jobs) {
+		reqCtx.Log.Info("async jobs are not finished, requeue", "jobs", formatAsyncJobs(jobs))
+		return reconcile.Result{RequeueAfter: asyncJobRequeuePeriod}, nil
 	}
 
 	klog.Infof("%s: ensure loadbalancer with service details, \n%+v", util.Key(svc), util.PrettyJson(svc))
@@ -164,21 +233,193 @@ func (m *ReconcileNLB) reconcile(request reconcile.Request) error {
 	} else {
 		err = m.reconcileLoadBalancerResources(reqCtx)
 	}
+	if jobs := tracker.Jobs(); len(jobs) > 0 {
+		return m.waitForAsyncJobs(reqCtx, jobs, err)
+	}
 	if err != nil {
-		return err
+		return reconcile.Result{}, err
 	}
 
 	reqCtx.Log.Info("successfully reconcile")
 	metric.SLBLatency.WithLabelValues("reconcile").Observe(metric.MsSince(startTime))
 
+	return reconcile.Result{}, nil
+}
+
+// reconcileBackends syncs the servers of the server groups of a service whose endpoints changed,
+// the load balancer and the listeners are not described. It falls back to the full reconcile
+// if the service changed since the last reconcile, or the server groups are not created yet.
+func (m *ReconcileNLB) reconcileBackends(request reconcile.Request) (reconcile.Result, error) {
+	startTime := time.Now()
+	svc := &v1.Service{}
+	if err := m.kubeClient.Get(context.Background(), request.NamespacedName, svc); err != nil {
+		if apierrors.IsNotFound(err) {
+			m.logger.Info("service not found, skip", "service", request.NamespacedName)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	if !canReconcileBackendsOnly(svc) {
+		return m.reconcile(request)
+	}
+
+	reqCtx, tracker := m.newRequestContext(svc)
+	local, err := m.builder.BuildBackendModel(reqCtx)
+	if err == nil {
+		// the backends are checked by the same quotas as the full reconcile
+		if err := m.quotaValidator.ValidateNLB(reqCtx.Ctx, local); err != nil {
+			m.record.Event(svc, v1.EventTypeWarning, helper.EventReasonQuotaExceeded, err.Error())
+			return reconcile.Result{}, err
+		}
+		err = m.applier.ApplyBackends(reqCtx, local)
+	}
+	if errors.Is(err, errServerGroupNotFound) {
+		reqCtx.Log.Info("server groups are not found, reconcile the load balancer")
+		return m.reconcile(request)
+	}
+	if jobs := tracker.Jobs(); len(jobs) > 0 {
+		return m.waitForAsyncJobs(reqCtx, jobs, err)
+	}
+	if err != nil {
+		m.record.Event(svc, v1.EventTypeWarning, helper.FailedSyncLB,
+			fmt.Sprintf("Error syncing backends: %s", helper.GetLogMessage(err)))
+		return reconcile.Result{}, err
+	}
+
+	reqCtx.Log.Info("successfully reconcile backends")
+	metric.SLBLatency.WithLabelValues("reconcile_backends").Observe(metric.MsSince(startTime))
+	return reconcile.Result{}, nil
+}
+
+// canReconcileBackendsOnly returns true if the load balancer is in sync with the service except the backends.
+// Health checks derived from the readinessProbe may change with the pods, so they need a full reconcile.
+func canReconcileBackendsOnly(svc *v1.Service) bool {
+	anno := &annotation.AnnotationRequest{Service: svc}
+	return !helper.NeedDeleteLoadBalancer(svc) &&
+		!strings.EqualFold(anno.Get(annotation.HealthCheckFromProbe), string(model.OnFlag)) &&
+		helper.HasFinalizer(svc, helper.NLBFinalizer) &&
+		!helper.IsServiceHashChanged(svc) &&
+		len(getAsyncJobs(svc)) == 0 &&
+		!ctrlCfg.ControllerCFG.DryRun
+}
+
+// newRequestContext returns the context of a reconcile and the tracker of its async jobs
+func (m *ReconcileNLB) newRequestContext(svc *v1.Service) (*svcCtx.RequestContext, *nlbmodel.AsyncJobTracker) {
+	// new context for each request
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, dryrun.ContextService, svc)
+	// the async jobs are not polled by the workers, the reconcile is requeued until they finish
+	tracker := &nlbmodel.AsyncJobTracker{}
+	ctx = nlbmodel.WithAsyncJobTracker(ctx, tracker)
+	return &svcCtx.RequestContext{
+		Ctx:      ctx,
+		Service:  svc,
+		Anno:     &annotation.AnnotationRequest{Service: svc},
+		Log:      m.logger.WithValues("service", util.Key(svc)),
+		Recorder: m.record,
+	}, tracker
+}
+
+// waitForAsyncJobs records the unfinished async jobs of the reconcile and requeues the service
+func (m *ReconcileNLB) waitForAsyncJobs(reqCtx *svcCtx.RequestContext, jobs []nlbmodel.AsyncJob, err error) (reconcile.Result, error) {
+	reqCtx.Log.Info("waiting for async jobs", "jobs", formatAsyncJobs(jobs), "error", err)
+	m.record.Event(reqCtx.Service, v1.EventTypeNormal, helper.WaitingForAsyncJob,
+		fmt.Sprintf("Waiting for async jobs %s", formatAsyncJobs(jobs)))
+	if err := m.updateAsyncJobCondition(reqCtx, jobs); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: asyncJobRequeuePeriod}, nil
+}
+
+// asyncJobsFinished checks the async jobs of the last reconcile, the errors are left to the reconcile to retry
+func (m *ReconcileNLB) asyncJobsFinished(reqCtx *svcCtx.RequestContext, jobs []nlbmodel.AsyncJob) bool {
+	for _, job := range jobs {
+		if job.Api == nlbmodel.ApiCreateLoadBalancer {
+			tracker := &nlbmodel.AsyncJobTracker{}
+			lb := &nlbmodel.NetworkLoadBalancer{
+				NamespacedName:        util.NamespacedName(reqCtx.Service),
+				LoadBalancerAttribute: &nlbmodel.LoadBalancerAttribute{LoadBalancerId: job.Id},
+			}
+			err := m.cloud.DescribeNLB(nlbmodel.WithAsyncJobTracker(reqCtx.Ctx, tracker), lb)
+			if len(tracker.Jobs()) > 0 {
+				return false
+			}
+			if err != nil {
+				reqCtx.Log.Error(err, "failed to describe nlb of async job", "nlb", job.Id)
+			}
+			continue
+		}
+
+		status, err := m.cloud.GetNLBJobStatus(reqCtx.Ctx, job.Id)
+		if err != nil {
+			reqCtx.Log.Error(err, "failed to get status of async job", "job", job.Id)
+			continue
+		}
+		switch status {
+		case nlbmodel.JobStatusSucceeded:
+		case nlbmodel.JobStatusFailed:
+			m.record.Event(reqCtx.Service, v1.EventTypeWarning, helper.FailedAsyncJob,
+				fmt.Sprintf("Async job %s of %s failed", job.Id, job.Api))
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (m *ReconcileNLB) updateAsyncJobCondition(reqCtx *svcCtx.RequestContext, jobs []nlbmodel.AsyncJob) error {
+	updated := reqCtx.Service.DeepCopy()
+	meta.SetStatusCondition(&updated.Status.Conditions, metav1.Condition{
+		Type:               LoadBalancerJobPendingCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             asyncJobProcessingReason,
+		Message:            formatAsyncJobs(jobs),
+		ObservedGeneration: reqCtx.Service.Generation,
+	})
+	if err := m.kubeClient.Status().Patch(reqCtx.Ctx, updated, client.MergeFrom(reqCtx.Service)); err != nil {
+		return fmt.Errorf("%s failed to record async jobs, error: %s", util.Key(reqCtx.Service), err.Error())
+	}
 	return nil
 }
 
+// formatAsyncJobs formats jobs as the message of LoadBalancerJobPendingCondition, e.g. "Api1/JobId1,Api2/JobId2"
+func formatAsyncJobs(jobs []nlbmodel.AsyncJob) string {
+	var items []string
+	for _, job := range jobs {
+		items = append(items, job.Api+"/"+job.Id)
+	}
+	return strings.Join(items, ",")
+}
+
+// getAsyncJobs returns the async jobs recorded in LoadBalancerJobPendingCondition
+func getAsyncJobs(svc *v1.Service) []nlbmodel.AsyncJob {
+	condition := meta.FindStatusCondition(svc.Status.Conditions, LoadBalancerJobPendingCondition)
+	if condition == nil || condition.Status != metav1.ConditionTrue {
+		return nil
+	}
+	var jobs []nlbmodel.AsyncJob
+	for _, item := range strings.Split(condition.Message, ",") {
+		api, id, found := strings.Cut(strings.TrimSpace(item), "/")
+		if found && api != "" && id != "" {
+			jobs = append(jobs, nlbmodel.AsyncJob{Api: api, Id: id})
+		}
+	}
+	return jobs
+}
+
+func hasAsyncJobs(reqCtx *svcCtx.RequestContext) bool {
+	tracker := nlbmodel.AsyncJobTrackerFrom(reqCtx.Ctx)
+	return tracker != nil && len(tracker.Jobs()) > 0
+}
+
 func (m *ReconcileNLB) cleanupLoadBalancerResources(reqCtx *svcCtx.RequestContext) error {
 	reqCtx.Log.Info("service do not need lb any more, try to delete it")
 	if helper.HasFinalizer(reqCtx.Service, helper.NLBFinalizer) {
 		lb, err := m.buildAndApplyModel(reqCtx)
 		if err != nil && !strings.Contains(err.Error(), "ResourceNotFound.loadBalancer") {
+			if hasAsyncJobs(reqCtx) {
+				return err
+			}
 			m.record.Event(reqCtx.Service, v1.EventTypeWarning, helper.FailedCleanLB,
 				fmt.Sprintf("Error deleting load balancer [%s]: %s",
 					lb.GetLoadBalancerId(), helper.GetLogMessage(err)))
@@ -219,6 +460,9 @@ func (m *ReconcileNLB) reconcileLoadBalancerResources(req *svcCtx.RequestContext
 
 	lb, err := m.buildAndApplyModel(req)
 	if err != nil {
+		if hasAsyncJobs(req) {
+			return err
+		}
 		m.record.Event(req.Service, v1.EventTypeWarning, helper.FailedSyncLB,
 			fmt.Sprintf("Error syncing load balancer [%s]: %s",
 				lb.GetLoadBalancerId(), helper.GetLogMessage(err)))
@@ -255,6 +499,11 @@ func (m *ReconcileNLB) buildAndApplyModel(reqCtx *svcCtx.RequestContext) (*nlbmo
 	}
 	m.logger.V(5).Info(fmt.Sprintf("local build: %s", mdlJson))
 
+	if err := m.quotaValidator.ValidateNLB(reqCtx.Ctx, localModel); err != nil {
+		m.record.Event(reqCtx.Service, v1.EventTypeWarning, helper.EventReasonQuotaExceeded, err.Error())
+		return nil, err
+	}
+
 	// apply model
 	remoteModel, err := m.applier.Apply(reqCtx, localModel)
 	if err != nil {
@@ -265,23 +514,19 @@ func (m *ReconcileNLB) buildAndApplyModel(reqCtx *svcCtx.RequestContext) (*nlbmo
 
 func (m *ReconcileNLB) updateServiceStatus(reqCtx *svcCtx.RequestContext, svc *v1.Service, lb *nlbmodel.NetworkLoadBalancer) error {
 	preStatus := svc.Status.LoadBalancer.DeepCopy()
-	newStatus := &v1.LoadBalancerStatus{}
 	if lb == nil {
 		return fmt.Errorf("lb not found, cannot not patch service status")
 	}
-
-	// SLB ExternalIPType, display the slb ip as service external ip
-	// If the length of elastic ip is 0, display the slb ip
-	if len(newStatus.Ingress) == 0 {
-		newStatus.Ingress = append(newStatus.Ingress,
-			v1.LoadBalancerIngress{
-				Hostname: lb.LoadBalancerAttribute.DNSName,
-			})
+	addressTypes, err := reqCtx.Anno.GetStatusAddresses()
+	if err != nil {
+		return err
 	}
+	newStatus := buildLoadBalancerStatus(lb, addressTypes)
+	conditions := buildLoadBalancerConditions(svc, lb)
 
 	// Write the state if changed
 	// TODO: Be careful here ... what if there were other changes to the service?
-	if !v1helper.LoadBalancerStatusEqual(preStatus, newStatus) {
+	if !v1helper.LoadBalancerStatusEqual(preStatus, newStatus) || !conditionsEqual(svc.Status.Conditions, conditions) {
 		util.ServiceLog.Info(fmt.Sprintf("status: [%v] [%v]", preStatus, newStatus))
 		var retErr error
 		_ = helper.Retry(
@@ -300,6 +545,7 @@ func (m *ReconcileNLB) updateServiceStatus(reqCtx *svcCtx.RequestContext, svc *v
 				}
 				updated := svcOld.DeepCopy()
 				updated.Status.LoadBalancer = *newStatus
+				updated.Status.Conditions = buildLoadBalancerConditions(svcOld, lb)
 				reqCtx.Log.Info(fmt.Sprintf("LoadBalancer: %v", updated.Status.LoadBalancer))
 				retErr = m.kubeClient.Status().Patch(reqCtx.Ctx, updated, client.MergeFrom(svcOld))
 				if retErr == nil {
@@ -332,13 +578,123 @@ func (m *ReconcileNLB) updateServiceStatus(reqCtx *svcCtx.RequestContext, svc *v
 
 }
 
+// buildLoadBalancerStatus publishes the addresses of addressTypes in the service status,
+// the addresses not allocated yet are published by the next reconcile
+func buildLoadBalancerStatus(lb *nlbmodel.NetworkLoadBalancer, addressTypes []string) *v1.LoadBalancerStatus {
+	status := &v1.LoadBalancerStatus{}
+	published := make(map[string]bool)
+	addIngress := func(ingress v1.LoadBalancerIngress) {
+		key := ingress.Hostname + "/" + ingress.IP
+		if ingress.Hostname == "" && ingress.IP == "" || published[key] {
+			return
+		}
+		published[key] = true
+		status.Ingress = append(status.Ingress, ingress)
+	}
+
+	internet := strings.EqualFold(lb.LoadBalancerAttribute.AddressType, nlbmodel.InternetAddressType)
+	for _, t := range addressTypes {
+		if t == annotation.StatusAddressHostname {
+			addIngress(v1.LoadBalancerIngress{Hostname: lb.LoadBalancerAttribute.DNSName})
+			continue
+		}
+		for _, z := range lb.LoadBalancerAttribute.ZoneMappings {
+			switch t {
+			case annotation.StatusAddressIPv4:
+				if internet {
+					addIngress(v1.LoadBalancerIngress{IP: z.PublicIPv4Addr})
+				} else {
+					addIngress(v1.LoadBalancerIngress{IP: z.IPv4Addr})
+				}
+			case annotation.StatusAddressPrivateIPv4:
+				addIngress(v1.LoadBalancerIngress{IP: z.IPv4Addr})
+			case annotation.StatusAddressIPv6:
+				addIngress(v1.LoadBalancerIngress{IP: z.IPv6Addr})
+			}
+		}
+	}
+	return status
+}
+
+// buildLoadBalancerConditions returns the conditions of svc updated by the status and the business status of lb
+func buildLoadBalancerConditions(svc *v1.Service, lb *nlbmodel.NetworkLoadBalancer) []metav1.Condition {
+	var conditions []metav1.Condition
+	for _, c := range svc.Status.Conditions {
+		conditions = append(conditions, *c.DeepCopy())
+	}
+
+	active := metav1.Condition{
+		Type:               LoadBalancerActiveCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             conditionReason(lb.LoadBalancerAttribute.LoadBalancerStatus),
+		Message:            fmt.Sprintf("load balancer %s is %s", lb.LoadBalancerAttribute.LoadBalancerId, lb.LoadBalancerAttribute.LoadBalancerStatus),
+		ObservedGeneration: svc.Generation,
+	}
+	if lb.LoadBalancerAttribute.LoadBalancerStatus == util.LoadBalancerStatusActive {
+		active.Status = metav1.ConditionTrue
+	}
+	meta.SetStatusCondition(&conditions, active)
+
+	business := metav1.Condition{
+		Type:               LoadBalancerBusinessNormalCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             conditionReason(lb.LoadBalancerAttribute.LoadBalancerBusinessStatus),
+		Message:            fmt.Sprintf("business status of load balancer %s is %s", lb.LoadBalancerAttribute.LoadBalancerId, lb.LoadBalancerAttribute.LoadBalancerBusinessStatus),
+		ObservedGeneration: svc.Generation,
+	}
+	switch lb.LoadBalancerAttribute.LoadBalancerBusinessStatus {
+	case util.LoadBalancerBusinessStatusNormal:
+		business.Status = metav1.ConditionTrue
+	case "":
+		business.Status = metav1.ConditionUnknown
+	}
+	meta.SetStatusCondition(&conditions, business)
+	// the async jobs are finished once the load balancer is synced
+	meta.RemoveStatusCondition(&conditions, LoadBalancerJobPendingCondition)
+	return conditions
+}
+
+func conditionReason(status string) string {
+	if status == "" {
+		return "Unknown"
+	}
+	return status
+}
+
+func removeLoadBalancerConditions(conditions []metav1.Condition) []metav1.Condition {
+	var result []metav1.Condition
+	for _, c := range conditions {
+		if c.Type != LoadBalancerActiveCondition && c.Type != LoadBalancerBusinessNormalCondition &&
+			c.Type != LoadBalancerJobPendingCondition {
+			result = append(result, *c.DeepCopy())
+		}
+	}
+	return result
+}
+
+// conditionsEqual compares the conditions regardless of the transition time
+func conditionsEqual(a, b []metav1.Condition) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, c := range a {
+		o := meta.FindStatusCondition(b, c.Type)
+		if o == nil || o.Status != c.Status || o.Reason != c.Reason ||
+			o.Message != c.Message || o.ObservedGeneration != c.ObservedGeneration {
+			return false
+		}
+	}
+	return true
+}
+
 func (m *ReconcileNLB) removeServiceStatus(reqCtx *svcCtx.RequestContext, svc *v1.Service) error {
 	preStatus := svc.Status.LoadBalancer.DeepCopy()
 	newStatus := &v1.LoadBalancerStatus{}
+	conditions := removeLoadBalancerConditions(svc.Status.Conditions)
 
 	// Write the state if changed
 	// TODO: Be careful here ... what if there were other changes to the service?
-	if !v1helper.LoadBalancerStatusEqual(preStatus, newStatus) {
+	if !v1helper.LoadBalancerStatusEqual(preStatus, newStatus) || !conditionsEqual(svc.Status.Conditions, conditions) {
 		util.ServiceLog.Info(fmt.Sprintf("status: [%v] [%v]", preStatus, newStatus))
 		return helper.Retry(
 			&wait.Backoff{
@@ -356,6 +712,7 @@ func (m *ReconcileNLB) removeServiceStatus(reqCtx *svcCtx.RequestContext, svc *v
 				}
 				updated := svcOld.DeepCopy()
 				updated.Status.LoadBalancer = *newStatus
+				updated.Status.Conditions = removeLoadBalancerConditions(svcOld.Status.Conditions)
 				reqCtx.Log.Info(fmt.Sprintf("LoadBalancer: %v", updated.Status.LoadBalancer))
 				err = m.kubeClient.Status().Patch(reqCtx.Ctx, updated, client.MergeFrom(svcOld))
 				if err == nil {
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/helper"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/service/reconcile/annotation"
+	nlbmodel "k8s.io/alibaba-load-balancer-controller/pkg/model/nlb"
+	prvd "k8s.io/alibaba-load-balancer-controller/pkg/provider"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// fakeJobProvider returns the status of the async jobs and the nlbs in memory
+type fakeJobProvider struct {
+	prvd.Provider
+	jobStatus map[string]string
+	// provisioning are the nlbs being created
+	provisioning map[string]bool
+}
+
+func (p *fakeJobProvider) GetNLBJobStatus(_ context.Context, jobId string) (string, error) {
+	return p.jobStatus[jobId], nil
+}
+
+func (p *fakeJobProvider) DescribeNLB(ctx context.Context, mdl *nlbmodel.NetworkLoadBalancer) error {
+	if p.provisioning[mdl.LoadBalancerAttribute.LoadBalancerId] {
+		nlbmodel.AsyncJobTrackerFrom(ctx).Add(nlbmodel.AsyncJob{
+			Api: nlbmodel.ApiCreateLoadBalancer, Id: mdl.LoadBalancerAttribute.LoadBalancerId})
+	}
+	return nil
+}
+
+func newJobTestReconciler(cloud prvd.Provider, objs ...client.Object) (*ReconcileNLB, *record.FakeRecorder) {
+	recorder := record.NewFakeRecorder(10)
+	return &ReconcileNLB{
+		cloud:        cloud,
+		kubeClient:   fake.NewClientBuilder().WithObjects(objs...).Build(),
+		logger:       logr.Discard(),
+		record:       recorder,
+		serviceLocks: newKeyLocks(),
+	}, recorder
+}
+
+func getStatusTestNLB(addressType string) *nlbmodel.NetworkLoadBalancer {
+	return &nlbmodel.NetworkLoadBalancer{
+		LoadBalancerAttribute: &nlbmodel.LoadBalancerAttribute{
+			LoadBalancerId: "nlb-id",
+			AddressType:    addressType,
+			DNSName:        "nlb-id.cn-hangzhou.nlb.aliyuncs.com",
+			ZoneMappings: []nlbmodel.ZoneMapping{
+				{ZoneId: "zone-a", IPv4Addr: "192.168.0.1", PublicIPv4Addr: "47.0.0.1", IPv6Addr: "2408::1"},
+				{ZoneId: "zone-b", IPv4Addr: "192.168.1.1", PublicIPv4Addr: "47.0.0.2"},
+			},
+		},
+	}
+}
+
+func TestBuildLoadBalancerStatus(t *testing.T) {
+	internet := getStatusTestNLB(nlbmodel.InternetAddressType)
+	intranet := getStatusTestNLB(nlbmodel.IntranetAddressType)
+
+	assert.Equal(t, []v1.LoadBalancerIngress{{Hostname: "nlb-id.cn-hangzhou.nlb.aliyuncs.com"}},
+		buildLoadBalancerStatus(internet, []string{annotation.StatusAddressHostname}).Ingress)
+
+	assert.Equal(t, []v1.LoadBalancerIngress{
+		{Hostname: "nlb-id.cn-hangzhou.nlb.aliyuncs.com"},
+		{IP: "47.0.0.1"},
+		{IP: "47.0.0.2"},
+		{IP: "2408::1"},
+	}, buildLoadBalancerStatus(internet, []string{annotation.StatusAddressHostname,
+		annotation.StatusAddressIPv4, annotation.StatusAddressIPv6}).Ingress)
+
+	assert.Equal(t, []v1.LoadBalancerIngress{{IP: "192.168.0.1"}, {IP: "192.168.1.1"}},
+		buildLoadBalancerStatus(intranet, []string{annotation.StatusAddressIPv4,
+			annotation.StatusAddressPrivateIPv4}).Ingress)
+
+	// addresses not allocated yet are skipped
+	internet.LoadBalancerAttribute.ZoneMappings[1].PublicIPv4Addr = ""
+	assert.Equal(t, []v1.LoadBalancerIngress{{IP: "47.0.0.1"}},
+		buildLoadBalancerStatus(internet, []string{annotation.StatusAddressIPv4}).Ingress)
+}
+
+func TestBuildLoadBalancerConditions(t *testing.T) {
+	svc := &v1.Service{ObjectMeta: metav1.ObjectMeta{Generation: 2}}
+	svc.Status.Conditions = []metav1.Condition{{Type: "Other", Status: metav1.ConditionTrue, Reason: "Other"}}
+	lb := getStatusTestNLB(nlbmodel.InternetAddressType)
+	lb.LoadBalancerAttribute.LoadBalancerStatus = "Active"
+	lb.LoadBalancerAttribute.LoadBalancerBusinessStatus = "Abnormal"
+
+	conditions := buildLoadBalancerConditions(svc, lb)
+	assert.Len(t, conditions, 3)
+	assert.True(t, meta.IsStatusConditionTrue(conditions, LoadBalancerActiveCondition))
+	business := meta.FindStatusCondition(conditions, LoadBalancerBusinessNormalCondition)
+	assert.Equal(t, metav1.ConditionFalse, business.Status)
+	assert.Equal(t, "Abnormal", business.Reason)
+	assert.Equal(t, int64(2), business.ObservedGeneration)
+	assert.False(t, conditionsEqual(svc.Status.Conditions, conditions))
+
+	svc.Status.Conditions = conditions
+	lb.LoadBalancerAttribute.LoadBalancerBusinessStatus = ""
+	conditions = buildLoadBalancerConditions(svc, lb)
+	assert.Equal(t, metav1.ConditionUnknown, meta.FindStatusCondition(conditions, LoadBalancerBusinessNormalCondition).Status)
+
+	svc.Status.Conditions = conditions
+	assert.True(t, conditionsEqual(svc.Status.Conditions, buildLoadBalancerConditions(svc, lb)))
+	assert.Equal(t, []metav1.Condition{{Type: "Other", Status: metav1.ConditionTrue, Reason: "Other"}},
+		removeLoadBalancerConditions(conditions))
+}
+
+func TestAsyncJobCondition(t *testing.T) {
+	jobs := []nlbmodel.AsyncJob{
+		{Api: nlbmodel.ApiCreateLoadBalancer, Id: "nlb-id"},
+		{Api: "DeleteListener", Id: "72dcd26b-f12d-4c27-b3af-18f6aed5"},
+	}
+	svc := &v1.Service{}
+	assert.Empty(t, getAsyncJobs(svc))
+
+	svc.Status.Conditions = []metav1.Condition{{
+		Type:    LoadBalancerJobPendingCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  asyncJobProcessingReason,
+		Message: formatAsyncJobs(jobs),
+	}}
+	assert.Equal(t, "CreateLoadBalancer/nlb-id,DeleteListener/72dcd26b-f12d-4c27-b3af-18f6aed5", formatAsyncJobs(jobs))
+	assert.Equal(t, jobs, getAsyncJobs(svc))
+
+	// the jobs are finished once the load balancer is synced
+	lb := getStatusTestNLB(nlbmodel.InternetAddressType)
+	assert.Nil(t, meta.FindStatusCondition(buildLoadBalancerConditions(svc, lb), LoadBalancerJobPendingCondition))
+	assert.Empty(t, removeLoadBalancerConditions(svc.Status.Conditions))
+}
+
+func TestAsyncJobsFinished(t *testing.T) {
+	cloud := &fakeJobProvider{
+		jobStatus:    map[string]string{"job-done": nlbmodel.JobStatusSucceeded, "job-failed": nlbmodel.JobStatusFailed, "job-running": nlbmodel.JobStatusProcessing},
+		provisioning: map[string]bool{"nlb-creating": true},
+	}
+	m, recorder := newJobTestReconciler(cloud)
+	reqCtx, _ := m.newRequestContext(&v1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nlb"}})
+
+	assert.True(t, m.asyncJobsFinished(reqCtx, []nlbmodel.AsyncJob{{Api: "DeleteListener", Id: "job-done"},
+		{Api: nlbmodel.ApiCreateLoadBalancer, Id: "nlb-active"}}))
+	assert.False(t, m.asyncJobsFinished(reqCtx, []nlbmodel.AsyncJob{{Api: "DeleteListener", Id: "job-done"},
+		{Api: "AddServersToServerGroup", Id: "job-running"}}))
+	assert.False(t, m.asyncJobsFinished(reqCtx, []nlbmodel.AsyncJob{{Api: nlbmodel.ApiCreateLoadBalancer, Id: "nlb-creating"}}))
+	// a failed job is finished, it is reported and left to the reconcile to retry
+	assert.True(t, m.asyncJobsFinished(reqCtx, []nlbmodel.AsyncJob{{Api: "DeleteListener", Id: "job-failed"}}))
+	assert.Len(t, recorder.Events, 1)
+	// the tracker of the reconcile is not touched by the checks
+	assert.Empty(t, nlbmodel.AsyncJobTrackerFrom(reqCtx.Ctx).Jobs())
+}
+
+func TestCanReconcileBackendsOnly(t *testing.T) {
+	svc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default", Name: "nlb",
+			Annotations: map[string]string{},
+			Finalizers:  []string{helper.NLBFinalizer},
+		},
+		Spec: v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer},
+	}
+	svc.Labels = map[string]string{helper.LabelServiceHash: helper.GetServiceHash(svc)}
+	assert.True(t, canReconcileBackendsOnly(svc))
+
+	// the health check derived from the readinessProbe is rebuilt by a full reconcile
+	svc.Annotations[annotation.Annotation(annotation.HealthCheckFromProbe)] = "on"
+	svc.Labels[helper.LabelServiceHash] = helper.GetServiceHash(svc)
+	assert.False(t, canReconcileBackendsOnly(svc))
+}
+
+func TestReconcileRequeuesUntilAsyncJobsFinish(t *testing.T) {
+	svc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nlb"},
+		Spec:       v1.ServiceSpec{Type: v1.ServiceTypeClusterIP},
+	}
+	cloud := &fakeJobProvider{jobStatus: map[string]string{"job-id": nlbmodel.JobStatusProcessing}}
+	m, recorder := newJobTestReconciler(cloud, svc)
+
+	reqCtx, _ := m.newRequestContext(svc)
+	jobs := []nlbmodel.AsyncJob{{Api: "DeleteListener", Id: "job-id"}}
+	result, err := m.waitForAsyncJobs(reqCtx, jobs, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, reconcile.Result{RequeueAfter: asyncJobRequeuePeriod}, result)
+	<-recorder.Events
+
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "nlb"}}
+	recorded := &v1.Service{}
+	assert.NoError(t, m.kubeClient.Get(context.TODO(), request.NamespacedName, recorded))
+	assert.Equal(t, jobs, getAsyncJobs(recorded))
+
+	// the service is requeued without reconciling while the job is processing
+	result, err = m.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, reconcile.Result{RequeueAfter: asyncJobRequeuePeriod}, result)
+	assert.Empty(t, recorder.Events)
+
+	// and reconciled once the job succeeds
+	cloud.jobStatus["job-id"] = nlbmodel.JobStatusSucceeded
+	result, err = m.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+	assert.Contains(t, <-recorder.Events, "Clean load balancer")
+}
@@ -32,6 +32,7 @@ const (
 	LoadBalancerName = AnnotationLoadBalancerPrefix + "name"                     // LoadBalancerName slb name
 	ResourceGroupId  = AnnotationLoadBalancerPrefix + "resource-group-id"        // ResourceGroupId resource group id
 	AdditionalTags   = AnnotationLoadBalancerPrefix + "additional-resource-tags" // AdditionalTags For example: "Key1=Val1,Key2=Val2,KeyNoVal1=,KeyNoVal2",same with aws
+	DeletionPolicy   = AnnotationLoadBalancerPrefix + "deletion-policy"          // DeletionPolicy Delete or Retain the lb once the service is deleted
 
 	CertID          = AnnotationLoadBalancerPrefix + "cert-id"           // CertID cert id
 	ProtocolPort    = AnnotationLoadBalancerPrefix + "protocol-port"     // ProtocolPort protocol port
@@ -63,32 +64,33 @@ const (
 	HostName               = AnnotationLoadBalancerPrefix + "hostname"                 // HostName hostname for service.status.ingress.hostname
 
 	// Listener Attribute
-	AclStatus                 = AnnotationLoadBalancerPrefix + "acl-status"                   // AclStatus enable or disable acl on all listener
-	AclID                     = AnnotationLoadBalancerPrefix + "acl-id"                       // AclID acl id
-	AclType                   = AnnotationLoadBalancerPrefix + "acl-type"                     // AclType acl type, black or white
-	ForwardPort               = AnnotationLoadBalancerPrefix + "forward-port"                 // ForwardPort loadbalancer forward port
-	EnableHttp2               = AnnotationLoadBalancerPrefix + "http2-enabled"                //EnableHttp2 enable http2 on https port
-	HealthCheckFlag           = AnnotationLoadBalancerPrefix + "health-check-flag"            // HealthCheckFlag health check flag
-	HealthCheckType           = AnnotationLoadBalancerPrefix + "health-check-type"            // HealthCheckType health check type
-	HealthCheckURI            = AnnotationLoadBalancerPrefix + "health-check-uri"             // HealthCheckURI health check uri
-	HealthCheckConnectPort    = AnnotationLoadBalancerPrefix + "health-check-connect-port"    // HealthCheckConnectPort health check connect port
-	HealthyThreshold          = AnnotationLoadBalancerPrefix + "healthy-threshold"            // HealthyThreshold health check healthy thresh hold
-	UnhealthyThreshold        = AnnotationLoadBalancerPrefix + "unhealthy-threshold"          // UnhealthyThreshold health check unhealthy thresh hold
-	HealthCheckInterval       = AnnotationLoadBalancerPrefix + "health-check-interval"        // HealthCheckInterval health check interval
-	HealthCheckConnectTimeout = AnnotationLoadBalancerPrefix + "health-check-connect-timeout" // HealthCheckConnectTimeout health check connect timeout
-	HealthCheckTimeout        = AnnotationLoadBalancerPrefix + "health-check-timeout"         // HealthCheckTimeout health check timeout
-	HealthCheckDomain         = AnnotationLoadBalancerPrefix + "health-check-domain"          // HealthCheckDomain health check domain
-	HealthCheckHTTPCode       = AnnotationLoadBalancerPrefix + "health-check-httpcode"        // HealthCheckHTTPCode health check http code
-	HealthCheckMethod         = AnnotationLoadBalancerPrefix + "health-check-method"          // HealthCheckMethod health check method for L7
-	SessionStick              = AnnotationLoadBalancerPrefix + "sticky-session"               // SessionStick sticky session
-	SessionStickType          = AnnotationLoadBalancerPrefix + "sticky-session-type"          // SessionStickType session sticky type
-	CookieTimeout             = AnnotationLoadBalancerPrefix + "cookie-timeout"               // CookieTimeout cookie timeout
-	Cookie                    = AnnotationLoadBalancerPrefix + "cookie"                       // Cookie lb cookie
-	PersistenceTimeout        = AnnotationLoadBalancerPrefix + "persistence-timeout"          // PersistenceTimeout persistence timeout
-	VGroupPort                = AnnotationLoadBalancerPrefix + "vgroup-port"                  // VGroupIDs binding user managed vGroup ids to ports
-	XForwardedForProto        = AnnotationLoadBalancerPrefix + "xforwardedfor-proto"          // XForwardedForProto whether to use the X-Forwarded-Proto header to retrieve the listener protocol
-	RequestTimeout            = AnnotationLoadBalancerPrefix + "request-timeout"              // RequestTimeout request timeout for L7
-	EstablishedTimeout        = AnnotationLoadBalancerPrefix + "established-timeout"          // EstablishedTimeout connection established time out for TCP
+	AclStatus                 = AnnotationLoadBalancerPrefix + "acl-status"                        // AclStatus enable or disable acl on all listener
+	AclID                     = AnnotationLoadBalancerPrefix + "acl-id"                            // AclID acl id
+	AclType                   = AnnotationLoadBalancerPrefix + "acl-type"                          // AclType acl type, black or white
+	ForwardPort               = AnnotationLoadBalancerPrefix + "forward-port"                      // ForwardPort loadbalancer forward port
+	EnableHttp2               = AnnotationLoadBalancerPrefix + "http2-enabled"                     //EnableHttp2 enable http2 on https port
+	HealthCheckFlag           = AnnotationLoadBalancerPrefix + "health-check-flag"                 // HealthCheckFlag health check flag
+	HealthCheckType           = AnnotationLoadBalancerPrefix + "health-check-type"                 // HealthCheckType health check type
+	HealthCheckURI            = AnnotationLoadBalancerPrefix + "health-check-uri"                  // HealthCheckURI health check uri
+	HealthCheckConnectPort    = AnnotationLoadBalancerPrefix + "health-check-connect-port"         // HealthCheckConnectPort health check connect port
+	HealthyThreshold          = AnnotationLoadBalancerPrefix + "healthy-threshold"                 // HealthyThreshold health check healthy thresh hold
+	UnhealthyThreshold        = AnnotationLoadBalancerPrefix + "unhealthy-threshold"               // UnhealthyThreshold health check unhealthy thresh hold
+	HealthCheckInterval       = AnnotationLoadBalancerPrefix + "health-check-interval"             // HealthCheckInterval health check interval
+	HealthCheckConnectTimeout = AnnotationLoadBalancerPrefix + "health-check-connect-timeout"      // HealthCheckConnectTimeout health check connect timeout
+	HealthCheckTimeout        = AnnotationLoadBalancerPrefix + "health-check-timeout"              // HealthCheckTimeout health check timeout
+	HealthCheckDomain         = AnnotationLoadBalancerPrefix + "health-check-domain"               // HealthCheckDomain health check domain
+	HealthCheckHTTPCode       = AnnotationLoadBalancerPrefix + "health-check-httpcode"             // HealthCheckHTTPCode health check http code
+	HealthCheckMethod         = AnnotationLoadBalancerPrefix + "health-check-method"               // HealthCheckMethod health check method for L7
+	HealthCheckFromProbe      = AnnotationLoadBalancerPrefix + "health-check-from-readiness-probe" // HealthCheckFromProbe derive health check from the readinessProbe of pods
+	SessionStick              = AnnotationLoadBalancerPrefix + "sticky-session"                    // SessionStick sticky session
+	SessionStickType          = AnnotationLoadBalancerPrefix + "sticky-session-type"               // SessionStickType session sticky type
+	CookieTimeout             = AnnotationLoadBalancerPrefix + "cookie-timeout"                    // CookieTimeout cookie timeout
+	Cookie                    = AnnotationLoadBalancerPrefix + "cookie"                            // Cookie lb cookie
+	PersistenceTimeout        = AnnotationLoadBalancerPrefix + "persistence-timeout"               // PersistenceTimeout persistence timeout
+	VGroupPort                = AnnotationLoadBalancerPrefix + "vgroup-port"                       // VGroupIDs binding user managed vGroup ids to ports
+	XForwardedForProto        = AnnotationLoadBalancerPrefix + "xforwardedfor-proto"               // XForwardedForProto whether to use the X-Forwarded-Proto header to retrieve the listener protocol
+	RequestTimeout            = AnnotationLoadBalancerPrefix + "request-timeout"                   // RequestTimeout request timeout for L7
+	EstablishedTimeout        = AnnotationLoadBalancerPrefix + "established-timeout"               // EstablishedTimeout connection established time out for TCP
 
 	// VServerBackend Attribute
 	BackendLabel      = AnnotationLoadBalancerPrefix + "backend-label"              // BackendLabel backend labels
@@ -101,10 +103,16 @@ const (
 // network load balancer
 const (
 	ZoneMaps = AnnotationLoadBalancerPrefix + "zone-maps" // ZoneMaps zone maps
+	// VSwitchTags filters the vSwitches discovered for the zones without ZoneMaps
+	VSwitchTags = AnnotationLoadBalancerPrefix + "vswitch-tags"
+	// StatusAddresses are the addresses published in the service status, separated by comma
+	StatusAddresses = AnnotationLoadBalancerPrefix + "status-addresses"
 
 	ProxyProtocol = AnnotationLoadBalancerPrefix + "proxy-protocol"
-	CaCertID      = AnnotationLoadBalancerPrefix + "cacert-id" // CertID cert id
-	CaCert        = AnnotationLoadBalancerPrefix + "cacert"    // CaCert enable ca
+	CaCertID      = AnnotationLoadBalancerPrefix + "cacert-id"     // CertID cert id
+	CaCert        = AnnotationLoadBalancerPrefix + "cacert"        // CaCert enable ca
+	CertSecret    = AnnotationLoadBalancerPrefix + "cert-secret"   // CertSecret tls secrets uploaded as server certificates
+	CaCertSecret  = AnnotationLoadBalancerPrefix + "cacert-secret" // CaCertSecret secret uploaded as ca certificate
 	Cps           = AnnotationLoadBalancerPrefix + "cps"
 
 	PreserveClientIp = AnnotationLoadBalancerPrefix + "preserve-client-ip"
@@ -191,27 +199,7 @@ func Annotation(k string) string {
 // pairs in the ServiceAnnotationLoadBalancerAdditionalTags annotation and returns
 // it as a map.
 func (n *AnnotationRequest) GetLoadBalancerAdditionalTags() []tag.Tag {
-	additionalTags := make(map[string]string)
-	additionalTagsList := n.Get(AdditionalTags)
-	if additionalTagsList != "" {
-		additionalTagsList = strings.TrimSpace(additionalTagsList)
-		// Break up list of "Key1=Val,Key2=Val2"
-		tagList := strings.Split(additionalTagsList, ",")
-
-		// Break up "Key=Val"
-		for _, tagSet := range tagList {
-			tag := strings.Split(strings.TrimSpace(tagSet), "=")
-
-			// Accept "Key=val" or "Key=" or just "Key"
-			if len(tag) >= 2 && len(tag[0]) != 0 {
-				// There is a key and a value, so save it
-				additionalTags[tag[0]] = tag[1]
-			} else if len(tag) == 1 && len(tag[0]) != 0 {
-				// Just "Key"
-				additionalTags[tag[0]] = ""
-			}
-		}
-	}
+	additionalTags := parseTagList(n.Get(AdditionalTags))
 	var tags []tag.Tag
 	for k, v := range additionalTags {
 		tags = append(tags, tag.Tag{
@@ -222,6 +210,67 @@ func (n *AnnotationRequest) GetLoadBalancerAdditionalTags() []tag.Tag {
 	return tags
 }
 
+// GetVSwitchTags returns the tags the discovered vSwitches must have
+func (n *AnnotationRequest) GetVSwitchTags() map[string]string {
+	return parseTagList(n.Get(VSwitchTags))
+}
+
+// address types of StatusAddresses
+const (
+	StatusAddressHostname    = "hostname"
+	StatusAddressIPv4        = "ipv4"
+	StatusAddressPrivateIPv4 = "private-ipv4"
+	StatusAddressIPv6        = "ipv6"
+)
+
+// GetStatusAddresses returns the address types published in the service status, defaults to hostname
+func (n *AnnotationRequest) GetStatusAddresses() ([]string, error) {
+	value := strings.TrimSpace(n.Get(StatusAddresses))
+	if value == "" {
+		return []string{StatusAddressHostname}, nil
+	}
+	var types []string
+	for _, t := range strings.Split(value, ",") {
+		t = strings.ToLower(strings.TrimSpace(t))
+		switch t {
+		case StatusAddressHostname, StatusAddressIPv4, StatusAddressPrivateIPv4, StatusAddressIPv6:
+			types = append(types, t)
+		default:
+			return nil, fmt.Errorf("unknown address type %s in %s, expect %s, %s, %s or %s", t, Annotation(StatusAddresses),
+				StatusAddressHostname, StatusAddressIPv4, StatusAddressPrivateIPv4, StatusAddressIPv6)
+		}
+	}
+	return types, nil
+}
+
+// parseTagList parses the list of "Key1=Val,Key2=Val2"
+func parseTagList(tagList string) map[string]string {
+	tags := make(map[string]string)
+	tagList = strings.TrimSpace(tagList)
+	if tagList == "" {
+		return tags
+	}
+	// Break up "Key=Val"
+	for _, tagSet := range strings.Split(tagList, ",") {
+		tag := strings.Split(strings.TrimSpace(tagSet), "=")
+
+		// Accept "Key=val" or "Key=" or just "Key"
+		if len(tag) >= 2 && len(tag[0]) != 0 {
+			// There is a key and a value, so save it
+			tags[tag[0]] = tag[1]
+		} else if len(tag) == 1 && len(tag[0]) != 0 {
+			// Just "Key"
+			tags[tag[0]] = ""
+		}
+	}
+	return tags
+}
+
 func (n *AnnotationRequest) IsForceOverride() bool {
 	return n.Get(OverrideListener) == "true"
 }
+
+// IsRetain returns whether the load balancer is retained once the service is deleted
+func (n *AnnotationRequest) IsRetain() bool {
+	return n.Get(DeletionPolicy) == util.DeletionPolicyRetain
+}
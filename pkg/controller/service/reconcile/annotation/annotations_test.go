@@ -40,6 +40,44 @@ func TestIsForceOverride(t *testing.T) {
 
 }
 
+func TestIsRetain(t *testing.T) {
+	svc := getDefaultService()
+	anno := NewAnnotationRequest(svc)
+	assert.False(t, anno.IsRetain())
+
+	svc.Annotations[Annotation(DeletionPolicy)] = "Retain"
+	assert.True(t, anno.IsRetain())
+
+	svc.Annotations[Annotation(DeletionPolicy)] = "Delete"
+	assert.False(t, anno.IsRetain())
+}
+
+func TestGetVSwitchTags(t *testing.T) {
+	svc := getDefaultService()
+	anno := NewAnnotationRequest(svc)
+	assert.Empty(t, anno.GetVSwitchTags())
+
+	svc.Annotations[Annotation(VSwitchTags)] = "usage=nlb, env=prod,shared"
+	assert.Equal(t, map[string]string{"usage": "nlb", "env": "prod", "shared": ""}, anno.GetVSwitchTags())
+}
+
+func TestGetStatusAddresses(t *testing.T) {
+	svc := getDefaultService()
+	anno := NewAnnotationRequest(svc)
+	types, err := anno.GetStatusAddresses()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{StatusAddressHostname}, types)
+
+	svc.Annotations[Annotation(StatusAddresses)] = "hostname, IPv4,ipv6"
+	types, err = anno.GetStatusAddresses()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{StatusAddressHostname, StatusAddressIPv4, StatusAddressIPv6}, types)
+
+	svc.Annotations[Annotation(StatusAddresses)] = "eip"
+	_, err = anno.GetStatusAddresses()
+	assert.Error(t, err)
+}
+
 func TestGetDefaultValue(t *testing.T) {
 	svc := getDefaultService()
 	anno := NewAnnotationRequest(svc)
@@ -73,6 +73,10 @@ type EndpointWithENI struct {
 }
 
 func (e *EndpointWithENI) setTrafficPolicy(reqCtx *svcCtx.RequestContext) {
+	if helper.IsIPBackendType(reqCtx.Service) {
+		e.TrafficPolicy = helper.IPTrafficPolicy
+		return
+	}
 	if helper.IsENIBackendType(reqCtx.Service) {
 		e.TrafficPolicy = helper.ENITrafficPolicy
 		return
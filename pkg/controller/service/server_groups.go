@@ -9,6 +9,7 @@ import (
 
 	"github.com/alibabacloud-go/tea/tea"
 	"github.com/mohae/deepcopy"
+	alibabacloudv1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
 	ctrlCfg "k8s.io/alibaba-load-balancer-controller/pkg/config"
 	"k8s.io/alibaba-load-balancer-controller/pkg/controller/helper"
 	"k8s.io/alibaba-load-balancer-controller/pkg/controller/service/reconcile/annotation"
@@ -65,6 +66,20 @@ func (mgr *ServerGroupManager) BuildLocalModel(reqCtx *svcCtx.RequestContext, md
 		return err
 	}
 
+	policy, err := helper.GetServerGroupPolicy(reqCtx.Ctx, mgr.kubeClient, reqCtx.Service.Namespace,
+		reqCtx.Service.Annotations[helper.ServerGroupPolicyAnnotation])
+	if err != nil {
+		return err
+	}
+
+	var pods []v1.Pod
+	if strings.EqualFold(reqCtx.Anno.Get(annotation.HealthCheckFromProbe), string(model.OnFlag)) {
+		pods, err = helper.ListServicePods(reqCtx.Ctx, mgr.kubeClient, reqCtx.Service)
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, lis := range mdl.Listeners {
 		sg := &nlbmodel.ServerGroup{
 			VPCId:       mgr.vpcId,
@@ -72,8 +87,16 @@ func (mgr *ServerGroupManager) BuildLocalModel(reqCtx *svcCtx.RequestContext, md
 			Tags:        getServerGroupTag(reqCtx),
 			Protocol:    nlbmodel.GetListenerProtocolType(lis.ListenerProtocol),
 		}
+		sg.ServerGroupType = nlbmodel.InstanceServerGroupType
+		if candidates.TrafficPolicy == helper.IPTrafficPolicy {
+			sg.ServerGroupType = nlbmodel.IpServerGroupType
+		}
 		sg.NamedKey = getServerGroupNamedKey(reqCtx.Service, sg.Protocol, lis.ServicePort)
 		sg.ServerGroupName = sg.NamedKey.Key()
+		if err := setServerGroupAttributeFromPolicy(sg, policy); err != nil {
+			return err
+		}
+		setServerGroupHealthCheckFromProbe(sg, pods, candidates.TrafficPolicy)
 		if err := setServerGroupAttributeFromAnno(sg, reqCtx.Anno); err != nil {
 			return err
 		}
@@ -108,7 +131,22 @@ func (mgr *ServerGroupManager) DeleteServerGroup(reqCtx *svcCtx.RequestContext,
 	return mgr.cloud.DeleteNLBServerGroup(reqCtx.Ctx, sgId)
 }
 
+// ReleaseServerGroup removes the tags the server group is found by, so that it is left to the listeners
+func (mgr *ServerGroupManager) ReleaseServerGroup(reqCtx *svcCtx.RequestContext, sgId string) error {
+	var keys []string
+	for _, t := range getServerGroupTag(reqCtx) {
+		keys = append(keys, t.Key)
+	}
+	return mgr.cloud.UntagNLBResource(reqCtx.Ctx, sgId, nlbmodel.ServerGroupTagType, keys)
+}
+
 func (mgr *ServerGroupManager) UpdateServerGroup(reqCtx *svcCtx.RequestContext, local, remote *nlbmodel.ServerGroup) error {
+	if local.ServerGroupType != "" && remote.ServerGroupType != "" &&
+		local.ServerGroupType != remote.ServerGroupType {
+		return fmt.Errorf("ServerGroupType of server group %s is %s, can not be changed to %s",
+			remote.ServerGroupId, remote.ServerGroupType, local.ServerGroupType)
+	}
+
 	update := deepcopy.Copy(remote).(*nlbmodel.ServerGroup)
 	needUpdate := false
 	updateDetail := ""
@@ -346,6 +384,12 @@ func (mgr *ServerGroupManager) setServerGroupServers(reqCtx *svcCtx.RequestConte
 		if err != nil {
 			return fmt.Errorf("build cluster backends error: %s", err.Error())
 		}
+	case helper.IPTrafficPolicy:
+		reqCtx.Log.Info(fmt.Sprintf("ip mode, build backends for %s", sg.NamedKey))
+		backends, err = buildIPBackends(reqCtx, candidates, *sg)
+		if err != nil {
+			return fmt.Errorf("build ip backends error: %s", err.Error())
+		}
 	default:
 		return fmt.Errorf("not supported traffic policy [%s]", candidates.TrafficPolicy)
 	}
@@ -481,6 +525,52 @@ func (mgr *ServerGroupManager) buildENIBackends(candidates *reconbackend.Endpoin
 	return setWeightBackends(helper.ENITrafficPolicy, backends, sg.Weight), nil
 }
 
+// buildIPBackends adds the addresses of the endpoints and the backend-ips annotation to an ip type server group,
+// the addresses are not required to be pods or nodes of the cluster
+func buildIPBackends(reqCtx *svcCtx.RequestContext, candidates *reconbackend.EndpointWithENI, sg nlbmodel.ServerGroup,
+) ([]nlbmodel.ServerGroupServer, error) {
+	backends := setGenericBackendAttribute(candidates, sg)
+
+	ips, err := helper.GetBackendIPs(reqCtx.Service)
+	if err != nil {
+		return nil, err
+	}
+	for _, ip := range ips {
+		port := ip.Port
+		if port == 0 {
+			port = sg.ServicePort.Port
+			if sg.ServicePort.TargetPort.Type == intstr.Int && sg.ServicePort.TargetPort.IntValue() != 0 {
+				port = int32(sg.ServicePort.TargetPort.IntValue())
+			}
+		}
+		backends = append(backends, nlbmodel.ServerGroupServer{
+			ServerIp:    ip.IP,
+			Port:        port,
+			Description: sg.ServerGroupName,
+		})
+	}
+
+	// the servers of an ip type server group are identified by ip and port, keep the first one of the same address
+	addrMap := make(map[string]bool)
+	var uniqBackends []nlbmodel.ServerGroupServer
+	for _, b := range backends {
+		addr := fmt.Sprintf("%s:%d", b.ServerIp, b.Port)
+		if addrMap[addr] {
+			continue
+		}
+		addrMap[addr] = true
+		b.NodeName = nil
+		b.ServerId = b.ServerIp
+		b.ServerType = nlbmodel.IpServerType
+		uniqBackends = append(uniqBackends, b)
+	}
+	if len(uniqBackends) == 0 {
+		return nil, nil
+	}
+
+	return setWeightBackends(helper.IPTrafficPolicy, uniqBackends, sg.Weight), nil
+}
+
 func (mgr *ServerGroupManager) buildLocalBackends(reqCtx *svcCtx.RequestContext, candidates *reconbackend.EndpointWithENI,
 	sg nlbmodel.ServerGroup) ([]nlbmodel.ServerGroupServer, error) {
 	initBackends := setGenericBackendAttribute(candidates, sg)
@@ -709,10 +799,11 @@ func setWeightBackends(mode helper.TrafficPolicy, backends []nlbmodel.ServerGrou
 	Calculate node weight by pod.
 	ClusterMode:  nodeWeight = 1
 	ENIMode:      podWeight = 1
+	IPMode:       ipWeight = 1
 	LocalMode:    node_weight = nodePodNum
 */
 func podNumberAlgorithm(mode helper.TrafficPolicy, backends []nlbmodel.ServerGroupServer) []nlbmodel.ServerGroupServer {
-	if mode == helper.ENITrafficPolicy || mode == helper.ClusterTrafficPolicy {
+	if mode == helper.ENITrafficPolicy || mode == helper.ClusterTrafficPolicy || mode == helper.IPTrafficPolicy {
 		for i := range backends {
 			backends[i].Weight = DefaultServerWeight
 		}
@@ -735,6 +826,7 @@ func podNumberAlgorithm(mode helper.TrafficPolicy, backends []nlbmodel.ServerGro
 	Calculate node weight by percent.
 	ClusterMode:  node_weight = weightSum/nodesNum
 	ENIMode:      pod_weight = weightSum/podsNum
+	IPMode:       ip_weight = weightSum/ipsNum
 	LocalMode:    node_weight = node_pod_num/pods_num *weightSum
 */
 func podPercentAlgorithm(mode helper.TrafficPolicy, backends []nlbmodel.ServerGroupServer, weight int,
@@ -746,7 +838,7 @@ func podPercentAlgorithm(mode helper.TrafficPolicy, backends []nlbmodel.ServerGr
 		return backends
 	}
 
-	if mode == helper.ENITrafficPolicy || mode == helper.ClusterTrafficPolicy {
+	if mode == helper.ENITrafficPolicy || mode == helper.ClusterTrafficPolicy || mode == helper.IPTrafficPolicy {
 		per := weight / len(backends)
 		if per < 1 {
 			per = 1
@@ -774,7 +866,7 @@ func podPercentAlgorithm(mode helper.TrafficPolicy, backends []nlbmodel.ServerGr
 
 func getServerGroupNamedKey(svc *v1.Service, protocol string, servicePort *v1.ServicePort) *nlbmodel.SGNamedKey {
 	sgPort := ""
-	if helper.IsENIBackendType(svc) {
+	if helper.IsENIBackendType(svc) || helper.IsIPBackendType(svc) {
 		switch servicePort.TargetPort.Type {
 		case intstr.Int:
 			sgPort = fmt.Sprintf("%d", servicePort.TargetPort.IntValue())
@@ -821,22 +913,32 @@ func setServerGroupAttributeFromAnno(sg *nlbmodel.ServerGroup, anno *annotation.
 		sg.ConnectionDrainTimeout = int32(timeout)
 	}
 
-	sg.Scheduler = anno.Get(annotation.Scheduler)
+	if anno.Get(annotation.Scheduler) != "" {
+		sg.Scheduler = anno.Get(annotation.Scheduler)
+	}
 
 	if anno.Get(annotation.PreserveClientIp) != "" {
 		sg.PreserveClientIpEnabled = tea.Bool(
 			strings.EqualFold(anno.Get(annotation.PreserveClientIp), string(model.OnFlag)))
 	}
 
-	// healthcheck
+	// healthcheck, the annotations override the health check derived from the readinessProbe
+	healthCheckConfig := sg.HealthCheckConfig
 	if anno.Get(annotation.HealthCheckFlag) != "" {
-		healthCheckConfig := &nlbmodel.HealthCheckConfig{
-			HealthCheckEnabled: tea.Bool(strings.EqualFold(anno.Get(annotation.HealthCheckFlag), string(model.OnFlag))),
+		enabled := strings.EqualFold(anno.Get(annotation.HealthCheckFlag), string(model.OnFlag))
+		if healthCheckConfig == nil || !enabled {
+			healthCheckConfig = &nlbmodel.HealthCheckConfig{}
 		}
-
-		if *healthCheckConfig.HealthCheckEnabled {
+		healthCheckConfig.HealthCheckEnabled = tea.Bool(enabled)
+	}
+	if healthCheckConfig != nil {
+		if tea.BoolValue(healthCheckConfig.HealthCheckEnabled) {
 			if anno.Get(annotation.HealthCheckType) != "" {
 				healthCheckConfig.HealthCheckType = anno.Get(annotation.HealthCheckType)
+				if !strings.EqualFold(healthCheckConfig.HealthCheckType, "http") {
+					healthCheckConfig.HealthCheckUrl = ""
+					healthCheckConfig.HealthCheckDomain = ""
+				}
 			}
 			if anno.Get(annotation.HealthCheckConnectPort) != "" {
 				checkPort, err := strconv.Atoi(anno.Get(annotation.HealthCheckConnectPort))
@@ -873,9 +975,15 @@ func setServerGroupAttributeFromAnno(sg *nlbmodel.ServerGroup, anno *annotation.
 				}
 				healthCheckConfig.HealthCheckInterval = int32(healthCheckInterval)
 			}
-			healthCheckConfig.HealthCheckDomain = anno.Get(annotation.HealthCheckDomain)
-			healthCheckConfig.HealthCheckUrl = anno.Get(annotation.HealthCheckURI)
-			healthCheckConfig.HttpCheckMethod = anno.Get(annotation.HealthCheckMethod)
+			if anno.Get(annotation.HealthCheckDomain) != "" {
+				healthCheckConfig.HealthCheckDomain = anno.Get(annotation.HealthCheckDomain)
+			}
+			if anno.Get(annotation.HealthCheckURI) != "" {
+				healthCheckConfig.HealthCheckUrl = anno.Get(annotation.HealthCheckURI)
+			}
+			if anno.Get(annotation.HealthCheckMethod) != "" {
+				healthCheckConfig.HttpCheckMethod = anno.Get(annotation.HealthCheckMethod)
+			}
 			if anno.Get(annotation.HealthCheckHTTPCode) != "" {
 				healthCheckConfig.HealthCheckHttpCode = strings.Split(anno.Get(annotation.HealthCheckHTTPCode), ",")
 			}
@@ -886,6 +994,99 @@ func setServerGroupAttributeFromAnno(sg *nlbmodel.ServerGroup, anno *annotation.
 	return nil
 }
 
+// setServerGroupAttributeFromPolicy sets the attributes of the ServerGroupPolicy referenced by the service,
+// which are overridden by the readinessProbe and the annotations.
+// The scheduler of the policy is shared with alb, so the schedulers not supported by nlb are refused.
+func setServerGroupAttributeFromPolicy(sg *nlbmodel.ServerGroup, policy *alibabacloudv1.ServerGroupPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	spec := policy.Spec
+	if spec.Scheduler != "" {
+		scheduler, ok := nlbmodel.GetSchedulerType(spec.Scheduler)
+		if !ok {
+			return fmt.Errorf("scheduler %s of ServerGroupPolicy %s/%s is not supported by nlb, valid values: %s",
+				spec.Scheduler, policy.Namespace, policy.Name, strings.Join(nlbmodel.Schedulers, ", "))
+		}
+		sg.Scheduler = scheduler
+	}
+	if spec.ConnectionDrain != nil {
+		if spec.ConnectionDrain.Enabled != nil {
+			sg.ConnectionDrainEnabled = tea.Bool(*spec.ConnectionDrain.Enabled)
+		}
+		if spec.ConnectionDrain.TimeoutSeconds != nil {
+			sg.ConnectionDrainTimeout = int32(*spec.ConnectionDrain.TimeoutSeconds)
+		}
+	}
+
+	hc := spec.HealthCheck
+	if hc == nil {
+		return nil
+	}
+	if hc.Enabled != nil && !*hc.Enabled {
+		sg.HealthCheckConfig = &nlbmodel.HealthCheckConfig{HealthCheckEnabled: tea.Bool(false)}
+		return nil
+	}
+	healthCheckConfig := &nlbmodel.HealthCheckConfig{
+		HealthCheckEnabled: tea.Bool(true),
+		HealthCheckType:    strings.ToLower(hc.Protocol),
+	}
+	if strings.EqualFold(hc.Protocol, "http") {
+		healthCheckConfig.HttpCheckMethod = strings.ToLower(hc.Method)
+		healthCheckConfig.HealthCheckUrl = hc.Path
+		healthCheckConfig.HealthCheckDomain = hc.Host
+		healthCheckConfig.HealthCheckHttpCode = hc.HTTPCodes
+	}
+	if hc.ConnectPort != nil {
+		healthCheckConfig.HealthCheckConnectPort = int32(*hc.ConnectPort)
+	}
+	if hc.HealthyThreshold != nil {
+		healthCheckConfig.HealthyThreshold = int32(*hc.HealthyThreshold)
+	}
+	if hc.UnhealthyThreshold != nil {
+		healthCheckConfig.UnhealthyThreshold = int32(*hc.UnhealthyThreshold)
+	}
+	if hc.TimeoutSeconds != nil {
+		healthCheckConfig.HealthCheckConnectTimeout = int32(*hc.TimeoutSeconds)
+	}
+	if hc.IntervalSeconds != nil {
+		healthCheckConfig.HealthCheckInterval = int32(*hc.IntervalSeconds)
+	}
+	sg.HealthCheckConfig = healthCheckConfig
+	return nil
+}
+
+// setServerGroupHealthCheckFromProbe derives the health check from the readinessProbe of the pods serving the port.
+// https probes are checked by tcp, and udp server groups are not changed.
+func setServerGroupHealthCheckFromProbe(sg *nlbmodel.ServerGroup, pods []v1.Pod, policy helper.TrafficPolicy) {
+	if len(pods) == 0 || sg.ServicePort == nil || sg.Protocol == nlbmodel.UDP {
+		return
+	}
+	hc := helper.GetReadinessProbeHealthCheck(pods, *sg.ServicePort)
+	if hc == nil {
+		return
+	}
+	connectPort, ok := hc.ConnectPort(policy == helper.ENITrafficPolicy || policy == helper.IPTrafficPolicy)
+	if !ok {
+		return
+	}
+	healthCheckConfig := &nlbmodel.HealthCheckConfig{
+		HealthCheckEnabled:        tea.Bool(true),
+		HealthCheckType:           "tcp",
+		HealthCheckConnectPort:    connectPort,
+		HealthyThreshold:          hc.HealthyThreshold,
+		UnhealthyThreshold:        hc.UnhealthyThreshold,
+		HealthCheckConnectTimeout: hc.NLBConnectTimeout(),
+		HealthCheckInterval:       hc.Interval,
+	}
+	if hc.Protocol == string(v1.URISchemeHTTP) {
+		healthCheckConfig.HealthCheckType = "http"
+		healthCheckConfig.HealthCheckUrl = hc.Path
+		healthCheckConfig.HealthCheckDomain = hc.Host
+	}
+	sg.HealthCheckConfig = healthCheckConfig
+}
+
 func diff(remote, local *nlbmodel.ServerGroup) (
 	[]nlbmodel.ServerGroupServer, []nlbmodel.ServerGroupServer, []nlbmodel.ServerGroupServer) {
 
@@ -947,7 +1148,7 @@ func isServerEqual(a, b nlbmodel.ServerGroupServer) bool {
 	case nlbmodel.EcsServerType:
 		return a.ServerId == b.ServerId
 	case nlbmodel.IpServerType:
-		return a.ServerIp == b.ServerIp
+		return a.ServerIp == b.ServerIp && a.Port == b.Port
 	default:
 		klog.Errorf("%s is not supported, skip", a.ServerType)
 		return false
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alibabacloud-go/tea/tea"
+	"github.com/stretchr/testify/assert"
+	alibabacloudv1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/helper"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/service/reconcile/annotation"
+	reconbackend "k8s.io/alibaba-load-balancer-controller/pkg/controller/service/reconcile/backend"
+	svcCtx "k8s.io/alibaba-load-balancer-controller/pkg/controller/service/reconcile/context"
+	nlbmodel "k8s.io/alibaba-load-balancer-controller/pkg/model/nlb"
+	v1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestBuildIPBackends(t *testing.T) {
+	svc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "external", Annotations: map[string]string{
+			helper.BackendIPs: "10.0.0.2,10.0.0.2:9090,172.16.0.1,172.16.0.2:9090",
+		}},
+		Spec: v1.ServiceSpec{Ports: []v1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromInt(8080)}}},
+	}
+	reqCtx := &svcCtx.RequestContext{
+		Ctx:     context.TODO(),
+		Service: svc,
+		Anno:    annotation.NewAnnotationRequest(svc),
+		Log:     ctrl.Log,
+	}
+
+	// the same addresses are set in both endpoints and endpointslices, either of them is used by the feature gate
+	ready := true
+	candidates := &reconbackend.EndpointWithENI{
+		TrafficPolicy: helper.IPTrafficPolicy,
+		Endpoints: &v1.Endpoints{Subsets: []v1.EndpointSubset{{
+			Addresses: []v1.EndpointAddress{{IP: "10.0.0.1"}, {IP: "10.0.0.2"}},
+			Ports:     []v1.EndpointPort{{Name: "http", Port: 8080}},
+		}}},
+		EndpointSlices: []discovery.EndpointSlice{{
+			Endpoints: []discovery.Endpoint{
+				{Addresses: []string{"10.0.0.1"}, Conditions: discovery.EndpointConditions{Ready: &ready}},
+				{Addresses: []string{"10.0.0.2"}, Conditions: discovery.EndpointConditions{Ready: &ready}},
+			},
+		}},
+	}
+	sg := nlbmodel.ServerGroup{ServerGroupName: "sg-8080", ServicePort: &svc.Spec.Ports[0]}
+
+	backends, err := buildIPBackends(reqCtx, candidates, sg)
+	assert.NoError(t, err)
+	ip := func(addr string, port int32) nlbmodel.ServerGroupServer {
+		return nlbmodel.ServerGroupServer{ServerId: addr, ServerIp: addr, ServerType: nlbmodel.IpServerType,
+			Port: port, Weight: DefaultServerWeight, Description: "sg-8080"}
+	}
+	assert.Equal(t, []nlbmodel.ServerGroupServer{
+		ip("10.0.0.1", 8080), ip("10.0.0.2", 8080), ip("10.0.0.2", 9090), ip("172.16.0.1", 8080), ip("172.16.0.2", 9090),
+	}, backends)
+
+	// the servers of the same ip on different ports are different servers
+	added, deleted, updated := diff(&nlbmodel.ServerGroup{Servers: backends[:2]}, &nlbmodel.ServerGroup{Servers: backends[:3]})
+	assert.Equal(t, []nlbmodel.ServerGroupServer{ip("10.0.0.2", 9090)}, added)
+	assert.Empty(t, deleted)
+	assert.Empty(t, updated)
+
+	svc.Annotations[helper.BackendIPs] = "172.16.0"
+	_, err = buildIPBackends(reqCtx, candidates, sg)
+	assert.Error(t, err)
+}
+
+func TestSetServerGroupHealthCheckFromProbe(t *testing.T) {
+	svc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "svc", Namespace: "default",
+			Annotations: map[string]string{
+				annotation.Annotation(annotation.HealthCheckFromProbe): "on",
+				annotation.Annotation(annotation.UnhealthyThreshold):   "4",
+				annotation.Annotation(annotation.HealthCheckInterval):  "5",
+			},
+		},
+	}
+	pods := []v1.Pod{{Spec: v1.PodSpec{Containers: []v1.Container{{
+		Ports: []v1.ContainerPort{{ContainerPort: 8080}},
+		ReadinessProbe: &v1.Probe{ProbeHandler: v1.ProbeHandler{HTTPGet: &v1.HTTPGetAction{
+			Path: "/ready", Port: intstr.FromInt(8080),
+		}}},
+	}}}}}
+	sg := &nlbmodel.ServerGroup{
+		Protocol:    nlbmodel.TCP,
+		ServicePort: &v1.ServicePort{Port: 80, TargetPort: intstr.FromInt(8080)},
+	}
+
+	setServerGroupHealthCheckFromProbe(sg, pods, helper.ClusterTrafficPolicy)
+	assert.NoError(t, setServerGroupAttributeFromAnno(sg, annotation.NewAnnotationRequest(svc)))
+	assert.Equal(t, &nlbmodel.HealthCheckConfig{
+		HealthCheckEnabled:        sg.HealthCheckConfig.HealthCheckEnabled,
+		HealthCheckType:           "http",
+		HealthCheckUrl:            "/ready",
+		HealthyThreshold:          2,
+		UnhealthyThreshold:        4,
+		HealthCheckConnectTimeout: 1,
+		HealthCheckInterval:       5,
+	}, sg.HealthCheckConfig)
+	assert.True(t, *sg.HealthCheckConfig.HealthCheckEnabled)
+
+	svc.Annotations[annotation.Annotation(annotation.HealthCheckFlag)] = "off"
+	assert.NoError(t, setServerGroupAttributeFromAnno(sg, annotation.NewAnnotationRequest(svc)))
+	assert.False(t, *sg.HealthCheckConfig.HealthCheckEnabled)
+	assert.Empty(t, sg.HealthCheckConfig.HealthCheckUrl)
+
+	udp := &nlbmodel.ServerGroup{Protocol: nlbmodel.UDP, ServicePort: sg.ServicePort}
+	setServerGroupHealthCheckFromProbe(udp, pods, helper.ClusterTrafficPolicy)
+	assert.Nil(t, udp.HealthCheckConfig)
+}
+
+func TestSetServerGroupAttributeFromPolicy(t *testing.T) {
+	policy := &alibabacloudv1.ServerGroupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"},
+		Spec: alibabacloudv1.ServerGroupPolicySpec{
+			Scheduler: "rr",
+			HealthCheck: &alibabacloudv1.ServerGroupPolicyHealthCheck{
+				Protocol:         "HTTP",
+				Path:             "/healthz",
+				Method:           "GET",
+				HTTPCodes:        []string{"http_2xx"},
+				HealthyThreshold: tea.Int(3),
+				IntervalSeconds:  tea.Int(10),
+			},
+			ConnectionDrain: &alibabacloudv1.ServerGroupPolicyConnectionDrain{
+				Enabled:        tea.Bool(true),
+				TimeoutSeconds: tea.Int(30),
+			},
+		},
+	}
+	svc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "svc", Namespace: "default",
+			Annotations: map[string]string{
+				helper.ServerGroupPolicyAnnotation:                    "policy",
+				annotation.Annotation(annotation.HealthCheckInterval): "5",
+				annotation.Annotation(annotation.HealthCheckURI):      "/ready",
+			},
+		},
+	}
+	sg := &nlbmodel.ServerGroup{Protocol: nlbmodel.TCP}
+
+	assert.NoError(t, setServerGroupAttributeFromPolicy(sg, policy))
+	assert.NoError(t, setServerGroupAttributeFromAnno(sg, annotation.NewAnnotationRequest(svc)))
+	assert.Equal(t, "Rr", sg.Scheduler)
+	assert.True(t, *sg.ConnectionDrainEnabled)
+	assert.Equal(t, int32(30), sg.ConnectionDrainTimeout)
+	assert.Equal(t, &nlbmodel.HealthCheckConfig{
+		HealthCheckEnabled:  sg.HealthCheckConfig.HealthCheckEnabled,
+		HealthCheckType:     "http",
+		HealthCheckUrl:      "/ready",
+		HealthCheckHttpCode: []string{"http_2xx"},
+		HttpCheckMethod:     "get",
+		HealthyThreshold:    3,
+		HealthCheckInterval: 5,
+	}, sg.HealthCheckConfig)
+	assert.True(t, *sg.HealthCheckConfig.HealthCheckEnabled)
+
+	svc.Annotations[annotation.Annotation(annotation.Scheduler)] = "wrr"
+	svc.Annotations[annotation.Annotation(annotation.HealthCheckFlag)] = "off"
+	assert.NoError(t, setServerGroupAttributeFromAnno(sg, annotation.NewAnnotationRequest(svc)))
+	assert.Equal(t, "wrr", sg.Scheduler)
+	assert.False(t, *sg.HealthCheckConfig.HealthCheckEnabled)
+
+	// the alb schedulers of a shared policy are not supported by nlb
+	policy.Spec.Scheduler = "Wlc"
+	assert.Error(t, setServerGroupAttributeFromPolicy(&nlbmodel.ServerGroup{}, policy))
+}
@@ -0,0 +1,249 @@
+// Package albimport reads an existing ALB instance through the provider and generates the AlbConfig,
+// Ingresses and Services which reproduce it, so that the instance comes under the management of the
+// controller without being recreated.
+package albimport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	albsdk "github.com/aliyun/alibaba-cloud-sdk-go/services/alb"
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	"k8s.io/alibaba-load-balancer-controller/pkg/migration"
+	prvd "k8s.io/alibaba-load-balancer-controller/pkg/provider"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	DefaultIngressClassName = "alb"
+	DefaultNamespace        = "default"
+)
+
+const (
+	// FindingPartial means the resource is imported with a different behavior
+	FindingPartial = "Partial"
+	// FindingUnsupported means the resource can't be managed by the controller and is dropped
+	FindingUnsupported = "Unsupported"
+	// FindingReview means the generated manifests need changes before they are applied
+	FindingReview = "Review"
+)
+
+// maxIngressOrder is the max order of Ingresses of an AlbConfig
+const maxIngressOrder = 1000
+
+// Options configures the import of an ALB instance
+type Options struct {
+	// LoadBalancerID is the ID of the ALB instance to import
+	LoadBalancerID string
+	// AlbConfigName is the name of the generated AlbConfig, the name of the ALB instance if empty
+	AlbConfigName string
+	// IngressClassName is the name of the generated IngressClass used by the Ingresses
+	IngressClassName string
+	// Namespace is the namespace of the generated Ingresses and Services
+	Namespace string
+}
+
+func (o *Options) setDefaults(lb *albsdk.GetLoadBalancerAttributeResponse) {
+	if o.AlbConfigName == "" {
+		o.AlbConfigName = dnsLabel(lb.LoadBalancerName, lb.LoadBalancerId)
+	}
+	if o.IngressClassName == "" {
+		o.IngressClassName = DefaultIngressClassName
+	}
+	if o.Namespace == "" {
+		o.Namespace = DefaultNamespace
+	}
+}
+
+// Finding reports a resource of the ALB instance which isn't imported as it is
+type Finding struct {
+	Resource string
+	Status   string
+	Message  string
+}
+
+// Result is the AlbConfig, Ingresses and Services equivalent to an ALB instance
+type Result struct {
+	IngressClass *networking.IngressClass
+	AlbConfig    *v1.AlbConfig
+	Ingresses    []*networking.Ingress
+	Services     []*corev1.Service
+	Findings     []Finding
+}
+
+// Import reads the ALB instance opts.LoadBalancerID and generates the manifests reproducing it. Once they
+// are applied, the controller reuses the instance, updates its listeners and rules in place, and adopts the
+// server groups of the rules by the adopt-server-groups annotations of the Ingresses.
+func Import(ctx context.Context, albProvider prvd.IALB, opts Options) (*Result, error) {
+	lb, err := albProvider.GetALB(ctx, opts.LoadBalancerID)
+	if err != nil {
+		return nil, fmt.Errorf("get loadbalancer %s: %s", opts.LoadBalancerID, err.Error())
+	}
+	if lb == nil {
+		return nil, fmt.Errorf("loadbalancer %s not found", opts.LoadBalancerID)
+	}
+	if lb.LoadBalancerEdition == util.LoadBalancerEditionBasic {
+		return nil, fmt.Errorf("loadbalancer %s of edition %s can't be used by the controller", lb.LoadBalancerId, lb.LoadBalancerEdition)
+	}
+	opts.setDefaults(lb)
+
+	im := &importer{
+		provider:     albProvider,
+		opts:         opts,
+		result:       &Result{},
+		services:     make(map[string]*serviceRef),
+		serviceNames: sets.NewString(),
+	}
+	im.result.IngressClass = migration.NewIngressClass(opts.IngressClassName, opts.AlbConfigName)
+	im.result.AlbConfig = buildAlbConfig(lb, opts)
+
+	listeners, err := albProvider.ListALBListeners(ctx, lb.LoadBalancerId)
+	if err != nil {
+		return nil, fmt.Errorf("list listeners: %s", err.Error())
+	}
+	sort.Slice(listeners, func(i, j int) bool {
+		return listeners[i].ListenerPort < listeners[j].ListenerPort
+	})
+	for _, ls := range listeners {
+		if err := im.importListener(ctx, ls); err != nil {
+			return nil, err
+		}
+	}
+	for _, ref := range im.services {
+		if ref != nil {
+			im.result.Services = append(im.result.Services, ref.svc)
+		}
+	}
+	sort.Slice(im.result.Services, func(i, j int) bool {
+		return im.result.Services[i].Name < im.result.Services[j].Name
+	})
+	return im.result, nil
+}
+
+type importer struct {
+	provider prvd.IALB
+	opts     Options
+	result   *Result
+	// services are the Services of the server groups by the server group ID
+	services     map[string]*serviceRef
+	serviceNames sets.String
+	// order is the order of the last Ingress, which keeps the priorities of the rules
+	order int
+}
+
+func (im *importer) report(resource, status, format string, args ...interface{}) {
+	im.result.Findings = append(im.result.Findings, Finding{
+		Resource: resource,
+		Status:   status,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func buildAlbConfig(lb *albsdk.GetLoadBalancerAttributeResponse, opts Options) *v1.AlbConfig {
+	// attributes of the instance are kept as they are, while the listeners are managed by the AlbConfig
+	forceOverride, listenerForceOverride := false, true
+	deletionProtection := lb.DeletionProtectionConfig.Enabled
+	spec := &v1.LoadBalancerSpec{
+		Id:                        lb.LoadBalancerId,
+		Name:                      lb.LoadBalancerName,
+		AddressAllocatedMode:      lb.AddressAllocatedMode,
+		AddressType:               lb.AddressType,
+		AddressIpVersion:          lb.AddressIpVersion,
+		Ipv6AddressType:           lb.Ipv6AddressType,
+		ResourceGroupId:           lb.ResourceGroupId,
+		Edition:                   lb.LoadBalancerEdition,
+		DeletionProtectionEnabled: &deletionProtection,
+		ForceOverride:             &forceOverride,
+		ListenerForceOverride:     &listenerForceOverride,
+	}
+	for _, zm := range lb.ZoneMappings {
+		spec.ZoneMappings = append(spec.ZoneMappings, v1.ZoneMapping{VSwitchId: zm.VSwitchId, ZoneId: zm.ZoneId})
+	}
+	copyJSON(lb.AccessLogConfig, &spec.AccessLogConfig)
+	copyJSON(lb.LoadBalancerBillingConfig, &spec.BillingConfig)
+	return &v1.AlbConfig{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1.SchemeGroupVersion.String(), Kind: "AlbConfig"},
+		ObjectMeta: metav1.ObjectMeta{Name: opts.AlbConfigName},
+		Spec:       v1.AlbConfigSpec{LoadBalancer: spec},
+	}
+}
+
+func (im *importer) importListener(ctx context.Context, ls albsdk.Listener) error {
+	attr, err := im.provider.GetALBListenerAttribute(ctx, ls.ListenerId)
+	if err != nil {
+		return fmt.Errorf("get listener %s: %s", ls.ListenerId, err.Error())
+	}
+	// GetListenerAttribute only returns the default certificate, the additional certificates are listed separately
+	var certs []albsdk.CertificateModel
+	if attr.ListenerProtocol == util.ListenerProtocolHTTPS || attr.ListenerProtocol == util.ListenerProtocolQUIC {
+		certs, err = im.provider.ListALBListenerCertificates(ctx, ls.ListenerId)
+		if err != nil {
+			return fmt.Errorf("list certificates of listener %s: %s", ls.ListenerId, err.Error())
+		}
+	}
+	im.result.AlbConfig.Spec.Listeners = append(im.result.AlbConfig.Spec.Listeners, buildListenerSpec(attr, certs))
+
+	rules, err := im.provider.ListALBListenerRules(ctx, ls.ListenerId)
+	if err != nil {
+		return fmt.Errorf("list rules of listener %s: %s", ls.ListenerId, err.Error())
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].Priority < rules[j].Priority
+	})
+	for i, rule := range rules {
+		if err := im.importRule(ctx, ls, rule, fmt.Sprintf("%s-%d-%d", im.opts.AlbConfigName, ls.ListenerPort, i+1)); err != nil {
+			return err
+		}
+	}
+	return im.importDefaultActions(ctx, ls, attr.DefaultActions)
+}
+
+// buildListenerSpec builds the listener of AlbConfig, certs are all the certificates of the listener
+func buildListenerSpec(attr *albsdk.GetListenerAttributeResponse, certs []albsdk.CertificateModel) *v1.ListenerSpec {
+	gzipEnabled, http2Enabled := attr.GzipEnabled, attr.Http2Enabled
+	ls := &v1.ListenerSpec{
+		Port:             intstr.FromInt(attr.ListenerPort),
+		Protocol:         attr.ListenerProtocol,
+		Description:      attr.ListenerDescription,
+		IdleTimeout:      attr.IdleTimeout,
+		RequestTimeout:   attr.RequestTimeout,
+		GzipEnabled:      &gzipEnabled,
+		SecurityPolicyId: attr.SecurityPolicyId,
+		CaEnabled:        attr.CaEnabled,
+	}
+	if attr.ListenerProtocol == util.ListenerProtocolHTTPS {
+		ls.Http2Enabled = &http2Enabled
+	}
+	for _, cert := range certs {
+		if cert.CertificateType == util.ListenerCertificateTypeCa {
+			continue
+		}
+		ls.Certificates = append(ls.Certificates, v1.Certificate{CertificateId: cert.CertificateId, IsDefault: cert.IsDefault})
+	}
+	for _, cert := range attr.CaCertificates {
+		ls.CaCertificates = append(ls.CaCertificates, v1.Certificate{CertificateId: cert.CertificateId, IsDefault: cert.IsDefault})
+	}
+	if len(attr.AclConfig.AclRelations) != 0 {
+		ls.AclConfig.AclType = attr.AclConfig.AclType
+		for _, relation := range attr.AclConfig.AclRelations {
+			ls.AclConfig.AclIds = append(ls.AclConfig.AclIds, relation.AclId)
+		}
+	}
+	copyJSON(attr.XForwardedForConfig, &ls.XForwardedForConfig)
+	copyJSON(attr.QuicConfig, &ls.QuicConfig)
+	copyJSON(attr.LogConfig, &ls.LogConfig)
+	return ls
+}
+
+// copyJSON copies the fields of from to the fields of to with the same names, which are matched case-insensitively
+func copyJSON(from, to interface{}) {
+	raw, _ := json.Marshal(from)
+	_ = json.Unmarshal(raw, to)
+}
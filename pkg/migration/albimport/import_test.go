@@ -0,0 +1,227 @@
+package albimport
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	albsdk "github.com/aliyun/alibaba-cloud-sdk-go/services/alb"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/annotations"
+	albmodel "k8s.io/alibaba-load-balancer-controller/pkg/model/alb"
+	prvd "k8s.io/alibaba-load-balancer-controller/pkg/provider"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+)
+
+type fakeALB struct {
+	prvd.IALB
+	lb        *albsdk.GetLoadBalancerAttributeResponse
+	listeners map[string]*albsdk.GetListenerAttributeResponse
+	certs     map[string][]albsdk.CertificateModel
+	rules     map[string][]albsdk.Rule
+	sgps      map[string]albsdk.ServerGroup
+	servers   map[string][]albsdk.BackendServer
+}
+
+func (f *fakeALB) GetALB(_ context.Context, lbID string) (*albsdk.GetLoadBalancerAttributeResponse, error) {
+	if f.lb.LoadBalancerId != lbID {
+		return nil, nil
+	}
+	return f.lb, nil
+}
+
+func (f *fakeALB) ListALBListeners(_ context.Context, _ string) ([]albsdk.Listener, error) {
+	var listeners []albsdk.Listener
+	for id, attr := range f.listeners {
+		listeners = append(listeners, albsdk.Listener{ListenerId: id, ListenerPort: attr.ListenerPort, ListenerProtocol: attr.ListenerProtocol})
+	}
+	return listeners, nil
+}
+
+func (f *fakeALB) GetALBListenerAttribute(_ context.Context, lsID string) (*albsdk.GetListenerAttributeResponse, error) {
+	return f.listeners[lsID], nil
+}
+
+func (f *fakeALB) ListALBListenerCertificates(_ context.Context, lsID string) ([]albsdk.CertificateModel, error) {
+	return f.certs[lsID], nil
+}
+
+func (f *fakeALB) ListALBListenerRules(_ context.Context, lsID string) ([]albsdk.Rule, error) {
+	return f.rules[lsID], nil
+}
+
+func (f *fakeALB) SelectALBServerGroupsByID(_ context.Context, serverGroupID string) (albmodel.ServerGroupWithTags, error) {
+	return albmodel.ServerGroupWithTags{ServerGroup: f.sgps[serverGroupID]}, nil
+}
+
+func (f *fakeALB) ListALBServers(_ context.Context, serverGroupID string) ([]albsdk.BackendServer, error) {
+	return f.servers[serverGroupID], nil
+}
+
+func forwardTo(sgpIDs ...string) albsdk.Action {
+	act := albsdk.Action{Type: util.RuleActionTypeForward}
+	for _, id := range sgpIDs {
+		act.ForwardGroupConfig.ServerGroupTuples = append(act.ForwardGroupConfig.ServerGroupTuples,
+			albsdk.ServerGroupTuple{ServerGroupId: id, Weight: 50})
+	}
+	return act
+}
+
+func newFakeALB() *fakeALB {
+	hostCond := albsdk.Condition{Type: util.RuleConditionFieldHost}
+	hostCond.HostConfig.Values = []string{"demo.example.com"}
+	pathCond := albsdk.Condition{Type: util.RuleConditionFieldPath}
+	pathCond.PathConfig.Values = []string{"/api/*"}
+	headerCond := albsdk.Condition{Type: "Header"}
+	headerCond.HeaderConfig.Key = "env"
+	headerCond.HeaderConfig.Values = []string{"gray"}
+	redirect := albsdk.Action{Type: util.RuleActionTypeRedirect, Order: 1}
+	redirect.RedirectConfig.Protocol = "HTTPS"
+	redirect.RedirectConfig.HttpCode = "301"
+
+	defaultAction := albsdk.DefaultAction{Type: util.RuleActionTypeForward}
+	defaultAction.ForwardGroupConfig.ServerGroupTuples = []albsdk.ServerGroupTuple{{ServerGroupId: "sgp-web"}}
+	api := albsdk.ServerGroup{ServerGroupId: "sgp-api", ServerGroupName: "API_v1", Scheduler: "Wrr", Protocol: util.ServerGroupProtocolHTTPS}
+	api.HealthCheckConfig.HealthCheckEnabled = true
+	api.HealthCheckConfig.HealthCheckPath = "/healthz"
+	api.HealthCheckConfig.HealthCheckHost = "api.example.com"
+	return &fakeALB{
+		lb: &albsdk.GetLoadBalancerAttributeResponse{
+			LoadBalancerId:      "alb-1",
+			LoadBalancerName:    "Prod_ALB",
+			LoadBalancerEdition: "Standard",
+			AddressType:         "Internet",
+		},
+		listeners: map[string]*albsdk.GetListenerAttributeResponse{
+			"lsn-80":  {ListenerPort: 80, ListenerProtocol: util.ListenerProtocolHTTP, DefaultActions: []albsdk.DefaultAction{defaultAction}},
+			"lsn-443": {ListenerPort: 443, ListenerProtocol: util.ListenerProtocolHTTPS, Http2Enabled: true},
+		},
+		certs: map[string][]albsdk.CertificateModel{
+			"lsn-443": {
+				{CertificateId: "cert-default", IsDefault: true},
+				{CertificateId: "cert-sni"},
+				{CertificateId: "ca-1", CertificateType: util.ListenerCertificateTypeCa},
+			},
+		},
+		rules: map[string][]albsdk.Rule{
+			"lsn-80": {
+				{RuleId: "rule-b", Priority: 20, RuleConditions: []albsdk.Condition{hostCond}, RuleActions: []albsdk.Action{redirect}},
+				{RuleId: "rule-a", Priority: 10, RuleConditions: []albsdk.Condition{hostCond, pathCond, headerCond},
+					RuleActions: []albsdk.Action{forwardTo("sgp-api")}},
+			},
+			"lsn-443": {
+				{RuleId: "rule-c", Priority: 10, RuleConditions: []albsdk.Condition{pathCond},
+					RuleActions: []albsdk.Action{forwardTo("sgp-api", "sgp-web")}},
+			},
+		},
+		sgps: map[string]albsdk.ServerGroup{
+			"sgp-api": api,
+			"sgp-web": {ServerGroupId: "sgp-web", ServerGroupName: "web", Scheduler: "Wrr", Protocol: "HTTP"},
+		},
+		servers: map[string][]albsdk.BackendServer{
+			"sgp-api": {{ServerId: "i-1", Port: 8080}, {ServerId: "i-2", Port: 8080}, {ServerId: "i-3", Port: 9090}},
+			"sgp-web": {{ServerId: "i-1", Port: 80}},
+		},
+	}
+}
+
+func findingsOf(findings []Finding, resource, status string) []Finding {
+	var found []Finding
+	for _, f := range findings {
+		if f.Resource == resource && f.Status == status {
+			found = append(found, f)
+		}
+	}
+	return found
+}
+
+func TestImport(t *testing.T) {
+	result, err := Import(context.TODO(), newFakeALB(), Options{LoadBalancerID: "alb-1"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "prod-alb", result.AlbConfig.Name)
+	assert.Equal(t, "alb-1", result.AlbConfig.Spec.LoadBalancer.Id)
+	assert.False(t, *result.AlbConfig.Spec.LoadBalancer.ForceOverride)
+	assert.True(t, *result.AlbConfig.Spec.LoadBalancer.ListenerForceOverride)
+	if assert.Len(t, result.AlbConfig.Spec.Listeners, 2) {
+		assert.Equal(t, 80, result.AlbConfig.Spec.Listeners[0].Port.IntValue())
+		assert.Nil(t, result.AlbConfig.Spec.Listeners[0].Http2Enabled)
+		assert.True(t, *result.AlbConfig.Spec.Listeners[1].Http2Enabled)
+		// the additional certificates are imported together with the default one
+		assert.Empty(t, result.AlbConfig.Spec.Listeners[0].Certificates)
+		assert.Equal(t, []v1.Certificate{
+			{CertificateId: "cert-default", IsDefault: true},
+			{CertificateId: "cert-sni"},
+		}, result.AlbConfig.Spec.Listeners[1].Certificates)
+	}
+	assert.Equal(t, "prod-alb", result.IngressClass.Spec.Parameters.Name)
+
+	if assert.Len(t, result.Services, 2) {
+		assert.Equal(t, "api-v1", result.Services[0].Name)
+		assert.Equal(t, int32(8080), result.Services[0].Spec.Ports[0].Port)
+		assert.Equal(t, "web", result.Services[1].Name)
+	}
+	assert.Len(t, findingsOf(result.Findings, "serverGroup sgp-api", FindingPartial), 2)
+
+	if !assert.Len(t, result.Ingresses, 4) {
+		return
+	}
+	// rules are ordered by priority
+	ruleA := result.Ingresses[0]
+	assert.Equal(t, "prod-alb-80-1", ruleA.Name)
+	assert.Equal(t, "1", ruleA.Annotations[annotations.Order])
+	assert.Equal(t, `[{"HTTP":80}]`, ruleA.Annotations[annotations.ListenPorts])
+	assert.Equal(t, `{"api-v1:8080":"sgp-api"}`, ruleA.Annotations[annotations.AlbAdoptServerGroups])
+	assert.Equal(t, "https", ruleA.Annotations[annotations.AlbBackendProtocol])
+	assert.Equal(t, "/healthz", ruleA.Annotations[annotations.HealthCheckPath])
+	assert.Equal(t, `[{"HeaderConfig":{"Key":"env","Values":["gray"]},"Type":"Header"}]`,
+		ruleA.Annotations["alb.ingress.kubernetes.io/conditions.api-v1"])
+	rule := ruleA.Spec.Rules[0]
+	assert.Equal(t, "demo.example.com", rule.Host)
+	assert.Equal(t, "/api/*", rule.HTTP.Paths[0].Path)
+	assert.Equal(t, int32(8080), rule.HTTP.Paths[0].Backend.Service.Port.Number)
+
+	ruleB := result.Ingresses[1]
+	assert.Equal(t, useAnnotationPortName, ruleB.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Name)
+	assert.Equal(t, `[{"RedirectConfig":{"Host":"","HttpCode":"301","Path":"","Port":"","Protocol":"HTTPS","Query":""},"Type":"Redirect"}]`,
+		ruleB.Annotations["alb.ingress.kubernetes.io/actions.prod-alb-80-2"])
+
+	// the default action is imported after the rules of the listener
+	defaultRule := result.Ingresses[2]
+	assert.Equal(t, "prod-alb-80-default", defaultRule.Name)
+	assert.Equal(t, "/*", defaultRule.Spec.Rules[0].HTTP.Paths[0].Path)
+	assert.Equal(t, `{"web:80":"sgp-web"}`, defaultRule.Annotations[annotations.AlbAdoptServerGroups])
+
+	// server groups already adopted get new ones
+	ruleC := result.Ingresses[3]
+	assert.Equal(t, "4", ruleC.Annotations[annotations.Order])
+	assert.NotContains(t, ruleC.Annotations, annotations.AlbAdoptServerGroups)
+	assert.Equal(t, useAnnotationPortName, ruleC.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Name)
+	assert.Equal(t, `[{"Type":"ForwardGroup","forwardConfig":{"serverGroups":[{"serverGroupID":"","serviceName":"api-v1","servicePort":8080,"weight":50},`+
+		`{"serverGroupID":"","serviceName":"web","servicePort":80,"weight":50}]}}]`, ruleC.Annotations["alb.ingress.kubernetes.io/actions.api-v1"])
+	assert.Len(t, findingsOf(result.Findings, "rule rule-c", FindingPartial), 1)
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, WriteManifests(buf, result))
+	assert.Contains(t, buf.String(), "kind: AlbConfig")
+}
+
+func TestImportUnsupported(t *testing.T) {
+	alb := newFakeALB()
+	_, err := Import(context.TODO(), alb, Options{LoadBalancerID: "alb-2"})
+	assert.Error(t, err)
+
+	fc := alb.sgps["sgp-web"]
+	fc.ServerGroupType = serverGroupTypeFc
+	alb.sgps["sgp-web"] = fc
+	result, err := Import(context.TODO(), alb, Options{LoadBalancerID: "alb-1", AlbConfigName: "prod"})
+	assert.NoError(t, err)
+	assert.Len(t, result.Ingresses, 2)
+	assert.Len(t, result.Services, 1)
+	assert.Len(t, findingsOf(result.Findings, "serverGroup sgp-web", FindingUnsupported), 1)
+
+	alb.lb.LoadBalancerEdition = util.LoadBalancerEditionBasic
+	_, err = Import(context.TODO(), alb, Options{LoadBalancerID: "alb-1"})
+	assert.Error(t, err)
+}
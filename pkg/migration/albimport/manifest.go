@@ -0,0 +1,31 @@
+package albimport
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"k8s.io/alibaba-load-balancer-controller/pkg/migration"
+)
+
+// WriteManifests writes the IngressClass, AlbConfig, Services and Ingresses of result as YAML documents
+func WriteManifests(w io.Writer, result *Result) error {
+	objects := []interface{}{result.IngressClass, result.AlbConfig}
+	for _, svc := range result.Services {
+		objects = append(objects, svc)
+	}
+	for _, ing := range result.Ingresses {
+		objects = append(objects, ing)
+	}
+	return migration.WriteManifests(w, objects...)
+}
+
+// WriteReport writes the findings as a table
+func WriteReport(w io.Writer, findings []Finding) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "RESOURCE\tSTATUS\tMESSAGE")
+	for _, f := range findings {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", f.Resource, f.Status, f.Message)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,270 @@
+package albimport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	albsdk "github.com/aliyun/alibaba-cloud-sdk-go/services/alb"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/annotations"
+	"k8s.io/alibaba-load-balancer-controller/pkg/model/alb/configcache"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// useAnnotationPortName is the backend port of the paths whose actions are all in the actions annotation
+const useAnnotationPortName = "use-annotation"
+
+// importedRule is a rule of a listener to reproduce with an Ingress of a single path
+type importedRule struct {
+	resource  string
+	name      string
+	listener  albsdk.Listener
+	host      string
+	path      string
+	direction string
+	// conditions are the conditions besides host and path, in the format of the conditions annotation
+	conditions []interface{}
+	actions    []albsdk.Action
+}
+
+// importRule imports a rule of a listener. The host and path conditions of a single value are kept in the
+// Ingress rule, the others go to the conditions annotation.
+func (im *importer) importRule(ctx context.Context, ls albsdk.Listener, rule albsdk.Rule, name string) error {
+	r := importedRule{
+		resource:  "rule " + rule.RuleId,
+		name:      name,
+		listener:  ls,
+		direction: rule.Direction,
+		actions:   rule.RuleActions,
+	}
+	for _, cond := range rule.RuleConditions {
+		switch {
+		case cond.Type == util.RuleConditionFieldHost && r.host == "" &&
+			len(cond.HostConfig.Values) == 1 && isIngressHost(cond.HostConfig.Values[0]):
+			r.host = cond.HostConfig.Values[0]
+		case cond.Type == util.RuleConditionFieldPath && r.path == "" &&
+			len(cond.PathConfig.Values) == 1 && strings.HasPrefix(cond.PathConfig.Values[0], "/"):
+			r.path = cond.PathConfig.Values[0]
+		default:
+			r.conditions = append(r.conditions, customConfig(cond, cond.Type))
+		}
+	}
+	return im.addIngress(ctx, r)
+}
+
+// importDefaultActions imports the default actions of a listener with a rule matching all requests after the
+// other rules, since the controller forwards the requests matching no rules to its own default server group
+func (im *importer) importDefaultActions(ctx context.Context, ls albsdk.Listener, defaultActions []albsdk.DefaultAction) error {
+	resource := fmt.Sprintf("listener %d/%s", ls.ListenerPort, ls.ListenerProtocol)
+	for _, act := range defaultActions {
+		if act.Type != util.RuleActionTypeForward {
+			im.report(resource, FindingUnsupported, "default action %s is dropped", act.Type)
+			continue
+		}
+		name := fmt.Sprintf("%s-%d-default", im.opts.AlbConfigName, ls.ListenerPort)
+		im.report(resource, FindingPartial, "the default action forwards to the default server group of the controller, "+
+			"the requests matching no rules are forwarded by Ingress %s instead", name)
+		return im.addIngress(ctx, importedRule{
+			resource: resource,
+			name:     name,
+			listener: ls,
+			path:     "/*",
+			actions: []albsdk.Action{{
+				Type:               act.Type,
+				ForwardGroupConfig: albsdk.ForwardGroupConfigInListRules{ServerGroupTuples: act.ForwardGroupConfig.ServerGroupTuples},
+			}},
+		})
+	}
+	return nil
+}
+
+// addIngress generates the Ingress of r. A rule forwarding to a single server group gets the Service of the
+// server group as the backend, the other actions go to the actions annotation.
+func (im *importer) addIngress(ctx context.Context, r importedRule) error {
+	if im.order >= maxIngressOrder {
+		im.report(r.resource, FindingUnsupported, "the Ingresses of an AlbConfig are ordered up to %d, the rule is dropped", maxIngressOrder)
+		return nil
+	}
+	im.order++
+	ing := im.newIngress(r.name, r.listener)
+
+	actions := append([]albsdk.Action(nil), r.actions...)
+	sort.SliceStable(actions, func(i, j int) bool {
+		return actions[i].Order < actions[j].Order
+	})
+	var forward []albsdk.ServerGroupTuple
+	var custom []interface{}
+	for _, act := range actions {
+		switch act.Type {
+		case util.RuleActionTypeForward:
+			forward = act.ForwardGroupConfig.ServerGroupTuples
+			if act.ForwardGroupConfig.ServerGroupStickySession.Enabled {
+				im.report(r.resource, FindingPartial, "the sticky session among server groups is dropped")
+			}
+		case util.RuleActionTypeCors:
+			cors := act.CorsConfig
+			custom = append(custom, map[string]interface{}{"Type": act.Type, "CorsConfig": configcache.CorsConfig{
+				AllowCredentials: cors.AllowCredentials,
+				MaxAge:           strconv.FormatInt(cors.MaxAge, 10),
+				AllowOrigin:      cors.AllowOrigin,
+				AllowMethods:     cors.AllowMethods,
+				AllowHeaders:     cors.AllowHeaders,
+				ExposeHeaders:    cors.ExposeHeaders,
+			}})
+		case util.RuleActionTypeTrafficLimit:
+			limit := configcache.TrafficLimitConfig{}
+			if act.TrafficLimitConfig.QPS > 0 {
+				limit.QPS = strconv.Itoa(act.TrafficLimitConfig.QPS)
+			}
+			if act.TrafficLimitConfig.PerIpQps > 0 {
+				limit.QPSPerIp = strconv.Itoa(act.TrafficLimitConfig.PerIpQps)
+			}
+			custom = append(custom, map[string]interface{}{"Type": act.Type, "TrafficLimitConfig": limit})
+		case util.RuleActionTypeTrafficMirror:
+			mirror := configcache.TrafficMirrorConfig{TargetType: act.TrafficMirrorConfig.TargetType}
+			for _, tuple := range act.TrafficMirrorConfig.MirrorGroupConfig.ServerGroupTuples {
+				mirror.MirrorGroupConfig.ServerGroupTuples = append(mirror.MirrorGroupConfig.ServerGroupTuples,
+					configcache.TrafficMirrorServerGroupTuple{ServerGroupID: tuple.ServerGroupId, Weight: tuple.Weight})
+			}
+			custom = append(custom, map[string]interface{}{"Type": act.Type, "TrafficMirrorConfig": mirror})
+			im.report(r.resource, FindingPartial, "the server groups mirrored to are referenced by ID and not managed by the controller")
+		case util.RuleActionTypeRedirect, util.RuleActionTypeFixedResponse, util.RuleActionTypeInsertHeader,
+			util.RuleActionTypeRemoveHeader, util.RuleActionTypeRewrite:
+			custom = append(custom, customConfig(act, act.Type))
+		default:
+			im.report(r.resource, FindingUnsupported, "action %s is dropped", act.Type)
+		}
+	}
+
+	backend := networking.IngressServiceBackend{Name: r.name, Port: networking.ServiceBackendPort{Name: useAnnotationPortName}}
+	if len(forward) != 0 {
+		var refs []*serviceRef
+		for _, tuple := range forward {
+			ref, err := im.serviceOf(ctx, tuple.ServerGroupId)
+			if err != nil {
+				return err
+			}
+			if ref == nil {
+				im.report(r.resource, FindingUnsupported, "the rule forwarding to server group %s is dropped", tuple.ServerGroupId)
+				im.order--
+				return nil
+			}
+			refs = append(refs, ref)
+		}
+		backend.Name = refs[0].svc.Name
+		if len(refs) == 1 {
+			backend.Port = networking.ServiceBackendPort{Number: int32(refs[0].port)}
+		} else {
+			var tuples []configcache.ServerGroupTuple
+			for i, ref := range refs {
+				tuples = append(tuples, configcache.ServerGroupTuple{ServiceName: ref.svc.Name, ServicePort: ref.port, Weight: forward[i].Weight})
+			}
+			custom = append(custom, map[string]interface{}{"Type": util.RuleActionTypeForward, "forwardConfig": map[string]interface{}{"serverGroups": tuples}})
+		}
+		im.adoptServerGroups(ing, r.resource, refs)
+	}
+
+	if len(r.conditions) != 0 {
+		ing.Annotations[fmt.Sprintf(annotations.INGRESS_ALB_CONDITIONS_ANNOTATIONS, backend.Name)] = marshalAnnotation(r.conditions)
+	}
+	if len(custom) != 0 {
+		ing.Annotations[fmt.Sprintf(annotations.INGRESS_ALB_ACTIONS_ANNOTATIONS, backend.Name)] = marshalAnnotation(custom)
+	}
+	if r.direction == util.RuleResponseDirection {
+		ing.Annotations[fmt.Sprintf(annotations.INGRESS_ALB_RULE_DIRECTION, backend.Name)] = r.direction
+	}
+	pathType := networking.PathTypeImplementationSpecific
+	ing.Spec.Rules = []networking.IngressRule{{
+		Host: r.host,
+		IngressRuleValue: networking.IngressRuleValue{HTTP: &networking.HTTPIngressRuleValue{
+			Paths: []networking.HTTPIngressPath{{
+				Path:     r.path,
+				PathType: &pathType,
+				Backend:  networking.IngressBackend{Service: &backend},
+			}},
+		}},
+	}}
+	im.result.Ingresses = append(im.result.Ingresses, ing)
+	return nil
+}
+
+// adoptServerGroups annotates ing to adopt the server groups of refs no other Ingress adopts, and to build
+// the server groups with the attributes of the first one
+func (im *importer) adoptServerGroups(ing *networking.Ingress, resource string, refs []*serviceRef) {
+	adopted := make(map[string]string)
+	for _, ref := range refs {
+		if !ref.adopted {
+			ref.adopted = true
+			adopted[fmt.Sprintf("%s:%d", ref.svc.Name, ref.port)] = ref.sgp.ServerGroupId
+		}
+	}
+	if len(adopted) != 0 {
+		ing.Annotations[annotations.AlbAdoptServerGroups] = marshalAnnotation(adopted)
+	}
+
+	anns, _ := serverGroupAnnotations(refs[0].sgp)
+	for k, v := range anns {
+		ing.Annotations[k] = v
+	}
+	for _, ref := range refs[1:] {
+		if others, _ := serverGroupAnnotations(ref.sgp); !equalAnnotations(anns, others) {
+			im.report(resource, FindingPartial, "server group %s gets the attributes of server group %s",
+				ref.sgp.ServerGroupId, refs[0].sgp.ServerGroupId)
+		}
+	}
+}
+
+func (im *importer) newIngress(name string, ls albsdk.Listener) *networking.Ingress {
+	className := im.opts.IngressClassName
+	return &networking.Ingress{
+		TypeMeta: metav1.TypeMeta{APIVersion: networking.SchemeGroupVersion.String(), Kind: "Ingress"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: im.opts.Namespace,
+			Annotations: map[string]string{
+				annotations.Order:       strconv.Itoa(im.order),
+				annotations.ListenPorts: marshalAnnotation([]map[string]int{{ls.ListenerProtocol: ls.ListenerPort}}),
+			},
+		},
+		Spec: networking.IngressSpec{IngressClassName: &className},
+	}
+}
+
+// isIngressHost returns whether host is allowed in Ingress rules, other hosts go to the conditions annotation
+func isIngressHost(host string) bool {
+	if strings.HasPrefix(host, "*.") {
+		return len(validation.IsWildcardDNS1123Subdomain(host)) == 0
+	}
+	return len(validation.IsDNS1123Subdomain(host)) == 0
+}
+
+// customConfig keeps the type and the config of the type of a condition or an action, as the conditions and
+// actions annotations expect
+func customConfig(v interface{}, typ string) map[string]interface{} {
+	fields := make(map[string]interface{})
+	copyJSON(v, &fields)
+	return map[string]interface{}{"Type": typ, typ + "Config": fields[typ+"Config"]}
+}
+
+func marshalAnnotation(v interface{}) string {
+	raw, _ := json.Marshal(v)
+	return string(raw)
+}
+
+func equalAnnotations(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,187 @@
+package albimport
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	albsdk "github.com/aliyun/alibaba-cloud-sdk-go/services/alb"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/annotations"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// serverGroupTypeFc is the type of server groups of Function Compute functions
+const serverGroupTypeFc = "Fc"
+
+// serviceRef is the Service standing for a server group
+type serviceRef struct {
+	sgp  albsdk.ServerGroup
+	svc  *corev1.Service
+	port int
+	// adopted is whether an Ingress adopts the server group already, the others get new server groups
+	adopted bool
+}
+
+// serviceOf returns the Service standing for the server group sgpID, which is generated on the first call.
+// It returns nil for the server groups the controller can't manage.
+func (im *importer) serviceOf(ctx context.Context, sgpID string) (*serviceRef, error) {
+	if ref, ok := im.services[sgpID]; ok {
+		return ref, nil
+	}
+	sgp, err := im.provider.SelectALBServerGroupsByID(ctx, sgpID)
+	if err != nil {
+		return nil, fmt.Errorf("get server group %s: %s", sgpID, err.Error())
+	}
+	resource := "serverGroup " + sgpID
+	if sgp.ServerGroupType == serverGroupTypeFc {
+		im.report(resource, FindingUnsupported, "server groups of Function Compute can't be managed by the controller")
+		im.services[sgpID] = nil
+		return nil, nil
+	}
+	servers, err := im.provider.ListALBServers(ctx, sgpID)
+	if err != nil {
+		return nil, fmt.Errorf("list servers of server group %s: %s", sgpID, err.Error())
+	}
+
+	name := dnsLabel(sgp.ServerGroupName, sgpID)
+	if im.serviceNames.Has(name) {
+		name = sgpID
+	}
+	im.serviceNames.Insert(name)
+	port, ports := serverPort(servers)
+	switch {
+	case len(ports) == 0:
+		im.report(resource, FindingReview, "no servers, the port of Service %s defaults to %d", name, port)
+	case len(ports) > 1:
+		im.report(resource, FindingPartial, "servers listen on ports %s, Service %s targets port %d only",
+			strings.Join(ports, ","), name, port)
+	}
+	_, dropped := serverGroupAnnotations(sgp.ServerGroup)
+	for _, attr := range dropped {
+		im.report(resource, FindingPartial, "%s is dropped", attr)
+	}
+	var serverIDs []string
+	for _, server := range servers {
+		serverIDs = append(serverIDs, server.ServerId)
+	}
+	im.report(resource, FindingReview, "set the selector of Service %s to the pods behind the servers [%s], the servers "+
+		"are replaced by the endpoints of the Service once the server group is adopted", name, strings.Join(serverIDs, ","))
+
+	ref := &serviceRef{
+		sgp:  sgp.ServerGroup,
+		port: port,
+		svc: &corev1.Service{
+			TypeMeta:   metav1.TypeMeta{APIVersion: corev1.SchemeGroupVersion.String(), Kind: "Service"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: im.opts.Namespace},
+			Spec: corev1.ServiceSpec{
+				Type: corev1.ServiceTypeClusterIP,
+				Ports: []corev1.ServicePort{{
+					Name:       "http",
+					Port:       int32(port),
+					TargetPort: intstr.FromInt(port),
+				}},
+			},
+		},
+	}
+	im.services[sgpID] = ref
+	return ref, nil
+}
+
+// serverPort returns the port most servers listen on, and all the ports
+func serverPort(servers []albsdk.BackendServer) (int, []string) {
+	counts := make(map[int]int)
+	for _, server := range servers {
+		counts[server.Port]++
+	}
+	port, most := 80, 0
+	var ports []string
+	for p, n := range counts {
+		ports = append(ports, strconv.Itoa(p))
+		if n > most || (n == most && p < port) {
+			port, most = p, n
+		}
+	}
+	sort.Strings(ports)
+	return port, ports
+}
+
+// serverGroupAnnotations returns the Ingress annotations which build the attributes of sgp, and the
+// attributes the annotations can't build
+func serverGroupAnnotations(sgp albsdk.ServerGroup) (map[string]string, []string) {
+	anns := map[string]string{
+		annotations.AlbBackendScheduler: strings.ToLower(sgp.Scheduler),
+	}
+	var dropped []string
+	switch sgp.Protocol {
+	case util.ServerGroupProtocolHTTPS:
+		anns[annotations.AlbBackendProtocol] = "https"
+	case util.ServerGroupProtocolGRPC:
+		anns[annotations.AlbBackendProtocol] = "grpc"
+	}
+	if strings.EqualFold(sgp.Scheduler, util.ServerGroupSchedulerUch) {
+		anns[annotations.AlbBackendUchSchedulerValue] = sgp.UchConfig.Value
+	}
+	if sgp.UpstreamKeepaliveEnabled {
+		anns[annotations.AlbBackendKeepalive] = "true"
+	}
+
+	hc := sgp.HealthCheckConfig
+	if hc.HealthCheckEnabled {
+		anns[annotations.HealthCheckEnabled] = "true"
+		anns[annotations.HealthCheckPath] = hc.HealthCheckPath
+		anns[annotations.HealthCheckMethod] = hc.HealthCheckMethod
+		anns[annotations.HealthCheckProtocol] = hc.HealthCheckProtocol
+		anns[annotations.HealthCheckTimeout] = strconv.Itoa(hc.HealthCheckTimeout)
+		anns[annotations.HealthCheckInterval] = strconv.Itoa(hc.HealthCheckInterval)
+		anns[annotations.HealthThreshold] = strconv.Itoa(hc.HealthyThreshold)
+		anns[annotations.UnHealthThreshold] = strconv.Itoa(hc.UnhealthyThreshold)
+		if len(hc.HealthCheckHttpCodes) != 0 {
+			anns[annotations.HealthCheckHTTPCode] = strings.Join(hc.HealthCheckHttpCodes, ",")
+		}
+		if hc.HealthCheckConnectPort != 0 {
+			anns[annotations.HealthCheckConnectPort] = strconv.Itoa(hc.HealthCheckConnectPort)
+		}
+		if hc.HealthCheckHost != "" && hc.HealthCheckHost != util.DefaultServerGroupHealthCheckHost {
+			dropped = append(dropped, fmt.Sprintf("health check host %s", hc.HealthCheckHost))
+		}
+	}
+
+	sticky := sgp.StickySessionConfig
+	if sticky.StickySessionEnabled {
+		anns[annotations.SessionStick] = "true"
+		anns[annotations.SessionStickType] = sticky.StickySessionType
+		if sticky.StickySessionType == util.ServerGroupStickySessionTypeInsert {
+			anns[annotations.CookieTimeout] = strconv.Itoa(sticky.CookieTimeout)
+		} else if sticky.Cookie != "" {
+			dropped = append(dropped, fmt.Sprintf("sticky session cookie %s", sticky.Cookie))
+		}
+	}
+	return anns, dropped
+}
+
+// dnsLabel converts name to a DNS-1035 label for the names of objects, fallback is used if it can't
+func dnsLabel(name, fallback string) string {
+	label := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		}
+		return '-'
+	}, name)
+	if len(label) > validation.DNS1035LabelMaxLength {
+		label = label[:validation.DNS1035LabelMaxLength]
+	}
+	label = strings.Trim(label, "-")
+	if len(validation.IsDNS1035Label(label)) != 0 {
+		return fallback
+	}
+	return label
+}
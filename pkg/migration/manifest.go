@@ -0,0 +1,93 @@
+// Package migration holds what the tools migrating existing load balancers to the controller share.
+package migration
+
+import (
+	"encoding/json"
+	"io"
+
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/store"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// NewIngressClass returns the IngressClass of the controller with the AlbConfig albConfigName
+func NewIngressClass(name, albConfigName string) *networking.IngressClass {
+	group := v1.SchemeGroupVersion.Group
+	return &networking.IngressClass{
+		TypeMeta:   metav1.TypeMeta{APIVersion: networking.SchemeGroupVersion.String(), Kind: "IngressClass"},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: networking.IngressClassSpec{
+			Controller: store.ALBIngressController,
+			Parameters: &networking.IngressClassParametersReference{
+				APIGroup: &group,
+				Kind:     "AlbConfig",
+				Name:     albConfigName,
+			},
+		},
+	}
+}
+
+// WriteManifests writes objects as YAML documents
+func WriteManifests(w io.Writer, objects ...interface{}) error {
+	for i, obj := range objects {
+		// fields of AlbConfig are not omitted when empty, whose zero values are the defaults
+		_, pruneZero := obj.(*v1.AlbConfig)
+		out, err := marshalManifest(obj, pruneZero)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// marshalManifest marshals obj without status, null fields and empty objects, and zero values if pruneZero
+func marshalManifest(obj interface{}, pruneZero bool) ([]byte, error) {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	delete(fields, "status")
+	return yaml.Marshal(pruneEmpty(fields, pruneZero))
+}
+
+func pruneEmpty(value interface{}, pruneZero bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			field = pruneEmpty(field, pruneZero)
+			if field == nil {
+				delete(v, key)
+				continue
+			}
+			v[key] = field
+		}
+		if len(v) == 0 {
+			return nil
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = pruneEmpty(v[i], pruneZero)
+		}
+		return v
+	case string, bool, float64:
+		if pruneZero && (v == "" || v == false || v == 0.0) {
+			return nil
+		}
+	}
+	return value
+}
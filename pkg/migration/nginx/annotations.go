@@ -0,0 +1,456 @@
+package nginx
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/annotations"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+	networking "k8s.io/api/networking/v1"
+)
+
+// nginx annotations, without the nginx prefix
+const (
+	nginxCanary                = "canary"
+	nginxCanaryByHeader        = "canary-by-header"
+	nginxCanaryByHeaderValue   = "canary-by-header-value"
+	nginxCanaryByCookie        = "canary-by-cookie"
+	nginxCanaryWeight          = "canary-weight"
+	nginxCanaryWeightTotal     = "canary-weight-total"
+	nginxSslRedirect           = "ssl-redirect"
+	nginxForceSslRedirect      = "force-ssl-redirect"
+	nginxRewriteTarget         = "rewrite-target"
+	nginxUseRegex              = "use-regex"
+	nginxBackendProtocol       = "backend-protocol"
+	nginxWhitelistSourceRange  = "whitelist-source-range"
+	nginxAllowlistSourceRange  = "allowlist-source-range"
+	nginxPermanentRedirect     = "permanent-redirect"
+	nginxPermanentRedirectCode = "permanent-redirect-code"
+	nginxTemporalRedirect      = "temporal-redirect"
+	nginxLimitRps              = "limit-rps"
+	nginxLimitRpm              = "limit-rpm"
+	nginxEnableCors            = "enable-cors"
+	nginxCorsAllowOrigin       = "cors-allow-origin"
+	nginxCorsAllowMethods      = "cors-allow-methods"
+	nginxCorsAllowHeaders      = "cors-allow-headers"
+	nginxCorsExposeHeaders     = "cors-expose-headers"
+	nginxCorsAllowCredentials  = "cors-allow-credentials"
+	nginxCorsMaxAge            = "cors-max-age"
+	nginxAffinity              = "affinity"
+	nginxSessionCookieName     = "session-cookie-name"
+	nginxSessionCookieMaxAge   = "session-cookie-max-age"
+	nginxSessionCookieExpires  = "session-cookie-expires"
+)
+
+const (
+	listenPortsHTTPAndHTTPS = `[{"HTTP": 80},{"HTTPS": 443}]`
+	// useAnnotationPortName is the port name of backends whose actions are all in the actions annotation
+	useAnnotationPortName = "use-annotation"
+	maxTrafficLimitQps    = 100000
+	maxCookieTimeout      = 86400
+)
+
+type annotationConverter func(c *ingressConverter, key, value string)
+
+// annotationConverters convert the nginx annotations, keyed by names without the nginx prefix. A converter
+// may convert related annotations along with the one it is called for.
+var annotationConverters = map[string]annotationConverter{
+	nginxCanary:                copyAnnotation(annotations.AlbCanary),
+	nginxCanaryByHeader:        copyAnnotation(annotations.AlbCanaryByHeader),
+	nginxCanaryByHeaderValue:   copyAnnotation(annotations.AlbCanaryByHeaderValue),
+	nginxCanaryByCookie:        copyAnnotation(annotations.AlbCanaryByCookie),
+	nginxCanaryWeight:          convertCanaryWeight,
+	nginxCanaryWeightTotal:     convertCanaryWeight,
+	nginxRewriteTarget:         convertRewriteTarget,
+	nginxUseRegex:              convertUseRegex,
+	nginxBackendProtocol:       convertBackendProtocol,
+	nginxWhitelistSourceRange:  convertSourceRange,
+	nginxAllowlistSourceRange:  convertSourceRange,
+	nginxPermanentRedirect:     convertRedirect,
+	nginxPermanentRedirectCode: convertRedirect,
+	nginxTemporalRedirect:      convertRedirect,
+	nginxLimitRps:              convertRateLimit,
+	nginxLimitRpm:              convertRateLimit,
+	nginxEnableCors:            copyAnnotation(annotations.AlbEnableCors),
+	nginxCorsAllowOrigin:       copyAnnotation(annotations.AlbCorsAllowOrigin),
+	nginxCorsAllowMethods:      copyAnnotation(annotations.AlbCorsAllowMethods),
+	nginxCorsAllowHeaders:      copyAnnotation(annotations.AlbCorsAllowHeaders),
+	nginxCorsExposeHeaders:     copyAnnotation(annotations.AlbCorsExposeHeaders),
+	nginxCorsAllowCredentials:  copyAnnotation(annotations.AlbCorsAllowCredentials),
+	nginxCorsMaxAge:            copyAnnotation(annotations.AlbCorsMaxAge),
+	nginxAffinity:              convertAffinity,
+	nginxSessionCookieName:     convertAffinity,
+	nginxSessionCookieMaxAge:   convertAffinity,
+	nginxSessionCookieExpires:  convertAffinity,
+}
+
+var validRedirectCodes = map[string]bool{"301": true, "302": true, "303": true, "307": true, "308": true}
+
+// captureGroupRegexp matches the $n capture groups of nginx, which are ${n} in ALB
+var captureGroupRegexp = regexp.MustCompile(`\$(\d+)`)
+
+func nginxKey(name string) string {
+	return annotations.AnnotationNginxPrefix + name
+}
+
+func copyAnnotation(albKey string) annotationConverter {
+	return func(c *ingressConverter, key, value string) {
+		c.dst.Annotations[albKey] = value
+		c.report(key, FindingConverted, "converted to %s", albKey)
+	}
+}
+
+// convertSslRedirect redirects HTTP requests to HTTPS for Ingresses with TLS unless disabled, as nginx does
+func (c *ingressConverter) convertSslRedirect() {
+	sslKey, forceKey := nginxKey(nginxSslRedirect), nginxKey(nginxForceSslRedirect)
+	hasTLS := len(c.src.Spec.TLS) > 0
+	redirect := hasTLS
+	if v, ok := c.src.Annotations[sslKey]; ok {
+		redirect = hasTLS && v == "true"
+	}
+	if v, ok := c.src.Annotations[forceKey]; ok && v == "true" {
+		redirect = true
+	}
+	if hasTLS || redirect {
+		c.listenHTTPS = true
+		if _, ok := c.dst.Annotations[annotations.ListenPorts]; !ok {
+			c.dst.Annotations[annotations.ListenPorts] = listenPortsHTTPAndHTTPS
+		}
+	}
+	if redirect {
+		c.dst.Annotations[annotations.AlbSslRedirect] = "true"
+	}
+
+	if _, ok := c.src.Annotations[sslKey]; ok || hasTLS {
+		c.report(sslKey, FindingConverted, "HTTP requests are redirected to HTTPS: %t", redirect)
+	}
+	if _, ok := c.src.Annotations[forceKey]; ok {
+		if redirect && !hasTLS {
+			c.report(forceKey, FindingPartial, "the Ingress has no TLS, a certificate of the HTTPS listener must be "+
+				"configured in the AlbConfig or discovered by the hosts")
+		} else {
+			c.report(forceKey, FindingConverted, "HTTP requests are redirected to HTTPS: %t", redirect)
+		}
+	}
+}
+
+func convertCanaryWeight(c *ingressConverter, _, _ string) {
+	weightKey, totalKey := nginxKey(nginxCanaryWeight), nginxKey(nginxCanaryWeightTotal)
+	weightValue, hasWeight := c.src.Annotations[weightKey]
+	totalValue, hasTotal := c.src.Annotations[totalKey]
+	if !hasWeight {
+		c.report(totalKey, FindingConverted, "no effect without %s", weightKey)
+		return
+	}
+	weight, err := strconv.Atoi(weightValue)
+	if err != nil || weight < 0 {
+		c.report(weightKey, FindingUnsupported, "invalid weight")
+		return
+	}
+	total := 100
+	if hasTotal {
+		total, err = strconv.Atoi(totalValue)
+		if err != nil || total <= 0 {
+			c.report(totalKey, FindingUnsupported, "invalid weight total")
+			c.report(weightKey, FindingUnsupported, "invalid weight total")
+			return
+		}
+		c.report(totalKey, FindingConverted, "merged into %s", annotations.AlbCanaryWeight)
+	}
+	percent := (weight*100 + total/2) / total
+	c.dst.Annotations[annotations.AlbCanaryWeight] = strconv.Itoa(percent)
+	if weight*100%total != 0 {
+		c.report(weightKey, FindingPartial, "rounded to %d percent in %s", percent, annotations.AlbCanaryWeight)
+		return
+	}
+	c.report(weightKey, FindingConverted, "converted to %s", annotations.AlbCanaryWeight)
+}
+
+// convertRewriteTarget converts the rewrite target, whose $n capture groups are written as ${n} in ALB. Paths
+// are regular expressions for both nginx and ALB once rewrite-target is set.
+func convertRewriteTarget(c *ingressConverter, key, value string) {
+	target := captureGroupRegexp.ReplaceAllString(value, "$${${1}}")
+	c.dst.Annotations[annotations.AlbRewriteTarget] = target
+	if target != value {
+		c.report(key, FindingConverted, "converted to %s, capture groups are written as ${n}", annotations.AlbRewriteTarget)
+		return
+	}
+	c.report(key, FindingConverted, "converted to %s", annotations.AlbRewriteTarget)
+}
+
+func convertUseRegex(c *ingressConverter, key, value string) {
+	if value == "true" {
+		c.dst.Annotations[annotations.AlbUseRegexPath] = "true"
+	}
+	c.report(key, FindingConverted, "converted to %s", annotations.AlbUseRegexPath)
+}
+
+func convertBackendProtocol(c *ingressConverter, key, value string) {
+	switch strings.ToUpper(value) {
+	case "HTTP":
+		c.dst.Annotations[annotations.AlbBackendProtocol] = "http"
+	case "HTTPS":
+		c.dst.Annotations[annotations.AlbBackendProtocol] = "https"
+	case "GRPCS":
+		c.dst.Annotations[annotations.AlbBackendProtocol] = "grpc"
+	case "GRPC":
+		c.dst.Annotations[annotations.AlbBackendProtocol] = "grpc"
+		c.report(key, FindingPartial, "ALB communicates with gRPC backends over TLS")
+		return
+	default:
+		c.report(key, FindingUnsupported, "backend protocol %s has no ALB equivalent", value)
+		return
+	}
+	c.report(key, FindingConverted, "converted to %s", annotations.AlbBackendProtocol)
+}
+
+// convertSourceRange restricts the rules of the Ingress to the source CIDRs by SourceIp conditions. Requests
+// from other sources fall through to the next rules instead of being denied.
+func convertSourceRange(c *ingressConverter, _, _ string) {
+	var keys, cidrs []string
+	for _, name := range []string{nginxWhitelistSourceRange, nginxAllowlistSourceRange} {
+		if v, ok := c.src.Annotations[nginxKey(name)]; ok {
+			keys = append(keys, nginxKey(name))
+			cidrs = append(cidrs, splitAndTrim(v)...)
+		}
+	}
+	if c.src.Annotations[nginxKey(nginxCanary)] == "true" {
+		for _, key := range keys {
+			c.report(key, FindingUnsupported, "custom conditions are not allowed on canary Ingresses")
+		}
+		return
+	}
+	condition := sourceIpCondition{Type: util.RuleConditionFieldSourceIp}
+	condition.SourceIpConfig.Values = cidrs
+	for _, svc := range c.serviceNames() {
+		if err := c.appendCustomAnnotation(annotations.INGRESS_ALB_CONDITIONS_ANNOTATIONS, svc, []sourceIpCondition{condition}); err != nil {
+			for _, key := range keys {
+				c.report(key, FindingUnsupported, "%s", err.Error())
+			}
+			return
+		}
+	}
+	for _, key := range keys {
+		c.report(key, FindingPartial, "converted to SourceIp conditions, requests from other sources are "+
+			"forwarded by the next rules or the default action of the listener instead of denied")
+	}
+}
+
+// convertRedirect replaces the forwarding of the Ingress with a Redirect action, the permanent redirect takes
+// precedence over the temporal one as nginx does.
+func convertRedirect(c *ingressConverter, _, _ string) {
+	permanentKey, codeKey, temporalKey :=
+		nginxKey(nginxPermanentRedirect), nginxKey(nginxPermanentRedirectCode), nginxKey(nginxTemporalRedirect)
+	key, code := permanentKey, "301"
+	target, ok := c.src.Annotations[permanentKey]
+	if ok {
+		if v, ok := c.src.Annotations[codeKey]; ok {
+			code = v
+			c.report(codeKey, FindingConverted, "merged into the Redirect action")
+		}
+		if _, ok := c.src.Annotations[temporalKey]; ok {
+			c.report(temporalKey, FindingUnsupported, "ignored as %s takes precedence", permanentKey)
+		}
+	} else if target, ok = c.src.Annotations[temporalKey]; ok {
+		key, code = temporalKey, "302"
+		if _, ok := c.src.Annotations[codeKey]; ok {
+			c.report(codeKey, FindingConverted, "no effect without %s", permanentKey)
+		}
+	} else {
+		c.report(codeKey, FindingConverted, "no effect without %s", permanentKey)
+		return
+	}
+
+	if !validRedirectCodes[code] {
+		c.report(key, FindingUnsupported, "redirect code %s is not supported by ALB", code)
+		return
+	}
+	u, err := url.Parse(target)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") || strings.Contains(target, "$") {
+		c.report(key, FindingUnsupported, "the target must be an absolute http or https URL without nginx variables")
+		return
+	}
+	action := redirectAction{Type: util.RuleActionTypeRedirect}
+	action.RedirectConfig = redirectConfig{
+		Protocol: strings.ToUpper(u.Scheme),
+		Host:     u.Hostname(),
+		Port:     u.Port(),
+		Path:     u.Path,
+		Query:    u.RawQuery,
+		HttpCode: code,
+	}
+	if action.RedirectConfig.Port == "" {
+		action.RedirectConfig.Port = map[string]string{"http": "80", "https": "443"}[u.Scheme]
+	}
+	if action.RedirectConfig.Path == "" {
+		action.RedirectConfig.Path = "/"
+	}
+	for _, svc := range c.serviceNames() {
+		if err := c.appendCustomAnnotation(annotations.INGRESS_ALB_ACTIONS_ANNOTATIONS, svc, []redirectAction{action}); err != nil {
+			c.report(key, FindingUnsupported, "%s", err.Error())
+			return
+		}
+	}
+	c.useAnnotationBackends()
+	c.report(key, FindingConverted, "converted to a Redirect action with code %s", code)
+}
+
+// convertRateLimit limits the requests per second of each client IP. nginx limits each replica separately,
+// while ALB limits the whole instance.
+func convertRateLimit(c *ingressConverter, _, _ string) {
+	qps := 0
+	var keys []string
+	for _, limit := range []struct {
+		name    string
+		seconds int
+	}{{nginxLimitRps, 1}, {nginxLimitRpm, 60}} {
+		key := nginxKey(limit.name)
+		v, ok := c.src.Annotations[key]
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			c.report(key, FindingUnsupported, "invalid rate limit")
+			continue
+		}
+		keys = append(keys, key)
+		perSecond := (n + limit.seconds - 1) / limit.seconds
+		if qps == 0 || perSecond < qps {
+			qps = perSecond
+		}
+	}
+	if qps == 0 {
+		return
+	}
+	if qps > maxTrafficLimitQps {
+		qps = maxTrafficLimitQps
+	}
+	c.dst.Annotations[annotations.AlbTrafficLimitIpQps] = strconv.Itoa(qps)
+	for _, key := range keys {
+		c.report(key, FindingPartial, "converted to %s %d, which limits each client IP across the ALB "+
+			"instance instead of each nginx replica", annotations.AlbTrafficLimitIpQps, qps)
+	}
+}
+
+// convertAffinity converts the cookie affinity to the session persistence of ALB by inserted cookies
+func convertAffinity(c *ingressConverter, _, _ string) {
+	affinityKey := nginxKey(nginxAffinity)
+	cookieKeys := []string{nginxKey(nginxSessionCookieName), nginxKey(nginxSessionCookieMaxAge), nginxKey(nginxSessionCookieExpires)}
+	affinity, ok := c.src.Annotations[affinityKey]
+	if affinity != "cookie" {
+		if ok {
+			c.report(affinityKey, FindingUnsupported, "affinity %s has no ALB equivalent", affinity)
+		}
+		for _, key := range cookieKeys {
+			if _, ok := c.src.Annotations[key]; ok {
+				c.report(key, FindingUnsupported, "no effect without cookie affinity")
+			}
+		}
+		return
+	}
+	c.dst.Annotations[annotations.SessionStick] = "true"
+	c.dst.Annotations[annotations.SessionStickType] = "Insert"
+	c.report(affinityKey, FindingConverted, "converted to %s", annotations.SessionStick)
+
+	if _, ok := c.src.Annotations[nginxKey(nginxSessionCookieName)]; ok {
+		c.report(nginxKey(nginxSessionCookieName), FindingPartial, "ALB inserts the cookie SERVERID")
+	}
+	timeoutKey := ""
+	for _, key := range cookieKeys[1:] {
+		if _, ok := c.src.Annotations[key]; !ok {
+			continue
+		}
+		if timeoutKey != "" {
+			c.report(key, FindingUnsupported, "ignored as %s takes precedence", timeoutKey)
+			continue
+		}
+		timeout, err := strconv.Atoi(c.src.Annotations[key])
+		if err != nil || timeout <= 0 {
+			c.report(key, FindingUnsupported, "invalid cookie timeout")
+			continue
+		}
+		timeoutKey = key
+		if timeout > maxCookieTimeout {
+			c.dst.Annotations[annotations.CookieTimeout] = strconv.Itoa(maxCookieTimeout)
+			c.report(key, FindingPartial, "limited to %d seconds in %s", maxCookieTimeout, annotations.CookieTimeout)
+			continue
+		}
+		c.dst.Annotations[annotations.CookieTimeout] = strconv.Itoa(timeout)
+		c.report(key, FindingConverted, "converted to %s", annotations.CookieTimeout)
+	}
+}
+
+type sourceIpCondition struct {
+	Type           string `json:"Type"`
+	SourceIpConfig struct {
+		Values []string `json:"Values"`
+	} `json:"SourceIpConfig"`
+}
+
+type redirectAction struct {
+	Type           string         `json:"Type"`
+	RedirectConfig redirectConfig `json:"RedirectConfig"`
+}
+
+type redirectConfig struct {
+	Host     string `json:"Host"`
+	HttpCode string `json:"HttpCode"`
+	Path     string `json:"Path"`
+	Port     string `json:"Port"`
+	Protocol string `json:"Protocol"`
+	Query    string `json:"Query,omitempty"`
+}
+
+// appendCustomAnnotation appends the items of a slice to the JSON array of the conditions or actions annotation of svc
+func (c *ingressConverter) appendCustomAnnotation(format, svc string, items interface{}) error {
+	key := fmt.Sprintf(format, svc)
+	var merged []json.RawMessage
+	if v := c.dst.Annotations[key]; v != "" {
+		if err := json.Unmarshal([]byte(v), &merged); err != nil {
+			return fmt.Errorf("invalid annotation %s: %s", key, err.Error())
+		}
+	}
+	raw, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	var added []json.RawMessage
+	if err := json.Unmarshal(raw, &added); err != nil {
+		return err
+	}
+	out, err := json.Marshal(append(merged, added...))
+	if err != nil {
+		return err
+	}
+	c.dst.Annotations[key] = string(out)
+	return nil
+}
+
+// useAnnotationBackends makes the backends take the actions of the actions annotation instead of forwarding
+func (c *ingressConverter) useAnnotationBackends() {
+	for i := range c.dst.Spec.Rules {
+		if c.dst.Spec.Rules[i].HTTP == nil {
+			continue
+		}
+		for j := range c.dst.Spec.Rules[i].HTTP.Paths {
+			if svc := c.dst.Spec.Rules[i].HTTP.Paths[j].Backend.Service; svc != nil {
+				svc.Port = networking.ServiceBackendPort{Name: useAnnotationPortName}
+			}
+		}
+	}
+}
+
+func splitAndTrim(values string) []string {
+	var result []string
+	for _, v := range strings.Split(values, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,231 @@
+package nginx
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/alibaba-load-balancer-controller/pkg/apis/alibabacloud/v1"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/annotations"
+	"k8s.io/alibaba-load-balancer-controller/pkg/migration"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	DefaultAlbConfigName    = "alb"
+	DefaultIngressClassName = "alb"
+	DefaultAddressType      = "Internet"
+)
+
+const (
+	// FindingConverted means the annotation is translated into ALB equivalents with the same behavior
+	FindingConverted = "Converted"
+	// FindingPartial means the annotation is translated into ALB equivalents with a different behavior
+	FindingPartial = "Partial"
+	// FindingUnsupported means the annotation has no ALB equivalent and is dropped
+	FindingUnsupported = "Unsupported"
+)
+
+// Options configures the conversion of ingress-nginx Ingresses
+type Options struct {
+	// AlbConfigName is the name of the generated AlbConfig
+	AlbConfigName string
+	// IngressClassName is the name of the generated IngressClass used by the converted Ingresses
+	IngressClassName string
+	// AddressType is the address type of the ALB instance, Internet or Intranet
+	AddressType string
+	// VSwitchIDs are the vSwitches of the ALB instance, which must be in two zones at least
+	VSwitchIDs []string
+	// NameSuffix is appended to the names of the converted Ingresses, so that they can run beside the nginx ones
+	NameSuffix string
+}
+
+func (o *Options) setDefaults() {
+	if o.AlbConfigName == "" {
+		o.AlbConfigName = DefaultAlbConfigName
+	}
+	if o.IngressClassName == "" {
+		o.IngressClassName = DefaultIngressClassName
+	}
+	if o.AddressType == "" {
+		o.AddressType = DefaultAddressType
+	}
+}
+
+// Finding reports how an nginx annotation of an Ingress is converted
+type Finding struct {
+	Ingress    string
+	Annotation string
+	Value      string
+	Status     string
+	Message    string
+}
+
+// Result is the ALB equivalent of a set of ingress-nginx Ingresses
+type Result struct {
+	IngressClass *networking.IngressClass
+	AlbConfig    *v1.AlbConfig
+	Ingresses    []*networking.Ingress
+	Findings     []Finding
+}
+
+// IsNginxIngress returns whether ing belongs to the ingress-nginx IngressClass class
+func IsNginxIngress(ing *networking.Ingress, class string) bool {
+	if ing.Spec.IngressClassName != nil {
+		return *ing.Spec.IngressClassName == class
+	}
+	return ing.Annotations[util.IngressClass] == class
+}
+
+// Convert translates ingress-nginx Ingresses into ALB Ingresses, along with the AlbConfig and IngressClass
+// they join. Annotations without ALB equivalents are dropped and reported as unsupported findings.
+func Convert(ings []*networking.Ingress, opts Options) *Result {
+	opts.setDefaults()
+	result := &Result{}
+	listenHTTPS := false
+	for _, ing := range ings {
+		c := newIngressConverter(ing, opts)
+		c.convert()
+		result.Ingresses = append(result.Ingresses, c.dst)
+		result.Findings = append(result.Findings, c.findings...)
+		listenHTTPS = listenHTTPS || c.listenHTTPS
+	}
+	result.IngressClass = migration.NewIngressClass(opts.IngressClassName, opts.AlbConfigName)
+	result.AlbConfig = buildAlbConfig(opts, listenHTTPS)
+	return result
+}
+
+func buildAlbConfig(opts Options, listenHTTPS bool) *v1.AlbConfig {
+	albconfig := &v1.AlbConfig{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1.SchemeGroupVersion.String(), Kind: "AlbConfig"},
+		ObjectMeta: metav1.ObjectMeta{Name: opts.AlbConfigName},
+		Spec: v1.AlbConfigSpec{
+			LoadBalancer: &v1.LoadBalancerSpec{
+				Name:        opts.AlbConfigName,
+				AddressType: opts.AddressType,
+			},
+			Listeners: []*v1.ListenerSpec{{Port: intstr.FromInt(80), Protocol: util.ListenerProtocolHTTP}},
+		},
+	}
+	for _, vsw := range opts.VSwitchIDs {
+		albconfig.Spec.LoadBalancer.ZoneMappings = append(albconfig.Spec.LoadBalancer.ZoneMappings, v1.ZoneMapping{VSwitchId: vsw})
+	}
+	if listenHTTPS {
+		albconfig.Spec.Listeners = append(albconfig.Spec.Listeners,
+			&v1.ListenerSpec{Port: intstr.FromInt(443), Protocol: util.ListenerProtocolHTTPS})
+	}
+	return albconfig
+}
+
+type ingressConverter struct {
+	src      *networking.Ingress
+	dst      *networking.Ingress
+	key      string
+	findings []Finding
+	// listenHTTPS is whether the Ingress listens on HTTPS
+	listenHTTPS bool
+	// handled are the nginx annotations converted along with others
+	handled map[string]bool
+}
+
+func newIngressConverter(ing *networking.Ingress, opts Options) *ingressConverter {
+	dst := &networking.Ingress{
+		TypeMeta: metav1.TypeMeta{APIVersion: networking.SchemeGroupVersion.String(), Kind: "Ingress"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        ing.Name + opts.NameSuffix,
+			Namespace:   ing.Namespace,
+			Labels:      ing.Labels,
+			Annotations: make(map[string]string),
+		},
+		Spec: *ing.Spec.DeepCopy(),
+	}
+	className := opts.IngressClassName
+	dst.Spec.IngressClassName = &className
+	for k, v := range ing.Annotations {
+		if k == util.IngressClass || strings.HasPrefix(k, annotations.AnnotationNginxPrefix) ||
+			k == "kubectl.kubernetes.io/last-applied-configuration" {
+			continue
+		}
+		dst.Annotations[k] = v
+	}
+	return &ingressConverter{
+		src:     ing,
+		dst:     dst,
+		key:     util.NamespacedName(ing).String(),
+		handled: make(map[string]bool),
+	}
+}
+
+func (c *ingressConverter) convert() {
+	c.convertPathTypes()
+	c.convertSslRedirect()
+	var keys []string
+	for k := range c.src.Annotations {
+		if strings.HasPrefix(k, annotations.AnnotationNginxPrefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if c.handled[k] {
+			continue
+		}
+		if convert, ok := annotationConverters[strings.TrimPrefix(k, annotations.AnnotationNginxPrefix)]; ok {
+			convert(c, k, c.src.Annotations[k])
+			continue
+		}
+		c.report(k, FindingUnsupported, "no ALB equivalent")
+	}
+}
+
+// convertPathTypes converts the paths matched by prefix in nginx to the Prefix pathType, since paths of the
+// ImplementationSpecific pathType are matched exactly by ALB.
+func (c *ingressConverter) convertPathTypes() {
+	prefix := networking.PathTypePrefix
+	for i := range c.dst.Spec.Rules {
+		if c.dst.Spec.Rules[i].HTTP == nil {
+			continue
+		}
+		for j := range c.dst.Spec.Rules[i].HTTP.Paths {
+			path := &c.dst.Spec.Rules[i].HTTP.Paths[j]
+			if path.PathType == nil || *path.PathType == networking.PathTypeImplementationSpecific {
+				path.PathType = &prefix
+			}
+		}
+	}
+}
+
+func (c *ingressConverter) report(annotation, status, format string, args ...interface{}) {
+	c.handled[annotation] = true
+	c.findings = append(c.findings, Finding{
+		Ingress:    c.key,
+		Annotation: annotation,
+		Value:      c.src.Annotations[annotation],
+		Status:     status,
+		Message:    fmt.Sprintf(format, args...),
+	})
+}
+
+// serviceNames returns the names of the Services the paths of the Ingress forward to
+func (c *ingressConverter) serviceNames() []string {
+	names := make(map[string]bool)
+	for _, rule := range c.dst.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service != nil {
+				names[path.Backend.Service.Name] = true
+			}
+		}
+	}
+	var list []string
+	for name := range names {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list
+}
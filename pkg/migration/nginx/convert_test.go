@@ -0,0 +1,132 @@
+package nginx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/alibaba-load-balancer-controller/pkg/controller/ingress/reconcile/annotations"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newNginxIngress(name string, anns map[string]string, tls bool) *networking.Ingress {
+	class := "nginx"
+	ing := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Annotations: map[string]string{}},
+		Spec: networking.IngressSpec{
+			IngressClassName: &class,
+			Rules: []networking.IngressRule{{
+				Host: "demo.example.com",
+				IngressRuleValue: networking.IngressRuleValue{HTTP: &networking.HTTPIngressRuleValue{
+					Paths: []networking.HTTPIngressPath{{
+						Path: "/api(/|$)(.*)",
+						Backend: networking.IngressBackend{Service: &networking.IngressServiceBackend{
+							Name: "api", Port: networking.ServiceBackendPort{Number: 80},
+						}},
+					}},
+				}},
+			}},
+		},
+	}
+	for k, v := range anns {
+		ing.Annotations[nginxKey(k)] = v
+	}
+	if tls {
+		ing.Spec.TLS = []networking.IngressTLS{{Hosts: []string{"demo.example.com"}, SecretName: "demo-tls"}}
+	}
+	return ing
+}
+
+func findingOf(findings []Finding, name string) Finding {
+	for _, f := range findings {
+		if f.Annotation == nginxKey(name) {
+			return f
+		}
+	}
+	return Finding{}
+}
+
+func TestConvert(t *testing.T) {
+	result := Convert([]*networking.Ingress{
+		newNginxIngress("api", map[string]string{
+			nginxRewriteTarget:        "/$2",
+			nginxWhitelistSourceRange: "10.0.0.0/8, 192.168.0.0/16",
+			nginxLimitRps:             "20",
+			nginxLimitRpm:             "600",
+			nginxEnableCors:           "true",
+			nginxCorsAllowOrigin:      "https://a.example.com",
+			"proxy-body-size":         "8m",
+		}, true),
+		newNginxIngress("api-canary", map[string]string{
+			nginxCanary:               "true",
+			nginxCanaryWeight:         "30",
+			nginxCanaryWeightTotal:    "200",
+			nginxWhitelistSourceRange: "10.0.0.0/8",
+		}, false),
+		newNginxIngress("old", map[string]string{
+			nginxPermanentRedirect:     "https://new.example.com/home?from=old",
+			nginxPermanentRedirectCode: "308",
+		}, false),
+	}, Options{NameSuffix: "-alb"})
+
+	assert.Equal(t, "alb", result.AlbConfig.Name)
+	assert.Len(t, result.AlbConfig.Spec.Listeners, 2)
+	assert.Equal(t, "alb", result.IngressClass.Spec.Parameters.Name)
+
+	ing := result.Ingresses[0]
+	assert.Equal(t, "api-alb", ing.Name)
+	assert.Equal(t, "alb", *ing.Spec.IngressClassName)
+	assert.Equal(t, networking.PathTypePrefix, *ing.Spec.Rules[0].HTTP.Paths[0].PathType)
+	assert.Equal(t, map[string]string{
+		annotations.AlbRewriteTarget:               "/${2}",
+		annotations.AlbTrafficLimitIpQps:           "10",
+		annotations.AlbEnableCors:                  "true",
+		annotations.AlbCorsAllowOrigin:             "https://a.example.com",
+		annotations.AlbSslRedirect:                 "true",
+		annotations.ListenPorts:                    listenPortsHTTPAndHTTPS,
+		"alb.ingress.kubernetes.io/conditions.api": `[{"Type":"SourceIp","SourceIpConfig":{"Values":["10.0.0.0/8","192.168.0.0/16"]}}]`,
+	}, ing.Annotations)
+	assert.Equal(t, FindingUnsupported, findingOf(result.Findings, "proxy-body-size").Status)
+	assert.Equal(t, FindingPartial, findingOf(result.Findings, nginxWhitelistSourceRange).Status)
+
+	canary := result.Ingresses[1]
+	assert.Equal(t, "15", canary.Annotations[annotations.AlbCanaryWeight])
+	assert.Equal(t, "true", canary.Annotations[annotations.AlbCanary])
+	assert.NotContains(t, canary.Annotations, "alb.ingress.kubernetes.io/conditions.api")
+	assert.NotContains(t, canary.Annotations, annotations.AlbSslRedirect)
+
+	redirect := result.Ingresses[2]
+	assert.Equal(t, useAnnotationPortName, redirect.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Name)
+	assert.Equal(t, `[{"Type":"Redirect","RedirectConfig":{"Host":"new.example.com","HttpCode":"308","Path":"/home",`+
+		`"Port":"443","Protocol":"HTTPS","Query":"from=old"}}]`, redirect.Annotations["alb.ingress.kubernetes.io/actions.api"])
+}
+
+func TestReadIngresses(t *testing.T) {
+	manifests := `
+apiVersion: v1
+kind: Service
+metadata:
+  name: api
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: networking.k8s.io/v1
+  kind: Ingress
+  metadata:
+    name: a
+- apiVersion: networking.k8s.io/v1
+  kind: Ingress
+  metadata:
+    name: b
+`
+	ings, err := ReadIngresses(strings.NewReader(manifests))
+	assert.NoError(t, err)
+	if assert.Len(t, ings, 2) {
+		assert.Equal(t, "b", ings[1].Name)
+	}
+
+	_, err = ReadIngresses(strings.NewReader("apiVersion: extensions/v1beta1\nkind: Ingress\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,98 @@
+package nginx
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"k8s.io/alibaba-load-balancer-controller/pkg/migration"
+	networking "k8s.io/api/networking/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+type manifestHeader struct {
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Items      []json.RawMessage `json:"items"`
+}
+
+// ReadIngresses reads the networking.k8s.io/v1 Ingresses of YAML or JSON manifests, including the items of
+// lists. Objects of other kinds are skipped.
+func ReadIngresses(r io.Reader) ([]*networking.Ingress, error) {
+	var ings []*networking.Ingress
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(r))
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			return ings, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		raw, err := yaml.YAMLToJSON(doc)
+		if err != nil {
+			return nil, err
+		}
+		if string(raw) == "null" {
+			continue
+		}
+		found, err := readIngresses(raw)
+		if err != nil {
+			return nil, err
+		}
+		ings = append(ings, found...)
+	}
+}
+
+func readIngresses(raw []byte) ([]*networking.Ingress, error) {
+	header := manifestHeader{}
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return nil, err
+	}
+	if strings.HasSuffix(header.Kind, "List") {
+		var ings []*networking.Ingress
+		for _, item := range header.Items {
+			found, err := readIngresses(item)
+			if err != nil {
+				return nil, err
+			}
+			ings = append(ings, found...)
+		}
+		return ings, nil
+	}
+	if header.Kind != "Ingress" {
+		return nil, nil
+	}
+	if header.APIVersion != networking.SchemeGroupVersion.String() {
+		return nil, fmt.Errorf("unsupported apiVersion %s of Ingress, expect %s",
+			header.APIVersion, networking.SchemeGroupVersion.String())
+	}
+	ing := &networking.Ingress{}
+	if err := json.Unmarshal(raw, ing); err != nil {
+		return nil, err
+	}
+	return []*networking.Ingress{ing}, nil
+}
+
+// WriteManifests writes the IngressClass, AlbConfig and Ingresses of result as YAML documents
+func WriteManifests(w io.Writer, result *Result) error {
+	objects := []interface{}{result.IngressClass, result.AlbConfig}
+	for _, ing := range result.Ingresses {
+		objects = append(objects, ing)
+	}
+	return migration.WriteManifests(w, objects...)
+}
+
+// WriteReport writes the findings as a table
+func WriteReport(w io.Writer, findings []Finding) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "INGRESS\tANNOTATION\tSTATUS\tMESSAGE")
+	for _, f := range findings {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", f.Ingress, f.Annotation, f.Status, f.Message)
+	}
+	return tw.Flush()
+}
@@ -33,6 +33,8 @@ type ALBListenerSpec struct {
 	DefaultActions      []Action            `json:"DefaultActions" xml:"DefaultActions"`
 	Certificates        []Certificate       `json:"Certificates" xml:"Certificates"`
 	CaCertificates      []Certificate       `json:"CaCertificates" xml:"CaCertificates"`
+	CaEnabled           bool                `json:"CaEnabled" xml:"CaEnabled"`
+	CaManaged           bool                `json:"CaManaged" xml:"CaManaged"` // CA certificates are uploaded from caBundle
 	GzipEnabled         bool                `json:"GzipEnabled" xml:"GzipEnabled"`
 	Http2Enabled        bool                `json:"Http2Enabled" xml:"Http2Enabled"`
 	IdleTimeout         int                 `json:"IdleTimeout" xml:"IdleTimeout"`
@@ -72,6 +74,7 @@ type ALBServerGroupSpec struct {
 	Tags                     []ALBTag            `json:"Tags" xml:"Tags"`
 	UpstreamKeepaliveEnabled bool                `json:"UpstreamKeepaliveEnabled" xml:"UpstreamKeepaliveEnabled"`
 	UchConfig                UchConfig           `json:"UchConfig" xml:"UchConfig"`
+	SlowStartConfig          SlowStartConfig     `json:"SlowStartConfig" xml:"SlowStartConfig"`
 }
 
 type AccessLogConfig struct {
@@ -177,6 +180,11 @@ type UchConfig struct {
 	Value string `json:"Value" xml:"Value"`
 }
 
+type SlowStartConfig struct {
+	SlowStartEnabled  bool `json:"SlowStartEnabled" xml:"SlowStartEnabled"`
+	SlowStartDuration int  `json:"SlowStartDuration" xml:"SlowStartDuration"`
+}
+
 type Action struct {
 	Order               int                  `json:"Order" xml:"Order"`
 	Type                string               `json:"Type" xml:"Type"`
@@ -1,6 +1,8 @@
 package alb
 
 import (
+	"fmt"
+
 	albsdk "github.com/aliyun/alibaba-cloud-sdk-go/services/alb"
 	"k8s.io/alibaba-load-balancer-controller/pkg/model/alb/core"
 )
@@ -42,9 +44,26 @@ type ListenerRuleStatus struct {
 
 type ListenerRuleSpec struct {
 	ListenerID core.StringToken `json:"listenerID"`
+	// Origin is the object the rule is built from
+	Origin RuleOrigin `json:"origin"`
 	ALBListenerRuleSpec
 }
 
+const (
+	RuleOriginKindIngress  = "Ingress"
+	RuleOriginKindAlbRoute = "AlbRoute"
+)
+
+type RuleOrigin struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+func (o RuleOrigin) String() string {
+	return fmt.Sprintf("%s %s/%s", o.Kind, o.Namespace, o.Name)
+}
+
 type ResAndSDKListenerRulePair struct {
 	ResLR *ListenerRule
 	SdkLR *albsdk.Rule
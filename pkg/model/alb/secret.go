@@ -69,6 +69,60 @@ func (sc *SecretCertificate) GetCertificateId(ctx context.Context) (string, erro
 	return sc.CertIdentifier().Resolve(ctx)
 }
 
+type SecretCACertificate struct {
+	core.ResourceMeta `json:"-"`
+
+	Spec SecretCACertificateSpec `json:"spec"`
+
+	Status *SecretCertificateStatus `json:"status,omitempty"`
+}
+
+func NewSecretCACertificate(stack core.Manager, id string, spec SecretCACertificateSpec) *SecretCACertificate {
+	sc := &SecretCACertificate{
+		ResourceMeta: core.NewResourceMeta(stack, "ALIYUN::ALB::CACERTIFICATE", id),
+		Spec:         spec,
+		Status:       nil,
+	}
+	_ = stack.AddResource(sc)
+	return sc
+}
+
+func (sc *SecretCACertificate) SetStatus(status SecretCertificateStatus) {
+	sc.Status = &status
+}
+
+func (sc *SecretCACertificate) CertIdentifier() core.StringToken {
+	return core.NewResourceFieldStringToken(sc, "status/certIdentifier",
+		func(ctx context.Context, res core.Resource, fieldPath string) (s string, err error) {
+			sc := res.(*SecretCACertificate)
+			if sc.Status == nil {
+				return "", errors.Errorf("SecretCACertificate is not fulfilled yet: %v", sc.ID())
+			}
+			return sc.Status.CertIdentifier, nil
+		},
+	)
+}
+
+// SecretCACertificateSpec is the ca bundle read from a Secret or ConfigMap
+type SecretCACertificateSpec struct {
+	CertName    string `json:"certName"`
+	Certificate string `json:"-"`
+}
+
+func (sc *SecretCACertificate) SetDefault() {
+}
+
+func (sc *SecretCACertificate) UnsetDefault() {
+}
+
+func (sc *SecretCACertificate) GetIsDefault() bool {
+	return false
+}
+
+func (sc *SecretCACertificate) GetCertificateId(ctx context.Context) (string, error) {
+	return sc.CertIdentifier().Resolve(ctx)
+}
+
 type FixedCertificate struct {
 	IsDefault     bool   `json:"IsDefault" xml:"IsDefault"`
 	CertificateId string `json:"CertificateId" xml:"CertificateId"`
@@ -52,6 +52,7 @@ type ServerGroupWithIngress struct {
 const (
 	ECSBackendType = "ecs"
 	ENIBackendType = "eni"
+	IPBackendType  = "ip"
 )
 
 const (
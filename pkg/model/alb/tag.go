@@ -8,6 +8,9 @@ type ServerGroupWithTags struct {
 	albsdk.ServerGroup
 	Servers []albsdk.BackendServer
 	Tags    map[string]string
+
+	// SlowStartConfig is read from the response, the sdk does not parse it
+	SlowStartConfig SlowStartConfig
 }
 type AlbLoadBalancerWithTags struct {
 	albsdk.LoadBalancer
@@ -17,3 +17,13 @@ type CertificateInfo struct {
 	SerialNo        string `json:"SerialNo" xml:"SerialNo"`
 	Sans            string `json:"Sans" xml:"Sans"`
 }
+
+// CACertificateInfo is a nested struct in cas ca certificate response
+type CACertificateInfo struct {
+	Identifier string `json:"Identifier" xml:"Identifier"`
+	Alias      string `json:"Alias" xml:"Alias"`
+	CommonName string `json:"CommonName" xml:"CommonName"`
+	Sha2       string `json:"Sha2" xml:"Sha2"`
+	BeforeDate int64  `json:"BeforeDate" xml:"BeforeDate"`
+	AfterDate  int64  `json:"AfterDate" xml:"AfterDate"`
+}
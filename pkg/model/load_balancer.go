@@ -70,6 +70,7 @@ const (
 const (
 	ECSBackendType = "ecs"
 	ENIBackendType = "eni"
+	IPBackendType  = "ip"
 )
 
 const ModificationProtectionReason = "managed.by.ack"
@@ -0,0 +1,58 @@
+package nlb
+
+import (
+	"context"
+	"sync"
+)
+
+// status of the async jobs of NLB
+const (
+	JobStatusSucceeded  = "Succeeded"
+	JobStatusFailed     = "Failed"
+	JobStatusProcessing = "Processing"
+)
+
+// ApiCreateLoadBalancer has no job, the nlb is tracked by its id until it is not provisioning
+const ApiCreateLoadBalancer = "CreateLoadBalancer"
+
+// AsyncJob is an async operation of NLB which is not finished yet
+type AsyncJob struct {
+	// Api is the OpenAPI started the job
+	Api string
+	// Id is the job id, or the nlb id for ApiCreateLoadBalancer
+	Id string
+}
+
+type asyncJobTrackerKey struct{}
+
+// AsyncJobTracker collects the async jobs not finished during a reconcile.
+// If a tracker is in the context, the provider returns an error instead of polling an unfinished job,
+// and the reconcile is requeued to resume the job.
+type AsyncJobTracker struct {
+	lock sync.Mutex
+	jobs []AsyncJob
+}
+
+// WithAsyncJobTracker returns a copy of ctx with tracker
+func WithAsyncJobTracker(ctx context.Context, tracker *AsyncJobTracker) context.Context {
+	return context.WithValue(ctx, asyncJobTrackerKey{}, tracker)
+}
+
+// AsyncJobTrackerFrom returns the tracker in ctx, or nil if the jobs should be polled
+func AsyncJobTrackerFrom(ctx context.Context) *AsyncJobTracker {
+	tracker, _ := ctx.Value(asyncJobTrackerKey{}).(*AsyncJobTracker)
+	return tracker
+}
+
+func (t *AsyncJobTracker) Add(job AsyncJob) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.jobs = append(t.jobs, job)
+}
+
+// Jobs returns the unfinished jobs in the order they were added
+func (t *AsyncJobTracker) Jobs() []AsyncJob {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return append([]AsyncJob(nil), t.jobs...)
+}
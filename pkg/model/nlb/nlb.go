@@ -68,6 +68,19 @@ const (
 	IpServerGroupType       = ServerGroupType("Ip")
 )
 
+// Schedulers are the scheduling algorithms of nlb server groups
+var Schedulers = []string{"Wrr", "Rr", "Sch", "Tch"}
+
+// GetSchedulerType returns the scheduler of nlb server groups matching the scheduler case-insensitively
+func GetSchedulerType(scheduler string) (string, bool) {
+	for _, s := range Schedulers {
+		if strings.EqualFold(scheduler, s) {
+			return s, true
+		}
+	}
+	return "", false
+}
+
 type ServerType string
 
 const (
@@ -203,6 +216,10 @@ type ZoneMapping struct {
 	ZoneId       string
 	IPv4Addr     string
 	AllocationId string
+
+	// auto-generated parameters
+	PublicIPv4Addr string
+	IPv6Addr       string
 }
 
 type HealthCheckConfig struct {
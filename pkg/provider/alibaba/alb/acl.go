@@ -649,6 +649,166 @@ func (m *ALBProvider) getAclById(traceID interface{}, aclId string) (albsdk.Acl,
 	return acls[0], nil
 }
 
+// FindAclByName returns the acl named aclName, or nil if it does not exist
+func (m *ALBProvider) FindAclByName(ctx context.Context, aclName string) (*albsdk.Acl, error) {
+	traceID := ctx.Value(util.TraceID)
+
+	listAclsReq := albsdk.CreateListAclsRequest()
+	listAclsReq.AclNames = &[]string{aclName}
+	startTime := time.Now()
+	m.logger.V(util.MgrLogLevel).Info("listing acls",
+		"aclName", aclName,
+		"traceID", traceID,
+		"startTime", startTime,
+		util.Action, util.ListAcl)
+	listAclResp, err := m.auth.ALB.ListAcls(listAclsReq)
+	if err != nil {
+		return nil, err
+	}
+	m.logger.V(util.MgrLogLevel).Info("listed acls",
+		"aclName", aclName,
+		"traceID", traceID,
+		"requestID", listAclResp.RequestId,
+		"elapsedTime", time.Since(startTime).Milliseconds(),
+		util.Action, util.ListAcl)
+	for i := range listAclResp.Acls {
+		if listAclResp.Acls[i].AclName == aclName {
+			return &listAclResp.Acls[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// ListAclTags returns the tags of the acl
+func (m *ALBProvider) ListAclTags(ctx context.Context, sdkAclID string) (map[string]string, error) {
+	traceID := ctx.Value(util.TraceID)
+
+	listTagReq := albsdk.CreateListTagResourcesRequest()
+	listTagReq.ResourceId = &[]string{sdkAclID}
+	listTagReq.ResourceType = acResourceType
+	tags := make(map[string]string)
+	for {
+		startTime := time.Now()
+		m.logger.V(util.MgrLogLevel).Info("listing acl tags",
+			"aclID", sdkAclID,
+			"traceID", traceID,
+			"startTime", startTime,
+			util.Action, util.ListALBTagResources)
+		listTagResp, err := m.auth.ALB.ListTagResources(listTagReq)
+		if err != nil {
+			return nil, err
+		}
+		m.logger.V(util.MgrLogLevel).Info("listed acl tags",
+			"aclID", sdkAclID,
+			"traceID", traceID,
+			"requestID", listTagResp.RequestId,
+			"elapsedTime", time.Since(startTime).Milliseconds(),
+			util.Action, util.ListALBTagResources)
+		for _, tag := range listTagResp.TagResources {
+			tags[tag.TagKey] = tag.TagValue
+		}
+		if listTagResp.NextToken == "" {
+			break
+		}
+		listTagReq.NextToken = listTagResp.NextToken
+	}
+	return tags, nil
+}
+
+// CreateAclWithEntries creates the acl with its entries and tags without associating it with a listener
+func (m *ALBProvider) CreateAclWithEntries(ctx context.Context, resAcl *alb.Acl, tags map[string]string) (alb.AclStatus, error) {
+	traceID := ctx.Value(util.TraceID)
+
+	aclResp, err := m.createAcl(traceID, resAcl)
+	if err != nil {
+		return alb.AclStatus{}, err
+	}
+	if err := m.waitAclStatus(traceID, aclResp.AclId); err != nil {
+		return alb.AclStatus{}, err
+	}
+	if err := m.tagAcl(traceID, aclResp.AclId, tags); err != nil {
+		m.deleteAcl(traceID, aclResp.AclId)
+		return alb.AclStatus{}, err
+	}
+	if err := m.addEntriesToAcl(traceID, resAcl.Spec.AclEntries, resAcl, aclResp.AclId); err != nil {
+		m.deleteAcl(traceID, aclResp.AclId)
+		return alb.AclStatus{}, err
+	}
+	if err := m.waitAclStatus(traceID, aclResp.AclId); err != nil {
+		return alb.AclStatus{}, err
+	}
+	return buildResAclStatus(aclResp.AclId), nil
+}
+
+func (m *ALBProvider) tagAcl(traceID interface{}, aclID string, tags map[string]string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	sdkTags := transTagMapToSDKTagResourcesTagList(tags)
+	tagReq := albsdk.CreateTagResourcesRequest()
+	tagReq.Tag = &sdkTags
+	tagReq.ResourceId = &[]string{aclID}
+	tagReq.ResourceType = acResourceType
+	startTime := time.Now()
+	m.logger.V(util.MgrLogLevel).Info("tagging acl",
+		"aclID", aclID,
+		"traceID", traceID,
+		"startTime", startTime,
+		util.Action, util.TagALBResource)
+	tagResp, err := m.auth.ALB.TagResources(tagReq)
+	if err != nil {
+		return err
+	}
+	m.logger.V(util.MgrLogLevel).Info("tagged acl",
+		"aclID", aclID,
+		"traceID", traceID,
+		"requestID", tagResp.RequestId,
+		"elapsedTime", time.Since(startTime).Milliseconds(),
+		util.Action, util.TagALBResource)
+	return nil
+}
+
+// UpdateAclEntries adds the missing entries to the acl and removes the entries not in resAcl
+func (m *ALBProvider) UpdateAclEntries(ctx context.Context, resAcl *alb.Acl, sdkAclID string) error {
+	traceID := ctx.Value(util.TraceID)
+
+	sdkAclEntries, err := m.listAclEntries(traceID, sdkAclID)
+	if err != nil {
+		return err
+	}
+	unmatchResAclEntries, unmatchSDKAclEntries := m.matchResAndSDKAclEntries(resAcl.Spec.AclEntries, sdkAclEntries)
+	if len(unmatchResAclEntries) > 0 {
+		m.logger.V(util.SynLogLevel).Info("update acl entries",
+			"aclID", sdkAclID,
+			"unmatchedResAcls", unmatchResAclEntries,
+			"traceID", traceID)
+		if err := m.addEntriesToAcl(traceID, unmatchResAclEntries, resAcl, sdkAclID); err != nil {
+			return err
+		}
+		if err := m.waitAclStatus(traceID, sdkAclID); err != nil {
+			return err
+		}
+	}
+	if len(unmatchSDKAclEntries) > 0 {
+		m.logger.V(util.SynLogLevel).Info("update acl entries",
+			"aclID", sdkAclID,
+			"unmatchedSDKAcls", unmatchSDKAclEntries,
+			"traceID", traceID)
+		if err := m.removeEntriesFromAcl(traceID, unmatchSDKAclEntries, resAcl, sdkAclID); err != nil {
+			return err
+		}
+		if err := m.waitAclStatus(traceID, sdkAclID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteAclByID deletes the acl, which fails if the acl is still associated with listeners
+func (m *ALBProvider) DeleteAclByID(ctx context.Context, sdkAclID string) error {
+	return m.deleteAcl(ctx.Value(util.TraceID), sdkAclID)
+}
+
 func isQuotaExceededError(err error) bool {
 	return strings.Contains(err.Error(), "QuotaExceeded.AclsNum")
 }
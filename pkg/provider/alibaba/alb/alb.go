@@ -812,6 +812,10 @@ var deleteALBLoadBalancerFunc = func(ctx context.Context, m *ALBProvider, lbID s
 	return lsResp, nil
 }
 
+func (m *ALBProvider) GetALB(ctx context.Context, lbID string) (*albsdk.GetLoadBalancerAttributeResponse, error) {
+	return getALBLoadBalancerAttributeFunc(ctx, lbID, m.auth, m.logger)
+}
+
 func (m *ALBProvider) DeleteALB(ctx context.Context, lbID string) error {
 	getLbResp, err := getALBLoadBalancerAttributeFunc(ctx, lbID, m.auth, m.logger)
 	if err != nil {
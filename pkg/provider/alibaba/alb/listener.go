@@ -134,6 +134,12 @@ var getALBListenerAttributeFunc = func(ctx context.Context, lsID string, auth *b
 	return getLsResp, nil
 }
 
+// ListALBListenerCertificates returns all the certificates of the listener, including the additional
+// certificates and the CA certificates, which are not returned by GetListenerAttribute
+func (m *ALBProvider) ListALBListenerCertificates(ctx context.Context, lsID string) ([]albsdk.CertificateModel, error) {
+	return m.listListenerCerts(ctx, lsID)
+}
+
 func (m *ALBProvider) listListenerCerts(ctx context.Context, lsID string) ([]albsdk.CertificateModel, error) {
 	traceID := ctx.Value(util.TraceID)
 
@@ -375,6 +381,9 @@ func buildSDKCreateListenerRequest(lsSpec albmodel.ListenerSpec) (*albsdk.Create
 				return nil, fmt.Errorf("invalid https listener SecurityPolicyId: %s", lsSpec.SecurityPolicyId)
 			}
 			createLsReq.SecurityPolicyId = lsSpec.SecurityPolicyId
+			if lsSpec.CaEnabled {
+				createLsReq.CaEnabled = requests.NewBoolean(true)
+			}
 		}
 		createLsReq.CaCertificates = transSDKCaCertificatesToCreateLs(ctx, lsSpec.CaCertificates)
 
@@ -515,6 +524,7 @@ func (m *ALBProvider) updateListenerAttribute(ctx context.Context, resLS *albmod
 		isSecurityPolicyIdNeedUpdate,
 		isIdleTimeoutNeedUpdate,
 		isListenerDescriptionNeedUpdate,
+		isCaConfigNeedUpdate,
 		isCertificatesNeedUpdate bool
 	)
 
@@ -610,6 +620,16 @@ func (m *ALBProvider) updateListenerAttribute(ctx context.Context, resLS *albmod
 					"traceID", traceID)
 				isSecurityPolicyIdNeedUpdate = true
 			}
+			isCaConfigNeedUpdate, err = isListenerCaConfigNeedUpdate(ctx, resLS, m.listenerIdSdkCertsMap[sdkLs.ListenerId])
+			if err != nil {
+				return err
+			}
+			if isCaConfigNeedUpdate {
+				m.logger.V(util.MgrLogLevel).Info("CaCertificates update",
+					"res", resLS.Spec.CaCertificates,
+					"listenerID", sdkLs.ListenerId,
+					"traceID", traceID)
+			}
 		}
 
 		desiredDefaultCerts, desiredExtraCerts = buildSDKCertificates(ctx, resLS.Spec.Certificates)
@@ -662,7 +682,7 @@ func (m *ALBProvider) updateListenerAttribute(ctx context.Context, resLS *albmod
 	if !isGzipEnabledNeedUpdate && !isQuicConfigUpdate && !isHttp2EnabledNeedUpdate &&
 		!isDefaultActionsNeedUpdate && !isRequestTimeoutNeedUpdate && !isXForwardedForConfigNeedUpdate &&
 		!isSecurityPolicyIdNeedUpdate && !isIdleTimeoutNeedUpdate && !isListenerDescriptionNeedUpdate &&
-		!isCertificatesNeedUpdate {
+		!isCaConfigNeedUpdate && !isCertificatesNeedUpdate {
 		return nil
 	}
 
@@ -700,6 +720,10 @@ func (m *ALBProvider) updateListenerAttribute(ctx context.Context, resLS *albmod
 	if isListenerDescriptionNeedUpdate {
 		updateLsReq.ListenerDescription = resLS.Spec.ListenerDescription
 	}
+	if isCaConfigNeedUpdate {
+		updateLsReq.CaEnabled = requests.NewBoolean(true)
+		updateLsReq.CaCertificates = transSDKCaCertificatesToUpdateLs(ctx, resLS.Spec.CaCertificates)
+	}
 	if isHTTPSListenerProtocol(sdkLs.ListenerProtocol) && isCertificatesNeedUpdate {
 		updateLsReq.Certificates = transSDKCertificatesToUpdateLs(desiredDefaultCerts)
 	}
@@ -732,6 +756,30 @@ func (m *ALBProvider) updateListenerAttribute(ctx context.Context, resLS *albmod
 	return nil
 }
 
+// isListenerCaConfigNeedUpdate compares the CA certificates of the caBundle with the CA certificates listed
+// together with the server certificates of the listener. The mutual tls config set in the console is kept
+// if the listener has no caBundle.
+func isListenerCaConfigNeedUpdate(ctx context.Context, resLS *albmodel.Listener, sdkCerts []albsdk.CertificateModel) (bool, error) {
+	if !resLS.Spec.CaManaged {
+		return false, nil
+	}
+	desiredCaCertIDs := sets.NewString()
+	for _, cert := range resLS.Spec.CaCertificates {
+		certId, err := cert.GetCertificateId(ctx)
+		if err != nil {
+			return false, err
+		}
+		desiredCaCertIDs.Insert(certId)
+	}
+	currentCaCertIDs := sets.NewString()
+	for _, cert := range sdkCerts {
+		if cert.CertificateType == util.ListenerCertificateTypeCa {
+			currentCaCertIDs.Insert(cert.CertificateId)
+		}
+	}
+	return !desiredCaCertIDs.Equal(currentCaCertIDs), nil
+}
+
 func transModelActionToSDKCreateLs(actions []albmodel.Action) (*[]albsdk.CreateListenerDefaultActions, error) {
 	createLsActions := make([]albsdk.CreateListenerDefaultActions, 0)
 	for _, action := range actions {
@@ -867,6 +915,17 @@ func transSDKCaCertificatesToCreateLs(ctx context.Context, certificates []albmod
 	return &createListenerAttributeCaCertificates
 }
 
+func transSDKCaCertificatesToUpdateLs(ctx context.Context, certificates []albmodel.Certificate) *[]albsdk.UpdateListenerAttributeCaCertificates {
+	updateListenerAttributeCaCertificates := make([]albsdk.UpdateListenerAttributeCaCertificates, 0)
+	for _, certificate := range certificates {
+		certId, _ := certificate.GetCertificateId(ctx)
+		updateListenerAttributeCaCertificates = append(updateListenerAttributeCaCertificates, albsdk.UpdateListenerAttributeCaCertificates{
+			CertificateId: certId,
+		})
+	}
+	return &updateListenerAttributeCaCertificates
+}
+
 func transSDKCertificatesToCreateLs(certificates []albsdk.Certificate) *[]albsdk.CreateListenerCertificates {
 	createListenerAttributeCertificates := make([]albsdk.CreateListenerCertificates, 0)
 	for _, certificate := range certificates {
@@ -889,7 +948,7 @@ func transSDKCertificatesToUpdateLs(certificates []albsdk.Certificate) *[]albsdk
 
 func transSDKXForwardedForConfigToCreateLs(c albmodel.XForwardedForConfig) albsdk.CreateListenerXForwardedForConfig {
 	return albsdk.CreateListenerXForwardedForConfig{
-		XForwardedForClientCertSubjectDNAlias:      c.XForwardedForClientCertIssuerDNAlias,
+		XForwardedForClientCertSubjectDNAlias:      c.XForwardedForClientCertSubjectDNAlias,
 		XForwardedForClientCertIssuerDNEnabled:     strconv.FormatBool(c.XForwardedForClientCertIssuerDNEnabled),
 		XForwardedForClientCertFingerprintEnabled:  strconv.FormatBool(c.XForwardedForClientCertFingerprintEnabled),
 		XForwardedForClientCertIssuerDNAlias:       c.XForwardedForClientCertIssuerDNAlias,
@@ -907,7 +966,7 @@ func transSDKXForwardedForConfigToCreateLs(c albmodel.XForwardedForConfig) albsd
 
 func transXForwardedForConfigToSDK(c albmodel.XForwardedForConfig) albsdk.XForwardedForConfig {
 	return albsdk.XForwardedForConfig{
-		XForwardedForClientCertSubjectDNAlias:      c.XForwardedForClientCertIssuerDNAlias,
+		XForwardedForClientCertSubjectDNAlias:      c.XForwardedForClientCertSubjectDNAlias,
 		XForwardedForClientCertIssuerDNEnabled:     c.XForwardedForClientCertIssuerDNEnabled,
 		XForwardedForClientCertFingerprintEnabled:  c.XForwardedForClientCertFingerprintEnabled,
 		XForwardedForClientCertIssuerDNAlias:       c.XForwardedForClientCertIssuerDNAlias,
@@ -925,7 +984,7 @@ func transXForwardedForConfigToSDK(c albmodel.XForwardedForConfig) albsdk.XForwa
 
 func transSDKXForwardedForConfigToUpdateLs(c albmodel.XForwardedForConfig) albsdk.UpdateListenerAttributeXForwardedForConfig {
 	return albsdk.UpdateListenerAttributeXForwardedForConfig{
-		XForwardedForClientCertSubjectDNAlias:      c.XForwardedForClientCertIssuerDNAlias,
+		XForwardedForClientCertSubjectDNAlias:      c.XForwardedForClientCertSubjectDNAlias,
 		XForwardedForClientCertIssuerDNEnabled:     strconv.FormatBool(c.XForwardedForClientCertIssuerDNEnabled),
 		XForwardedForClientCertFingerprintEnabled:  strconv.FormatBool(c.XForwardedForClientCertFingerprintEnabled),
 		XForwardedForClientCertIssuerDNAlias:       c.XForwardedForClientCertIssuerDNAlias,
@@ -949,6 +1008,9 @@ func buildSDKCertificatesModel(modelCerts []albsdk.CertificateModel) ([]albsdk.C
 	var defaultSDKCerts []albsdk.CertificateModel
 	var extraSDKCerts []albsdk.CertificateModel
 	for _, cert := range modelCerts {
+		if cert.CertificateType == util.ListenerCertificateTypeCa {
+			continue
+		}
 		if cert.IsDefault {
 			defaultSDKCerts = append(defaultSDKCerts, cert)
 		} else {
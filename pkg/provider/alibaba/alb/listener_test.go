@@ -0,0 +1,52 @@
+package alb
+
+import (
+	"context"
+	"testing"
+
+	albsdk "github.com/aliyun/alibaba-cloud-sdk-go/services/alb"
+	"github.com/stretchr/testify/assert"
+	albmodel "k8s.io/alibaba-load-balancer-controller/pkg/model/alb"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+)
+
+func TestIsListenerCaConfigNeedUpdate(t *testing.T) {
+	ctx := context.TODO()
+	sdkCerts := []albsdk.CertificateModel{
+		{CertificateId: "cert-1", IsDefault: true, CertificateType: "Server"},
+		{CertificateId: "ca-1", CertificateType: util.ListenerCertificateTypeCa},
+	}
+	resLS := &albmodel.Listener{}
+
+	// the mutual tls config set in the console is kept without caBundle
+	needUpdate, err := isListenerCaConfigNeedUpdate(ctx, resLS, sdkCerts)
+	assert.NoError(t, err)
+	assert.False(t, needUpdate)
+
+	resLS.Spec.CaManaged = true
+	resLS.Spec.CaEnabled = true
+	resLS.Spec.CaCertificates = []albmodel.Certificate{&albmodel.FixedCertificate{CertificateId: "ca-1"}}
+	needUpdate, err = isListenerCaConfigNeedUpdate(ctx, resLS, sdkCerts)
+	assert.NoError(t, err)
+	assert.False(t, needUpdate)
+
+	resLS.Spec.CaCertificates = []albmodel.Certificate{&albmodel.FixedCertificate{CertificateId: "ca-2"}}
+	needUpdate, err = isListenerCaConfigNeedUpdate(ctx, resLS, sdkCerts)
+	assert.NoError(t, err)
+	assert.True(t, needUpdate)
+
+	needUpdate, err = isListenerCaConfigNeedUpdate(ctx, resLS, sdkCerts[:1])
+	assert.NoError(t, err)
+	assert.True(t, needUpdate)
+}
+
+func TestBuildSDKCertificatesModel(t *testing.T) {
+	defaultCerts, extraCerts := buildSDKCertificatesModel([]albsdk.CertificateModel{
+		{CertificateId: "cert-1", IsDefault: true},
+		{CertificateId: "cert-2"},
+		{CertificateId: "ca-1", CertificateType: util.ListenerCertificateTypeCa},
+	})
+	assert.Equal(t, []albsdk.CertificateModel{{CertificateId: "cert-1", IsDefault: true}}, defaultCerts)
+	// the CA certificates are not extra server certificates
+	assert.Equal(t, []albsdk.CertificateModel{{CertificateId: "cert-2"}}, extraCerts)
+}
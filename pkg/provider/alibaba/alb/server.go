@@ -225,6 +225,56 @@ func (m *ALBProvider) ReplaceALBServers(ctx context.Context, serverGroupID strin
 	return nil
 }
 
+func (m *ALBProvider) UpdateALBServers(ctx context.Context, serverGroupID string, resServers []alb.BackendItem) error {
+	if len(serverGroupID) == 0 {
+		return fmt.Errorf("empty server group id when update servers error")
+	}
+	if len(resServers) == 0 {
+		return nil
+	}
+
+	traceID := ctx.Value(util.TraceID)
+
+	serversToUpdate := make([]albsdk.UpdateServerGroupServersAttributeServers, 0, len(resServers))
+	for _, resServer := range resServers {
+		serverToAdd, err := transModelBackendToSDKReplaceServersInServerGroupAddedServer(resServer)
+		if err != nil {
+			return err
+		}
+		serversToUpdate = append(serversToUpdate, albsdk.UpdateServerGroupServersAttributeServers{
+			ServerType:  serverToAdd.ServerType,
+			Port:        serverToAdd.Port,
+			Description: serverToAdd.Description,
+			ServerIp:    serverToAdd.ServerIp,
+			Weight:      serverToAdd.Weight,
+			ServerId:    serverToAdd.ServerId,
+		})
+	}
+
+	updateServersReq := albsdk.CreateUpdateServerGroupServersAttributeRequest()
+	updateServersReq.ServerGroupId = serverGroupID
+	updateServersReq.Servers = &serversToUpdate
+
+	startTime := time.Now()
+	m.logger.V(util.MgrLogLevel).Info("updating server in server group",
+		"serverGroupID", serverGroupID,
+		"traceID", traceID,
+		"servers", serversToUpdate,
+		"startTime", startTime,
+		util.Action, util.UpdateALBServersAttributeInServerGroup)
+	updateServersResp, err := m.auth.ALB.UpdateServerGroupServersAttribute(updateServersReq)
+	if err != nil {
+		return err
+	}
+	m.logger.V(util.MgrLogLevel).Info("updated server in server group",
+		"serverGroupID", serverGroupID,
+		"traceID", traceID,
+		"requestID", updateServersResp.RequestId,
+		"elapsedTime", time.Since(startTime).Milliseconds(),
+		util.Action, util.UpdateALBServersAttributeInServerGroup)
+	return nil
+}
+
 func (m *ALBProvider) ListALBServers(ctx context.Context, serverGroupID string) ([]albsdk.BackendServer, error) {
 	if len(serverGroupID) == 0 {
 		return nil, fmt.Errorf("empty server group id when list servers error")
@@ -310,6 +360,8 @@ func transSDKBackendServerToReplaceServersInServerGroupRemovedServer(server albs
 func transModelBackendToSDKAddServersToServerGroupServer(server alb.BackendItem) (*albsdk.AddServersToServerGroupServers, error) {
 	serverToAdd := new(albsdk.AddServersToServerGroupServers)
 
+	serverToAdd.Description = server.Description
+
 	serverToAdd.ServerIp = server.ServerIp
 
 	if len(server.ServerId) == 0 {
@@ -338,6 +390,8 @@ func transModelBackendToSDKAddServersToServerGroupServer(server alb.BackendItem)
 func transModelBackendToSDKReplaceServersInServerGroupAddedServer(server alb.BackendItem) (*albsdk.ReplaceServersInServerGroupAddedServers, error) {
 	serverToAdd := new(albsdk.ReplaceServersInServerGroupAddedServers)
 
+	serverToAdd.Description = server.Description
+
 	serverToAdd.ServerIp = server.ServerIp
 
 	if len(server.ServerId) == 0 {
@@ -397,7 +451,8 @@ func isServerPortValid(port int) bool {
 func isServerTypeValid(serverType string) bool {
 	if !strings.EqualFold(serverType, util.ServerTypeEcs) &&
 		!strings.EqualFold(serverType, util.ServerTypeEni) &&
-		!strings.EqualFold(serverType, util.ServerTypeEci) {
+		!strings.EqualFold(serverType, util.ServerTypeEci) &&
+		!strings.EqualFold(serverType, util.ServerTypeIp) {
 		return false
 	}
 
@@ -2,6 +2,7 @@ package alb
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -116,7 +117,7 @@ func isServerGroupActive(status string) bool {
 }
 
 func (m *ALBProvider) UpdateALBServerGroup(ctx context.Context, resSGP *alb.ServerGroup, sdkSGP alb.ServerGroupWithTags) (alb.ServerGroupStatus, error) {
-	_, err := m.updateServerGroupAttribute(ctx, resSGP, &sdkSGP.ServerGroup)
+	_, err := m.updateServerGroupAttribute(ctx, resSGP, &sdkSGP.ServerGroup, sdkSGP.SlowStartConfig)
 	if err != nil {
 		return alb.ServerGroupStatus{}, err
 	}
@@ -159,7 +160,8 @@ func (m *ALBProvider) DeleteALBServerGroup(ctx context.Context, serverGroupID st
 	return nil
 }
 
-func (m *ALBProvider) updateServerGroupAttribute(ctx context.Context, resSGP *alb.ServerGroup, sdkSGP *albsdk.ServerGroup) (*albsdk.UpdateServerGroupAttributeResponse, error) {
+func (m *ALBProvider) updateServerGroupAttribute(ctx context.Context, resSGP *alb.ServerGroup, sdkSGP *albsdk.ServerGroup,
+	sdkSlowStart alb.SlowStartConfig) (*albsdk.UpdateServerGroupAttributeResponse, error) {
 	traceID := ctx.Value(util.TraceID)
 
 	var (
@@ -169,7 +171,8 @@ func (m *ALBProvider) updateServerGroupAttribute(ctx context.Context, resSGP *al
 		isServerGroupKeepaliveNeedUpdate,
 		isServiceNameNeedUpdate,
 		isSchedulerNeedUpdate,
-		isUchConfigNeedUpdate bool
+		isUchConfigNeedUpdate,
+		isSlowStartConfigNeedUpdate bool
 	)
 	if resSGP.Spec.ServerGroupName != sdkSGP.ServerGroupName {
 		m.logger.V(util.MgrLogLevel).Info("ServerGroupName update:",
@@ -263,9 +266,22 @@ func (m *ALBProvider) updateServerGroupAttribute(ctx context.Context, resSGP *al
 		isStickySessionConfigNeedUpdate = true
 	}
 
+	if err := checkSlowStartConfigValid(resSGP.Spec.SlowStartConfig, resSGP.Spec.Scheduler); err != nil {
+		return nil, err
+	}
+	if resSGP.Spec.SlowStartConfig != sdkSlowStart &&
+		(resSGP.Spec.SlowStartConfig.SlowStartEnabled || sdkSlowStart.SlowStartEnabled) {
+		m.logger.V(util.MgrLogLevel).Info("SlowStartConfig update:",
+			"res", resSGP.Spec.SlowStartConfig,
+			"sdk", sdkSlowStart,
+			"serverGroupID", sdkSGP.ServerGroupId,
+			"traceID", traceID)
+		isSlowStartConfigNeedUpdate = true
+	}
+
 	if !isServerGroupNameNeedUpdate && !isSchedulerNeedUpdate && !isUchConfigNeedUpdate &&
 		!isHealthCheckConfigNeedUpdate && !isStickySessionConfigNeedUpdate &&
-		!isServerGroupKeepaliveNeedUpdate &&
+		!isServerGroupKeepaliveNeedUpdate && !isSlowStartConfigNeedUpdate &&
 		!isServiceNameNeedUpdate {
 		return nil, nil
 	}
@@ -295,6 +311,9 @@ func (m *ALBProvider) updateServerGroupAttribute(ctx context.Context, resSGP *al
 		updateSgpReq.Scheduler = resSGP.Spec.Scheduler
 		updateSgpReq.UchConfig = *transSDKUchConfigToUpdateSGP(resSGP.Spec.UchConfig)
 	}
+	if isSlowStartConfigNeedUpdate {
+		setSlowStartConfigParams(updateSgpReq.GetQueryParams(), resSGP.Spec.SlowStartConfig)
+	}
 
 	startTime := time.Now()
 	m.logger.V(util.MgrLogLevel).Info("updating server group attribute",
@@ -363,10 +382,60 @@ func buildSDKServerGroupCreateRequest(sgpSpec alb.ServerGroupSpec) (*albsdk.Crea
 	}
 	sgpReq.StickySessionConfig = *transSDKStickySessionConfigToCreateSGP(sgpSpec.StickySessionConfig)
 	sgpReq.ServerGroupType = sgpSpec.ServerGroupType
+	if err := checkSlowStartConfigValid(sgpSpec.SlowStartConfig, sgpSpec.Scheduler); err != nil {
+		return nil, err
+	}
+	if sgpSpec.SlowStartConfig.SlowStartEnabled {
+		setSlowStartConfigParams(sgpReq.GetQueryParams(), sgpSpec.SlowStartConfig)
+	}
 
 	return sgpReq, nil
 }
 
+// setSlowStartConfigParams sets the slow start of the request, which is not a field of the requests of the sdk
+func setSlowStartConfigParams(params map[string]string, conf alb.SlowStartConfig) {
+	params["SlowStartConfig.SlowStartEnabled"] = strconv.FormatBool(conf.SlowStartEnabled)
+	if conf.SlowStartEnabled {
+		params["SlowStartConfig.SlowStartDuration"] = strconv.Itoa(conf.SlowStartDuration)
+	}
+}
+
+// parseSlowStartConfigs reads the slow start of the server groups from the response of ListServerGroups,
+// which is not parsed by the sdk
+func parseSlowStartConfigs(resp *albsdk.ListServerGroupsResponse) (map[string]alb.SlowStartConfig, error) {
+	body := struct {
+		ServerGroups []struct {
+			ServerGroupId   string
+			SlowStartConfig alb.SlowStartConfig
+		}
+	}{}
+	if len(resp.GetHttpContentBytes()) == 0 {
+		return nil, nil
+	}
+	if err := json.Unmarshal(resp.GetHttpContentBytes(), &body); err != nil {
+		return nil, fmt.Errorf("parse slow start of server groups error: %s", err.Error())
+	}
+	configs := make(map[string]alb.SlowStartConfig, len(body.ServerGroups))
+	for _, sgp := range body.ServerGroups {
+		configs[sgp.ServerGroupId] = sgp.SlowStartConfig
+	}
+	return configs, nil
+}
+
+func checkSlowStartConfigValid(conf alb.SlowStartConfig, scheduler string) error {
+	if !conf.SlowStartEnabled {
+		return nil
+	}
+	if conf.SlowStartDuration < 30 || conf.SlowStartDuration > 900 {
+		return fmt.Errorf("invalid server group SlowStartDuration: %v", conf.SlowStartDuration)
+	}
+	if !strings.EqualFold(scheduler, util.ServerGroupSchedulerWrr) &&
+		!strings.EqualFold(scheduler, util.ServerGroupSchedulerWlc) {
+		return fmt.Errorf("slow start is not supported by server group scheduler: %s", scheduler)
+	}
+	return nil
+}
+
 func checkHealthCheckConfigValid(conf alb.HealthCheckConfig) error {
 	if !conf.HealthCheckEnabled {
 		return nil
@@ -0,0 +1,63 @@
+package alb
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/responses"
+	albsdk "github.com/aliyun/alibaba-cloud-sdk-go/services/alb"
+	"github.com/stretchr/testify/assert"
+	albmodel "k8s.io/alibaba-load-balancer-controller/pkg/model/alb"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+)
+
+func TestBuildSDKServerGroupCreateRequestSlowStart(t *testing.T) {
+	spec := albmodel.ServerGroupSpec{}
+	spec.ServerGroupName = "sgp"
+	spec.VpcId = "vpc-1"
+	spec.Scheduler = util.ServerGroupSchedulerWrr
+	spec.Protocol = util.ServerGroupProtocolHTTP
+	spec.SlowStartConfig = albmodel.SlowStartConfig{SlowStartEnabled: true, SlowStartDuration: 60}
+
+	req, err := buildSDKServerGroupCreateRequest(spec)
+	assert.NoError(t, err)
+	assert.Equal(t, "true", req.GetQueryParams()["SlowStartConfig.SlowStartEnabled"])
+	assert.Equal(t, "60", req.GetQueryParams()["SlowStartConfig.SlowStartDuration"])
+
+	// slow start is only supported by the wrr and wlc schedulers
+	spec.Scheduler = util.ServerGroupSchedulerSch
+	_, err = buildSDKServerGroupCreateRequest(spec)
+	assert.Error(t, err)
+
+	spec.Scheduler = util.ServerGroupSchedulerWlc
+	spec.SlowStartConfig.SlowStartDuration = 10
+	_, err = buildSDKServerGroupCreateRequest(spec)
+	assert.Error(t, err)
+
+	spec.SlowStartConfig = albmodel.SlowStartConfig{}
+	req, err = buildSDKServerGroupCreateRequest(spec)
+	assert.NoError(t, err)
+	assert.NotContains(t, req.GetQueryParams(), "SlowStartConfig.SlowStartEnabled")
+}
+
+func TestParseSlowStartConfigs(t *testing.T) {
+	resp := albsdk.CreateListServerGroupsResponse()
+	err := responses.Unmarshal(resp, &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body: io.NopCloser(bytes.NewBufferString(`{"ServerGroups":[` +
+			`{"ServerGroupId":"sgp-1","SlowStartConfig":{"SlowStartEnabled":true,"SlowStartDuration":60}},` +
+			`{"ServerGroupId":"sgp-2"}]}`)),
+	}, "JSON")
+	assert.NoError(t, err)
+	assert.Len(t, resp.ServerGroups, 2)
+
+	configs, err := parseSlowStartConfigs(resp)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]albmodel.SlowStartConfig{
+		"sgp-1": {SlowStartEnabled: true, SlowStartDuration: 60},
+		"sgp-2": {},
+	}, configs)
+}
@@ -34,10 +34,16 @@ func transTagFilterToListAlbLoadBalancersTags(tagFilters map[string]string) []al
 }
 
 func (m *ALBProvider) ListALBServerGroupsByTag(ctx context.Context, tagFilters map[string]string) ([]albsdk.ServerGroup, error) {
+	serverGroups, _, err := m.listALBServerGroupsByTag(ctx, tagFilters)
+	return serverGroups, err
+}
+
+// listALBServerGroupsByTag returns the server groups with the tags and their slow start by server group id
+func (m *ALBProvider) listALBServerGroupsByTag(ctx context.Context, tagFilters map[string]string) ([]albsdk.ServerGroup, map[string]alb.SlowStartConfig, error) {
 	traceID := ctx.Value(util.TraceID)
 
 	if len(tagFilters) == 0 {
-		return nil, fmt.Errorf("invalid tag filter: %v for listing server groups", tagFilters)
+		return nil, nil, fmt.Errorf("invalid tag filter: %v for listing server groups", tagFilters)
 	}
 
 	listTags := transTagFilterToListServerGroupTags(tagFilters)
@@ -45,6 +51,7 @@ func (m *ALBProvider) ListALBServerGroupsByTag(ctx context.Context, tagFilters m
 	var (
 		nextToken    string
 		serverGroups []albsdk.ServerGroup
+		slowStarts   = make(map[string]alb.SlowStartConfig)
 	)
 
 	sgpReq := albsdk.CreateListServerGroupsRequest()
@@ -61,7 +68,7 @@ func (m *ALBProvider) ListALBServerGroupsByTag(ctx context.Context, tagFilters m
 			util.Action, util.ListALBServerGroups)
 		sgpResp, err := m.auth.ALB.ListServerGroups(sgpReq)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		m.logger.V(util.MgrLogLevel).Info("listed server groups by tag",
 			"requestID", sgpResp.RequestId,
@@ -71,6 +78,13 @@ func (m *ALBProvider) ListALBServerGroupsByTag(ctx context.Context, tagFilters m
 			util.Action, util.ListALBServerGroups)
 
 		serverGroups = append(serverGroups, sgpResp.ServerGroups...)
+		configs, err := parseSlowStartConfigs(sgpResp)
+		if err != nil {
+			return nil, nil, err
+		}
+		for id, conf := range configs {
+			slowStarts[id] = conf
+		}
 
 		if sgpResp.NextToken == "" {
 			break
@@ -79,7 +93,7 @@ func (m *ALBProvider) ListALBServerGroupsByTag(ctx context.Context, tagFilters m
 		}
 	}
 
-	return serverGroups, nil
+	return serverGroups, slowStarts, nil
 }
 func (m *ALBProvider) ListAlbLoadBalancersByTag(ctx context.Context, tagFilters map[string]string) ([]albsdk.LoadBalancer, error) {
 	traceID := ctx.Value(util.TraceID)
@@ -131,7 +145,7 @@ func (m *ALBProvider) ListAlbLoadBalancersByTag(ctx context.Context, tagFilters
 }
 
 func (m *ALBProvider) ListALBServerGroupsWithTags(ctx context.Context, tagFilters map[string]string) ([]alb.ServerGroupWithTags, error) {
-	serverGroups, err := m.ListALBServerGroupsByTag(ctx, tagFilters)
+	serverGroups, slowStarts, err := m.listALBServerGroupsByTag(ctx, tagFilters)
 	if err != nil {
 		return nil, err
 	}
@@ -141,8 +155,9 @@ func (m *ALBProvider) ListALBServerGroupsWithTags(ctx context.Context, tagFilter
 		tagMap := transSDKTagListToMap(serverGroup.Tags)
 
 		serverGroupsWithTags = append(serverGroupsWithTags, alb.ServerGroupWithTags{
-			ServerGroup: serverGroup,
-			Tags:        tagMap,
+			ServerGroup:     serverGroup,
+			Tags:            tagMap,
+			SlowStartConfig: slowStarts[serverGroup.ServerGroupId],
 		})
 	}
 
@@ -178,11 +193,16 @@ func (m *ALBProvider) SelectALBServerGroupsByID(ctx context.Context, serverGroup
 	if sgpResp.TotalCount == 0 {
 		return alb.ServerGroupWithTags{}, fmt.Errorf("ServerGroupID: %s not exist", serverGroupID)
 	}
+	slowStarts, err := parseSlowStartConfigs(sgpResp)
+	if err != nil {
+		return alb.ServerGroupWithTags{}, err
+	}
 	sdkSgp := sgpResp.ServerGroups[0]
 	tagMap := transSDKTagListToMap(sdkSgp.Tags)
 	serverGroupWithTag := alb.ServerGroupWithTags{
-		ServerGroup: sdkSgp,
-		Tags:        tagMap,
+		ServerGroup:     sdkSgp,
+		Tags:            tagMap,
+		SlowStartConfig: slowStarts[sdkSgp.ServerGroupId],
 	}
 	return serverGroupWithTag, nil
 }
@@ -3,6 +3,7 @@ package cas
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"strconv"
 	"sync"
 	"time"
@@ -51,10 +52,13 @@ const (
 
 const (
 	certsCacheKey                         = "CertificateInfo"
+	caCertsCacheKey                       = "CACertificateInfo"
 	DescribeSSLCertificateList            = "DescribeSSLCertificateList"
 	DescribeSSLCertificatePublicKeyDetail = "DescribeSSLCertificatePublicKeyDetail"
 	CreateSSLCertificateWithName          = "CreateSSLCertificateWithName"
 	DeleteSSLCertificate                  = "DeleteSSLCertificate"
+	DescribeCACertificateList             = "DescribePcaAndExternalCACertificateList"
+	UploadCACertificate                   = "UploadPCACert"
 	DefaultSSLCertificatePollInterval     = 30 * time.Second
 	DefaultSSLCertificateTimeout          = 60 * time.Second
 )
@@ -211,3 +215,107 @@ func (c CASProvider) DescribeSSLCertificateList(ctx context.Context) ([]model.Ce
 	c.certsCache.Set(certsCacheKey, certificateInfos, c.certsCacheTTL)
 	return certificateInfos, nil
 }
+
+func (c CASProvider) CreateCACertificateWithName(ctx context.Context, certName, certificate string) (string, error) {
+	traceID := ctx.Value(util.TraceID)
+
+	rpcRequest := &requests.RpcRequest{}
+	rpcRequest.InitWithApiInfo("cas", "2020-04-07", UploadCACertificate, "cas", "openAPI")
+	rpcRequest.Method = requests.POST
+	rpcRequest.Domain = CASDomain
+	rpcRequest.QueryParams = map[string]string{
+		"Name": certName,
+		"Cert": certificate,
+	}
+	response := responses.NewCommonResponse()
+	if err := util.RetryImmediateOnError(DefaultSSLCertificatePollInterval, DefaultSSLCertificateTimeout, func(err error) bool {
+		return false
+	}, func() error {
+		startTime := time.Now()
+		c.logger.Info("creating ca certificate",
+			"traceID", traceID,
+			"startTime", startTime,
+			"action", UploadCACertificate)
+		err := c.casDoAction(rpcRequest, response)
+		if err != nil {
+			return err
+		}
+		if !response.IsSuccess() {
+			return fmt.Errorf("%s error: %s", UploadCACertificate, response.GetHttpContentString())
+		}
+		c.logger.Info("created ca certificate",
+			"traceID", traceID,
+			"certName", certName,
+			"elapsedTime", time.Since(startTime).Milliseconds(),
+			"response", response,
+			"action", UploadCACertificate)
+		return nil
+	}); err != nil {
+		return "", errors.Wrap(err, "failed to uploadCACertificate")
+	}
+	c.loadCertMutex.Lock()
+	defer c.loadCertMutex.Unlock()
+	c.certsCache.Delete(caCertsCacheKey)
+	resp := map[string]interface{}{}
+	_ = json.Unmarshal(response.GetHttpContentBytes(), &resp)
+	id, _ := resp["Identifier"].(string)
+	if id == "" {
+		return "", fmt.Errorf("%s returns empty identifier for %s", UploadCACertificate, certName)
+	}
+	return id, nil
+}
+
+func (c CASProvider) DescribeCACertificateList(ctx context.Context) ([]model.CACertificateInfo, error) {
+	traceID := ctx.Value(util.TraceID)
+	c.loadCertMutex.Lock()
+	defer c.loadCertMutex.Unlock()
+
+	if rawCacheItem, ok := c.certsCache.Get(caCertsCacheKey); ok {
+		return rawCacheItem.([]model.CACertificateInfo), nil
+	}
+
+	rpcRequest := &requests.RpcRequest{}
+	rpcRequest.InitWithApiInfo("cas", "2020-04-07", DescribeCACertificateList, "cas", "openAPI")
+	rpcRequest.Method = requests.POST
+	rpcRequest.Domain = CASDomain
+
+	response := responses.NewCommonResponse()
+
+	certificateInfos := make([]model.CACertificateInfo, 0)
+	pageNumber := 1
+	for {
+		rpcRequest.QueryParams = map[string]string{
+			"ShowSize":    strconv.Itoa(CASShowSize),
+			"CurrentPage": strconv.Itoa(pageNumber),
+		}
+
+		startTime := time.Now()
+		c.logger.Info("listing ca certificate",
+			"traceID", traceID,
+			"startTime", startTime,
+			"action", DescribeCACertificateList)
+		err := c.casDoAction(rpcRequest, response)
+		if err != nil {
+			c.logger.Error(err, "DescribeCACertificateList error")
+			return nil, err
+		}
+		c.logger.Info("listed ca certificate",
+			"traceID", traceID,
+			"elapsedTime", time.Since(startTime).Milliseconds(),
+			"action", DescribeCACertificateList)
+		resp := map[string]interface{}{}
+		_ = json.Unmarshal(response.GetHttpContentBytes(), &resp)
+		certBytes, _ := json.Marshal(resp["CertificateList"])
+		certs := []model.CACertificateInfo{}
+		_ = json.Unmarshal(certBytes, &certs)
+		certificateInfos = append(certificateInfos, certs...)
+		totalCount, _ := resp["TotalCount"].(float64)
+		if len(certs) != 0 && pageNumber*CASShowSize < int(totalCount) {
+			pageNumber++
+		} else {
+			break
+		}
+	}
+	c.certsCache.Set(caCertsCacheKey, certificateInfos, c.certsCacheTTL)
+	return certificateInfos, nil
+}
@@ -16,7 +16,9 @@ func (p *NLBProvider) ListNLBListeners(ctx context.Context, lbId string) ([]*nlb
 	nextToken := ""
 	for {
 		req := &nlb.ListListenersRequest{}
-		req.LoadBalancerIds = []*string{tea.String(lbId)}
+		if lbId != "" {
+			req.LoadBalancerIds = []*string{tea.String(lbId)}
+		}
 		req.MaxResults = tea.Int32(100)
 		req.NextToken = tea.String(nextToken)
 
@@ -140,7 +142,7 @@ func (p *NLBProvider) DeleteNLBListener(ctx context.Context, listenerId string)
 	if resp == nil || resp.Body == nil {
 		return fmt.Errorf("OpenAPI DeleteNLBListener resp is nil")
 	}
-	return p.waitJobFinish("DeleteListener", tea.StringValue(resp.Body.JobId))
+	return p.waitJobFinish(ctx, "DeleteListener", tea.StringValue(resp.Body.JobId))
 }
 
 func (p *NLBProvider) StartNLBListener(ctx context.Context, listenerId string) error {
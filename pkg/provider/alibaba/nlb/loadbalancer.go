@@ -75,6 +75,7 @@ func (p *NLBProvider) DescribeNLB(ctx context.Context, mdl *nlbmodel.NetworkLoad
 		retErr error
 		resp   *nlb.GetLoadBalancerAttributeResponse
 	)
+	tracker := nlbmodel.AsyncJobTrackerFrom(ctx)
 	_ = wait.PollImmediate(20*time.Second, 2*time.Minute, func() (bool, error) {
 		req := &nlb.GetLoadBalancerAttributeRequest{}
 		req.LoadBalancerId = tea.String(mdl.LoadBalancerAttribute.LoadBalancerId)
@@ -93,6 +94,10 @@ func (p *NLBProvider) DescribeNLB(ctx context.Context, mdl *nlbmodel.NetworkLoad
 
 		if tea.StringValue(resp.Body.LoadBalancerStatus) == string(Provisioning) {
 			retErr = fmt.Errorf("nlb %s is in creating status", mdl.LoadBalancerAttribute.LoadBalancerId)
+			if tracker != nil {
+				tracker.Add(nlbmodel.AsyncJob{Api: nlbmodel.ApiCreateLoadBalancer, Id: mdl.LoadBalancerAttribute.LoadBalancerId})
+				return false, retErr
+			}
 			return false, nil
 		}
 
@@ -153,7 +158,7 @@ func (p *NLBProvider) DeleteNLB(ctx context.Context, mdl *nlbmodel.NetworkLoadBa
 	if resp == nil || resp.Body == nil {
 		return fmt.Errorf("OpenAPI DeleteNLB resp is nil")
 	}
-	return p.waitJobFinish("DeleteLoadBalancer", tea.StringValue(resp.Body.JobId), 20*time.Second, 3*time.Minute)
+	return p.waitJobFinish(ctx, "DeleteLoadBalancer", tea.StringValue(resp.Body.JobId), 20*time.Second, 3*time.Minute)
 }
 
 func (p *NLBProvider) UpdateNLB(ctx context.Context, mdl *nlbmodel.NetworkLoadBalancer) error {
@@ -197,6 +202,27 @@ func (p *NLBProvider) UpdateNLBZones(ctx context.Context, mdl *nlbmodel.NetworkL
 	return util.SDKError("UpdateLoadBalancerZones", err)
 }
 
+func (p *NLBProvider) DescribeNLBZones(ctx context.Context) ([]string, error) {
+	req := &nlb.DescribeZonesRequest{}
+	req.RegionId = tea.String(p.auth.Region)
+
+	resp, err := p.auth.NLB.DescribeZones(req)
+	if err != nil {
+		return nil, util.SDKError("DescribeZones", err)
+	}
+	if resp == nil || resp.Body == nil {
+		return nil, fmt.Errorf("DescribeZones response is nil, resp [%+v]", resp)
+	}
+
+	var ids []string
+	for _, z := range resp.Body.Zones {
+		if z.ZoneId != nil {
+			ids = append(ids, *z.ZoneId)
+		}
+	}
+	return ids, nil
+}
+
 // tag
 func (p *NLBProvider) TagNLBResource(ctx context.Context, resourceId string, resourceType nlbmodel.TagResourceType, tags []tag.Tag,
 ) error {
@@ -214,6 +240,17 @@ func (p *NLBProvider) TagNLBResource(ctx context.Context, resourceId string, res
 	return util.SDKError("TagResources", err)
 }
 
+func (p *NLBProvider) UntagNLBResource(ctx context.Context, resourceId string, resourceType nlbmodel.TagResourceType, tagKeys []string,
+) error {
+	req := &nlb.UntagResourcesRequest{}
+	req.ResourceType = tea.String(string(resourceType))
+	req.ResourceId = []*string{tea.String(resourceId)}
+	req.TagKey = tea.StringSlice(tagKeys)
+
+	_, err := p.auth.NLB.UntagResources(req)
+	return util.SDKError("UntagResources", err)
+}
+
 func (p *NLBProvider) ListNLBTagResources(ctx context.Context, lbId string) ([]tag.Tag, error) {
 	req := &nlb.ListTagResourcesRequest{}
 	req.ResourceType = tea.String("loadbalancer")
@@ -327,14 +364,23 @@ func loadResponse(resp interface{}, lb *nlbmodel.NetworkLoadBalancer) error {
 		lb.LoadBalancerAttribute.LoadBalancerStatus = tea.StringValue(resp.LoadBalancerStatus)
 		lb.LoadBalancerAttribute.ResourceGroupId = tea.StringValue(resp.ResourceGroupId)
 		lb.LoadBalancerAttribute.DNSName = tea.StringValue(resp.DNSName)
+		lb.LoadBalancerAttribute.LoadBalancerBusinessStatus = tea.StringValue(resp.LoadBalancerBusinessStatus)
 
 		for _, z := range resp.ZoneMappings {
-			lb.LoadBalancerAttribute.ZoneMappings = append(lb.LoadBalancerAttribute.ZoneMappings,
-				nlbmodel.ZoneMapping{
-					ZoneId:    tea.StringValue(z.ZoneId),
-					VSwitchId: tea.StringValue(z.VSwitchId),
-				},
-			)
+			zoneMapping := nlbmodel.ZoneMapping{
+				ZoneId:    tea.StringValue(z.ZoneId),
+				VSwitchId: tea.StringValue(z.VSwitchId),
+			}
+			// an nlb has one address in each zone, which carries the private, public and ipv6 addresses of the zone,
+			// so the first address is used
+			if len(z.LoadBalancerAddresses) != 0 {
+				addr := z.LoadBalancerAddresses[0]
+				zoneMapping.IPv4Addr = tea.StringValue(addr.PrivateIPv4Address)
+				zoneMapping.AllocationId = tea.StringValue(addr.AllocationId)
+				zoneMapping.PublicIPv4Addr = tea.StringValue(addr.PublicIPv4Address)
+				zoneMapping.IPv6Addr = tea.StringValue(addr.Ipv6Address)
+			}
+			lb.LoadBalancerAttribute.ZoneMappings = append(lb.LoadBalancerAttribute.ZoneMappings, zoneMapping)
 		}
 
 	case *nlb.ListLoadBalancersResponseBodyLoadBalancers:
@@ -345,14 +391,23 @@ func loadResponse(resp interface{}, lb *nlbmodel.NetworkLoadBalancer) error {
 		lb.LoadBalancerAttribute.LoadBalancerStatus = tea.StringValue(resp.LoadBalancerStatus)
 		lb.LoadBalancerAttribute.ResourceGroupId = tea.StringValue(resp.ResourceGroupId)
 		lb.LoadBalancerAttribute.DNSName = tea.StringValue(resp.DNSName)
+		lb.LoadBalancerAttribute.LoadBalancerBusinessStatus = tea.StringValue(resp.LoadBalancerBusinessStatus)
 
 		for _, z := range resp.ZoneMappings {
-			lb.LoadBalancerAttribute.ZoneMappings = append(lb.LoadBalancerAttribute.ZoneMappings,
-				nlbmodel.ZoneMapping{
-					ZoneId:    tea.StringValue(z.ZoneId),
-					VSwitchId: tea.StringValue(z.VSwitchId),
-				},
-			)
+			zoneMapping := nlbmodel.ZoneMapping{
+				ZoneId:    tea.StringValue(z.ZoneId),
+				VSwitchId: tea.StringValue(z.VSwitchId),
+			}
+			// an nlb has one address in each zone, which carries the private, public and ipv6 addresses of the zone,
+			// so the first address is used
+			if len(z.LoadBalancerAddresses) != 0 {
+				addr := z.LoadBalancerAddresses[0]
+				zoneMapping.IPv4Addr = tea.StringValue(addr.PrivateIPv4Address)
+				zoneMapping.AllocationId = tea.StringValue(addr.AllocationId)
+				zoneMapping.PublicIPv4Addr = tea.StringValue(addr.PublicIPv4Address)
+				zoneMapping.IPv6Addr = tea.StringValue(addr.Ipv6Address)
+			}
+			lb.LoadBalancerAttribute.ZoneMappings = append(lb.LoadBalancerAttribute.ZoneMappings, zoneMapping)
 		}
 	default:
 		return fmt.Errorf("[%T] type not supported", resp)
@@ -365,7 +420,9 @@ const (
 	DefaultRetryTimeout  = 30 * time.Second
 )
 
-func (p *NLBProvider) waitJobFinish(api, jobId string, args ...time.Duration) error {
+// waitJobFinish polls the job until it succeeds. If there is an AsyncJobTracker in ctx,
+// the job is checked only once and added to the tracker if it is not finished.
+func (p *NLBProvider) waitJobFinish(ctx context.Context, api, jobId string, args ...time.Duration) error {
 	var interval, timeout time.Duration
 	if len(args) < 2 {
 		interval = DefaultRetryInterval
@@ -374,29 +431,48 @@ func (p *NLBProvider) waitJobFinish(api, jobId string, args ...time.Duration) er
 		interval = args[0]
 		timeout = args[1]
 	}
-	var (
-		resp   *nlb.GetJobStatusResponse
-		retErr error
-	)
+	tracker := nlbmodel.AsyncJobTrackerFrom(ctx)
+	var retErr error
 	_ = wait.PollImmediate(interval, timeout, func() (bool, error) {
-		req := &nlb.GetJobStatusRequest{}
-		req.JobId = tea.String(jobId)
-		resp, retErr = p.auth.NLB.GetJobStatus(req)
+		var status string
+		status, retErr = p.getJobStatus(api, jobId)
 		if retErr != nil {
-			retErr = util.SDKError(fmt.Sprintf("%s-GetJobStatus", api), retErr)
 			return false, retErr
 		}
-		if resp == nil || resp.Body == nil {
-			retErr = fmt.Errorf("OpenAPI %s GetJobStatus resp is nil, JobId: %s", api, jobId)
-			return false, nil
+		switch status {
+		case nlbmodel.JobStatusSucceeded:
+			return true, nil
+		case nlbmodel.JobStatusFailed:
+			retErr = fmt.Errorf("OpenAPI %s job %s failed", api, jobId)
+			return false, retErr
 		}
-
-		retErr = nil
-		return tea.StringValue(resp.Body.Status) == "Succeeded", retErr
+		if tracker != nil {
+			tracker.Add(nlbmodel.AsyncJob{Api: api, Id: jobId})
+			retErr = fmt.Errorf("OpenAPI %s job %s is %s", api, jobId, status)
+			return false, retErr
+		}
+		return false, nil
 	})
 	return retErr
 }
 
+func (p *NLBProvider) GetNLBJobStatus(ctx context.Context, jobId string) (string, error) {
+	return p.getJobStatus("GetJobStatus", jobId)
+}
+
+func (p *NLBProvider) getJobStatus(api, jobId string) (string, error) {
+	req := &nlb.GetJobStatusRequest{}
+	req.JobId = tea.String(jobId)
+	resp, err := p.auth.NLB.GetJobStatus(req)
+	if err != nil {
+		return "", util.SDKError(fmt.Sprintf("%s-GetJobStatus", api), err)
+	}
+	if resp == nil || resp.Body == nil {
+		return "", fmt.Errorf("OpenAPI %s GetJobStatus resp is nil, JobId: %s", api, jobId)
+	}
+	return tea.StringValue(resp.Body.Status), nil
+}
+
 // NLBRegionIds used for e2etest
 func (p *NLBProvider) NLBRegionIds() ([]string, error) {
 	req := &nlb.DescribeRegionsRequest{}
@@ -1,13 +1,21 @@
 package nlb
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	openapi "github.com/alibabacloud-go/darabonba-openapi/v2/client"
 	nlb "github.com/alibabacloud-go/nlb-20220430/client"
 	"github.com/alibabacloud-go/tea/tea"
+	"github.com/stretchr/testify/assert"
+	nlbmodel "k8s.io/alibaba-load-balancer-controller/pkg/model/nlb"
+	"k8s.io/alibaba-load-balancer-controller/pkg/provider/alibaba/base"
 	"k8s.io/alibaba-load-balancer-controller/pkg/provider/alibaba/util"
 	"k8s.io/apimachinery/pkg/util/wait"
 )
@@ -161,3 +169,76 @@ func TestGetLoadBalancer(t *testing.T) {
 		return true, nil
 	})
 }
+
+// newJobStatusProvider returns a provider whose GetJobStatus answers the statuses in turn, the last one repeated
+func newJobStatusProvider(t *testing.T, statuses ...string) (*NLBProvider, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := int(atomic.AddInt32(&calls, 1)) - 1
+		if i >= len(statuses) {
+			i = len(statuses) - 1
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"RequestId":"3C3AD7A8-A9A1-5D4C-8C3E-0B6D6E2F5E1A","Status":"%s"}`, statuses[i])
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := nlb.NewClient(&openapi.Config{
+		RegionId:        tea.String("cn-hangzhou"),
+		AccessKeyId:     tea.String("ak"),
+		AccessKeySecret: tea.String("sk"),
+		Protocol:        tea.String("HTTP"),
+		Endpoint:        tea.String(strings.TrimPrefix(server.URL, "http://")),
+	})
+	if err != nil {
+		t.Fatalf("init nlb client error: %s", err.Error())
+	}
+	return NewNLBProvider(&base.ClientMgr{NLB: client}), &calls
+}
+
+func TestWaitJobFinish(t *testing.T) {
+	// with a tracker, an unfinished job is checked once and tracked instead of polled
+	p, calls := newJobStatusProvider(t, nlbmodel.JobStatusProcessing)
+	tracker := &nlbmodel.AsyncJobTracker{}
+	err := p.waitJobFinish(nlbmodel.WithAsyncJobTracker(context.TODO(), tracker), "DeleteListener", "job-id",
+		time.Millisecond, time.Second)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+	assert.Equal(t, []nlbmodel.AsyncJob{{Api: "DeleteListener", Id: "job-id"}}, tracker.Jobs())
+
+	// a finished job is not tracked
+	p, _ = newJobStatusProvider(t, nlbmodel.JobStatusSucceeded)
+	tracker = &nlbmodel.AsyncJobTracker{}
+	assert.NoError(t, p.waitJobFinish(nlbmodel.WithAsyncJobTracker(context.TODO(), tracker), "DeleteListener", "job-id"))
+	assert.Empty(t, tracker.Jobs())
+
+	// without a tracker, the job is polled until it finishes
+	p, calls = newJobStatusProvider(t, nlbmodel.JobStatusProcessing, nlbmodel.JobStatusProcessing, nlbmodel.JobStatusSucceeded)
+	assert.NoError(t, p.waitJobFinish(context.TODO(), "DeleteListener", "job-id", time.Millisecond, time.Second))
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+
+	p, _ = newJobStatusProvider(t, nlbmodel.JobStatusFailed)
+	assert.Error(t, p.waitJobFinish(context.TODO(), "DeleteListener", "job-id", time.Millisecond, time.Second))
+}
+
+func TestLoadResponseZoneAddresses(t *testing.T) {
+	resp := &nlb.GetLoadBalancerAttributeResponseBody{
+		LoadBalancerId: tea.String("nlb-1"),
+		ZoneMappings: []*nlb.GetLoadBalancerAttributeResponseBodyZoneMappings{{
+			ZoneId:    tea.String("cn-hangzhou-a"),
+			VSwitchId: tea.String("vsw-1"),
+			LoadBalancerAddresses: []*nlb.GetLoadBalancerAttributeResponseBodyZoneMappingsLoadBalancerAddresses{
+				{PrivateIPv4Address: tea.String("10.0.0.1"), AllocationId: tea.String("eip-1"), PublicIPv4Address: tea.String("1.1.1.1")},
+				{PrivateIPv4Address: tea.String("10.0.0.2"), AllocationId: tea.String("eip-2"), PublicIPv4Address: tea.String("2.2.2.2")},
+			},
+		}},
+	}
+	mdl := &nlbmodel.NetworkLoadBalancer{LoadBalancerAttribute: &nlbmodel.LoadBalancerAttribute{}}
+	assert.NoError(t, loadResponse(resp, mdl))
+	if assert.Len(t, mdl.LoadBalancerAttribute.ZoneMappings, 1) {
+		zm := mdl.LoadBalancerAttribute.ZoneMappings[0]
+		assert.Equal(t, "10.0.0.1", zm.IPv4Addr)
+		assert.Equal(t, "eip-1", zm.AllocationId)
+		assert.Equal(t, "1.1.1.1", zm.PublicIPv4Addr)
+	}
+}
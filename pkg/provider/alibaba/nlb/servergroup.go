@@ -16,12 +16,27 @@ import (
 
 // ServerGroup
 func (p *NLBProvider) ListNLBServerGroups(ctx context.Context, tags []tag.Tag) ([]*nlbmodel.ServerGroup, error) {
+	return p.listNLBServerGroups(ctx, tags, nil)
+}
+
+// ListNLBServerGroupsByIds returns the server groups of the ids, whatever their tags are
+func (p *NLBProvider) ListNLBServerGroupsByIds(ctx context.Context, sgIds []string) ([]*nlbmodel.ServerGroup, error) {
+	if len(sgIds) == 0 {
+		return nil, nil
+	}
+	return p.listNLBServerGroups(ctx, nil, sgIds)
+}
+
+func (p *NLBProvider) listNLBServerGroups(ctx context.Context, tags []tag.Tag, sgIds []string) ([]*nlbmodel.ServerGroup, error) {
 	var remoteServerGroups []*nlb.ListServerGroupsResponseBodyServerGroups
 	var nextToken = ""
 	for {
 		req := &nlb.ListServerGroupsRequest{}
 		req.MaxResults = tea.Int32(100)
 		req.NextToken = tea.String(nextToken)
+		if len(sgIds) != 0 {
+			req.ServerGroupIds = tea.StringSlice(sgIds)
+		}
 		for _, t := range tags {
 			req.Tag = append(req.Tag, &nlb.ListServerGroupsRequestTag{
 				Key:   tea.String(t.Key),
@@ -103,6 +118,9 @@ func (p *NLBProvider) CreateNLBServerGroup(ctx context.Context, sg *nlbmodel.Ser
 	req.ServerGroupName = tea.String(sg.ServerGroupName)
 	req.VpcId = tea.String(sg.VPCId)
 	req.Protocol = tea.String(sg.Protocol)
+	if sg.ServerGroupType != "" {
+		req.ServerGroupType = tea.String(string(sg.ServerGroupType))
+	}
 	if sg.AddressIPVersion != "" {
 		req.AddressIPVersion = tea.String(string(sg.AddressIPVersion))
 	}
@@ -168,6 +186,9 @@ func (p *NLBProvider) CreateNLBServerGroup(ctx context.Context, sg *nlbmodel.Ser
 	}
 
 	sg.ServerGroupId = tea.StringValue(resp.Body.ServerGroupId)
+	if nlbmodel.AsyncJobTrackerFrom(ctx) != nil {
+		return p.waitJobFinish(ctx, "CreateServerGroup", tea.StringValue(resp.Body.JobId))
+	}
 
 	var (
 		getResp *nlb.ListServerGroupsResponse
@@ -290,7 +311,7 @@ func (p *NLBProvider) AddNLBServers(ctx context.Context, sgId string, backends [
 	if resp == nil || resp.Body == nil {
 		return fmt.Errorf("OpenAPI AddServersToServerGroup resp is nil")
 	}
-	return p.waitJobFinish("AddServersToServerGroup", tea.StringValue(resp.Body.JobId))
+	return p.waitJobFinish(ctx, "AddServersToServerGroup", tea.StringValue(resp.Body.JobId))
 }
 
 func (p *NLBProvider) RemoveNLBServers(ctx context.Context, sgId string, backends []nlbmodel.ServerGroupServer,
@@ -318,7 +339,7 @@ func (p *NLBProvider) RemoveNLBServers(ctx context.Context, sgId string, backend
 	if resp == nil || resp.Body == nil {
 		return fmt.Errorf("OpenAPI RemoveServersFromServerGroup resp is nil")
 	}
-	return p.waitJobFinish("RemoveServersFromServerGroup", tea.StringValue(resp.Body.JobId))
+	return p.waitJobFinish(ctx, "RemoveServersFromServerGroup", tea.StringValue(resp.Body.JobId))
 }
 
 func (p *NLBProvider) UpdateNLBServers(ctx context.Context, sgId string, backends []nlbmodel.ServerGroupServer,
@@ -348,7 +369,7 @@ func (p *NLBProvider) UpdateNLBServers(ctx context.Context, sgId string, backend
 	if resp == nil || resp.Body == nil {
 		return fmt.Errorf("OpenAPI UpdateServerGroupServersAttribute resp is nil")
 	}
-	return p.waitJobFinish("UpdateServerGroupServersAttribute", tea.StringValue(resp.Body.JobId))
+	return p.waitJobFinish(ctx, "UpdateServerGroupServersAttribute", tea.StringValue(resp.Body.JobId))
 }
 
 func (p *NLBProvider) ListNLBServers(ctx context.Context, sgId string) ([]nlbmodel.ServerGroupServer, error) {
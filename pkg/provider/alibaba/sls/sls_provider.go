@@ -1,14 +1,28 @@
 package sls
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
 	prvd "k8s.io/alibaba-load-balancer-controller/pkg/provider"
 	"k8s.io/alibaba-load-balancer-controller/pkg/provider/alibaba/base"
+	"k8s.io/alibaba-load-balancer-controller/pkg/util"
+	"k8s.io/klog/v2"
 
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/responses"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/sls"
 )
 
+const (
+	SLSVersion = "2020-12-30"
+	GetLogs    = "GetLogs"
+)
+
 func NewSLSProvider(
 	auth *base.ClientMgr,
 ) *SLSProvider {
@@ -28,3 +42,46 @@ func (p SLSProvider) SLSDoAction(request requests.AcsRequest, response responses
 func (p SLSProvider) AnalyzeProductLog(request *sls.AnalyzeProductLogRequest) (response *sls.AnalyzeProductLogResponse, err error) {
 	return p.auth.SLS.AnalyzeProductLog(request)
 }
+
+func (p SLSProvider) GetLogs(ctx context.Context, project, logstore, query string, from, to time.Time) ([]map[string]string, error) {
+	traceID := ctx.Value(util.TraceID)
+	request := &requests.RoaRequest{}
+	request.InitWithApiInfo("Sls", SLSVersion, GetLogs, "/logstores/[logstore]/logs", "", "")
+	request.Method = requests.GET
+	request.Domain = logProjectDomain(project, p.auth.Region, p.auth.SLS.Network)
+	request.PathParams["logstore"] = logstore
+	request.QueryParams = map[string]string{
+		"from":  strconv.FormatInt(from.Unix(), 10),
+		"to":    strconv.FormatInt(to.Unix(), 10),
+		"query": query,
+	}
+
+	startTime := time.Now()
+	response := responses.NewCommonResponse()
+	if err := p.SLSDoAction(request, response); err != nil {
+		return nil, errors.Wrapf(err, "failed to get logs of logstore %s/%s", project, logstore)
+	}
+	if !response.IsSuccess() {
+		return nil, fmt.Errorf("failed to get logs of logstore %s/%s, status %d: %s",
+			project, logstore, response.GetHttpStatus(), response.GetHttpContentString())
+	}
+	// the logs are partial if the query is not complete, which must not be taken as the result
+	if progress := response.GetHttpHeaders()["X-Log-Progress"]; len(progress) != 0 && progress[0] != "Complete" {
+		return nil, fmt.Errorf("failed to get logs of logstore %s/%s, query is %s", project, logstore, progress[0])
+	}
+	var logs []map[string]string
+	if err := json.Unmarshal(response.GetHttpContentBytes(), &logs); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse logs of logstore %s/%s", project, logstore)
+	}
+	klog.V(5).Infof("[%s] got %d logs of logstore %s/%s, elapsed %dms", traceID, len(logs), project, logstore,
+		time.Since(startTime).Milliseconds())
+	return logs, nil
+}
+
+// logProjectDomain returns the endpoint of the log project, the intranet one in vpc
+func logProjectDomain(project, region, network string) string {
+	if network == "vpc" {
+		return fmt.Sprintf("%s.%s-intranet.log.aliyuncs.com", project, region)
+	}
+	return fmt.Sprintf("%s.%s.log.aliyuncs.com", project, region)
+}
@@ -0,0 +1,82 @@
+package sls
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/sls"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/alibaba-load-balancer-controller/pkg/provider/alibaba/base"
+)
+
+// recordedTransport returns the recorded response of GetLogs and keeps the request
+type recordedTransport struct {
+	status   int
+	progress string
+	body     string
+	request  *http.Request
+}
+
+func (t *recordedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.request = req
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set("X-Log-Requestid", "6530E1D6B9E2C83C3C7A5E1A")
+	if t.progress != "" {
+		header.Set("X-Log-Progress", t.progress)
+	}
+	return &http.Response{
+		StatusCode: t.status,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(t.body)),
+		Request:    req,
+	}, nil
+}
+
+func newTestProvider(t *testing.T, transport *recordedTransport) *SLSProvider {
+	client, err := sls.NewClientWithAccessKey("cn-hangzhou", "ak", "sk")
+	assert.NoError(t, err)
+	client.SetTransport(transport)
+	return NewSLSProvider(&base.ClientMgr{Region: "cn-hangzhou", SLS: client})
+}
+
+func TestGetLogs(t *testing.T) {
+	from, to := time.Unix(1697700000, 0), time.Unix(1697700060, 0)
+	transport := &recordedTransport{
+		status:   http.StatusOK,
+		progress: "Complete",
+		body: `[{"__source__":"","__time__":"1697700012","server_group_id":"sgp-canary","status":"200","request_time":"0.012"},` +
+			`{"__source__":"","__time__":"1697700031","server_group_id":"sgp-canary","status":"502","request_time":"0.305"}]`,
+	}
+	provider := newTestProvider(t, transport)
+
+	logs, err := provider.GetLogs(context.TODO(), "k8s-log", "alb-access", "server_group_id: sgp-canary", from, to)
+	assert.NoError(t, err)
+	if assert.Len(t, logs, 2) {
+		assert.Equal(t, "sgp-canary", logs[0]["server_group_id"])
+		assert.Equal(t, "502", logs[1]["status"])
+		assert.Equal(t, "0.305", logs[1]["request_time"])
+	}
+	assert.Equal(t, http.MethodGet, transport.request.Method)
+	assert.Equal(t, "k8s-log.cn-hangzhou.log.aliyuncs.com", transport.request.URL.Host)
+	assert.Equal(t, "/logstores/alb-access/logs", transport.request.URL.Path)
+	query := transport.request.URL.Query()
+	assert.Equal(t, "1697700000", query.Get("from"))
+	assert.Equal(t, "1697700060", query.Get("to"))
+	assert.Equal(t, "server_group_id: sgp-canary", query.Get("query"))
+
+	// the partial logs of an incomplete query are not returned
+	transport.progress = "Incomplete"
+	_, err = provider.GetLogs(context.TODO(), "k8s-log", "alb-access", "*", from, to)
+	assert.Error(t, err)
+
+	transport.status = http.StatusNotFound
+	transport.progress = ""
+	transport.body = `{"errorCode":"LogStoreNotExist","errorMessage":"logstore alb-access does not exist"}`
+	_, err = provider.GetLogs(context.TODO(), "k8s-log", "alb-access", "*", from, to)
+	assert.Error(t, err)
+}
@@ -59,6 +59,9 @@ func (p DryRunALB) UpdateALB(ctx context.Context, resLB *albmodel.AlbLoadBalance
 func (p DryRunALB) DeleteALB(ctx context.Context, lbID string) error {
 	return nil
 }
+func (p DryRunALB) GetALB(ctx context.Context, lbID string) (*albsdk.GetLoadBalancerAttributeResponse, error) {
+	return nil, nil
+}
 
 // ALB Listener
 func (p DryRunALB) CreateALBListener(ctx context.Context, resLS *albmodel.Listener) (albmodel.ListenerStatus, error) {
@@ -99,6 +102,9 @@ func (p DryRunALB) ListALBListenerRules(ctx context.Context, lsID string) ([]alb
 func (p DryRunALB) GetALBListenerAttribute(ctx context.Context, lsID string) (*albsdk.GetListenerAttributeResponse, error) {
 	return nil, nil
 }
+func (p DryRunALB) ListALBListenerCertificates(ctx context.Context, lsID string) ([]albsdk.CertificateModel, error) {
+	return nil, nil
+}
 
 // ALB Server
 func (p DryRunALB) RegisterALBServers(ctx context.Context, serverGroupID string, resServers []albmodel.BackendItem) error {
@@ -110,6 +116,9 @@ func (p DryRunALB) DeregisterALBServers(ctx context.Context, serverGroupID strin
 func (p DryRunALB) ReplaceALBServers(ctx context.Context, serverGroupID string, resServers []albmodel.BackendItem, sdkServers []albsdk.BackendServer) error {
 	return nil
 }
+func (p DryRunALB) UpdateALBServers(ctx context.Context, serverGroupID string, resServers []albmodel.BackendItem) error {
+	return nil
+}
 func (p DryRunALB) ListALBServers(ctx context.Context, serverGroupID string) ([]albsdk.BackendServer, error) {
 	return nil, nil
 }
@@ -159,3 +168,19 @@ func (p DryRunALB) AssociateAclWithListener(ctx context.Context, traceID interfa
 func (p DryRunALB) DisassociateAclWithListener(traceID interface{}, listenerID string, aclIds []string) error {
 	return nil
 }
+
+func (p DryRunALB) FindAclByName(ctx context.Context, aclName string) (*albsdk.Acl, error) {
+	return p.alb.FindAclByName(ctx, aclName)
+}
+func (p DryRunALB) ListAclTags(ctx context.Context, sdkAclID string) (map[string]string, error) {
+	return p.alb.ListAclTags(ctx, sdkAclID)
+}
+func (p DryRunALB) CreateAclWithEntries(ctx context.Context, resAcl *albmodel.Acl, tags map[string]string) (albmodel.AclStatus, error) {
+	return albmodel.AclStatus{}, nil
+}
+func (p DryRunALB) UpdateAclEntries(ctx context.Context, resAcl *albmodel.Acl, sdkAclID string) error {
+	return nil
+}
+func (p DryRunALB) DeleteAclByID(ctx context.Context, sdkAclID string) error {
+	return nil
+}
@@ -28,6 +28,10 @@ func (d DryRunNLB) TagNLBResource(ctx context.Context, resourceId string, resour
 	panic("implement me")
 }
 
+func (d DryRunNLB) UntagNLBResource(ctx context.Context, resourceId string, resourceType nlbmodel.TagResourceType, tagKeys []string) error {
+	return nil
+}
+
 func (d DryRunNLB) ListNLBTagResources(ctx context.Context, lbId string) ([]tag.Tag, error) {
 	//TODO implement me
 	panic("implement me")
@@ -68,11 +72,24 @@ func (d DryRunNLB) UpdateNLBZones(ctx context.Context, mdl *nlbmodel.NetworkLoad
 	panic("implement me")
 }
 
+func (d DryRunNLB) DescribeNLBZones(ctx context.Context) ([]string, error) {
+	return d.nlb.DescribeNLBZones(ctx)
+}
+
+func (d DryRunNLB) GetNLBJobStatus(ctx context.Context, jobId string) (string, error) {
+	return d.nlb.GetNLBJobStatus(ctx, jobId)
+}
+
 func (d DryRunNLB) ListNLBServerGroups(ctx context.Context, tags []tag.Tag) ([]*nlbmodel.ServerGroup, error) {
 	//TODO implement me
 	panic("implement me")
 }
 
+func (d DryRunNLB) ListNLBServerGroupsByIds(ctx context.Context, sgIds []string) ([]*nlbmodel.ServerGroup, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
 func (d DryRunNLB) CreateNLBServerGroup(ctx context.Context, sg *nlbmodel.ServerGroup) error {
 	//TODO implement me
 	panic("implement me")
@@ -103,6 +120,10 @@ func (d DryRunNLB) UpdateNLBServers(ctx context.Context, sgId string, backends [
 	panic("implement me")
 }
 
+func (d DryRunNLB) ListNLBServers(ctx context.Context, sgId string) ([]nlbmodel.ServerGroupServer, error) {
+	return d.nlb.ListNLBServers(ctx, sgId)
+}
+
 func (d DryRunNLB) ListNLBListeners(ctx context.Context, lbId string) ([]*nlbmodel.ListenerAttribute, error) {
 	//TODO implement me
 	panic("implement me")
@@ -1,6 +1,9 @@
 package dryrun
 
 import (
+	"context"
+	"time"
+
 	prvd "k8s.io/alibaba-load-balancer-controller/pkg/provider"
 	"k8s.io/alibaba-load-balancer-controller/pkg/provider/alibaba/base"
 	slsprvd "k8s.io/alibaba-load-balancer-controller/pkg/provider/alibaba/sls"
@@ -29,3 +32,7 @@ func (p DryRunSLS) SLSDoAction(request requests.AcsRequest, response responses.A
 func (s DryRunSLS) AnalyzeProductLog(request *sls.AnalyzeProductLogRequest) (response *sls.AnalyzeProductLogResponse, err error) {
 	return s.auth.SLS.AnalyzeProductLog(request)
 }
+
+func (s DryRunSLS) GetLogs(ctx context.Context, project, logstore, query string, from, to time.Time) ([]map[string]string, error) {
+	return s.sls.GetLogs(ctx, project, logstore, query, from, to)
+}
@@ -140,12 +140,16 @@ type ILoadBalancer interface {
 type ISLS interface {
 	AnalyzeProductLog(request *sls.AnalyzeProductLogRequest) (response *sls.AnalyzeProductLogResponse, err error)
 	SLSDoAction(request requests.AcsRequest, response responses.AcsResponse) (err error)
+	// GetLogs queries the logs of logstore in [from, to), every log is a map from field to value
+	GetLogs(ctx context.Context, project, logstore, query string, from, to time.Time) ([]map[string]string, error)
 }
 
 type ICAS interface {
 	DescribeSSLCertificateList(ctx context.Context) ([]model.CertificateInfo, error)
 	CreateSSLCertificateWithName(ctx context.Context, certName, certificate, privateKey string) (string, error)
 	DeleteSSLCertificate(ctx context.Context, certId string) error
+	DescribeCACertificateList(ctx context.Context) ([]model.CACertificateInfo, error)
+	CreateCACertificateWithName(ctx context.Context, certName, certificate string) (string, error)
 }
 
 type IALB interface {
@@ -158,6 +162,7 @@ type IALB interface {
 	UnReuseALB(ctx context.Context, lbID string, trackingProvider tracking.TrackingProvider) error
 	UpdateALB(ctx context.Context, resLB *albmodel.AlbLoadBalancer, sdkLB alb.LoadBalancer, trackingProvider tracking.TrackingProvider) (albmodel.LoadBalancerStatus, error)
 	DeleteALB(ctx context.Context, lbID string) error
+	GetALB(ctx context.Context, lbID string) (*alb.GetLoadBalancerAttributeResponse, error)
 	// ALB Listener
 	CreateALBListener(ctx context.Context, resLS *albmodel.Listener) (albmodel.ListenerStatus, error)
 	UpdateALBListener(ctx context.Context, resLS *albmodel.Listener, sdkLB *alb.Listener) (albmodel.ListenerStatus, error)
@@ -173,11 +178,15 @@ type IALB interface {
 	DeleteALBListenerRules(ctx context.Context, sdkLRIds []string) error
 	ListALBListenerRules(ctx context.Context, lsID string) ([]alb.Rule, error)
 	GetALBListenerAttribute(ctx context.Context, lsID string) (*alb.GetListenerAttributeResponse, error)
+	// ListALBListenerCertificates returns the default, additional and CA certificates of the listener
+	ListALBListenerCertificates(ctx context.Context, lsID string) ([]alb.CertificateModel, error)
 
 	// ALB Server
 	RegisterALBServers(ctx context.Context, serverGroupID string, resServers []albmodel.BackendItem) error
 	DeregisterALBServers(ctx context.Context, serverGroupID string, sdkServers []alb.BackendServer) error
 	ReplaceALBServers(ctx context.Context, serverGroupID string, resServers []albmodel.BackendItem, sdkServers []alb.BackendServer) error
+	// UpdateALBServers updates the weights and descriptions of the servers in the server group
+	UpdateALBServers(ctx context.Context, serverGroupID string, resServers []albmodel.BackendItem) error
 	ListALBServers(ctx context.Context, serverGroupID string) ([]alb.BackendServer, error)
 
 	// ALB ServerGroup
@@ -201,11 +210,19 @@ type IALB interface {
 	ListAclEntriesByID(traceID interface{}, sdkAclID string) ([]alb.AclEntry, error)
 	AssociateAclWithListener(ctx context.Context, traceID interface{}, resAcl *albmodel.Acl, aclIds []string) error
 	DisassociateAclWithListener(traceID interface{}, listenerID string, aclIds []string) error
+
+	// AccessControlList support, the acls are shared by listeners and not associated by these methods
+	FindAclByName(ctx context.Context, aclName string) (*alb.Acl, error)
+	ListAclTags(ctx context.Context, sdkAclID string) (map[string]string, error)
+	CreateAclWithEntries(ctx context.Context, resAcl *albmodel.Acl, tags map[string]string) (albmodel.AclStatus, error)
+	UpdateAclEntries(ctx context.Context, resAcl *albmodel.Acl, sdkAclID string) error
+	DeleteAclByID(ctx context.Context, sdkAclID string) error
 }
 
 type INLB interface {
 	//Tag
 	TagNLBResource(ctx context.Context, resourceId string, resourceType nlbmodel.TagResourceType, tags []tag.Tag) error
+	UntagNLBResource(ctx context.Context, resourceId string, resourceType nlbmodel.TagResourceType, tagKeys []string) error
 	ListNLBTagResources(ctx context.Context, lbId string) ([]tag.Tag, error)
 	// NetworkLoadBalancer
 	FindNLB(ctx context.Context, mdl *nlbmodel.NetworkLoadBalancer) error
@@ -215,17 +232,25 @@ type INLB interface {
 	UpdateNLB(ctx context.Context, mdl *nlbmodel.NetworkLoadBalancer) error
 	UpdateNLBAddressType(ctx context.Context, mdl *nlbmodel.NetworkLoadBalancer) error
 	UpdateNLBZones(ctx context.Context, mdl *nlbmodel.NetworkLoadBalancer) error
+	// DescribeNLBZones returns the zones supporting nlb in the region of the cluster
+	DescribeNLBZones(ctx context.Context) ([]string, error)
+	// GetNLBJobStatus returns the status of an async job, such as Processing, Succeeded and Failed
+	GetNLBJobStatus(ctx context.Context, jobId string) (string, error)
 
 	// ServerGroup
 	ListNLBServerGroups(ctx context.Context, tags []tag.Tag) ([]*nlbmodel.ServerGroup, error)
+	// ListNLBServerGroupsByIds returns the server groups of the ids, including the ones without tags
+	ListNLBServerGroupsByIds(ctx context.Context, sgIds []string) ([]*nlbmodel.ServerGroup, error)
 	CreateNLBServerGroup(ctx context.Context, sg *nlbmodel.ServerGroup) error
 	DeleteNLBServerGroup(ctx context.Context, sgId string) error
 	UpdateNLBServerGroup(ctx context.Context, sg *nlbmodel.ServerGroup) error
 	AddNLBServers(ctx context.Context, sgId string, backends []nlbmodel.ServerGroupServer) error
 	RemoveNLBServers(ctx context.Context, sgId string, backends []nlbmodel.ServerGroupServer) error
 	UpdateNLBServers(ctx context.Context, sgId string, backends []nlbmodel.ServerGroupServer) error
+	ListNLBServers(ctx context.Context, sgId string) ([]nlbmodel.ServerGroupServer, error)
 
 	// Listener
+	// ListNLBListeners returns the listeners of the nlb, or all the nlb listeners in the region if lbId is empty
 	ListNLBListeners(ctx context.Context, lbId string) ([]*nlbmodel.ListenerAttribute, error)
 	CreateNLBListener(ctx context.Context, lbId string, lis *nlbmodel.ListenerAttribute) error
 	UpdateNLBListener(ctx context.Context, lis *nlbmodel.ListenerAttribute) error
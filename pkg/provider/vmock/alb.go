@@ -53,6 +53,9 @@ func (p MockALB) UpdateALB(ctx context.Context, resLB *albmodel.AlbLoadBalancer,
 func (p MockALB) DeleteALB(ctx context.Context, lbID string) error {
 	return nil
 }
+func (p MockALB) GetALB(ctx context.Context, lbID string) (*albsdk.GetLoadBalancerAttributeResponse, error) {
+	return nil, nil
+}
 
 // ALB Listener
 func (p MockALB) CreateALBListener(ctx context.Context, resLS *albmodel.Listener) (albmodel.ListenerStatus, error) {
@@ -70,6 +73,9 @@ func (p MockALB) ListALBListeners(ctx context.Context, lbID string) ([]albsdk.Li
 func (p MockALB) GetALBListenerAttribute(ctx context.Context, lsID string) (*albsdk.GetListenerAttributeResponse, error) {
 	return nil, nil
 }
+func (p MockALB) ListALBListenerCertificates(ctx context.Context, lsID string) ([]albsdk.CertificateModel, error) {
+	return nil, nil
+}
 
 // ALB Listener Rule
 func (p MockALB) CreateALBListenerRule(ctx context.Context, resLR *albmodel.ListenerRule) (albmodel.ListenerRuleStatus, error) {
@@ -104,6 +110,9 @@ func (p MockALB) DeregisterALBServers(ctx context.Context, serverGroupID string,
 func (p MockALB) ReplaceALBServers(ctx context.Context, serverGroupID string, resServers []albmodel.BackendItem, sdkServers []albsdk.BackendServer) error {
 	return nil
 }
+func (p MockALB) UpdateALBServers(ctx context.Context, serverGroupID string, resServers []albmodel.BackendItem) error {
+	return nil
+}
 func (p MockALB) ListALBServers(ctx context.Context, serverGroupID string) ([]albsdk.BackendServer, error) {
 	return nil, nil
 }
@@ -153,3 +162,19 @@ func (p MockALB) AssociateAclWithListener(ctx context.Context, traceID interface
 func (p MockALB) DisassociateAclWithListener(traceID interface{}, listenerID string, aclIds []string) error {
 	return nil
 }
+
+func (p MockALB) FindAclByName(ctx context.Context, aclName string) (*albsdk.Acl, error) {
+	return nil, nil
+}
+func (p MockALB) ListAclTags(ctx context.Context, sdkAclID string) (map[string]string, error) {
+	return nil, nil
+}
+func (p MockALB) CreateAclWithEntries(ctx context.Context, resAcl *albmodel.Acl, tags map[string]string) (albmodel.AclStatus, error) {
+	return albmodel.AclStatus{}, nil
+}
+func (p MockALB) UpdateAclEntries(ctx context.Context, resAcl *albmodel.Acl, sdkAclID string) error {
+	return nil
+}
+func (p MockALB) DeleteAclByID(ctx context.Context, sdkAclID string) error {
+	return nil
+}
@@ -33,6 +33,10 @@ func (m MockNLB) TagNLBResource(ctx context.Context, resourceId string, resource
 	return nil
 }
 
+func (m MockNLB) UntagNLBResource(ctx context.Context, resourceId string, resourceType nlbmodel.TagResourceType, tagKeys []string) error {
+	return nil
+}
+
 func (m MockNLB) ListNLBTagResources(ctx context.Context, lbId string) ([]tag.Tag, error) {
 	return nil, nil
 }
@@ -95,6 +99,18 @@ func (m MockNLB) UpdateNLBZones(ctx context.Context, mdl *nlbmodel.NetworkLoadBa
 	return nil
 }
 
+func (m MockNLB) DescribeNLBZones(ctx context.Context) ([]string, error) {
+	return []string{"cn-hangzhou-a", "cn-hangzhou-b"}, nil
+}
+
+func (m MockNLB) GetNLBJobStatus(ctx context.Context, jobId string) (string, error) {
+	return nlbmodel.JobStatusSucceeded, nil
+}
+
+func (m MockNLB) ListNLBServerGroupsByIds(ctx context.Context, sgIds []string) ([]*nlbmodel.ServerGroup, error) {
+	return nil, nil
+}
+
 func (m MockNLB) ListNLBServerGroups(ctx context.Context, tags []tag.Tag) ([]*nlbmodel.ServerGroup, error) {
 	found := false
 	for _, t := range tags {
@@ -231,6 +247,10 @@ func (m MockNLB) UpdateNLBServers(ctx context.Context, sgId string, backends []n
 	return nil
 }
 
+func (m MockNLB) ListNLBServers(ctx context.Context, sgId string) ([]nlbmodel.ServerGroupServer, error) {
+	return nil, nil
+}
+
 func (m MockNLB) ListNLBListeners(ctx context.Context, lbId string) ([]*nlbmodel.ListenerAttribute, error) {
 	if lbId == ExistNLBID {
 		listeners := []*nlbmodel.ListenerAttribute{
@@ -1,6 +1,9 @@
 package vmock
 
 import (
+	"context"
+	"time"
+
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/responses"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/sls"
@@ -23,3 +26,7 @@ func (s MockSLS) SLSDoAction(request requests.AcsRequest, response responses.Acs
 func (s MockSLS) AnalyzeProductLog(request *sls.AnalyzeProductLogRequest) (response *sls.AnalyzeProductLogResponse, err error) {
 	return nil, nil
 }
+
+func (s MockSLS) GetLogs(ctx context.Context, project, logstore, query string, from, to time.Time) ([]map[string]string, error) {
+	return nil, nil
+}
@@ -38,6 +38,7 @@ const (
 	MoveResourceGroup          = "MoveResourceGroup"
 	TagALBResource             = "TagALBResource"
 	UnTagALBResource           = "UnTagALBResource"
+	ListALBTagResources        = "ListALBTagResources"
 	AnalyzeProductLog          = "AnalyzeProductLog"
 	OpenProductDataCollection  = "OpenProductDataCollection"
 	CloseProductDataCollection = "CloseProductDataCollection"
@@ -61,6 +62,7 @@ const (
 	RemoveALBServersFromServerGroup             = "RemoveALBServersFromServerGroup"
 	ReplaceALBServersInServerGroupAsynchronous  = "ReplaceALBServersInServerGroupAsynchronous"
 	ReplaceALBServersInServerGroup              = "ReplaceALBServersInServerGroup"
+	UpdateALBServersAttributeInServerGroup      = "UpdateALBServersAttributeInServerGroup"
 
 	ALBInnerServiceManagedControl = "InnerServiceManagedControl"
 
@@ -105,6 +107,8 @@ const (
 	LoadBalancerStatusConfiguring  = "Configuring"
 	LoadBalancerStatusCreateFailed = "CreateFailed"
 
+	LoadBalancerBusinessStatusNormal = "Normal"
+
 	ListenerStatusProvisioning = "Provisioning"
 	ListenerStatusRunning      = "Running"
 	ListenerStatusConfiguring  = "Configuring"
@@ -173,6 +177,7 @@ const (
 	ServerTypeEcs = "Ecs"
 	ServerTypeEni = "Eni"
 	ServerTypeEci = "Eci"
+	ServerTypeIp  = "Ip"
 )
 
 const (
@@ -229,6 +234,11 @@ const (
 
 	AlbConfigTagKey     = "albconfig"
 	AlbConfigFullTagKey = IngressTagKeyPrefix + "/" + AlbConfigTagKey
+
+	// ServerGroupAdoptedTagKey marks an adopted server group whose servers are kept until its service has backends
+	ServerGroupAdoptedTagKey = IngressTagKeyPrefix + "/adopted"
+	// AccessControlListTagKey is the name of the AccessControlList managing the acl
+	AccessControlListTagKey = IngressTagKeyPrefix + "/accesscontrollist"
 )
 
 const (
@@ -292,6 +302,7 @@ const (
 	DefaultServerGroupScheduler                string = ServerGroupSchedulerWrr
 	DefaultServerGroupProtocol                 string = ServerGroupProtocolHTTP
 	DefaultServerGroupType                     string = "instance"
+	IpServerGroupType                          string = "ip"
 	DefaultServerGroupUpstreamKeepaliveEnabled bool   = false
 
 	DefaultServerGroupHealthCheckInterval            = 2                                   // 1~50
@@ -322,6 +333,9 @@ const (
 	//Server: Rewrite Cookie.
 	//The load balancer finds that the user has customized the cookie and will rewrite the original cookie. The next time the client visits with a new cookie, the load balancer service will direct the request to the back-end server that was previously recorded.
 	DefaultServerGroupStickySessionType = ServerGroupStickySessionTypeInsert
+	// Slow start duration. Unit: second
+	// Value: 30~900
+	DefaultServerGroupSlowStartDuration = 30
 
 	DefaultLoadBalancerAddressType                        string = LoadBalancerAddressTypeInternet
 	DefaultLoadBalancerAddressAllocatedMode               string = LoadBalancerAddressAllocatedModeDynamic
@@ -342,6 +356,17 @@ const (
 	ActionTrafficLimitQpsMin = 1
 )
 const IsReuseLb string = "is_reuse_lb"
+
+// IsRetainLb is the context key whether the load balancers of a deleted object are retained
+const IsRetainLb string = "is_retain_lb"
+
+// MultiClusterConfig is the context key of the clusters sharing the loadBalancer
+const MultiClusterConfig string = "multi_cluster_config"
+
+const (
+	DeletionPolicyDelete = "Delete"
+	DeletionPolicyRetain = "Retain"
+)
 const (
 	ServerGroupSchedulerWrr     = "Wrr"
 	ServerGroupSchedulerWlc     = "Wlc"
@@ -399,6 +424,8 @@ const (
 
 const (
 	CertAlgorithmSM2 = "SM2"
+	// ListenerCertificateTypeCa is the type of the CA certificates listed by ListListenerCertificates
+	ListenerCertificateTypeCa = "Ca"
 )
 
 type ContextTraceID string
@@ -60,6 +60,11 @@ type Conf struct {
 	// EnableScaleSubresource by default will be nil and means disabled, if
 	// the object is present it will set this scale configuration to the subresource.
 	EnableScaleSubresource *apiextv1.CustomResourceSubresourceScale
+	// Schema is the OpenAPI v3 schema to validate the CRD.
+	// By default unknown fields are preserved without validation.
+	Schema *apiextv1.JSONSchemaProps
+	// PrinterColumns are the additional columns shown by kubectl get.
+	PrinterColumns []apiextv1.CustomResourceColumnDefinition
 }
 
 func (c *Conf) getName() string {
@@ -112,9 +117,12 @@ func (c *Client) EnsurePresent(conf Conf) error {
 
 	// Create subresources
 	subres := c.createSubresources(conf)
-	xPreserveUnknownFields := true
-	openAPIV3Schema := &apiextv1.JSONSchemaProps{
-		XPreserveUnknownFields: &xPreserveUnknownFields,
+	openAPIV3Schema := conf.Schema
+	if openAPIV3Schema == nil {
+		xPreserveUnknownFields := true
+		openAPIV3Schema = &apiextv1.JSONSchemaProps{
+			XPreserveUnknownFields: &xPreserveUnknownFields,
+		}
 	}
 	schema := &apiextv1.CustomResourceValidation{OpenAPIV3Schema: openAPIV3Schema}
 	crd := &apiextv1.CustomResourceDefinition{
@@ -124,33 +132,7 @@ func (c *Client) EnsurePresent(conf Conf) error {
 		Spec: apiextv1.CustomResourceDefinitionSpec{
 			Group: conf.Group,
 			Versions: []apiextv1.CustomResourceDefinitionVersion{{Name: conf.Version, Served: true, Storage: true, Subresources: subres, Schema: schema,
-				AdditionalPrinterColumns: []apiextv1.CustomResourceColumnDefinition{
-					{
-						Name:     "ALBID",
-						Type:     "string",
-						JSONPath: ".status.loadBalancer.id",
-					},
-					{
-						Name:     "DNSNAME",
-						Type:     "string",
-						JSONPath: ".status.loadBalancer.dnsname",
-					},
-					{
-						Name:     "PORT&PROTOCOL",
-						Type:     "string",
-						JSONPath: ".status.loadBalancer.listeners[*].portAndProtocol",
-					},
-					{
-						Name:     "CERTID",
-						Type:     "string",
-						JSONPath: ".status.loadBalancer.listeners[*].certificates[*].certificateId",
-					},
-					{
-						Name:     "AGE",
-						Type:     "date",
-						JSONPath: ".metadata.creationTimestamp",
-					},
-				}}},
+				AdditionalPrinterColumns: conf.PrinterColumns}},
 			Scope: conf.Scope,
 			Names: apiextv1.CustomResourceDefinitionNames{
 				Plural:     conf.NamePlural,
@@ -38,6 +38,24 @@ var (
 		},
 		[]string{"verb"},
 	)
+
+	// ALBCertificateExpireDays days left before the certificates attached to alb listeners expire
+	ALBCertificateExpireDays = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ccm_alb_certificate_expire_days",
+			Help: "Days left before the certificate attached to an ALB listener expires, negative if already expired.",
+		},
+		[]string{"albconfig", "listener", "host", "certificate_id"},
+	)
+
+	// NLBCertificateExpireDays days left before the certificates attached to nlb listeners expire
+	NLBCertificateExpireDays = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ccm_nlb_certificate_expire_days",
+			Help: "Days left before the certificate attached to an NLB listener expires, negative if already expired.",
+		},
+		[]string{"service", "listener", "certificate_id"},
+	)
 )
 
 // MsSince returns milliseconds since start.
@@ -50,4 +68,6 @@ func RegisterPrometheus() {
 	metrics.Registry.MustRegister(RouteLatency)
 	metrics.Registry.MustRegister(NodeLatency)
 	metrics.Registry.MustRegister(SLBLatency)
+	metrics.Registry.MustRegister(ALBCertificateExpireDays)
+	metrics.Registry.MustRegister(NLBCertificateExpireDays)
 }